@@ -0,0 +1,244 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/mockgraph"
+	"github.com/manutej/maat-terminal/internal/tui"
+)
+
+// runBench implements `maat bench`: generates synthetic graphs, measures store
+// insert/query throughput and render latency, and prints a report.
+func runBench(args []string) int {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	sizesFlag := fs.String("nodes", "1000,10000", "Comma-separated synthetic graph sizes (node counts)")
+	termsFlag := fs.String("terms", "80x24,120x40,200x60", "Comma-separated terminal sizes (WxH) to render at")
+	renders := fs.Int("renders", 20, "Renders per terminal size")
+	queries := fs.Int("queries", 500, "Store lookups per query benchmark")
+	skipStore := fs.Bool("no-store", false, "Skip the store insert/query benchmarks")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: maat bench [--nodes 1000,10000] [--terms 80x24,120x40]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	sizes, err := parseSizes(*sizesFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --nodes: %v\n", err)
+		return 2
+	}
+	terms, err := parseTerms(*termsFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --terms: %v\n", err)
+		return 2
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() { _ = w.Flush() }()
+	fmt.Fprintln(w, "NODES\tBENCHMARK\tOPS\tTOTAL\tPER OP\tTHROUGHPUT")
+
+	for _, size := range sizes {
+		nodes, edges := mockgraph.Generate(mockgraph.Sized(size))
+		label := fmt.Sprintf("%d", len(nodes))
+
+		if !*skipStore {
+			results, err := benchStore(nodes, edges, *queries)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Store benchmark failed: %v\n", err)
+				return 1
+			}
+			for _, r := range results {
+				r.print(w, label)
+			}
+		}
+
+		for _, r := range benchRender(nodes, edges, terms, *renders) {
+			r.print(w, label)
+		}
+	}
+	return 0
+}
+
+// benchResult is one line of the report
+type benchResult struct {
+	name      string
+	ops       int
+	durations []time.Duration // Per-op samples (nil when only the total is known)
+	total     time.Duration
+}
+
+// print writes the result as a tab-separated report row
+func (r benchResult) print(w *tabwriter.Writer, label string) {
+	perOp := r.total / time.Duration(max(r.ops, 1))
+	throughput := float64(r.ops) / r.total.Seconds()
+
+	detail := fmt.Sprintf("%.0f ops/s", throughput)
+	if len(r.durations) > 0 {
+		// Latency-oriented rows also report the 95th percentile
+		sorted := append([]time.Duration(nil), r.durations...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		detail += fmt.Sprintf(" (p95 %s)", sorted[len(sorted)*95/100].Round(time.Microsecond))
+	}
+	fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n", label, r.name, r.ops, r.total.Round(time.Millisecond), perOp.Round(time.Microsecond), detail)
+}
+
+// benchStore measures inserts and lookups against a throwaway on-disk store.
+// A file (not :memory:) is used so every pooled connection sees the same data.
+func benchStore(nodes []graph.Node, edges []graph.Edge, queries int) ([]benchResult, error) {
+	dir, err := os.MkdirTemp("", "maat-bench-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	store, err := graph.NewStore(filepath.Join(dir, "bench.db"))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = store.Close() }()
+
+	var results []benchResult
+
+	start := time.Now()
+	for _, node := range nodes {
+		if err := store.UpsertNode(node); err != nil {
+			return nil, err
+		}
+	}
+	results = append(results, benchResult{name: "store upsert node", ops: len(nodes), total: time.Since(start)})
+
+	start = time.Now()
+	for _, edge := range edges {
+		if err := store.UpsertEdge(edge); err != nil {
+			return nil, err
+		}
+	}
+	results = append(results, benchResult{name: "store upsert edge", ops: len(edges), total: time.Since(start)})
+
+	lookups := []struct {
+		name string
+		run  func(id string) error
+	}{
+		{"store get node", func(id string) error { _, err := store.GetNode(id); return err }},
+		{"store neighbors", func(id string) error { _, err := store.GetNeighbors(id); return err }},
+	}
+	for _, lookup := range lookups {
+		r := benchResult{name: lookup.name, ops: queries}
+		for i := 0; i < queries; i++ {
+			id := nodes[(i*7919)%len(nodes)].ID // Spread lookups across the graph
+			opStart := time.Now()
+			if err := lookup.run(id); err != nil {
+				return nil, err
+			}
+			r.durations = append(r.durations, time.Since(opStart))
+		}
+		r.total = sum(r.durations)
+		results = append(results, r)
+	}
+
+	// Full scans are much slower - a handful is enough
+	scans := []struct {
+		name string
+		run  func() error
+	}{
+		{"store list issues", func() error {
+			_, err := store.ListNodes(&graph.NodeFilter{Types: []graph.NodeType{graph.NodeTypeIssue}})
+			return err
+		}},
+		{"store sql group by", func() error {
+			_, err := store.Query("SELECT type, COUNT(*) FROM nodes GROUP BY type")
+			return err
+		}},
+	}
+	for _, scan := range scans {
+		r := benchResult{name: scan.name, ops: 10}
+		for i := 0; i < r.ops; i++ {
+			opStart := time.Now()
+			if err := scan.run(); err != nil {
+				return nil, err
+			}
+			r.durations = append(r.durations, time.Since(opStart))
+		}
+		r.total = sum(r.durations)
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+// benchRender measures full-frame render latency and keystroke-to-frame
+// latency (navigate + render) at each terminal size.
+func benchRender(nodes []graph.Node, edges []graph.Edge, terms [][2]int, renders int) []benchResult {
+	base := tui.NewModelWithData(nodes, edges, "").WithFilterMode(tui.FilterAll)
+
+	var results []benchResult
+	for _, term := range terms {
+		sized, _ := base.Update(tea.WindowSizeMsg{Width: term[0], Height: term[1]})
+
+		frame := benchResult{name: fmt.Sprintf("render %dx%d", term[0], term[1]), ops: renders}
+		for i := 0; i < renders; i++ {
+			opStart := time.Now()
+			_ = sized.View()
+			frame.durations = append(frame.durations, time.Since(opStart))
+		}
+		frame.total = sum(frame.durations)
+
+		keystroke := benchResult{name: fmt.Sprintf("keystroke %dx%d", term[0], term[1]), ops: renders}
+		m := sized
+		for i := 0; i < renders; i++ {
+			opStart := time.Now()
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+			_ = m.View()
+			keystroke.durations = append(keystroke.durations, time.Since(opStart))
+		}
+		keystroke.total = sum(keystroke.durations)
+
+		results = append(results, frame, keystroke)
+	}
+	return results
+}
+
+// parseSizes parses a comma-separated list of positive node counts
+func parseSizes(s string) ([]int, error) {
+	var sizes []int
+	for _, part := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("bad size %q", part)
+		}
+		sizes = append(sizes, n)
+	}
+	return sizes, nil
+}
+
+// parseTerms parses a comma-separated list of WxH terminal sizes
+func parseTerms(s string) ([][2]int, error) {
+	var terms [][2]int
+	for _, part := range strings.Split(s, ",") {
+		var width, height int
+		if _, err := fmt.Sscanf(strings.TrimSpace(part), "%dx%d", &width, &height); err != nil || width <= 0 || height <= 0 {
+			return nil, fmt.Errorf("bad terminal size %q", part)
+		}
+		terms = append(terms, [2]int{width, height})
+	}
+	return terms, nil
+}
+
+// sum adds up duration samples
+func sum(durations []time.Duration) time.Duration {
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// runDB implements `maat db <command>`: upkeep for the graph store.
+func runDB(args []string) int {
+	if len(args) == 0 || args[0] != "maintain" {
+		fmt.Fprintln(os.Stderr, "Usage: maat db maintain [--every 24h]")
+		return 2
+	}
+	return runDBMaintain(args[1:])
+}
+
+// runDBMaintain implements `maat db maintain`: removes orphaned edges,
+// optimizes full-text indexes, refreshes statistics and vacuums, reporting
+// the size before and after. --every repeats it until interrupted.
+func runDBMaintain(args []string) int {
+	fs := flag.NewFlagSet("db maintain", flag.ExitOnError)
+	dbPath := fs.String("db", "", "Path to the graph database (default from config)")
+	projectPath := fs.String("path", ".", "Project whose graph database to use (without --db or a configured one)")
+	configPath := fs.String("config", config.DefaultPath(), "Path to the config file")
+	every := fs.Duration("every", 0, "Run again at this interval until interrupted (e.g. 24h; default once)")
+	_ = fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (using defaults)\n", err)
+	}
+
+	absPath, err := filepath.Abs(*projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid path %q: %v\n", *projectPath, err)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for {
+		if err := maintainOnce(ctx, resolveDBPath(*dbPath, cfg, absPath), cfg.Database); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			if *every == 0 {
+				return 1
+			}
+		}
+		if *every == 0 {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Next run at %s\n", time.Now().Add(*every).Format("2006-01-02 15:04"))
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-time.After(*every):
+		}
+	}
+}
+
+// maintainOnce opens the store for a single run, so a scheduled loop never
+// holds it between runs
+func maintainOnce(ctx context.Context, dbPath string, db config.DatabaseConfig) error {
+	store, err := openStore(dbPath, db)
+	if err != nil {
+		return fmt.Errorf("opening store: %w", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	maintainer, ok := store.(graph.Maintainer)
+	if !ok {
+		return fmt.Errorf("the %s backend needs no maintenance", db.Backend)
+	}
+	report, err := maintainer.Maintain(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed %d orphaned edges\n", report.OrphanEdges)
+	if len(report.FTSTables) > 0 {
+		fmt.Printf("Optimized full-text indexes: %s\n", strings.Join(report.FTSTables, ", "))
+	}
+	fmt.Printf("Size: %s → %s (%s) in %s\n", formatBytes(report.SizeBefore), formatBytes(report.SizeAfter),
+		formatBytesDelta(report.SizeAfter-report.SizeBefore), report.Duration.Round(time.Millisecond))
+	return nil
+}
+
+// formatBytes renders a size with a binary unit ("12.4 MiB")
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGT"[exp])
+}
+
+// formatBytesDelta is formatBytes with a sign
+func formatBytesDelta(n int64) string {
+	if n < 0 {
+		return "-" + formatBytes(-n)
+	}
+	return "+" + formatBytes(n)
+}
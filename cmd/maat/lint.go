@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// runLint implements `maat lint`: checks the store for schema violations,
+// dangling edges and duplicate entities, with a suggested fix for each.
+// --fix applies the safe repairs. Exits 1 while issues remain, for CI.
+func runLint(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	dbPath := fs.String("db", "", "Path to the graph database (default from config)")
+	projectPath := fs.String("path", ".", "Project whose graph database to use (without --db or a configured one)")
+	configPath := fs.String("config", config.DefaultPath(), "Path to the config file")
+	fix := fs.Bool("fix", false, "Delete edges with unknown relations or missing endpoints")
+	_ = fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (using defaults)\n", err)
+	}
+	absPath, err := filepath.Abs(*projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid path %q: %v\n", *projectPath, err)
+		return 2
+	}
+	store, err := openStore(resolveDBPath(*dbPath, cfg, absPath), cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening store: %v\n", err)
+		return 1
+	}
+	defer func() { _ = store.Close() }()
+
+	issues, err := graph.Lint(store)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fixed := 0
+	if *fix {
+		fixed, err = graph.FixLint(store, issues)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	remaining, fixable := 0, 0
+	for _, issue := range issues {
+		if *fix && issue.Fixable() {
+			continue
+		}
+		remaining++
+		if issue.Fixable() {
+			fixable++
+		}
+		fmt.Printf("%s  %s: %s\n", issue.Check, issue.Subject, issue.Problem)
+		fmt.Printf("    fix: %s\n", issue.Fix)
+	}
+
+	if fixed > 0 {
+		fmt.Printf("Fixed %d issues\n", fixed)
+	}
+	if remaining == 0 {
+		if fixed == 0 {
+			fmt.Println("No issues found")
+		}
+		return 0
+	}
+	summary := fmt.Sprintf("%d issues", remaining)
+	if fixable > 0 {
+		summary += fmt.Sprintf(" (%d fixable with --fix)", fixable)
+	}
+	fmt.Println(summary)
+	return 1
+}
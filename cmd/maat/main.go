@@ -0,0 +1,872 @@
+// Command maat is the MAAT terminal workspace entry point.
+//
+// Usage:
+//
+//	maat                      # Scan current directory
+//	maat --path /some/path    # Scan specific project
+//	maat --mock               # Use mock data (original demo)
+//	maat --mock-size 5000     # Generated mock graph of about 5000 nodes
+//	maat --role exec --exec   # Leadership roll-up view
+//	maat --mock-linear        # Add issues from an in-process fake Linear API
+//	maat --linear-issues 0    # Load every Linear issue of the team (default cap 2000)
+//	maat --osv                # Flag vulnerable go.mod / package.json dependencies
+//	maat --vault ~/notes      # Merge an Obsidian vault's notes into the graph
+//	maat --mail ~/Mail/INBOX  # Add decision email threads as discussions
+//	maat --script rules.star  # Custom filters, decorations and badges in Starlark
+//	maat sql "SELECT ..."     # Run a read-only query against the graph store
+//	maat bench                # Benchmark store and render performance
+//	maat trace CET-352        # PRs, commits and files behind an issue
+//	maat standup --me alice   # Yesterday / today / blockers as Markdown
+//	maat release-notes --from v1.0  # Issues shipped since a tag, as Markdown
+//	maat retro --cycle "Cycle 12"   # A cycle's retro: done, carried over, blocked, added
+//	maat replay demo.yaml     # Replay keys recorded with --record (demos, e2e tests)
+//	maat replay bug.jsonl     # Re-drive Update with a --record-updates log
+//	maat db maintain          # Orphan cleanup, ANALYZE and VACUUM for the store
+//	maat lint --fix           # Check the store for schema problems, repair the safe ones
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/crash"
+	"github.com/manutej/maat-terminal/internal/datasource"
+	"github.com/manutej/maat-terminal/internal/datasource/linearfake"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/mockgraph"
+	"github.com/manutej/maat-terminal/internal/paths"
+	"github.com/manutej/maat-terminal/internal/replay"
+	"github.com/manutej/maat-terminal/internal/tui"
+)
+
+func main() {
+	// Files an XDG variable now places outside ~/.maat move there first, so
+	// setting one doesn't start from an empty database
+	moved, err := paths.MigrateLegacy()
+	for _, path := range moved {
+		fmt.Fprintf(os.Stderr, "Moved to %s\n", path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not move files out of ~/.maat: %v\n", err)
+	}
+
+	// Subcommands are dispatched before flag parsing so each can own its flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "sql":
+			os.Exit(runSQL(os.Args[2:]))
+		case "bench":
+			os.Exit(runBench(os.Args[2:]))
+		case "trace":
+			os.Exit(runTrace(os.Args[2:]))
+		case "standup":
+			os.Exit(runStandup(os.Args[2:]))
+		case "release-notes":
+			os.Exit(runReleaseNotes(os.Args[2:]))
+		case "retro":
+			os.Exit(runRetro(os.Args[2:]))
+		case "replay":
+			os.Exit(runReplay(os.Args[2:]))
+		case "db":
+			os.Exit(runDB(os.Args[2:]))
+		case "lint":
+			os.Exit(runLint(os.Args[2:]))
+		}
+	}
+
+	projectPath := flag.String("path", ".", "Project path to scan")
+	useMock := flag.Bool("mock", false, "Use mock data instead of scanning")
+	mockSize := flag.Int("mock-size", 0, "Generate a mock graph of about this many nodes (implies --mock; default is the small demo graph)")
+	mockSeed := flag.Int64("mock-seed", 1, "Random seed for the mock graph (same seed, same graph)")
+	mockLinear := flag.Bool("mock-linear", false, "Load Linear issues from an in-process fake API (no LINEAR_API_KEY needed)")
+	useGit := flag.Bool("git", true, "Scan git history (commits, branches)")
+	useFiles := flag.Bool("files", true, "Scan source files")
+	useDeps := flag.Bool("deps", true, "Read go.mod and package.json dependencies")
+	checkOSV := flag.Bool("osv", false, "Flag dependencies with known vulnerabilities from OSV (sends their names and versions to api.osv.dev)")
+	maxCommits := flag.Int("commits", 50, "Maximum number of commits to load")
+	maxFiles := flag.Int("max-files", 200, "Maximum number of files to scan")
+	maxIssues := flag.Int("linear-issues", 2000, "Maximum number of Linear issues to load, paging through the team's issues (0 = all)")
+	vault := flag.String("vault", "", "Obsidian vault to merge into the graph (default from config)")
+	localTasks := flag.String("tasks", "", "Comma-separated todo.txt files or Taskwarrior exports (`task export > tasks.json`) to add")
+	mailPath := flag.String("mail", "", "Maildir or mbox whose labelled threads become discussions (default from config)")
+	mailLabel := flag.String("mail-label", "", "Label marking decision threads (default from config, else \"decision\")")
+	scriptPath := flag.String("script", "", "Starlark rules file: filter(node), decorate(node), badges(node) (default from config, else maat.star in the config directory)")
+	dbPath := flag.String("db", "", "Path to the graph database (default from config, else the project's under graphs/ in the data directory)")
+	configPath := flag.String("config", config.DefaultPath(), "Path to the config file")
+	role := flag.String("role", string(graph.RoleIC), "Viewer role: exec | lead | ic (hides nodes above this access level)")
+	execMode := flag.Bool("exec", false, "Start in exec mode (project/service roll-ups only)")
+	me := flag.String("me", os.Getenv("GITHUB_USER"), "Your GitHub login, name or email, for my-work mode (M key) and the \"needs my review\" PR filter (N key)")
+	record := flag.String("record", "", "Record key presses with their timing to a scenario file for `maat replay`")
+	recordUpdates := flag.String("record-updates", "", "Log every message the UI handles, with the resulting state hash, for `maat replay` bug reports")
+	plain := flag.Bool("plain", false, "Print the graph as a plain-text tree and exit (automatic when stdout is not a terminal)")
+	inline := flag.Bool("inline", false, "Run without the alternate screen so the last screen stays in scrollback (I toggles)")
+	accessible := flag.Bool("accessible", false, "Screen-reader friendly output (no box drawing, emoji or color-only selection)")
+	recordHTTP := flag.String("record-http", "", "Record Linear API exchanges to this cassette file, for tests and offline demos")
+	replayHTTP := flag.String("replay-http", "", "Answer Linear API requests from this recorded cassette instead of the network (needs LINEAR_TEAM_ID, not a key)")
+	activityLog := flag.String("activity-log", "", "Append what was done this session (syncs, jumps, writes, exports) to this Markdown file on exit")
+	fullSync := flag.Bool("full-sync", false, "Reload every source in full instead of fetching only what changed since the last sync")
+	offline := flag.Bool("offline", false, "Start from the graph earlier runs stored, without loading any source (syncs stay off)")
+	flag.Parse()
+
+	if !graph.ValidateRole(*role) {
+		fmt.Fprintf(os.Stderr, "Invalid role %q (want exec, lead or ic)\n", *role)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (using defaults)\n", err)
+	}
+
+	viewer := *me
+	if viewer == "" {
+		viewer = cfg.Me
+	}
+
+	absPath, err := filepath.Abs(*projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid path %q: %v\n", *projectPath, err)
+		os.Exit(1)
+	}
+
+	// Redaction guards what gets stored; refuse to load rather than skip a bad rule
+	redactor, err := datasource.NewRedactor(cfg.Redact)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid redaction config: %v\n", err)
+		os.Exit(1)
+	}
+
+	cassette, err := openCassette(*recordHTTP, *replayHTTP)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+
+	// Linear's requests spend the TUI's rate limit one by one; the model
+	// shares the dispatcher. A bad rate_limits entry is reported when the
+	// model is built
+	rateLimits, err := tui.ParseRateLimits(cfg.RateLimits)
+	if err != nil {
+		rateLimits = tui.DefaultRateLimits()
+	}
+	dispatcher := tui.NewDispatcher(rateLimits)
+
+	loader, cleanup := newLoader(absPath, sourceOptions{
+		mock:       *useMock || *mockSize > 0,
+		mockGraph:  mockOptions(*mockSize, *mockSeed),
+		mockLinear: *mockLinear,
+		git:        *useGit,
+		files:      *useFiles,
+		deps:       *useDeps,
+		osv:        *checkOSV,
+		maxCommits: *maxCommits,
+		maxFiles:   *maxFiles,
+		maxIssues:  *maxIssues,
+		people:     cfg.People,
+		vault:      resolveVault(*vault, cfg),
+		localTasks: resolveLocalTasks(*localTasks, cfg),
+		mail:       resolveMail(*mailPath, *mailLabel, cfg),
+		hooks:      cfg.Hooks,
+		redactor:   redactor,
+		autoLink:   cfg.AutoLinkRules(),
+		cassette:   cassette,
+		dispatcher: dispatcher,
+	})
+	defer cleanup()
+
+	// An interrupt or SIGTERM cancels loading and, later, the TUI's reads;
+	// a second interrupt exits at once
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	// Loaded graphs are kept in the store, so the next start (or an
+	// --offline one) has them; demo graphs leave it alone
+	demo := *useMock || *mockSize > 0
+	var store graph.GraphStore
+	if !demo {
+		store, err = openStore(resolveDBPath(*dbPath, cfg, absPath), cfg.Database)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: graph store unavailable: %v\n", err)
+			store = nil
+		} else {
+			defer func() { _ = store.Close() }()
+			// Linear fetches only what changed since the stored sync
+			loader.SetSyncStore(store, *fullSync)
+		}
+	}
+
+	var nodes []graph.Node
+	var edges []graph.Edge
+	if *offline {
+		if store == nil {
+			fmt.Fprintln(os.Stderr, "--offline starts from the graph store, which is unavailable")
+			os.Exit(1)
+		}
+		nodes, edges, err = graph.ReadStored(store)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read the stored graph: %v\n", err)
+			os.Exit(1)
+		}
+		if len(nodes) == 0 {
+			fmt.Fprintln(os.Stderr, "The graph store is empty; run once without --offline to fill it")
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Stored graph: %d nodes, %d edges\n", len(nodes), len(edges))
+	} else {
+		nodes, edges, err = loader.LoadAll(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load data: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Loaded %d nodes, %d edges\n", len(nodes), len(edges))
+		if store != nil {
+			var stored bool
+			nodes, edges, stored = storeLoad(store, cfg, nodes, edges, loadedAll(loader.SyncRuns()))
+			if stored {
+				loader.SaveSyncCursors(ctx)
+			}
+		}
+	}
+
+	// Idle nodes past their TTL leave the working graph for the store's
+	// archive tables; demo graphs keep everything
+	var expired []graph.Node
+	var expiredEdges []graph.Edge
+	var rules []graph.TTLRule
+	if !*useMock && *mockSize == 0 {
+		var errs []error
+		rules, errs = ttlRules(cfg)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		if nodes, err = graph.ApplyRestored(ctx, store, nodes); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		nodes, edges, expired, expiredEdges = graph.ApplyTTL(nodes, edges, rules, time.Now())
+	}
+
+	userQueries, err := config.LoadSavedQueries(config.QueriesPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	reviewed, err := config.LoadReviewedPRs(config.ReviewedPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	session := modelSession{
+		Path:           absPath,
+		Nodes:          nodes,
+		Edges:          edges,
+		Role:           graph.Role(*role),
+		Exec:           *execMode,
+		Accessible:     *accessible,
+		Inline:         *inline,
+		Viewer:         viewer,
+		ConfigViews:    cfg.SavedQueries,
+		UserViews:      userQueries,
+		StatusBar:      cfg.StatusBar,
+		Details:        cfg.Details,
+		Metrics:        cfg.Metrics,
+		NodeTypes:      cfg.NodeTypes,
+		ProjectColors:  cfg.ProjectColors,
+		Confirm:        cfg.Confirm,
+		RateLimits:     cfg.RateLimits,
+		IssueTemplates: cfg.IssueTemplateList(),
+		Reviewed:       reviewed,
+		QueriesPath:    config.QueriesPath(),
+		ReviewedPath:   config.ReviewedPath(),
+		Script:         resolveScript(*scriptPath, cfg),
+	}
+	// Without a terminal (a pipe or script) print the tree as plain text
+	// instead of starting the TUI
+	plainOutput := *plain || !isTerminal(os.Stdout)
+	if !plainOutput {
+		session.LightBackground = !lipgloss.HasDarkBackground()
+	}
+
+	// Resume where the last session left off; demo graphs neither resume nor save
+	if !*useMock && *mockSize == 0 && !plainOutput {
+		session.Resume, err = config.LoadSession(config.SessionPath())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		session.SessionPath = config.SessionPath()
+	}
+	for _, err := range loader.Errors() {
+		session.LoadErrors = append(session.LoadErrors, err.Error())
+	}
+	session.Truncations = loader.Truncations()
+	model, warnings := session.build()
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", warning)
+	}
+	model = model.WithContext(ctx).WithActivityExport(*activityLog).WithDispatcher(dispatcher)
+	if *useGit && !*useMock && *mockSize == 0 {
+		// Branches load older commits as they are expanded; d shows diffs
+		model = model.WithBranchHistory(loader).WithDiffs(loader)
+	}
+	if !*useMock && *mockSize == 0 {
+		// Space previews scanned files and vault notes
+		model = model.WithPreviews(loader)
+	}
+	if plainOutput {
+		fmt.Print(tui.RenderPlain(model))
+		return
+	}
+
+	// The store is optional for the TUI - without it the SQL prompt is disabled
+	if store == nil && demo && !cfg.Database.Shared() {
+		store, err = openStore(resolveDBPath(*dbPath, cfg, absPath), cfg.Database)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: graph store unavailable: %v\n", err)
+		} else {
+			defer func() { _ = store.Close() }()
+		}
+	}
+	if store != nil {
+		model = model.WithStore(store)
+		archiveExpired(ctx, store, expired, expiredEdges)
+		recordSyncRuns(ctx, store, loader.SyncRuns())
+	}
+	if !demo && !*offline {
+		// Syncs reload sources the way this load did, store what they
+		// loaded and log what they cost
+		model = model.WithSourceRefresh(syncer{Loader: loader, shared: sharedStore(store, cfg), local: localStore(store, cfg), history: store, archive: store, ttl: rules})
+		// c in Details posts comments on Linear issues, and e edits them
+		// in Linear
+		model = model.WithComments(loader).WithWriteBack(loader)
+	}
+
+	var program tea.Model = model
+	var updateLog *os.File
+	var logger replay.Logger
+	if *recordUpdates != "" {
+		updateLog, err = os.Create(*recordUpdates)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to record updates: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() { _ = updateLog.Close() }()
+		logger, err = replay.NewLogger(model, replay.NewCodec(tui.ReplayableMsgs()...), updateLog, session)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to record updates: %v\n", err)
+			os.Exit(1)
+		}
+		program = logger
+		fmt.Fprintf(os.Stderr, "Recording updates to %s (it contains the loaded graph; review before sharing)\n", *recordUpdates)
+	}
+
+	var options []tea.ProgramOption
+	if !*inline {
+		options = append(options, tea.WithAltScreen())
+	}
+	var recorder *replay.Recorder
+	if *record != "" {
+		if !*useMock && *mockSize == 0 {
+			fmt.Fprintln(os.Stderr, "Warning: replays load the mock graph; record with --mock for a faithful replay")
+		}
+		recorder = replay.NewRecorder(*mockSeed, *mockSize)
+		options = append(options, tea.WithFilter(recorder.Filter))
+	}
+
+	// A panic quits cleanly through the guard, so the terminal is restored
+	guard := crash.NewGuard(program)
+	p := tea.NewProgram(guard, options...)
+	// Syncs from the TUI show each source as it finishes, and what they
+	// report goes to the activity log instead of over the screen
+	loader.SetProgress(func(source string, done, total int) {
+		p.Send(tui.LoadProgressMsg{Source: source, Done: done, Total: total})
+	})
+	loader.SetLog(nil)
+	_, err = p.Run()
+	// Quitting from the TUI waits for writes; a signal does not, so wait here
+	if pending := model.PendingWrites(); pending > 0 {
+		fmt.Fprintf(os.Stderr, "Saving… waiting for %d pending writes\n", pending)
+		if !model.WaitForWrites(tui.ShutdownTimeout) {
+			fmt.Fprintf(os.Stderr, "Gave up after %s; %d writes may not have completed\n", tui.ShutdownTimeout, model.PendingWrites())
+		}
+	}
+	report := guard.Report()
+	if report != nil {
+		reportCrash(*report)
+	} else if err != nil {
+		if errors.Is(err, tea.ErrProgramPanic) {
+			fmt.Fprintf(os.Stderr, "MAAT crashed. Please file the trace above at %s\n", crash.IssuesURL)
+		}
+		fmt.Fprintf(os.Stderr, "Error running MAAT: %v\n", err)
+		os.Exit(1)
+	}
+	if recorder != nil {
+		if err := recorder.Scenario().Write(*record); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to save recording: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Recorded %d keys to %s\n", len(recorder.Scenario().Events), *record)
+	}
+	if updateLog != nil {
+		if err := logger.Err(); err != nil {
+			fmt.Fprintf(os.Stderr, "Update log is incomplete: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Recorded updates to %s; reproduce with `maat replay %s`\n", *recordUpdates, *recordUpdates)
+	}
+	if report != nil {
+		os.Exit(1)
+	}
+}
+
+// isTerminal reports whether f is an interactive terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// reportCrash saves a crash report and says where to file it
+func reportCrash(report crash.Report) {
+	fmt.Fprintf(os.Stderr, "MAAT crashed: %s\n", report.Panic)
+	path, err := report.Write(filepath.Join(paths.State(), "crashes"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n\n%s\n", err, report)
+		path = "the report above"
+	} else {
+		fmt.Fprintf(os.Stderr, "A crash report was written to %s\n", path)
+	}
+	fmt.Fprintf(os.Stderr, "Please open an issue at %s and attach %s.\n", crash.IssuesURL, path)
+	fmt.Fprintln(os.Stderr, "It includes the titles of recently viewed items; review it before sharing.")
+}
+
+// resolveDBPath picks the database path: explicit flag, then config, then
+// the project's default
+func resolveDBPath(flagValue string, cfg config.Config, projectPath string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if cfg.Database.Path != "" {
+		return cfg.Database.Path
+	}
+	return defaultDBPath(projectPath)
+}
+
+// resolveVault picks the Obsidian vault: explicit flag, then config.
+func resolveVault(flagValue string, cfg config.Config) string {
+	if flagValue != "" {
+		return expandHome(flagValue)
+	}
+	return expandHome(cfg.Obsidian.Vault)
+}
+
+// resolveLocalTasks picks the local task files: explicit flag (comma-separated), then config.
+func resolveLocalTasks(flagValue string, cfg config.Config) []string {
+	paths := cfg.LocalTasks
+	if flagValue != "" {
+		paths = strings.Split(flagValue, ",")
+	}
+	var resolved []string
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path != "" {
+			resolved = append(resolved, expandHome(path))
+		}
+	}
+	return resolved
+}
+
+// resolveMail picks the mailbox and its decision label: explicit flags, then config.
+func resolveMail(pathFlag, labelFlag string, cfg config.Config) mailOptions {
+	opts := mailOptions{path: expandHome(cfg.Mail.Path), label: cfg.Mail.Label}
+	if pathFlag != "" {
+		opts.path = expandHome(pathFlag)
+	}
+	if labelFlag != "" {
+		opts.label = labelFlag
+	}
+	return opts
+}
+
+// resolveScript picks the Starlark rules file: explicit flag, then config,
+// then maat.star in the config directory if it exists ("" = no rules).
+func resolveScript(flagValue string, cfg config.Config) string {
+	if flagValue != "" {
+		return expandHome(flagValue)
+	}
+	if cfg.Script != "" {
+		return expandHome(cfg.Script)
+	}
+	if _, err := os.Stat(config.DefaultScriptPath()); err == nil {
+		return config.DefaultScriptPath()
+	}
+	return ""
+}
+
+// expandHome expands a leading "~/" to the home directory
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// defaultDBPath returns the default graph database of the project at
+// projectPath (absolute), in the data directory. Each project has its own,
+// so one project's complete load never prunes another's nodes.
+func defaultDBPath(projectPath string) string {
+	return filepath.Join(paths.Data(), "graphs", paths.ProjectKey(projectPath)+".db")
+}
+
+// openStore opens the graph store: the SQLite file at dbPath (creating the
+// parent directory if needed) or another backend named by database.backend,
+// such as the shared Postgres database at database.url. With
+// database.encrypt set the SQLite store is encrypted at rest, its key
+// fetched from the configured credential source.
+func openStore(dbPath string, db config.DatabaseConfig) (graph.GraphStore, error) {
+	if db.Backend != "" && db.Backend != config.BackendSQLite {
+		if db.Encrypt {
+			return nil, fmt.Errorf("database.encrypt applies to the sqlite backend only")
+		}
+		if db.Backend == config.BackendPostgres && db.URL == "" {
+			return nil, fmt.Errorf("database.url is required for the postgres backend")
+		}
+		return graph.Open(db.Backend, db.URL)
+	}
+
+	if dbPath != ":memory:" {
+		if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+			return nil, fmt.Errorf("creating database directory: %w", err)
+		}
+	}
+	if !db.Encrypt || dbPath == ":memory:" {
+		if graph.IsEncrypted(dbPath) {
+			return nil, fmt.Errorf("%s is encrypted; set database.encrypt in the config to open it", dbPath)
+		}
+		return graph.Open(config.BackendSQLite, dbPath)
+	}
+	key, err := db.KeySource().Resolve(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("database key: %w", err)
+	}
+	store, err := graph.NewEncryptedStore(dbPath, key)
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// ttlRules converts the configured TTL rules, skipping invalid ones
+func ttlRules(cfg config.Config) ([]graph.TTLRule, []error) {
+	var rules []graph.TTLRule
+	var errs []error
+	for _, rule := range cfg.TTLRules() {
+		age, err := config.ParseAge(rule.MaxAge)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("ttl rule for %s/%s ignored: %w", rule.Type, rule.Source, err))
+			continue
+		}
+		rules = append(rules, graph.TTLRule{Type: graph.NodeType(rule.Type), Source: rule.Source, MaxAge: age})
+	}
+	return rules, errs
+}
+
+// archiveExpired moves nodes past their TTL into the store's archive tables
+func archiveExpired(ctx context.Context, store graph.GraphStore, nodes []graph.Node, edges []graph.Edge) {
+	archiver, ok := store.(graph.Archiver)
+	if !ok || len(nodes) == 0 {
+		return
+	}
+	if err := archiver.Archive(ctx, nodes, edges); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: archiving expired nodes: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Archived %d nodes past their TTL\n", len(nodes))
+}
+
+// recordSyncRuns logs what each source's load cost to the store's
+// sync_runs table, for the sync stats view (T)
+func recordSyncRuns(ctx context.Context, store graph.GraphStore, runs []graph.SyncRun) {
+	recorder, ok := store.(graph.SyncRecorder)
+	if !ok || len(runs) == 0 {
+		return
+	}
+	if err := recorder.RecordSyncRuns(ctx, runs); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: recording sync stats: %v\n", err)
+	}
+}
+
+// storeLoad writes a startup load to the store and returns the graph to show:
+// the load merged with what teammates published to a shared store, or with
+// what this machine stored (hand-made nodes, and the last load of a source
+// that failed this time). complete says every source loaded; the bool
+// returned says the load was stored.
+func storeLoad(store graph.GraphStore, cfg config.Config, nodes []graph.Node, edges []graph.Edge, complete bool) ([]graph.Node, []graph.Edge, bool) {
+	merge, label := graph.MergeShared, "Shared graph"
+	if !cfg.Database.Shared() {
+		merge = func(store graph.GraphStore, nodes []graph.Node, edges []graph.Edge) ([]graph.Node, []graph.Edge, int, error) {
+			return graph.Persist(store, nodes, edges, complete)
+		}
+		label = "Stored graph"
+	}
+	nodes, edges, skipped, err := merge(store, nodes, edges)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d nodes and edges could not be written to the graph store\n", skipped)
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d nodes, %d edges\n", label, len(nodes), len(edges))
+	return nodes, edges, err == nil
+}
+
+// loadedAll reports whether every source loaded, so what they no longer
+// report can be dropped from the store
+func loadedAll(runs []graph.SyncRun) bool {
+	for _, run := range runs {
+		if run.Error != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// sharedStore returns store when it holds the team's shared graph
+func sharedStore(store graph.GraphStore, cfg config.Config) graph.GraphStore {
+	if !cfg.Database.Shared() {
+		return nil
+	}
+	return store
+}
+
+// localStore returns store when it holds only this machine's graph
+func localStore(store graph.GraphStore, cfg config.Config) graph.GraphStore {
+	if cfg.Database.Shared() {
+		return nil
+	}
+	return store
+}
+
+// sourceOptions selects the data sources a loader reads from
+type sourceOptions struct {
+	mock       bool              // Demo graph instead of scanning
+	mockGraph  mockgraph.Options // Shape of the demo graph (zero = defaults)
+	mockLinear bool              // In-process fake Linear API
+	git        bool
+	files      bool
+	deps       bool // Dependency manifests (go.mod, package.json)
+	osv        bool // Check dependencies against OSV
+	maxCommits int
+	maxFiles   int
+	maxIssues  int             // Linear issue cap (0 = every issue)
+	people     []config.Person // Identity mapping from config
+	vault      string          // Obsidian vault directory ("" = none)
+	localTasks []string        // todo.txt files and Taskwarrior exports
+	mail       mailOptions
+	hooks      config.HooksConfig    // Shell commands run on sync events (none for read-only reports)
+	redactor   *datasource.Redactor  // Masks sensitive text at ingestion
+	autoLink   []config.AutoLinkRule // References in node text that become edges
+	cassette   *datasource.Cassette  // Records or replays Linear's HTTP exchanges (nil = network)
+	dispatcher *tui.Dispatcher       // Rations Linear's requests (nil = unlimited)
+}
+
+// linearClient returns the client Linear's requests go through: rationed
+// by the dispatcher and recorded or replayed by the cassette, or nil for
+// the default client. A replay calls no API, so it is not rationed.
+func (o sourceOptions) linearClient() *http.Client {
+	if o.cassette != nil && o.cassette.Replaying() {
+		return o.cassette.Client()
+	}
+	var transport http.RoundTripper
+	if o.cassette != nil {
+		transport = o.cassette
+	}
+	if o.dispatcher != nil {
+		transport = o.dispatcher.Transport(tui.ProviderLinear, transport)
+	}
+	if transport == nil {
+		return nil
+	}
+	return &http.Client{Transport: transport, Timeout: 30 * time.Second}
+}
+
+// openCassette returns the cassette --record-http or --replay-http name,
+// or nil for neither
+func openCassette(record, replay string) (*datasource.Cassette, error) {
+	switch {
+	case record != "" && replay != "":
+		return nil, fmt.Errorf("--record-http and --replay-http cannot be used together")
+	case replay != "":
+		return datasource.LoadCassette(replay)
+	case record != "":
+		fmt.Fprintf(os.Stderr, "Recording Linear API exchanges to %s (it contains your issues; review before sharing)\n", record)
+		return datasource.NewRecorder(record, nil), nil
+	}
+	return nil, nil
+}
+
+// syncer reloads sources for the TUI's syncs the way startup loaded them:
+// merged with the shared store, if there is one, kept in the local one
+// otherwise, and with idle nodes past their TTL left out.
+type syncer struct {
+	*datasource.Loader
+	shared  graph.GraphStore // nil = no shared store
+	local   graph.GraphStore // Where loads are kept between runs (nil = nowhere)
+	history graph.GraphStore // Where sync runs are logged (nil = nowhere)
+	archive graph.GraphStore // Where restores from the archive are recorded (nil = nowhere)
+	ttl     []graph.TTLRule
+}
+
+// LoadAll reloads every source
+func (s syncer) LoadAll(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
+	nodes, edges, err := s.Loader.LoadAll(ctx)
+	recordSyncRuns(ctx, s.history, s.Loader.SyncRuns())
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case s.shared != nil:
+		if nodes, edges, _, err = graph.MergeShared(s.shared, nodes, edges); err != nil {
+			return nil, nil, err
+		}
+	case s.local != nil:
+		if nodes, edges, _, err = graph.Persist(s.local, nodes, edges, loadedAll(s.Loader.SyncRuns())); err != nil {
+			return nil, nil, err
+		}
+	}
+	// Stored (or there is no store, and so no cursors): the cursors can move
+	s.Loader.SaveSyncCursors(ctx)
+	nodes, _ = graph.ApplyRestored(ctx, s.archive, nodes)
+	nodes, edges, _, _ = graph.ApplyTTL(nodes, edges, s.ttl, time.Now())
+	return nodes, edges, nil
+}
+
+// RefreshNode reloads only the sources behind nodeID
+func (s syncer) RefreshNode(ctx context.Context, nodeID string) ([]graph.Node, []graph.Edge, []string, error) {
+	nodes, edges, removed, err := s.Loader.RefreshNode(ctx, nodeID)
+	recordSyncRuns(ctx, s.history, s.Loader.SyncRuns())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if s.local != nil {
+		if _, err := graph.PersistRefresh(s.local, nodes, edges, removed); err != nil {
+			return nil, nil, nil, fmt.Errorf("storing the refresh: %w", err)
+		}
+		s.Loader.SaveSyncCursors(ctx)
+	}
+	nodes, _ = graph.ApplyRestored(ctx, s.archive, nodes)
+	nodes, edges, _, _ = graph.ApplyTTL(nodes, edges, s.ttl, time.Now())
+	return nodes, edges, removed, nil
+}
+
+// mockOptions shapes the mock graph: the demo graph, or about size nodes
+func mockOptions(size int, seed int64) mockgraph.Options {
+	opts := mockgraph.DefaultOptions()
+	if size > 0 {
+		opts = mockgraph.Sized(size)
+	}
+	opts.Seed = seed
+	return opts
+}
+
+// mailOptions selects the mailbox read for decision threads
+type mailOptions struct {
+	path  string // Maildir directory or mbox file ("" = none)
+	label string // "" = source default
+}
+
+// newLoader builds a loader for the project at absPath. The returned cleanup
+// releases anything the sources hold open (e.g. the fake Linear server).
+func newLoader(absPath string, opts sourceOptions) (*datasource.Loader, func()) {
+	cleanup := func() {}
+	loader := datasource.NewLoader()
+	loader.SetPeople(opts.people)
+	loader.SetHooks(opts.hooks, config.SyncStatePath(absPath))
+	loader.SetRedactor(opts.redactor)
+	if linker, err := datasource.NewAutoLinker(opts.autoLink); err != nil {
+		// A bad rule only costs some edges; keep the default rules
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	} else {
+		loader.SetAutoLinker(linker)
+	}
+	if opts.mock {
+		loader.AddSource(datasource.NewMockSource(opts.mockGraph))
+	} else {
+		if opts.git {
+			gitScanner := datasource.NewGitScanner(absPath)
+			gitScanner.SetMaxCommits(opts.maxCommits)
+			loader.AddSource(gitScanner)
+		}
+		if opts.files {
+			fileScanner := datasource.NewFileScanner(absPath, fmt.Sprintf("project:%s", filepath.Base(absPath)))
+			fileScanner.SetMaxFiles(opts.maxFiles)
+			loader.AddSource(fileScanner)
+		}
+		if opts.deps {
+			deps := datasource.NewDependencySource(absPath, fmt.Sprintf("project:%s", filepath.Base(absPath)))
+			if opts.osv {
+				deps.SetOSV(datasource.DefaultOSVEndpoint)
+				deps.SetOSVCache(filepath.Join(paths.Cache(), "osv.json"))
+			}
+			loader.AddSource(deps)
+		}
+		// A replayed cassette answers without a key
+		hasKey := os.Getenv("LINEAR_API_KEY") != "" || (opts.cassette != nil && opts.cassette.Replaying())
+		if teamID := os.Getenv("LINEAR_TEAM_ID"); teamID != "" && hasKey && !opts.mockLinear {
+			linear := datasource.NewLinearSource(teamID)
+			linear.SetMaxIssues(opts.maxIssues)
+			if client := opts.linearClient(); client != nil {
+				linear.SetHTTPClient(client)
+			}
+			loader.AddSource(linear)
+		}
+	}
+	if opts.mockLinear {
+		// Enough issues to span several pages of the real page size
+		fake := linearfake.NewServer()
+		cleanup = fake.Close
+		fake.Seed(3, 120)
+
+		linear := datasource.NewLinearSource(fake.TeamID())
+		linear.SetEndpoint(fake.URL)
+		linear.SetAPIKey(fake.APIKey())
+		linear.SetMaxIssues(opts.maxIssues)
+		if client := opts.linearClient(); client != nil {
+			linear.SetHTTPClient(client)
+		}
+		loader.AddSource(linear)
+	}
+	if opts.vault != "" {
+		loader.AddSource(datasource.NewObsidianSource(opts.vault))
+	}
+	for _, path := range opts.localTasks {
+		loader.AddSource(datasource.NewLocalTasksSource(path))
+	}
+	if opts.mail.path != "" {
+		mail := datasource.NewMailSource(opts.mail.path)
+		if opts.mail.label != "" {
+			mail.SetLabel(opts.mail.label)
+		}
+		loader.AddSource(mail)
+	}
+	if opts.cassette != nil {
+		closeSources := cleanup
+		cleanup = func() {
+			closeSources()
+			if err := opts.cassette.Save(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+	return loader, cleanup
+}
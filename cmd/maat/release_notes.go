@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/datasource"
+	"github.com/manutej/maat-terminal/internal/tui"
+)
+
+// runReleaseNotes implements `maat release-notes --from v1.0 --to HEAD`:
+// prints the issues the commits between two refs delivered, grouped by
+// project or label, as Markdown release notes.
+func runReleaseNotes(args []string) int {
+	fs := flag.NewFlagSet("release-notes", flag.ExitOnError)
+	projectPath := fs.String("path", ".", "Project path to scan")
+	useMock := fs.Bool("mock", false, "Use mock data instead of scanning")
+	mockLinear := fs.Bool("mock-linear", false, "Load Linear issues from an in-process fake API")
+	maxCommits := fs.Int("commits", 500, "Maximum number of commits to load (the range must be among them)")
+	maxFiles := fs.Int("max-files", 200, "Maximum number of files to scan")
+	configPath := fs.String("config", config.DefaultPath(), "Path to the config file")
+	from := fs.String("from", "", "Ref the notes start after: a tag (v1.0), branch or commit hash")
+	to := fs.String("to", "HEAD", "Ref the notes end at")
+	groupBy := fs.String("group", tui.GroupByProject, "Group issues by project or label")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: maat release-notes --from <ref> [--to <ref>] [flags]")
+		fmt.Fprintln(os.Stderr, "\nLists the issues delivered by commits after --from up to --to, with the PRs")
+		fmt.Fprintln(os.Stderr, "that landed them, grouped by project or label. Commits and PRs that reference")
+		fmt.Fprintln(os.Stderr, "no issue are listed under \"Other changes\".")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if *from == "" || fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+	if *groupBy != tui.GroupByProject && *groupBy != tui.GroupByLabel {
+		fmt.Fprintf(os.Stderr, "Invalid --group %q (want project or label)\n", *groupBy)
+		return 2
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (using defaults)\n", err)
+	}
+
+	absPath, err := filepath.Abs(*projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid path %q: %v\n", *projectPath, err)
+		return 1
+	}
+
+	// Redaction guards what gets stored; refuse to load rather than skip a bad rule
+	redactor, err := datasource.NewRedactor(cfg.Redact)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid redaction config: %v\n", err)
+		return 1
+	}
+
+	loader, cleanup := newLoader(absPath, sourceOptions{
+		mock:       *useMock,
+		mockLinear: *mockLinear,
+		git:        true,
+		files:      true,
+		maxCommits: *maxCommits,
+		maxFiles:   *maxFiles,
+		people:     cfg.People,
+		vault:      resolveVault("", cfg),
+		localTasks: resolveLocalTasks("", cfg),
+		mail:       resolveMail("", "", cfg),
+		redactor:   redactor,
+		autoLink:   cfg.AutoLinkRules(),
+	})
+	defer cleanup()
+
+	nodes, edges, err := loader.LoadAll(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load data: %v\n", err)
+		return 1
+	}
+
+	model := tui.NewModelWithData(nodes, edges, absPath)
+	notes, err := model.ReleaseNotes(*from, *to, *groupBy)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v (raise --commits if it is older history)\n", err)
+		return 1
+	}
+	if notes.IsEmpty() {
+		fmt.Fprintf(os.Stderr, "No commits after %s up to %s\n", *from, *to)
+		return 1
+	}
+	fmt.Print(notes.Markdown())
+	return 0
+}
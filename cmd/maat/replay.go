@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/replay"
+	"github.com/manutej/maat-terminal/internal/tui"
+)
+
+// runReplay implements `maat replay <scenario.yaml>`: loads the scenario's
+// mock graph and presses its recorded keys with their timing. Live it drives
+// the TUI for screencasts; headless it prints the resulting screen(s), for
+// end-to-end regression tests of the Update loop.
+func runReplay(args []string) int {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	headless := fs.Bool("headless", false, "Run without a terminal and print the final screen")
+	frames := fs.Bool("frames", false, "With --headless, print the screen after every key")
+	speed := fs.Float64("speed", 1, "Playback speed multiplier (0 = no delays)")
+	settle := fs.Duration("settle", time.Second, "Wait after the last key before quitting (0 = stay open; live only)")
+	until := fs.Int("until", 0, "Update logs: stop after this message number (0 = all)")
+	interactive := fs.Bool("interactive", false, "Update logs: open the replayed state in the TUI")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: maat replay [--headless [--frames]] [--speed 2] <scenario.yaml>")
+		fmt.Fprintln(os.Stderr, "       maat replay [--until N] [--interactive] <updates.jsonl>")
+		fmt.Fprintln(os.Stderr, "\nRecord a scenario with `maat --mock --record demo.yaml`,")
+		fmt.Fprintln(os.Stderr, "or every message of a session with `maat --record-updates updates.jsonl`.")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	if replay.IsUpdateLog(fs.Arg(0)) {
+		return replayUpdates(fs.Arg(0), *until, *interactive)
+	}
+	scenario, err := replay.Load(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	// The same loader as `maat --mock`, so merging and inference match the recording
+	loader, cleanup := newLoader(".", sourceOptions{mock: true, mockGraph: mockOptions(scenario.Size, scenario.Seed)})
+	defer cleanup()
+	nodes, edges, err := loader.LoadAll(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load data: %v\n", err)
+		return 1
+	}
+	player, err := replay.NewPlayer(tui.NewModelWithData(nodes, edges, "mock"), scenario)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	player = player.WithSpeed(*speed)
+
+	if !*headless {
+		p := tea.NewProgram(player.WithQuitAfter(*settle), tea.WithAltScreen())
+		if _, err := p.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running replay: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	// Headless: the real runtime without a terminal; the scenario sets the size
+	width, height := scenario.ScreenSize()
+	player = player.WithScreenSize(width, height).WithFrames(*frames).WithQuitAfter(max(*settle, time.Millisecond))
+	p := tea.NewProgram(player, tea.WithInput(nil), tea.WithOutput(io.Discard), tea.WithoutRenderer())
+	final, err := p.Run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error running replay: %v\n", err)
+		return 1
+	}
+	played := final.(replay.Player)
+	if *frames {
+		for i, frame := range played.Frames() {
+			fmt.Printf("--- frame %d ---\n%s\n", i+1, frame)
+		}
+		return 0
+	}
+	fmt.Println(played.View())
+	return 0
+}
+
+// replayUpdates re-drives Update with a --record-updates log, starting from
+// the recorded session, and reports the first step whose state hash differs
+// from the recording. The final screen is printed, or opened live.
+func replayUpdates(path string, until int, interactive bool) int {
+	log, err := replay.ReadUpdateLog(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	var session modelSession
+	if err := json.Unmarshal(log.Header.Session, &session); err != nil {
+		fmt.Fprintf(os.Stderr, "Update log session is unreadable: %v\n", err)
+		return 1
+	}
+	model, warnings := session.build()
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", warning)
+	}
+
+	result, err := log.Replay(model, replay.NewCodec(tui.ReplayableMsgs()...), until)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Replay stopped: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "Replayed %d of %d messages (%d not replayable)\n", result.Applied, len(log.Entries), len(result.Skipped))
+	for _, entry := range result.Skipped {
+		fmt.Fprintf(os.Stderr, "  skipped #%d %s %s\n", entry.Seq, entry.Type, entry.Text)
+	}
+	status := 0
+	if d := result.Divergence; d != nil {
+		fmt.Fprintf(os.Stderr, "Diverged at #%d (%s): recorded %s, replayed %s\n", d.Seq, d.Type, d.Recorded, d.Replayed)
+		status = 1
+	} else {
+		fmt.Fprintln(os.Stderr, "Every state matched the recording")
+	}
+
+	if interactive {
+		if _, err := tea.NewProgram(result.Model, tea.WithAltScreen()).Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running MAAT: %v\n", err)
+			return 1
+		}
+		return status
+	}
+	fmt.Println(result.Model.View())
+	return status
+}
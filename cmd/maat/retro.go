@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/datasource"
+	"github.com/manutej/maat-terminal/internal/tui"
+)
+
+// runRetro implements `maat retro`: prints a cycle's completed and
+// carried-over issues, what was blocked and for how long, and the largest
+// mid-cycle additions as Markdown.
+func runRetro(args []string) int {
+	fs := flag.NewFlagSet("retro", flag.ExitOnError)
+	projectPath := fs.String("path", ".", "Project path to scan")
+	useMock := fs.Bool("mock", false, "Use mock data instead of scanning")
+	mockLinear := fs.Bool("mock-linear", false, "Load Linear issues from an in-process fake API")
+	maxCommits := fs.Int("commits", 50, "Maximum number of commits to load")
+	maxFiles := fs.Int("max-files", 200, "Maximum number of files to scan")
+	configPath := fs.String("config", config.DefaultPath(), "Path to the config file")
+	cycleName := fs.String("cycle", "", "Cycle to report on (default: the latest one started)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: maat retro [--cycle NAME] [flags]")
+		fmt.Fprintln(os.Stderr, "\nCompleted vs. carried-over issues of a Linear cycle, the issues that were")
+		fmt.Fprintln(os.Stderr, "blocked and for how long, and the largest scope added mid-cycle.")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (using defaults)\n", err)
+	}
+
+	absPath, err := filepath.Abs(*projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid path %q: %v\n", *projectPath, err)
+		return 1
+	}
+
+	// Redaction guards what gets stored; refuse to load rather than skip a bad rule
+	redactor, err := datasource.NewRedactor(cfg.Redact)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid redaction config: %v\n", err)
+		return 1
+	}
+
+	loader, cleanup := newLoader(absPath, sourceOptions{
+		mock:       *useMock,
+		mockLinear: *mockLinear,
+		git:        true,
+		files:      true,
+		maxCommits: *maxCommits,
+		maxFiles:   *maxFiles,
+		people:     cfg.People,
+		vault:      resolveVault("", cfg),
+		localTasks: resolveLocalTasks("", cfg),
+		mail:       resolveMail("", "", cfg),
+		redactor:   redactor,
+		autoLink:   cfg.AutoLinkRules(),
+	})
+	defer cleanup()
+
+	nodes, edges, err := loader.LoadAll(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load data: %v\n", err)
+		return 1
+	}
+
+	now := time.Now()
+	model := tui.NewModelWithData(nodes, edges, absPath)
+	name := *cycleName
+	if name == "" {
+		latest, ok := model.LatestCycle(now)
+		if !ok {
+			fmt.Fprintln(os.Stderr, "No loaded issue is planned in a cycle")
+			return 1
+		}
+		name = latest.Name
+	}
+	retro, ok := model.Retro(name, now)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "No loaded issue is planned in cycle %q\n", name)
+		return 1
+	}
+	fmt.Print(retro.Markdown())
+	return 0
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/script"
+	"github.com/manutej/maat-terminal/internal/tui"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// modelSession is everything the TUI's starting model is built from.
+// An update log (--record-updates) stores it so `maat replay` can rebuild
+// exactly the model the recorded messages were delivered to.
+type modelSession struct {
+	Path           string                      `json:"path"`
+	Nodes          []graph.Node                `json:"nodes"`
+	Edges          []graph.Edge                `json:"edges"`
+	Role           graph.Role                  `json:"role"`
+	Exec           bool                        `json:"exec"`
+	Accessible     bool                        `json:"accessible"`
+	Inline         bool                        `json:"inline"`
+	Viewer         string                      `json:"viewer"`
+	ConfigViews    []config.SavedQuery         `json:"config_views,omitempty"`
+	UserViews      []config.SavedQuery         `json:"user_views,omitempty"`
+	StatusBar      []string                    `json:"status_bar,omitempty"`
+	Details        map[string]string           `json:"details,omitempty"`
+	Metrics        []config.MetricRule         `json:"metrics,omitempty"`
+	Confirm        map[string]string           `json:"confirm,omitempty"`
+	RateLimits     map[string]config.RateLimit `json:"rate_limits,omitempty"`
+	IssueTemplates []config.IssueTemplate      `json:"issue_templates,omitempty"`
+	Reviewed       map[string]time.Time        `json:"reviewed,omitempty"`
+	LoadErrors     []string                    `json:"load_errors,omitempty"`
+	Truncations    []string                    `json:"truncations,omitempty"`
+	Script         string                      `json:"script,omitempty"` // Rules are loaded from this path again on replay
+	Resume         config.SessionState         `json:"resume"`
+	SessionPath    string                      `json:"-"` // Where the session state is saved on quit
+	QueriesPath    string                      `json:"-"` // Where views and viewed marks persist;
+	ReviewedPath   string                      `json:"-"` // a replay keeps them in memory only
+
+	// Styles from config, over the built-in ones
+	NodeTypes     map[string]config.NodeTypeConfig `json:"node_types,omitempty"`
+	ProjectColors map[string]string                `json:"project_colors,omitempty"`
+
+	// The terminal it ran in: Markdown is rendered for its background
+	LightBackground bool `json:"light_background,omitempty"`
+}
+
+// build returns the starting model, with warnings for config it could not use
+func (s modelSession) build() (tui.Model, []error) {
+	var warnings []error
+	model := tui.NewModelWithData(s.Nodes, s.Edges, s.Path).
+		WithRole(s.Role).
+		WithExecMode(s.Exec).
+		WithAccessible(s.Accessible).
+		WithAltScreen(!s.Inline).
+		WithLightBackground(s.LightBackground).
+		WithViewer(s.Viewer)
+
+	model = model.WithSessionState(s.Resume, s.SessionPath)
+
+	// ICs land on their own work when their identity is known
+	if s.Role == graph.RoleIC && !s.Exec {
+		if _, ok := model.ViewerPerson(); ok {
+			model = model.WithMyWork(true)
+		}
+	}
+
+	model = model.WithSavedQueries(s.ConfigViews, s.UserViews, s.QueriesPath)
+
+	statusBar, err := tui.ParseStatusBar(s.StatusBar)
+	if err != nil {
+		warnings = append(warnings, fmt.Errorf("%w (using the default status bar)", err))
+	}
+	model = model.WithStatusBar(statusBar)
+
+	detailTemplates, err := tui.ParseDetailTemplates(s.Details)
+	if err != nil {
+		warnings = append(warnings, fmt.Errorf("%w (using the built-in Details layout)", err))
+	}
+	model = model.WithDetailTemplates(detailTemplates)
+
+	metrics, err := tui.ParseMetrics(s.Metrics)
+	if err != nil {
+		warnings = append(warnings, fmt.Errorf("%w (no metrics)", err))
+	}
+	model = model.WithMetrics(metrics)
+
+	nodeTypes := styles.NodeTypeStyles{}
+	for name, style := range s.NodeTypes {
+		nodeTypes = nodeTypes.With(name, styles.NodeTypeOverride{
+			Icon:     style.Icon,
+			Color:    lipgloss.Color(style.Color),
+			Label:    style.Label,
+			Priority: style.Priority,
+		})
+	}
+	model = model.WithNodeTypeStyles(nodeTypes)
+
+	projectColors := styles.ProjectColors{}
+	for name, color := range s.ProjectColors {
+		projectColors[name] = lipgloss.Color(color)
+	}
+	model = model.WithProjectColors(projectColors)
+
+	confirmPolicies, err := tui.ParseConfirmPolicies(s.Confirm)
+	if err != nil {
+		warnings = append(warnings, fmt.Errorf("%w (every write asks first)", err))
+	}
+	model = model.WithConfirmPolicies(confirmPolicies)
+
+	rateLimits, err := tui.ParseRateLimits(s.RateLimits)
+	if err != nil {
+		rateLimits = tui.DefaultRateLimits()
+		warnings = append(warnings, fmt.Errorf("%w (using the default rate limits)", err))
+	}
+	model = model.WithRateLimits(rateLimits)
+
+	issueTemplates, err := tui.ParseIssueTemplates(s.IssueTemplates)
+	if err != nil {
+		issueTemplates = config.DefaultIssueTemplates
+		warnings = append(warnings, fmt.Errorf("%w (using the default issue templates)", err))
+	}
+	model = model.WithIssueTemplates(issueTemplates)
+
+	loadErrors := make([]error, len(s.LoadErrors))
+	for i, msg := range s.LoadErrors {
+		loadErrors[i] = errors.New(msg)
+	}
+	model = model.WithLoadErrors(loadErrors).WithTruncations(s.Truncations)
+
+	if s.Script != "" {
+		engine, err := script.Load(s.Script)
+		if err != nil {
+			warnings = append(warnings, err)
+		} else {
+			model = model.WithScript(engine)
+		}
+	}
+
+	return model.WithReviewedPRs(s.Reviewed, s.ReviewedPath), warnings
+}
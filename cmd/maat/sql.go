@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/manutej/maat-terminal/internal/config"
+)
+
+// runSQL implements `maat sql "SELECT ..."`: a read-only query escape hatch
+// against the graph store, printing results as an aligned table.
+func runSQL(args []string) int {
+	fs := flag.NewFlagSet("sql", flag.ExitOnError)
+	dbPath := fs.String("db", "", "Path to the graph database (default from config)")
+	projectPath := fs.String("path", ".", "Project whose graph database to use (without --db or a configured one)")
+	configPath := fs.String("config", config.DefaultPath(), "Path to the config file")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: maat sql [--db path | --path project] \"SELECT ...\"")
+		fmt.Fprintln(os.Stderr, "\nBuilt-in views: issue_dependencies, pr_file_map, license_report")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	query := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(query) == "" {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (using defaults)\n", err)
+	}
+
+	absPath, err := filepath.Abs(*projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid path %q: %v\n", *projectPath, err)
+		return 2
+	}
+	store, err := openStore(resolveDBPath(*dbPath, cfg, absPath), cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening store: %v\n", err)
+		return 1
+	}
+	defer func() { _ = store.Close() }()
+
+	result, err := store.Query(query)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(result.Columns, "\t"))
+	separators := make([]string, len(result.Columns))
+	for i, col := range result.Columns {
+		separators[i] = strings.Repeat("-", len(col))
+	}
+	fmt.Fprintln(w, strings.Join(separators, "\t"))
+	for _, row := range result.Rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	_ = w.Flush()
+
+	fmt.Fprintf(os.Stderr, "(%d rows)\n", len(result.Rows))
+	return 0
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/datasource"
+	"github.com/manutej/maat-terminal/internal/tui"
+)
+
+// runStandup implements `maat standup`: prints the viewer's yesterday /
+// today / blockers as Markdown, ready to paste into a chat or read aloud.
+func runStandup(args []string) int {
+	fs := flag.NewFlagSet("standup", flag.ExitOnError)
+	projectPath := fs.String("path", ".", "Project path to scan")
+	useMock := fs.Bool("mock", false, "Use mock data instead of scanning")
+	mockLinear := fs.Bool("mock-linear", false, "Load Linear issues from an in-process fake API")
+	maxCommits := fs.Int("commits", 50, "Maximum number of commits to load")
+	maxFiles := fs.Int("max-files", 200, "Maximum number of files to scan")
+	configPath := fs.String("config", config.DefaultPath(), "Path to the config file")
+	me := fs.String("me", os.Getenv("GITHUB_USER"), "Your GitHub login, name or email")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: maat standup [flags]")
+		fmt.Fprintln(os.Stderr, "\nYesterday: your merged PRs, commits and completed issues from the last day.")
+		fmt.Fprintln(os.Stderr, "Today: your in-progress issues. Blockers: your blocked issues.")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (using defaults)\n", err)
+	}
+	viewer := *me
+	if viewer == "" {
+		viewer = cfg.Me
+	}
+
+	absPath, err := filepath.Abs(*projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid path %q: %v\n", *projectPath, err)
+		return 1
+	}
+
+	// Redaction guards what gets stored; refuse to load rather than skip a bad rule
+	redactor, err := datasource.NewRedactor(cfg.Redact)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid redaction config: %v\n", err)
+		return 1
+	}
+
+	loader, cleanup := newLoader(absPath, sourceOptions{
+		mock:       *useMock,
+		mockLinear: *mockLinear,
+		git:        true,
+		files:      true,
+		maxCommits: *maxCommits,
+		maxFiles:   *maxFiles,
+		people:     cfg.People,
+		vault:      resolveVault("", cfg),
+		localTasks: resolveLocalTasks("", cfg),
+		mail:       resolveMail("", "", cfg),
+		redactor:   redactor,
+		autoLink:   cfg.AutoLinkRules(),
+	})
+	defer cleanup()
+
+	nodes, edges, err := loader.LoadAll(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load data: %v\n", err)
+		return 1
+	}
+
+	model := tui.NewModelWithData(nodes, edges, absPath).WithViewer(viewer)
+	standup, ok := model.Standup(time.Now())
+	if !ok {
+		fmt.Fprintf(os.Stderr, "No person matches %q; set --me or \"me\" in the config\n", viewer)
+		return 1
+	}
+	fmt.Print(standup.Markdown())
+	return 0
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/datasource"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui"
+)
+
+// runTrace implements `maat trace <issue|file|person|release>`: lists the PRs
+// implementing an issue, the commits referencing it and the files that work
+// touched. Given a file it runs the reverse (impact) trace instead; given a
+// person or team it lists everything involving them; given a release (v1.2)
+// it lists what shipped in it.
+func runTrace(args []string) int {
+	fs := flag.NewFlagSet("trace", flag.ExitOnError)
+	projectPath := fs.String("path", ".", "Project path to scan")
+	useMock := fs.Bool("mock", false, "Use mock data instead of scanning")
+	mockLinear := fs.Bool("mock-linear", false, "Load Linear issues from an in-process fake API")
+	maxCommits := fs.Int("commits", 50, "Maximum number of commits to load")
+	maxFiles := fs.Int("max-files", 200, "Maximum number of files to scan")
+	configPath := fs.String("config", config.DefaultPath(), "Path to the config file")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: maat trace [flags] <issue|file|person|release>")
+		fmt.Fprintln(os.Stderr, "\nThe target is a node ID (issue:12), short reference (CET-352, 12), file path or person (alice).")
+		fmt.Fprintln(os.Stderr, "Files get an impact report: the work touching them and the issues behind it.")
+		fmt.Fprintln(os.Stderr, "People and teams list the work assigned to them, written by them and owned by them.")
+		fmt.Fprintln(os.Stderr, "Releases (a tag such as v1.2) list the commits and issues that shipped in them.")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	ref := fs.Arg(0)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (using defaults)\n", err)
+	}
+
+	absPath, err := filepath.Abs(*projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid path %q: %v\n", *projectPath, err)
+		return 1
+	}
+
+	// Redaction guards what gets stored; refuse to load rather than skip a bad rule
+	redactor, err := datasource.NewRedactor(cfg.Redact)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid redaction config: %v\n", err)
+		return 1
+	}
+
+	loader, cleanup := newLoader(absPath, sourceOptions{
+		mock:       *useMock,
+		mockLinear: *mockLinear,
+		git:        true,
+		files:      true,
+		maxCommits: *maxCommits,
+		maxFiles:   *maxFiles,
+		people:     cfg.People,
+		vault:      resolveVault("", cfg),
+		localTasks: resolveLocalTasks("", cfg),
+		mail:       resolveMail("", "", cfg),
+		redactor:   redactor,
+		autoLink:   cfg.AutoLinkRules(),
+	})
+	defer cleanup()
+
+	nodes, edges, err := loader.LoadAll(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load data: %v\n", err)
+		return 1
+	}
+
+	model := tui.NewModelWithData(nodes, edges, absPath)
+	target, ok := model.ResolveNodeRef(ref)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "No node matches %q\n", ref)
+		return 1
+	}
+
+	fmt.Printf("%s  %s", target.ID, target.Title)
+	if target.Status != "" {
+		fmt.Printf("  [%s]", target.Status)
+	}
+	fmt.Println()
+
+	var sections []traceSection
+	switch target.Type {
+	case graph.NodeTypeFile:
+		impact := model.Impact(target.ID)
+		if impact.IsEmpty() {
+			fmt.Println("\nNo tracked commits or PRs touch this file.")
+			return 0
+		}
+		sections = []traceSection{
+			{"Issues", impact.Issues},
+			{"Pull requests", impact.PRs},
+			{"Commits", impact.Commits},
+			{"Projects", impact.Projects},
+		}
+	case graph.NodeTypePerson, graph.NodeTypeTeam:
+		involvement := model.Involvement(target.ID)
+		if involvement.IsEmpty() {
+			fmt.Println("\nNothing in the graph involves them.")
+			return 0
+		}
+		sections = []traceSection{
+			{"Assigned", involvement.Assigned},
+			{"Authored", involvement.Authored},
+			{"Owns", involvement.Owned},
+		}
+	case graph.NodeTypeRelease:
+		release := model.Shipped(target.ID)
+		if release.IsEmpty() {
+			fmt.Println("\nThe tagged commit is not among the loaded commits (raise --commits).")
+			return 0
+		}
+		sections = []traceSection{
+			{"Issues", release.Issues},
+			{"Commits", release.Commits},
+		}
+	default:
+		trace := model.Trace(target.ID)
+		if trace.IsEmpty() {
+			fmt.Println("\nNo PRs, commits or files reference this issue.")
+			return 0
+		}
+		sections = []traceSection{
+			{"Pull requests", trace.PRs},
+			{"Commits", trace.Commits},
+			{"Files", trace.Files},
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, section := range sections {
+		fmt.Fprintf(w, "\n%s (%d)\n", section.label, len(section.nodes))
+		for _, node := range section.nodes {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", node.ID, node.Title, node.Status)
+		}
+	}
+	_ = w.Flush()
+	return 0
+}
+
+// traceSection is one labelled list in the trace output
+type traceSection struct {
+	label string
+	nodes []tui.DisplayNode
+}
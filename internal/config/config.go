@@ -0,0 +1,300 @@
+// Package config loads MAAT's YAML configuration.
+// Following Commandment #9 (Terminal Citizenship): a missing config file is
+// not an error - every setting has a sensible default.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/credentials"
+	"github.com/manutej/maat-terminal/internal/paths"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the root of the user configuration file
+type Config struct {
+	Database       DatabaseConfig            `yaml:"database"`
+	SavedQueries   []SavedQuery              `yaml:"saved_queries"`
+	People         []Person                  `yaml:"people"`
+	Me             string                    `yaml:"me"` // Your name, GitHub login or email (default for --me)
+	Obsidian       ObsidianConfig            `yaml:"obsidian"`
+	LocalTasks     []string                  `yaml:"local_tasks"` // todo.txt files or Taskwarrior exports
+	Mail           MailConfig                `yaml:"mail"`
+	Hooks          HooksConfig               `yaml:"hooks"`
+	Redact         []RedactRule              `yaml:"redact"`          // Masks sensitive text in node data as it is loaded
+	TTL            []TTLRule                 `yaml:"ttl"`             // When idle nodes leave the working graph (default: commits after 90 days)
+	AutoLink       []AutoLinkRule            `yaml:"autolink"`        // References in node text that become mentions edges (default: identifiers, paths, PR numbers)
+	Script         string                    `yaml:"script"`          // Starlark rules file (default maat.star in the config directory when present)
+	Details        map[string]string         `yaml:"details"`         // Details view text/template per node type ("Issue", ...) or "default"
+	StatusBar      []string                  `yaml:"status_bar"`      // Status bar segments in order (view, filters, sync, errors, focused, keys, ...)
+	NodeTypes      map[string]NodeTypeConfig `yaml:"node_types"`      // Icon/color overrides per node type ("Issue", ...)
+	ProjectColors  map[string]string         `yaml:"project_colors"`  // Accent per project name, ANSI 256 code or hex (default: picked from the name)
+	Confirm        map[string]string         `yaml:"confirm"`         // When writes ask first, per action category (edit, delete, write_back, queue, default): always, destructive or never
+	RateLimits     map[string]RateLimit      `yaml:"rate_limits"`     // API call limits per provider (linear, github); unset fields keep the defaults
+	IssueTemplates []IssueTemplate           `yaml:"issue_templates"` // Presets offered when creating an issue (default: bug, chore, spike)
+	Metrics        []MetricRule              `yaml:"metrics"`         // Computed fields shown as tree badges and Table view columns
+}
+
+// Storage backends for the graph store
+const (
+	BackendSQLite   = "sqlite"   // A local file (the default)
+	BackendPostgres = "postgres" // A database shared by the team
+	BackendMemory   = "memory"   // In process only; nothing is kept after exit
+)
+
+// DatabaseConfig controls where the graph store lives
+type DatabaseConfig struct {
+	Backend string             `yaml:"backend"` // sqlite (default) | postgres | memory
+	Path    string             `yaml:"path"`    // SQLite file
+	URL     string             `yaml:"url"`     // Postgres connection URL; keep the password in $PGPASSWORD or ~/.pgpass
+	Encrypt bool               `yaml:"encrypt"` // Keep the store encrypted at rest (SQLite only)
+	Key     credentials.Source `yaml:"key"`     // Encryption passphrase (default $MAAT_DB_KEY)
+}
+
+// Shared reports whether the store is shared with a team rather than local.
+func (d DatabaseConfig) Shared() bool {
+	return d.Backend == BackendPostgres
+}
+
+// KeySource returns where the encryption passphrase comes from.
+func (d DatabaseConfig) KeySource() credentials.Source {
+	if d.Key.IsZero() {
+		return credentials.Source{Env: "MAAT_DB_KEY"}
+	}
+	return d.Key
+}
+
+// ObsidianConfig points at a personal knowledge base to merge into the graph
+type ObsidianConfig struct {
+	Vault string `yaml:"vault"` // Vault directory; "~/" expands to the home directory
+}
+
+// MailConfig points at a mailbox whose labelled threads become discussions
+type MailConfig struct {
+	Path  string `yaml:"path"`  // Maildir directory or mbox file; "~/" expands to the home directory
+	Label string `yaml:"label"` // Label marking decision threads (default "decision")
+}
+
+// HooksConfig lists shell commands run on sync events. Each command runs
+// with "sh -c" and receives the event as JSON on stdin.
+type HooksConfig struct {
+	PreSync       []string `yaml:"pre_sync"`        // Before sources load
+	PostSync      []string `yaml:"post_sync"`       // After the graph is merged
+	OnNodeChanged []string `yaml:"on_node_changed"` // Once per node added, updated or removed since the last sync
+}
+
+// RedactRule masks sensitive text in node data as sources load it, before
+// anything reaches the store, hooks or exports. A rule without a pattern or
+// preset replaces the listed fields outright.
+type RedactRule struct {
+	Pattern string   `yaml:"pattern,omitempty"` // Regular expression to mask
+	Preset  string   `yaml:"preset,omitempty"`  // Built-in patterns: "secrets" (API tokens, private keys)
+	Fields  []string `yaml:"fields,omitempty"`  // Data fields to apply to, nested ones included (default: all)
+	Types   []string `yaml:"types,omitempty"`   // Node types to apply to ("Issue", "Commit"; default: all)
+	Replace string   `yaml:"replace,omitempty"` // Replacement, may use $1 (default "[redacted]")
+}
+
+// TTLRule drops idle nodes of a type and/or source from the working graph;
+// the store keeps them in its archive tables. The first matching rule applies.
+type TTLRule struct {
+	Type   string `yaml:"type,omitempty"`   // Node type ("Commit"; default: any)
+	Source string `yaml:"source,omitempty"` // Source ("git", "linear"; default: any)
+	MaxAge string `yaml:"max_age"`          // "90d", "2w", "36h"; "0" keeps matching nodes forever
+}
+
+// DefaultTTL applies when the config has no ttl section; "ttl: []" turns
+// expiry off.
+var DefaultTTL = []TTLRule{{Type: "Commit", MaxAge: "90d"}}
+
+// TTLRules returns the configured TTL rules, or DefaultTTL when unset.
+func (c Config) TTLRules() []TTLRule {
+	if c.TTL == nil {
+		return DefaultTTL
+	}
+	return c.TTL
+}
+
+// AutoLinkRule turns references written in node text (descriptions, commit
+// messages) into "mentions" edges to the nodes they name, once every source
+// has loaded. References naming no loaded node are ignored.
+type AutoLinkRule struct {
+	Preset  string   `yaml:"preset,omitempty"`  // Built-in rule: "identifiers" (CET-352), "paths" (internal/tui/view.go), "prs" (#42, PR 42)
+	Pattern string   `yaml:"pattern,omitempty"` // Regular expression; its first group (or the whole match) is the reference
+	Target  string   `yaml:"target,omitempty"`  // What the reference is: "identifier" (default), "path", "pr" or "id"
+	Types   []string `yaml:"types,omitempty"`   // Node types whose text is scanned ("Issue", "Commit"; default: all)
+	Fields  []string `yaml:"fields,omitempty"`  // Data fields scanned (default: title, description, message, body)
+}
+
+// DefaultAutoLink applies when the config has no autolink section;
+// "autolink: []" turns auto-linking off.
+var DefaultAutoLink = []AutoLinkRule{{Preset: "identifiers"}, {Preset: "paths"}, {Preset: "prs"}}
+
+// AutoLinkRules returns the configured auto-link rules, or DefaultAutoLink
+// when unset.
+func (c Config) AutoLinkRules() []AutoLinkRule {
+	if c.AutoLink == nil {
+		return DefaultAutoLink
+	}
+	return c.AutoLink
+}
+
+// ParseAge parses a TTL age: a Go duration ("36h") or a whole number of
+// days or weeks ("90d", "2w").
+func ParseAge(age string) (time.Duration, error) {
+	age = strings.TrimSpace(age)
+	if n, ok := strings.CutSuffix(age, "d"); ok {
+		days, err := strconv.Atoi(n)
+		if err != nil || days < 0 {
+			return 0, fmt.Errorf("invalid age %q", age)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	if n, ok := strings.CutSuffix(age, "w"); ok {
+		weeks, err := strconv.Atoi(n)
+		if err != nil || weeks < 0 {
+			return 0, fmt.Errorf("invalid age %q", age)
+		}
+		return time.Duration(weeks) * 7 * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(age)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q", age)
+	}
+	return d, nil
+}
+
+// NodeTypeConfig restyles a node type. Empty fields keep the built-in style;
+// types MAAT does not know yet (e.g. from plugins) can be styled the same way.
+type NodeTypeConfig struct {
+	Icon     string `yaml:"icon"`     // Emoji or glyph shown before titles
+	Color    string `yaml:"color"`    // ANSI 256 code ("214") or hex ("#F59E0B")
+	Label    string `yaml:"label"`    // Plain-text name in accessible mode
+	Priority *int   `yaml:"priority"` // Sort rank among siblings, lower first (built-in types use 0-12)
+}
+
+// IssueTemplate prefills the new-issue form (n key): a title prefix,
+// labels and the project the issue goes under.
+type IssueTemplate struct {
+	Name        string   `yaml:"name"`
+	TitlePrefix string   `yaml:"title_prefix,omitempty"` // e.g. "Bug: "
+	Labels      []string `yaml:"labels,omitempty"`
+	Project     string   `yaml:"project,omitempty"`     // Project name or node ID (default: the focused node's project)
+	Description string   `yaml:"description,omitempty"` // Starting description, e.g. headings to fill in
+}
+
+// DefaultIssueTemplates apply when the config has no issue_templates
+// section; "issue_templates: []" offers a blank issue only.
+var DefaultIssueTemplates = []IssueTemplate{
+	{Name: "bug", TitlePrefix: "Bug: ", Labels: []string{"bug"}, Description: "Steps to reproduce:\n\nExpected:\n\nActual:\n"},
+	{Name: "chore", TitlePrefix: "Chore: ", Labels: []string{"chore"}},
+	{Name: "spike", TitlePrefix: "Spike: ", Labels: []string{"spike"}, Description: "Question:\n\nTimebox:\n"},
+}
+
+// IssueTemplateList returns the configured issue templates, or
+// DefaultIssueTemplates when unset.
+func (c Config) IssueTemplateList() []IssueTemplate {
+	if c.IssueTemplates == nil {
+		return DefaultIssueTemplates
+	}
+	return c.IssueTemplates
+}
+
+// MetricRule is a user-defined computed field: an expression over a node's
+// data fields, or a count of the nodes related to it. Set one of Expr and
+// Count.
+type MetricRule struct {
+	Name  string       `yaml:"name"`            // Badge label and Table view column
+	Expr  string       `yaml:"expr,omitempty"`  // Starlark expression over data fields, e.g. "estimate * priority"
+	Count *MetricCount `yaml:"count,omitempty"` // Related nodes to count instead
+	Types []string     `yaml:"types,omitempty"` // Node types that get the metric ("Project"; default: all)
+}
+
+// MetricCount selects the related nodes a count metric counts. Empty
+// fields match everything.
+type MetricCount struct {
+	Relation  string `yaml:"relation,omitempty"`  // Edge relation ("owns", "blocks")
+	Type      string `yaml:"type,omitempty"`      // Related node type ("Issue")
+	Status    string `yaml:"status,omitempty"`    // all | active | not_done | done
+	Direction string `yaml:"direction,omitempty"` // out (edges from the node) | in (edges to it) | both (default)
+}
+
+// RateLimit caps how hard maat calls one provider's API. Zero keeps the
+// built-in limit.
+type RateLimit struct {
+	Concurrency int `yaml:"concurrency"` // Calls running at once
+	PerMinute   int `yaml:"per_minute"`  // Calls started per minute
+}
+
+// SavedQuery is a named combination of filters - a terminal equivalent of
+// Linear's custom views. Empty fields leave that dimension unfiltered.
+type SavedQuery struct {
+	Name   string `yaml:"name"`
+	Filter string `yaml:"filter,omitempty"` // all | projects | issues | prs | files | commits | people | docs
+	Status string `yaml:"status,omitempty"` // all | active | not_done | done
+	Search string `yaml:"search,omitempty"` // case-insensitive title match
+	SQL    string `yaml:"sql,omitempty"`    // SELECT whose first column is node IDs
+}
+
+// Person maps one human's accounts across sources so that their git
+// commits, Linear issues and GitHub reviews land on a single Person node.
+// Accounts not listed here are still merged by email/username heuristics.
+type Person struct {
+	Name    string   `yaml:"name"`              // Display name of the merged node
+	Emails  []string `yaml:"emails,omitempty"`  // Git author / Linear account emails
+	GitHub  string   `yaml:"github,omitempty"`  // GitHub login, without "@"
+	Linear  string   `yaml:"linear,omitempty"`  // Linear display name
+	Aliases []string `yaml:"aliases,omitempty"` // Other names the person commits under
+}
+
+// Dir returns the MAAT configuration directory (see the paths package)
+func Dir() string {
+	return paths.Config()
+}
+
+// DefaultScriptPath returns where a Starlark rules file is picked up without configuration
+func DefaultScriptPath() string {
+	return filepath.Join(Dir(), "maat.star")
+}
+
+// DefaultPath returns the default config file location
+func DefaultPath() string {
+	return filepath.Join(Dir(), "config.yaml")
+}
+
+// Load reads the config file at path. A missing file yields an empty Config.
+func Load(path string) (Config, error) {
+	var cfg Config
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("reading config: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+
+	cfg.Database.Path = ExpandHome(cfg.Database.Path)
+	return cfg, nil
+}
+
+// ExpandHome replaces a leading ~ with the user's home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
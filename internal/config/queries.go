@@ -0,0 +1,51 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/manutej/maat-terminal/internal/paths"
+	"gopkg.in/yaml.v3"
+)
+
+// Interactively saved queries live in their own file so that rewriting them
+// never clobbers comments or formatting in the hand-edited config.yaml.
+
+// QueriesPath returns the location of interactively saved queries
+func QueriesPath() string {
+	return filepath.Join(paths.Data(), "queries.yaml")
+}
+
+// LoadSavedQueries reads saved queries from path. A missing file yields none.
+func LoadSavedQueries(path string) ([]SavedQuery, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading saved queries: %w", err)
+	}
+
+	var queries []SavedQuery
+	if err := yaml.Unmarshal(data, &queries); err != nil {
+		return nil, fmt.Errorf("parsing saved queries %s: %w", path, err)
+	}
+	return queries, nil
+}
+
+// WriteSavedQueries replaces the saved queries file at path
+func WriteSavedQueries(path string, queries []SavedQuery) error {
+	data, err := yaml.Marshal(queries)
+	if err != nil {
+		return fmt.Errorf("encoding saved queries: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing saved queries: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/paths"
+	"gopkg.in/yaml.v3"
+)
+
+// PRs marked as viewed in the review queue are remembered locally only -
+// nothing is written back to GitHub.
+
+// ReviewedPath returns the location of the locally viewed PRs
+func ReviewedPath() string {
+	return filepath.Join(paths.Data(), "reviewed.yaml")
+}
+
+// LoadReviewedPRs reads when each PR was marked viewed. A missing file yields none.
+func LoadReviewedPRs(path string) (map[string]time.Time, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading viewed PRs: %w", err)
+	}
+
+	var viewed map[string]time.Time
+	if err := yaml.Unmarshal(data, &viewed); err != nil {
+		return nil, fmt.Errorf("parsing viewed PRs %s: %w", path, err)
+	}
+	return viewed, nil
+}
+
+// WriteReviewedPRs replaces the viewed PRs file at path
+func WriteReviewedPRs(path string, viewed map[string]time.Time) error {
+	data, err := yaml.Marshal(viewed)
+	if err != nil {
+		return fmt.Errorf("encoding viewed PRs: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing viewed PRs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/manutej/maat-terminal/internal/paths"
+	"gopkg.in/yaml.v3"
+)
+
+// SessionState is where the last session left off: saved on quit and
+// restored on the next start.
+type SessionState struct {
+	Focused string `yaml:"focused,omitempty"` // Node ID
+	Filter  string `yaml:"filter,omitempty"`  // Type filter key ("issues")
+	Status  string `yaml:"status,omitempty"`  // Status filter key ("not_done")
+}
+
+// SessionPath returns the location of the saved session state
+func SessionPath() string {
+	return filepath.Join(paths.State(), "session.yaml")
+}
+
+// LoadSession reads the saved session state. A missing file yields an empty state.
+func LoadSession(path string) (SessionState, error) {
+	var state SessionState
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("reading session: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return SessionState{}, fmt.Errorf("parsing session %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// WriteSession replaces the session state file at path
+func WriteSession(path string, state SessionState) error {
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encoding session: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/manutej/maat-terminal/internal/paths"
+)
+
+// The sync state remembers a digest of every node from the last sync of a
+// project, by source, so on-node-changed hooks fire only for what actually
+// changed in the sources that loaded.
+
+// SyncState maps source name -> node ID -> digest
+type SyncState map[string]map[string]string
+
+// SyncStatePath returns the location of a project's last sync's node digests
+func SyncStatePath(projectPath string) string {
+	return filepath.Join(paths.State(), "sync-state", paths.ProjectKey(projectPath)+".json")
+}
+
+// LoadSyncState reads the node digests from the last sync. A missing file
+// yields nil (no previous sync), distinct from an empty graph.
+func LoadSyncState(path string) (SyncState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading sync state: %w", err)
+	}
+
+	state := SyncState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing sync state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// WriteSyncState replaces the sync state file at path
+func WriteSyncState(path string, state SyncState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encoding sync state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing sync state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+// Package crash keeps a panic inside the TUI from leaving the terminal
+// unusable. A Guard wraps the program's model: a panic in Update, View or a
+// command is recovered, written to a crash report with the model's state and
+// the messages that led up to it, and the program quits cleanly so Bubble Tea
+// restores the terminal on its normal shutdown path.
+package crash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// IssuesURL is where crash reports should be filed.
+const IssuesURL = "https://github.com/manutej/maat-terminal/issues/new"
+
+// historySize is how many recent messages a report includes
+const historySize = 30
+
+// maxLineLen caps a remembered message, in bytes
+const maxLineLen = 240
+
+// sequenceType is the unexported message tea.Sequence commands return, which
+// the runtime only runs in order when it keeps that type
+var sequenceType = reflect.TypeOf(tea.Sequence(func() tea.Msg { return nil }, func() tea.Msg { return nil })())
+
+// Summarizer is implemented by models that can describe their state for a report.
+type Summarizer interface {
+	CrashSummary() string
+}
+
+// wrapper is implemented by models that wrap another (recorders, players)
+type wrapper interface {
+	Model() tea.Model
+}
+
+// Report is a recovered panic with the context needed to reproduce it.
+type Report struct {
+	Time     time.Time
+	Version  string
+	Where    string // "update", "view" or "command"
+	Panic    string
+	Stack    string
+	Summary  string   // Model state when the panic happened
+	Messages []string // Most recent last
+}
+
+// panicMsg carries a panic recovered inside a command back to Update
+type panicMsg struct {
+	value any
+	stack string
+}
+
+// state is shared by every copy of a Guard
+type state struct {
+	start   time.Time
+	history []string
+	report  *Report
+}
+
+// Guard wraps a model and turns panics into crash reports. It is a tea.Model
+// with value semantics.
+type Guard struct {
+	model   tea.Model
+	version string
+	state   *state
+}
+
+// buildVersion identifies the binary: module version and VCS revision when known
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	version := info.Main.Version
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" && len(setting.Value) >= 12 {
+			version += " " + setting.Value[:12]
+		}
+	}
+	return version + " " + info.GoVersion
+}
+
+// NewGuard wraps model.
+func NewGuard(model tea.Model) Guard {
+	return Guard{model: model, version: buildVersion(), state: &state{start: time.Now()}}
+}
+
+// Report returns the crash, or nil when the program did not panic.
+func (g Guard) Report() *Report {
+	return g.state.report
+}
+
+// Init starts the wrapped model.
+func (g Guard) Init() tea.Cmd {
+	return guardCmd(g.model.Init())
+}
+
+// Update forwards the message, recovering any panic it causes.
+func (g Guard) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	if g.state.report != nil {
+		return g, nil
+	}
+	if p, ok := msg.(panicMsg); ok {
+		g.crash("command", p.value, p.stack)
+		return g, tea.Quit
+	}
+	g.remember(msg)
+
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash("update", r, string(debug.Stack()))
+			model, cmd = g, tea.Quit
+		}
+	}()
+	g.model, cmd = g.model.Update(msg)
+	return g, guardCmd(cmd)
+}
+
+// View renders the wrapped model, or nothing once it has crashed.
+func (g Guard) View() (view string) {
+	if g.state.report != nil {
+		return ""
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			g.crash("view", r, string(debug.Stack()))
+			view = ""
+		}
+	}()
+	return g.model.View()
+}
+
+// remember keeps a printed copy of the last messages
+func (g Guard) remember(msg tea.Msg) {
+	line := fmt.Sprintf("+%s %T %+v", time.Since(g.state.start).Round(time.Millisecond), msg, msg)
+	if len(line) > maxLineLen {
+		cut := maxLineLen
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		line = line[:cut] + "…"
+	}
+	g.state.history = append(g.state.history, line)
+	if len(g.state.history) > historySize {
+		g.state.history = g.state.history[len(g.state.history)-historySize:]
+	}
+}
+
+// crash records the first panic; later ones are fallout from it
+func (g Guard) crash(where string, value any, stack string) {
+	if g.state.report != nil {
+		return
+	}
+	g.state.report = &Report{
+		Time:     time.Now(),
+		Version:  g.version,
+		Where:    where,
+		Panic:    fmt.Sprint(value),
+		Stack:    stack,
+		Summary:  summarize(g.model),
+		Messages: append([]string(nil), g.state.history...),
+	}
+}
+
+// summarize asks the innermost model that can describe itself
+func summarize(model tea.Model) (summary string) {
+	defer func() {
+		if r := recover(); r != nil {
+			summary = fmt.Sprintf("(summary unavailable: %v)", r)
+		}
+	}()
+	for model != nil {
+		if s, ok := model.(Summarizer); ok {
+			return s.CrashSummary()
+		}
+		w, ok := model.(wrapper)
+		if !ok {
+			break
+		}
+		model = w.Model()
+	}
+	return fmt.Sprintf("(%T has no summary)", model)
+}
+
+// guardCmd runs cmd with panics recovered and sent back as messages.
+// Batches and sequences are unwrapped so each command in them is guarded too.
+func guardCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = panicMsg{value: r, stack: string(debug.Stack())}
+			}
+		}()
+		msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			guarded := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				guarded[i] = guardCmd(c)
+			}
+			return guarded
+		}
+		if reflect.TypeOf(msg) == sequenceType {
+			sequence := reflect.ValueOf(msg)
+			guarded := reflect.MakeSlice(sequenceType, sequence.Len(), sequence.Len())
+			for i := 0; i < sequence.Len(); i++ {
+				c, _ := sequence.Index(i).Interface().(tea.Cmd)
+				guarded.Index(i).Set(reflect.ValueOf(guardCmd(c)))
+			}
+			return guarded.Interface()
+		}
+		return msg
+	}
+}
+
+// String formats the report as a plain-text file to attach to an issue.
+func (r Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "MAAT crash report\n\n")
+	fmt.Fprintf(&b, "Time:    %s\n", r.Time.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version: %s\n", r.Version)
+	fmt.Fprintf(&b, "Panic:   %s (in %s)\n\n", r.Panic, r.Where)
+	fmt.Fprintf(&b, "== Model ==\n%s\n\n", strings.TrimRight(r.Summary, "\n"))
+	fmt.Fprintf(&b, "== Last %d messages ==\n", len(r.Messages))
+	for _, msg := range r.Messages {
+		fmt.Fprintf(&b, "%s\n", msg)
+	}
+	fmt.Fprintf(&b, "\n== Stack ==\n%s", r.Stack)
+	return b.String()
+}
+
+// Write saves the report in dir as crash-<time>.txt and returns its path.
+func (r Report) Write(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("writing crash report: %w", err)
+	}
+	path := filepath.Join(dir, "crash-"+r.Time.Format("20060102-150405")+".txt")
+	if err := os.WriteFile(path, []byte(r.String()), 0o644); err != nil {
+		return "", fmt.Errorf("writing crash report: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,115 @@
+package crash
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fragile panics on "p" in Update, in a batched command on "c" and in a
+// sequenced one on "s"
+type fragile struct {
+	keys int
+}
+
+func (f fragile) Init() tea.Cmd { return nil }
+
+func (f fragile) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return f, nil
+	}
+	f.keys++
+	switch key.String() {
+	case "p":
+		panic("boom in update")
+	case "c":
+		return f, tea.Batch(nil, func() tea.Msg { panic("boom in command") })
+	case "s":
+		return f, tea.Sequence(func() tea.Msg { return nil }, func() tea.Msg { panic("boom in sequence") })
+	}
+	return f, nil
+}
+
+func (f fragile) View() string { return "ok" }
+
+func (f fragile) CrashSummary() string { return fmt.Sprintf("keys pressed: %d", f.keys) }
+
+func press(key string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// TestGuardRecoversUpdatePanic checks that a panic becomes a report and a quit.
+func TestGuardRecoversUpdatePanic(t *testing.T) {
+	var model tea.Model = NewGuard(fragile{})
+	model, _ = model.Update(press("j"))
+	model, cmd := model.Update(press("p"))
+
+	report := model.(Guard).Report()
+	if report == nil {
+		t.Fatal("no report after a panic in Update")
+	}
+	if cmd == nil {
+		t.Fatal("no quit command after a panic")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("the command after a panic does not quit")
+	}
+	text := report.String()
+	for _, want := range []string{"boom in update", "(in update)", "keys pressed: 1", "tea.KeyMsg j", "crash_test.go"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("report is missing %q:\n%s", want, text)
+		}
+	}
+	if view := model.View(); view != "" {
+		t.Errorf("View after a crash = %q, want nothing", view)
+	}
+}
+
+// TestGuardRecoversCommandPanic runs the real runtime: a panic inside a
+// batched command must end the program normally, with a report.
+func TestGuardRecoversCommandPanic(t *testing.T) {
+	guard := NewGuard(fragile{})
+	p := tea.NewProgram(guard, tea.WithInput(nil), tea.WithOutput(io.Discard), tea.WithoutRenderer())
+	go p.Send(press("c"))
+	if _, err := p.Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	report := guard.Report()
+	if report == nil || report.Where != "command" || report.Panic != "boom in command" {
+		t.Fatalf("report = %+v, want the command panic", report)
+	}
+}
+
+// TestGuardRecoversSequencePanic checks commands run by tea.Sequence (the
+// shutdown saves) are guarded like batched ones.
+func TestGuardRecoversSequencePanic(t *testing.T) {
+	guard := NewGuard(fragile{})
+	p := tea.NewProgram(guard, tea.WithInput(nil), tea.WithOutput(io.Discard), tea.WithoutRenderer())
+	go p.Send(press("s"))
+	if _, err := p.Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	report := guard.Report()
+	if report == nil || report.Where != "command" || report.Panic != "boom in sequence" {
+		t.Fatalf("report = %+v, want the sequence panic", report)
+	}
+}
+
+// TestRememberCutsOnARune checks a long message is shortened without
+// splitting a multi-byte character.
+func TestRememberCutsOnARune(t *testing.T) {
+	guard := NewGuard(fragile{})
+	guard.remember(press(strings.Repeat("é", 200)))
+	line := guard.state.history[0]
+	if !utf8.ValidString(line) {
+		t.Errorf("remembered line is not valid UTF-8: %q", line)
+	}
+	if len(line) > maxLineLen+len("…") {
+		t.Errorf("remembered line is %d bytes, want at most %d", len(line), maxLineLen+len("…"))
+	}
+}
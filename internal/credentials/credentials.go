@@ -0,0 +1,73 @@
+// Package credentials resolves secrets MAAT needs without keeping them in
+// its config file: from an environment variable, or from the output of a
+// command such as a password manager or the OS keychain CLI.
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// commandTimeout bounds a key command, which may prompt (a GPG pinentry)
+const commandTimeout = 2 * time.Minute
+
+// Source says where a secret comes from. Command wins over Env when both
+// are set and the command succeeds.
+type Source struct {
+	Env     string `yaml:"env,omitempty"`     // Environment variable holding the secret
+	Command string `yaml:"command,omitempty"` // Shell command printing the secret, e.g. "pass show maat/db"
+}
+
+// IsZero reports whether no source is configured.
+func (s Source) IsZero() bool {
+	return s.Env == "" && s.Command == ""
+}
+
+// String describes the source without revealing the secret.
+func (s Source) String() string {
+	switch {
+	case s.Command != "" && s.Env != "":
+		return fmt.Sprintf("command %q or $%s", s.Command, s.Env)
+	case s.Command != "":
+		return fmt.Sprintf("command %q", s.Command)
+	case s.Env != "":
+		return "$" + s.Env
+	}
+	return "no source"
+}
+
+// Resolve returns the secret. Trailing newlines from a command are trimmed;
+// everything else is kept verbatim.
+func (s Source) Resolve(ctx context.Context) (string, error) {
+	if s.Command != "" {
+		ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, "sh", "-c", s.Command)
+		var stderr bytes.Buffer
+		cmd.Stdin = os.Stdin // Let the command prompt for a passphrase
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err == nil {
+			if secret := strings.TrimRight(string(out), "\r\n"); secret != "" {
+				return secret, nil
+			}
+			err = fmt.Errorf("printed nothing")
+		} else if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		if s.Env == "" {
+			return "", fmt.Errorf("key command %q: %w", s.Command, err)
+		}
+	}
+	if s.Env != "" {
+		if secret := os.Getenv(s.Env); secret != "" {
+			return secret, nil
+		}
+	}
+	return "", fmt.Errorf("no secret found (%s)", s)
+}
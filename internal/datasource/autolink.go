@@ -0,0 +1,244 @@
+package datasource
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// Targets an auto-link reference can name
+const (
+	linkIdentifier = "identifier" // An issue identifier, CET-352
+	linkPath       = "path"       // A scanned file's path
+	linkPR         = "pr"         // A pull request number
+	linkID         = "id"         // A node ID
+)
+
+// autoLinkPresets are the built-in rules, by preset name
+var autoLinkPresets = map[string]config.AutoLinkRule{
+	"identifiers": {Pattern: `\b([A-Z][A-Z0-9]{1,9}-\d+)\b`, Target: linkIdentifier},
+	"paths": {
+		Pattern: `(?:^|[\s(\x60'"])((?:[\w.-]+/)+[\w.-]+\.\w+|[\w-]+\.(?:go|js|ts|tsx|jsx|py|rb|rs|java|kt|c|cpp|h|hpp|md|yaml|yml|json|toml|html|css|scss))\b`,
+		Target:  linkPath,
+	},
+	"prs": {Pattern: `(?i)(?:#|\bPR\s?#?)(\d+)\b`, Target: linkPR},
+}
+
+// closingKeyword ends the text before an identifier a commit or PR delivers
+// ("Fixes CET-352", "closes: CET-1"), making its link "implements"
+var closingKeyword = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?|implement(?:s|ed)?)\s*:?\s*$`)
+
+// defaultLinkFields are the data fields scanned when a rule names none
+var defaultLinkFields = []string{"title", "description", "message", "body"}
+
+// autoLinkRule is a compiled config.AutoLinkRule
+type autoLinkRule struct {
+	pattern *regexp.Regexp
+	target  string
+	types   map[graph.NodeType]bool
+	fields  []string
+}
+
+// AutoLinker turns references in node text into "mentions" edges once every
+// source has loaded.
+type AutoLinker struct {
+	rules []autoLinkRule
+}
+
+// NewAutoLinker compiles auto-link rules.
+func NewAutoLinker(rules []config.AutoLinkRule) (*AutoLinker, error) {
+	a := &AutoLinker{}
+	for i, rule := range rules {
+		if rule.Preset != "" {
+			preset, ok := autoLinkPresets[rule.Preset]
+			if !ok {
+				return nil, fmt.Errorf("autolink rule %d: unknown preset %q", i+1, rule.Preset)
+			}
+			if rule.Pattern == "" {
+				rule.Pattern = preset.Pattern
+			}
+			if rule.Target == "" {
+				rule.Target = preset.Target
+			}
+		}
+		if rule.Pattern == "" {
+			return nil, fmt.Errorf("autolink rule %d: needs a pattern or a preset", i+1)
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("autolink rule %d: %w", i+1, err)
+		}
+		compiled := autoLinkRule{pattern: re, target: rule.Target, fields: rule.Fields}
+		switch compiled.target {
+		case "":
+			compiled.target = linkIdentifier
+		case linkIdentifier, linkPath, linkPR, linkID:
+		default:
+			return nil, fmt.Errorf("autolink rule %d: unknown target %q (identifier, path, pr or id)", i+1, rule.Target)
+		}
+		if len(compiled.fields) == 0 {
+			compiled.fields = defaultLinkFields
+		}
+		if len(rule.Types) > 0 {
+			compiled.types = make(map[graph.NodeType]bool, len(rule.Types))
+			for _, t := range rule.Types {
+				compiled.types[graph.NodeType(t)] = true
+			}
+		}
+		a.rules = append(a.rules, compiled)
+	}
+	return a, nil
+}
+
+// defaultAutoLinker links with config.DefaultAutoLink
+func defaultAutoLinker() *AutoLinker {
+	linker, err := NewAutoLinker(config.DefaultAutoLink)
+	if err != nil {
+		panic(err) // The presets are fixed; this is a programming error
+	}
+	return linker
+}
+
+// SetAutoLinker replaces the rules that turn references in node text into
+// edges (config "autolink"). nil turns auto-linking off.
+func (l *Loader) SetAutoLinker(a *AutoLinker) {
+	l.autoLinker = a
+}
+
+// Link adds a "mentions" edge from each node to the loaded nodes its text
+// references, so "see CET-352" or "touches internal/tui/view.go" becomes
+// navigable. A commit or PR naming an issue identifier after a closing
+// keyword ("Fixes CET-352") implements it instead, so issue, commit and
+// file chains run across sources. Pairs already joined by an edge are left
+// alone.
+func (a *AutoLinker) Link(nodes []graph.Node, edges []graph.Edge) []graph.Edge {
+	if a == nil || len(a.rules) == 0 {
+		return edges
+	}
+	index := newLinkIndex(nodes)
+	linked := make(map[[2]string]bool, len(edges))
+	for _, edge := range edges {
+		linked[[2]string{edge.FromID, edge.ToID}] = true
+		linked[[2]string{edge.ToID, edge.FromID}] = true
+	}
+
+	for i := range nodes {
+		node := &nodes[i]
+		var data map[string]interface{}
+		if err := json.Unmarshal(node.Data, &data); err != nil {
+			continue
+		}
+		for _, rule := range a.rules {
+			if rule.types != nil && !rule.types[node.Type] {
+				continue
+			}
+			text := linkText(data, rule.fields)
+			for _, match := range rule.pattern.FindAllStringSubmatchIndex(text, -1) {
+				start, end := match[0], match[1]
+				if len(match) > 3 && match[2] >= 0 {
+					start, end = match[2], match[3]
+				}
+				target, ok := index.lookup(rule.target, text[start:end])
+				if !ok || target == node.ID || linked[[2]string{node.ID, target}] {
+					continue
+				}
+				linked[[2]string{node.ID, target}] = true
+				linked[[2]string{target, node.ID}] = true
+				relation := graph.EdgeMentions
+				if rule.target == linkIdentifier && delivers(node.Type, text[:start]) {
+					relation = graph.EdgeImplements
+				}
+				edges = append(edges, graph.Edge{
+					ID:       fmt.Sprintf("edge:autolink:%s-%s", sanitizeID(node.ID), sanitizeID(target)),
+					FromID:   node.ID,
+					ToID:     target,
+					Relation: relation,
+					Metadata: graph.EdgeMetadata{CreatedAt: node.Metadata.UpdatedAt},
+				})
+			}
+		}
+	}
+	return edges
+}
+
+// delivers reports whether a commit or PR reference follows a closing
+// keyword on its line
+func delivers(t graph.NodeType, before string) bool {
+	if t != graph.NodeTypeCommit && t != graph.NodeTypePR {
+		return false
+	}
+	if i := strings.LastIndexByte(before, '\n'); i >= 0 {
+		before = before[i+1:]
+	}
+	return closingKeyword.MatchString(before)
+}
+
+// linkText joins the string fields of data a rule scans
+func linkText(data map[string]interface{}, fields []string) string {
+	var text []string
+	for _, field := range fields {
+		if value, ok := data[field].(string); ok && value != "" {
+			text = append(text, value)
+		}
+	}
+	return strings.Join(text, "\n")
+}
+
+// linkIndex finds loaded nodes by what references call them
+type linkIndex struct {
+	ids         map[string]bool
+	identifiers map[string]string // Uppercased identifier -> node ID
+	paths       map[string]string // Lowercased file path -> node ID
+	prs         map[string]string // PR number -> node ID
+}
+
+// newLinkIndex indexes nodes by identifier, file path and PR number
+func newLinkIndex(nodes []graph.Node) linkIndex {
+	index := linkIndex{
+		ids:         make(map[string]bool, len(nodes)),
+		identifiers: make(map[string]string),
+		paths:       make(map[string]string),
+		prs:         make(map[string]string),
+	}
+	for i := range nodes {
+		node := &nodes[i]
+		index.ids[node.ID] = true
+		if identifier := node.Identifier(); identifier != "" {
+			index.identifiers[strings.ToUpper(identifier)] = node.ID
+		}
+		switch node.Type {
+		case graph.NodeTypeFile:
+			index.paths[strings.ToLower(node.Title())] = node.ID
+		case graph.NodeTypePR:
+			var data struct {
+				Number int `json:"number"`
+			}
+			if err := json.Unmarshal(node.Data, &data); err == nil && data.Number > 0 {
+				index.prs[fmt.Sprint(data.Number)] = node.ID
+			}
+		}
+	}
+	return index
+}
+
+// lookup finds the node a reference of the given target kind names
+func (x linkIndex) lookup(target, ref string) (string, bool) {
+	var id string
+	switch target {
+	case linkIdentifier:
+		id = x.identifiers[strings.ToUpper(ref)]
+	case linkPath:
+		id = x.paths[strings.ToLower(strings.TrimPrefix(ref, "./"))]
+	case linkPR:
+		id = x.prs[strings.TrimLeft(ref, "0")]
+	case linkID:
+		if x.ids[ref] {
+			id = ref
+		}
+	}
+	return id, id != ""
+}
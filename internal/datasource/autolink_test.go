@@ -0,0 +1,77 @@
+package datasource
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+func TestAutoLinkerLinksIdentifiersPathsAndPRs(t *testing.T) {
+	nodes := []graph.Node{
+		{ID: "issue:a", Type: graph.NodeTypeIssue, Data: json.RawMessage(`{"identifier":"CET-1","description":"Blocked by CET-2 and CET-99; see internal/tui/view.go, PR #42"}`)},
+		{ID: "issue:b", Type: graph.NodeTypeIssue, Data: json.RawMessage(`{"identifier":"CET-2"}`)},
+		{ID: "file:internal-tui-view.go", Type: graph.NodeTypeFile, Data: json.RawMessage(`{"path":"internal/tui/view.go"}`)},
+		{ID: "pr:42", Type: graph.NodeTypePR, Data: json.RawMessage(`{"title":"Fix view","number":42}`)},
+		{ID: "commit:c", Type: graph.NodeTypeCommit, Data: json.RawMessage(`{"message":"Fix CET-2","body":"Refs CET-1"}`)},
+	}
+	edges := []graph.Edge{{FromID: "commit:c", ToID: "issue:a", Relation: graph.EdgeMentions}}
+
+	linked := defaultAutoLinker().Link(nodes, edges)
+	got := map[[2]string]bool{}
+	for _, edge := range linked[1:] {
+		got[[2]string{edge.FromID, edge.ToID}] = true
+	}
+	for _, want := range [][2]string{
+		{"issue:a", "issue:b"},
+		{"issue:a", "file:internal-tui-view.go"},
+		{"issue:a", "pr:42"},
+		{"commit:c", "issue:b"},
+	} {
+		if !got[want] {
+			t.Errorf("missing %s mentions %s", want[0], want[1])
+		}
+	}
+	// The commit already mentions CET-1; unknown CET-99 links nothing
+	if len(got) != 4 {
+		t.Errorf("got %d new edges, want 4: %v", len(got), got)
+	}
+
+	// Rules can be narrowed to node types
+	linker, err := NewAutoLinker([]config.AutoLinkRule{{Preset: "identifiers", Types: []string{"Commit"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(linker.Link(nodes, edges)) - len(edges); n != 1 {
+		t.Errorf("commit-only rule added %d edges, want 1", n)
+	}
+	if _, err := NewAutoLinker([]config.AutoLinkRule{{Pattern: `RFC-(\d+)`, Target: "ticket"}}); err == nil {
+		t.Error("unknown target accepted")
+	}
+}
+
+func TestAutoLinkerLinksDeliveredIssuesAsImplements(t *testing.T) {
+	nodes := []graph.Node{
+		{ID: "linear:CET-352", Type: graph.NodeTypeIssue, Data: json.RawMessage(`{"identifier":"CET-352"}`)},
+		{ID: "linear:CET-353", Type: graph.NodeTypeIssue, Data: json.RawMessage(`{"identifier":"CET-353","description":"Fixes CET-352 once it lands"}`)},
+		{ID: "commit:a", Type: graph.NodeTypeCommit, Data: json.RawMessage(`{"message":"Fix login redirect","body":"Closes: CET-352\nSee CET-353"}`)},
+		{ID: "commit:b", Type: graph.NodeTypeCommit, Data: json.RawMessage(`{"message":"CET-353 follow-up"}`)},
+	}
+
+	relations := map[[2]string]graph.EdgeType{}
+	for _, edge := range defaultAutoLinker().Link(nodes, nil) {
+		relations[[2]string{edge.FromID, edge.ToID}] = edge.Relation
+	}
+	for pair, want := range map[[2]string]graph.EdgeType{
+		{"commit:a", "linear:CET-352"}: graph.EdgeImplements,
+		{"commit:a", "linear:CET-353"}: graph.EdgeMentions,
+		{"commit:b", "linear:CET-353"}: graph.EdgeMentions,
+		// Only commits and PRs deliver issues
+		{"linear:CET-353", "linear:CET-352"}: graph.EdgeMentions,
+	} {
+		if got := relations[pair]; got != want {
+			t.Errorf("%s -> %s: got %q, want %q", pair[0], pair[1], got, want)
+		}
+	}
+}
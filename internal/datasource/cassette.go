@@ -0,0 +1,175 @@
+package datasource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Cassette records an API source's HTTP exchanges to a file, or replays them
+// from one, so the source runs in integration tests and offline demos with
+// no network. Install it as the transport of the client the source uses
+// (SetHTTPClient). Credentials are never recorded.
+type Cassette struct {
+	path   string
+	replay bool
+	next   http.RoundTripper // Real transport while recording
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool // Interactions already replayed
+}
+
+// Interaction is one recorded request and the response it got
+type Interaction struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"` // Path and query; any host matches, so a fake server's port may change
+	Body        string `json:"body"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	RetryAfter  string `json:"retry_after,omitempty"`
+	Complexity  string `json:"complexity,omitempty"` // Linear's X-Complexity, so replays report the cost
+	Response    string `json:"response"`
+}
+
+// NewRecorder returns a cassette that passes requests on to next (nil = the
+// default transport) and keeps every exchange for Save to write to path.
+func NewRecorder(path string, next http.RoundTripper) *Cassette {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Cassette{path: path, next: next}
+}
+
+// LoadCassette reads a recorded cassette to replay. Each recorded exchange
+// answers a request with the same method, path and body, in recorded order.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	var interactions []Interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+	}
+	return &Cassette{path: path, replay: true, interactions: interactions, used: make([]bool, len(interactions))}, nil
+}
+
+// Replaying reports whether the cassette answers requests itself
+func (c *Cassette) Replaying() bool {
+	return c.replay
+}
+
+// Client returns an HTTP client going through the cassette, with the same
+// timeout API sources use by default
+func (c *Cassette) Client() *http.Client {
+	return &http.Client{Transport: c, Timeout: 30 * time.Second}
+}
+
+// RoundTrip records or replays one exchange
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if c.replay {
+		return c.play(req, string(body))
+	}
+
+	resp, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, Interaction{
+		Method:      req.Method,
+		Path:        req.URL.RequestURI(),
+		Body:        string(body),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		RetryAfter:  resp.Header.Get("Retry-After"),
+		Complexity:  resp.Header.Get("X-Complexity"),
+		Response:    string(respBody),
+	})
+	return resp, nil
+}
+
+// play answers a request from the first unused matching interaction, or
+// once all are used the last one again, so a demo can refresh
+func (c *Cassette) play(req *http.Request, body string) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	match := -1
+	for i, recorded := range c.interactions {
+		if recorded.Method != req.Method || recorded.Path != req.URL.RequestURI() || recorded.Body != body {
+			continue
+		}
+		match = i
+		if !c.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("cassette %s has no recorded response for %s %s", c.path, req.Method, req.URL)
+	}
+	recorded := c.interactions[match]
+	c.used[match] = true
+
+	header := make(http.Header)
+	if recorded.ContentType != "" {
+		header.Set("Content-Type", recorded.ContentType)
+	}
+	if recorded.RetryAfter != "" {
+		header.Set("Retry-After", recorded.RetryAfter)
+	}
+	if recorded.Complexity != "" {
+		header.Set("X-Complexity", recorded.Complexity)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Response))),
+		ContentLength: int64(len(recorded.Response)),
+		Request:       req,
+	}, nil
+}
+
+// Save writes the recorded exchanges to the cassette's file. Responses
+// carry whatever the API returned, so the file is readable by its owner only.
+func (c *Cassette) Save() error {
+	if c.replay {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.path, data, 0o600); err != nil {
+		return fmt.Errorf("writing cassette: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package datasource
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// TestCassetteReplaysARecordedLoad checks a load recorded against the fake
+// Linear API replays with the server gone, and that the key is not kept.
+func TestCassetteReplaysARecordedLoad(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(2, 5)
+	src.SetPageSize(2)
+	path := filepath.Join(t.TempDir(), "linear.json")
+
+	recorder := NewRecorder(path, nil)
+	src.SetHTTPClient(recorder.Client())
+	recorded, _, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatalf("recording: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), srv.APIKey()) {
+		t.Error("cassette contains the API key")
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src.SetHTTPClient(cassette.Client())
+	replayed, _, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatalf("replaying: %v", err)
+	}
+	if got, want := countType(replayed, graph.NodeTypeIssue), countType(recorded, graph.NodeTypeIssue); got != want || got != 5 {
+		t.Errorf("replayed %d issues, recorded %d; want 5", got, want)
+	}
+
+	// Once every exchange has answered, the last ones answer again
+	if again, _, err := src.Load(context.Background()); err != nil || countType(again, graph.NodeTypeIssue) != 5 {
+		t.Errorf("reloading from the cassette: %d issues, %v", countType(again, graph.NodeTypeIssue), err)
+	}
+
+	// A request the cassette never saw fails
+	src.SetPageSize(3)
+	if _, _, err := src.Load(context.Background()); err == nil {
+		t.Error("a replayed cassette answered a request it did not record")
+	}
+}
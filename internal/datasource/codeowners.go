@@ -0,0 +1,181 @@
+package datasource
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// codeownersLocations are where GitHub looks for CODEOWNERS, in precedence order
+var codeownersLocations = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// codeownersRule is one CODEOWNERS line: a path pattern and who owns it
+type codeownersRule struct {
+	pattern string
+	owners  []string // Empty means explicitly unowned
+	re      *regexp.Regexp
+	dirOnly bool // Trailing slash: matches directory contents only
+}
+
+// codeowners is a parsed CODEOWNERS file. The last matching rule wins.
+type codeowners struct {
+	path  string // Location relative to the project root
+	rules []codeownersRule
+}
+
+// loadCodeowners reads the first CODEOWNERS file found under rootPath.
+// It returns nil when the project declares no owners.
+func loadCodeowners(rootPath string) (*codeowners, error) {
+	for _, loc := range codeownersLocations {
+		f, err := os.Open(filepath.Join(rootPath, filepath.FromSlash(loc)))
+		if err != nil {
+			continue
+		}
+		defer f.Close()
+
+		co := &codeowners{path: loc}
+		scanner := bufio.NewScanner(f)
+		lineNo := 0
+		for scanner.Scan() {
+			lineNo++
+			fields := strings.Fields(stripCodeownersComment(scanner.Text()))
+			if len(fields) == 0 {
+				continue
+			}
+			rule, err := compileCodeownersRule(fields[0], fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", loc, lineNo, err)
+			}
+			co.rules = append(co.rules, rule)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("read %s: %w", loc, err)
+		}
+		return co, nil
+	}
+	return nil, nil
+}
+
+// stripCodeownersComment drops a "#" comment; "\#" is a literal hash
+func stripCodeownersComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] != '\\') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// compileCodeownersRule translates a gitignore-style pattern into a regexp
+// over slash-separated paths relative to the project root.
+func compileCodeownersRule(pattern string, owners []string) (codeownersRule, error) {
+	rule := codeownersRule{pattern: pattern, owners: owners}
+
+	p := strings.ReplaceAll(pattern, `\#`, "#")
+	if strings.HasSuffix(p, "/") {
+		rule.dirOnly = true
+		p = strings.TrimSuffix(p, "/")
+	}
+	// A slash at the start or in the middle anchors the pattern to the root
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return rule, fmt.Errorf("empty pattern %q", pattern)
+	}
+
+	var re strings.Builder
+	if anchored {
+		re.WriteString("^")
+	} else {
+		re.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			re.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case p[i] == '*':
+			re.WriteString("[^/]*")
+		case p[i] == '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+	// "dir/*" matches direct children only; anything else also covers
+	// everything beneath a matching directory
+	if strings.HasSuffix(p, "/*") {
+		re.WriteString("$")
+	} else {
+		re.WriteString("(?:/.*)?$")
+	}
+
+	compiled, err := regexp.Compile(re.String())
+	if err != nil {
+		return rule, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	rule.re = compiled
+	return rule, nil
+}
+
+// matches reports whether the rule applies to a slash-separated relative path
+func (r codeownersRule) matches(relPath string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		// "docs/" owns files through their enclosing directories
+		dir := path.Dir(relPath)
+		return dir != "." && r.re.MatchString(dir)
+	}
+	return r.re.MatchString(relPath)
+}
+
+// OwnersOf returns the declared owners of a path (nil when unowned)
+func (c *codeowners) OwnersOf(relPath string, isDir bool) []string {
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].matches(relPath, isDir) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// ownedPath is a scanned file or directory that CODEOWNERS rules can match
+type ownedPath struct {
+	relPath string // Slash-separated, relative to the project root
+	nodeID  string
+	isDir   bool
+}
+
+// declaredOwnership creates Team/Person nodes for the declared owners and
+// "owns" edges from each owner to the files and directories assigned to them.
+func (c *codeowners) declaredOwnership(paths []ownedPath) ([]graph.Node, []graph.Edge) {
+	owners := newPeople("codeowners")
+	var edges []graph.Edge
+
+	for _, p := range paths {
+		for _, owner := range c.OwnersOf(p.relPath, p.isDir) {
+			ownerID := owners.login(owner)
+			edges = append(edges, graph.Edge{
+				ID:       fmt.Sprintf("edge:codeowner:%s:%s", ownerID, sanitizeID(p.relPath)),
+				FromID:   ownerID,
+				ToID:     p.nodeID,
+				Relation: graph.EdgeOwns,
+				Metadata: graph.EdgeMetadata{CreatedAt: time.Now()},
+			})
+		}
+	}
+	return owners.nodes(), edges
+}
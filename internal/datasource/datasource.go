@@ -2,9 +2,15 @@ package datasource
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/manutej/maat-terminal/internal/config"
 	"github.com/manutej/maat-terminal/internal/graph"
 )
 
@@ -21,6 +27,107 @@ type DataSource interface {
 	SupportsRefresh() bool
 }
 
+// HistorySource is a DataSource whose history can be loaded a page at a time
+// beyond what Load returned (a git repository's older commits).
+type HistorySource interface {
+	// LoadBranchHistory loads up to limit more commits held by a branch
+	// node, skipping the skip commits already loaded
+	LoadBranchHistory(ctx context.Context, branchID string, skip, limit int) ([]graph.Node, []graph.Edge, error)
+}
+
+// DiffSource is a DataSource that can show the changes a node made (a git
+// commit's patch). Diff returns ErrNoDiff for nodes it does not know.
+type DiffSource interface {
+	Diff(ctx context.Context, node graph.Node) (string, error)
+}
+
+// ErrNoDiff is returned for a node no source can show changes for.
+var ErrNoDiff = errors.New("no diff available")
+
+// ContentSource is a DataSource that can read a node's contents (a scanned
+// file). Content returns ErrNoContent for nodes it does not know.
+type ContentSource interface {
+	Content(ctx context.Context, node graph.Node) ([]byte, error)
+}
+
+// ErrNoContent is returned for a node no source can read.
+var ErrNoContent = errors.New("no contents available")
+
+// CommentSource is a DataSource that can post a comment on a node (a
+// Linear issue). PostComment returns ErrNoComments for nodes it does not know.
+type CommentSource interface {
+	PostComment(ctx context.Context, node graph.Node, body string) error
+}
+
+// ErrNoComments is returned for a node no source can post comments on.
+var ErrNoComments = errors.New("comments not supported")
+
+// EditSource is a DataSource that can write edits of a node's title and
+// description back (a Linear issue). Both methods return ErrNotEditable for
+// nodes it does not know.
+type EditSource interface {
+	// RemoteFields re-reads the node's "title" and "description" and when
+	// it last changed
+	RemoteFields(ctx context.Context, node graph.Node) (time.Time, map[string]string, error)
+	// UpdateFields writes the given fields, leaving the others alone
+	UpdateFields(ctx context.Context, node graph.Node, fields map[string]string) error
+}
+
+// ErrNotEditable is returned for a node no source can write edits back to.
+var ErrNotEditable = errors.New("editing not supported")
+
+// TruncatingSource is a DataSource that loads within a node budget (a git
+// repository's commit cap). Truncation describes what the last Load left
+// out, e.g. "truncated at 50 commits", or is empty when nothing was.
+type TruncatingSource interface {
+	Truncation() string
+}
+
+// TelemetrySource is a DataSource that calls a remote API. Telemetry
+// returns its running totals since it was created; the loader records the
+// difference across each Load as that sync's cost.
+type TelemetrySource interface {
+	Telemetry() Telemetry
+}
+
+// IncrementalSource is a DataSource that can load only what changed since a
+// time (Linear's updated issues), instead of everything. Its nodes' Source
+// must be its Name, so what it loaded before can be found in the store.
+type IncrementalSource interface {
+	LoadSince(ctx context.Context, since time.Time) (Changes, error)
+}
+
+// ScopedSource is an IncrementalSource whose loads depend on a setting
+// besides its name (Linear's team). SyncScope names it, so a cursor kept
+// for another team, by this user or another on a shared store, is not
+// taken for this one's.
+type ScopedSource interface {
+	SyncScope() string
+}
+
+// Changes is what an incremental load found changed since the last sync.
+type Changes struct {
+	Nodes []graph.Node
+	Edges []graph.Edge
+	// Restated lists the nodes whose edges Edges gives in full; their
+	// earlier edges are dropped (an issue that moved to another project)
+	Restated []string
+	// Removed lists the nodes gone from the source since (archived or
+	// deleted); they and their edges are dropped from the earlier load
+	Removed []string
+}
+
+// syncCursorOverlap is how far before its cursor an incremental load starts,
+// so clock skew between here and the API doesn't lose an update
+const syncCursorOverlap = time.Minute
+
+// Telemetry is what a source has spent talking to its API.
+type Telemetry struct {
+	Requests int
+	Bytes    int64 // Request and response bodies
+	Cost     int   // Points the API charged (Linear complexity)
+}
+
 // Config holds configuration for data sources
 type Config struct {
 	// ProjectPath is the local path to scan (for git/files)
@@ -38,35 +145,411 @@ type Config struct {
 
 // Loader orchestrates loading from multiple data sources
 type Loader struct {
+	mu sync.Mutex // Held by each LoadAll or RefreshNode, and by reads of what it left behind
+
 	sources []DataSource
+	people  []config.Person // Identity mapping used to merge people across sources
+
+	errors      []error         // Sources that failed during the last LoadAll
+	truncations []string        // Sources the last LoadAll capped, e.g. "git: truncated at 50 commits"
+	runs        []graph.SyncRun // What each source's load cost during the last LoadAll
+	diagnostics []string        // What the last LoadAll reported as it ran, warnings included
+	warnings    []string        // The diagnostics about something that went wrong
+	log         io.Writer       // Diagnostics are written here as they happen (nil = only kept)
+
+	syncedUntil map[string]time.Time // Cursors the last LoadAll or RefreshNode moved, saved by SaveSyncCursors
+
+	loads []sourceLoad // Each source's last result, kept so one source can reload alone
+
+	syncStore graph.GraphStore // Holds incremental sources' cursors and earlier loads (nil = always load in full)
+	fullSync  bool             // Load incremental sources in full anyway, still moving their cursors
+
+	hooks         config.HooksConfig // Shell commands run on sync events
+	hookStatePath string             // Node digests from the last sync, for on-node-changed hooks
+
+	redactor   *Redactor   // Masks sensitive text as nodes load (nil = none)
+	autoLinker *AutoLinker // Turns references in node text into edges (nil = none)
+
+	progress func(source string, done, total int) // Told as each source finishes (nil = nobody)
 }
 
 // NewLoader creates a new data source loader
 func NewLoader(sources ...DataSource) *Loader {
-	return &Loader{sources: sources}
+	l := &Loader{autoLinker: defaultAutoLinker(), log: os.Stderr}
+	for _, source := range sources {
+		l.AddSource(source)
+	}
+	return l
 }
 
-// LoadAll loads data from all configured sources and merges results
+// LoadAll loads data from all configured sources and merges results.
+// Nodes several sources share (people seen by both git and Linear) are kept
+// once, people with several accounts are unified into one Person node, and
+// references one source makes to another's nodes are resolved.
+// Configured hooks run before loading and after merging.
 func (l *Loader) LoadAll(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.diagnostics, l.warnings = nil, nil
+	l.syncedUntil = nil
+	l.runPreSyncHooks(ctx)
+	l.errors = nil
+	l.truncations = nil
+	l.runs = nil
+	l.loads = make([]sourceLoad, len(l.sources))
+
+	for i := range l.sources {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		_ = l.loadSource(ctx, i) // Recorded in Errors; the other sources still load
+		if l.progress != nil {
+			l.progress(l.sources[i].Name(), i+1, len(l.sources))
+		}
+	}
+
+	allNodes, allEdges := l.merge()
+	l.runPostSyncHooks(ctx, allNodes, allEdges)
+	return allNodes, allEdges, nil
+}
+
+// SetProgress has LoadAll report each source as it finishes, failed or
+// not, with how many of the sources are done.
+func (l *Loader) SetProgress(report func(source string, done, total int)) {
+	l.progress = report
+}
+
+// sourceLoad is what one source returned from its last Load
+type sourceLoad struct {
+	nodes []graph.Node
+	edges []graph.Edge
+}
+
+// loadSource loads the i-th source, remembering its result for merge and
+// recording its failure or truncation and what the load cost. A failed
+// source keeps its last result.
+func (l *Loader) loadSource(ctx context.Context, i int) error {
+	source := l.sources[i]
+	run := graph.SyncRun{Source: source.Name(), StartedAt: time.Now()}
+	var spent Telemetry
+	metered, isMetered := source.(TelemetrySource)
+	if isMetered {
+		spent = metered.Telemetry()
+	}
+	var nodes []graph.Node
+	var edges []graph.Edge
+	var err error
+	if incremental, ok := source.(IncrementalSource); ok && l.syncStore != nil {
+		nodes, edges, err = l.loadChanges(ctx, source, incremental)
+	} else {
+		nodes, edges, err = source.Load(ctx)
+	}
+	run.Duration = time.Since(run.StartedAt)
+	if isMetered {
+		now := metered.Telemetry()
+		run.Requests = now.Requests - spent.Requests
+		run.Bytes = now.Bytes - spent.Bytes
+		run.Cost = now.Cost - spent.Cost
+	}
+	if err != nil {
+		err = fmt.Errorf("%s: %w", source.Name(), err)
+		l.warnf("Error loading from %v", err)
+		l.errors = append(l.errors, err)
+		run.Error = err.Error()
+		l.runs = append(l.runs, run)
+		return err
+	}
+	run.Nodes, run.Edges = len(nodes), len(edges)
+	l.runs = append(l.runs, run)
+	l.notef("Loaded %d nodes from %s", len(nodes), source.Name())
+	if capped, ok := source.(TruncatingSource); ok {
+		if truncation := capped.Truncation(); truncation != "" {
+			l.warnf("Warning: %s: %s", source.Name(), truncation)
+			l.truncations = append(l.truncations, source.Name()+": "+truncation)
+		}
+	}
+	kept := make([]graph.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if graph.IsLocalID(node.ID) {
+			// Reserved for entities created locally; a source must not shadow one
+			l.errors = append(l.errors, fmt.Errorf("%s: node %s uses the reserved %q prefix", source.Name(), node.ID, graph.LocalPrefix))
+			continue
+		}
+		kept = append(kept, node)
+	}
+	l.loads[i] = sourceLoad{nodes: kept, edges: edges}
+	return nil
+}
+
+// merge combines the sources' last results into one graph: shared nodes
+// kept once (first source wins, but a person keeps every source's account
+// identifiers), people unified and references resolved.
+func (l *Loader) merge() ([]graph.Node, []graph.Edge) {
 	var allNodes []graph.Node
 	var allEdges []graph.Edge
+	index := make(map[string]int) // Node ID -> position in allNodes
+	for _, load := range l.loads {
+		for _, node := range load.nodes {
+			i, seen := index[node.ID]
+			if !seen {
+				index[node.ID] = len(allNodes)
+				allNodes = append(allNodes, l.redactor.Redact(node))
+			} else if node.Type == graph.NodeTypePerson && allNodes[i].Type == graph.NodeTypePerson {
+				allNodes[i] = mergeSamePerson(allNodes[i], l.redactor.Redact(node))
+			}
+		}
+		allEdges = append(allEdges, load.edges...)
+	}
+
+	allNodes, allEdges = unifyPeople(allNodes, allEdges, l.people)
+	allEdges = resolveRefs(allNodes, allEdges)
+	allEdges = l.autoLinker.Link(allNodes, allEdges)
+	return allNodes, allEdges
+}
+
+// SourcesOf names the refreshable sources whose last load produced nodeID
+// (a project node can come from both the git and the file scan).
+func (l *Loader) SourcesOf(nodeID string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var names []string
+	for _, i := range l.sourcesOf(nodeID) {
+		names = append(names, l.sources[i].Name())
+	}
+	return names
+}
+
+// sourcesOf returns the indexes of the refreshable sources whose last load
+// produced nodeID
+func (l *Loader) sourcesOf(nodeID string) []int {
+	var indexes []int
+	for i, load := range l.loads {
+		if !l.sources[i].SupportsRefresh() {
+			continue
+		}
+		for _, node := range load.nodes {
+			if node.ID == nodeID {
+				indexes = append(indexes, i)
+				break
+			}
+		}
+	}
+	return indexes
+}
+
+// RefreshNode reloads only the sources that produced nodeID, keeping every
+// other source's last result (and a failed source's). It returns what those sources produce now
+// (merged with the rest of the graph like LoadAll), the edges touching it,
+// and the IDs they produced last time that are gone. Hooks run as for LoadAll.
+func (l *Loader) RefreshNode(ctx context.Context, nodeID string) (nodes []graph.Node, edges []graph.Edge, removed []string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	indexes := l.sourcesOf(nodeID)
+	if len(indexes) == 0 {
+		return nil, nil, nil, fmt.Errorf("no source loaded %s", nodeID)
+	}
+	l.diagnostics, l.warnings = nil, nil
+	l.syncedUntil = nil
+	l.runPreSyncHooks(ctx)
+	l.errors = nil
+	l.truncations = nil
+	l.runs = nil
+
+	before := make(map[string]bool)
+	after := make(map[string]bool)
+	for _, i := range indexes {
+		for _, node := range l.loads[i].nodes {
+			before[node.ID] = true
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := l.loadSource(ctx, i); err != nil {
+			return nil, nil, nil, err
+		}
+		for _, node := range l.loads[i].nodes {
+			after[node.ID] = true
+		}
+	}
+
+	allNodes, allEdges := l.merge()
+	l.runPostSyncHooks(ctx, allNodes, allEdges)
 
+	present := make(map[string]bool, len(allNodes))
+	for _, node := range allNodes {
+		present[node.ID] = true
+		if after[node.ID] {
+			nodes = append(nodes, node)
+		}
+	}
+	for _, edge := range allEdges {
+		if after[edge.FromID] || after[edge.ToID] {
+			edges = append(edges, edge)
+		}
+	}
+	for id := range before {
+		if !present[id] {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+	return nodes, edges, removed, nil
+}
+
+// LoadBranchHistory loads another page of a branch's commits from the source
+// that has the branch, redacted and with people unified like LoadAll.
+func (l *Loader) LoadBranchHistory(ctx context.Context, branchID string, skip, limit int) ([]graph.Node, []graph.Edge, error) {
 	for _, source := range l.sources {
-		nodes, edges, err := source.Load(ctx)
+		history, ok := source.(HistorySource)
+		if !ok {
+			continue
+		}
+		nodes, edges, err := history.LoadBranchHistory(ctx, branchID, skip, limit)
+		if errors.Is(err, ErrUnknownBranch) {
+			continue
+		}
 		if err != nil {
-			// Log error but continue with other sources
-			fmt.Fprintf(os.Stderr, "Error loading from %s: %v\n", source.Name(), err)
+			return nil, nil, fmt.Errorf("%s: %w", source.Name(), err)
+		}
+		for i := range nodes {
+			nodes[i] = l.redactor.Redact(nodes[i])
+		}
+		nodes, edges = unifyPeople(nodes, edges, l.people)
+		return nodes, edges, nil
+	}
+	return nil, nil, fmt.Errorf("%w %s: no source has its history", ErrUnknownBranch, branchID)
+}
+
+// Diff returns the changes a node made, as a unified diff, from the first
+// source that can show them.
+func (l *Loader) Diff(ctx context.Context, node graph.Node) (string, error) {
+	for _, source := range l.sources {
+		differ, ok := source.(DiffSource)
+		if !ok {
+			continue
+		}
+		diff, err := differ.Diff(ctx, node)
+		if errors.Is(err, ErrNoDiff) {
 			continue
 		}
-		fmt.Fprintf(os.Stderr, "Loaded %d nodes from %s\n", len(nodes), source.Name())
-		allNodes = append(allNodes, nodes...)
-		allEdges = append(allEdges, edges...)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", source.Name(), err)
+		}
+		return diff, nil
 	}
+	return "", fmt.Errorf("%w for %s", ErrNoDiff, node.ID)
+}
 
-	return allNodes, allEdges, nil
+// Content reads a node's contents from the first source that knows it
+func (l *Loader) Content(ctx context.Context, node graph.Node) ([]byte, error) {
+	for _, source := range l.sources {
+		reader, ok := source.(ContentSource)
+		if !ok {
+			continue
+		}
+		content, err := reader.Content(ctx, node)
+		if errors.Is(err, ErrNoContent) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source.Name(), err)
+		}
+		return content, nil
+	}
+	return nil, fmt.Errorf("%w for %s", ErrNoContent, node.ID)
+}
+
+// PostComment posts a comment on a node through the first source that knows it
+func (l *Loader) PostComment(ctx context.Context, node graph.Node, body string) error {
+	for _, source := range l.sources {
+		commenter, ok := source.(CommentSource)
+		if !ok {
+			continue
+		}
+		err := commenter.PostComment(ctx, node, body)
+		if errors.Is(err, ErrNoComments) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", source.Name(), err)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w on %s", ErrNoComments, node.ID)
+}
+
+// RemoteFields re-reads a node's editable fields through the first source
+// that knows it
+func (l *Loader) RemoteFields(ctx context.Context, node graph.Node) (time.Time, map[string]string, error) {
+	for _, source := range l.sources {
+		editor, ok := source.(EditSource)
+		if !ok {
+			continue
+		}
+		updatedAt, fields, err := editor.RemoteFields(ctx, node)
+		if errors.Is(err, ErrNotEditable) {
+			continue
+		}
+		if err != nil {
+			return time.Time{}, nil, fmt.Errorf("%s: %w", source.Name(), err)
+		}
+		return updatedAt, fields, nil
+	}
+	return time.Time{}, nil, fmt.Errorf("%w on %s", ErrNotEditable, node.ID)
+}
+
+// UpdateFields writes edited fields back through the first source that
+// knows the node
+func (l *Loader) UpdateFields(ctx context.Context, node graph.Node, fields map[string]string) error {
+	for _, source := range l.sources {
+		editor, ok := source.(EditSource)
+		if !ok {
+			continue
+		}
+		err := editor.UpdateFields(ctx, node, fields)
+		if errors.Is(err, ErrNotEditable) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", source.Name(), err)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w on %s", ErrNotEditable, node.ID)
+}
+
+// Errors returns the sources that failed during the last LoadAll or RefreshNode
+func (l *Loader) Errors() []error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.errors
+}
+
+// Truncations returns the sources the last LoadAll or RefreshNode capped, each described
+// like "git: truncated at 50 commits (120 more on branches)"
+func (l *Loader) Truncations() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.truncations
+}
+
+// SyncRuns returns what each source reloaded by the last LoadAll or
+// RefreshNode cost, failed loads included
+func (l *Loader) SyncRuns() []graph.SyncRun {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.runs
+}
+
+// SetPeople sets the configured identity mapping (config "people")
+func (l *Loader) SetPeople(people []config.Person) {
+	l.people = people
 }
 
 // AddSource adds a new data source
 func (l *Loader) AddSource(source DataSource) {
+	if reporting, ok := source.(ReportingSource); ok {
+		reporting.SetReporter(l.report)
+	}
 	l.sources = append(l.sources, source)
 }
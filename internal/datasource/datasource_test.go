@@ -0,0 +1,89 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// stubSource returns whatever nodes it currently holds
+type stubSource struct {
+	name  string
+	nodes []graph.Node
+	loads int
+	err   error // Returned instead of the nodes when set
+}
+
+func (s *stubSource) Name() string          { return s.name }
+func (s *stubSource) SupportsRefresh() bool { return true }
+
+func (s *stubSource) Load(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
+	s.loads++
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	var edges []graph.Edge
+	for _, node := range s.nodes[1:] {
+		edges = append(edges, graph.Edge{FromID: s.nodes[0].ID, ToID: node.ID, Relation: graph.EdgeOwns})
+	}
+	return append([]graph.Node(nil), s.nodes...), edges, nil
+}
+
+// TestRefreshNodeReloadsOnlyTheSourcesBehindIt checks a scoped refresh
+// leaves other sources alone and reports the nodes that went away.
+func TestRefreshNodeReloadsOnlyTheSourcesBehindIt(t *testing.T) {
+	node := func(id string) graph.Node {
+		return graph.Node{ID: id, Type: graph.NodeTypeCommit, Data: []byte(`{}`)}
+	}
+	git := &stubSource{name: "git", nodes: []graph.Node{node("project:a"), node("commit:1"), node("commit:2")}}
+	linear := &stubSource{name: "linear", nodes: []graph.Node{node("project:b"), node("issue:1")}}
+	loader := NewLoader(git, linear)
+	if _, _, err := loader.LoadAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := loader.SourcesOf("commit:1"); !reflect.DeepEqual(got, []string{"git"}) {
+		t.Errorf("SourcesOf(commit:1) = %v, want [git]", got)
+	}
+	git.nodes = []graph.Node{node("project:a"), node("commit:2"), node("commit:3")}
+	nodes, edges, removed, err := loader.RefreshNode(context.Background(), "project:a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if git.loads != 2 || linear.loads != 1 {
+		t.Errorf("loads: git %d, linear %d; want 2 and 1", git.loads, linear.loads)
+	}
+	if len(nodes) != 3 || len(edges) != 2 {
+		t.Errorf("refresh returned %d nodes and %d edges, want 3 and 2", len(nodes), len(edges))
+	}
+	if !reflect.DeepEqual(removed, []string{"commit:1"}) {
+		t.Errorf("removed = %v, want [commit:1]", removed)
+	}
+	if _, _, _, err := loader.RefreshNode(context.Background(), "commit:1"); err == nil {
+		t.Error("refreshing a node no source produces should fail")
+	}
+}
+
+// TestLoadAllReportsProgress checks each source is reported as it finishes.
+func TestLoadAllReportsProgress(t *testing.T) {
+	node := func(id string) graph.Node {
+		return graph.Node{ID: id, Type: graph.NodeTypeCommit, Data: []byte(`{}`)}
+	}
+	loader := NewLoader(
+		&stubSource{name: "git", nodes: []graph.Node{node("project:a")}},
+		&stubSource{name: "linear", nodes: []graph.Node{node("project:b")}},
+	)
+	var got []string
+	loader.SetProgress(func(source string, done, total int) {
+		got = append(got, fmt.Sprintf("%s %d/%d", source, done, total))
+	})
+	if _, _, err := loader.LoadAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"git 1/2", "linear 2/2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("progress = %v, want %v", got, want)
+	}
+}
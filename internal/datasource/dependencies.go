@@ -0,0 +1,436 @@
+package datasource
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// DefaultOSVEndpoint is OSV's batch query API
+const DefaultOSVEndpoint = "https://api.osv.dev/v1/querybatch"
+
+// osvBatchSize is the most queries sent in one OSV request
+const osvBatchSize = 1000
+
+// osvCacheTTL is how long a cached OSV answer is trusted before asking again
+const osvCacheTTL = 24 * time.Hour
+
+// Ecosystems, as OSV names them
+const (
+	ecosystemGo  = "Go"
+	ecosystemNPM = "npm"
+)
+
+// DependencySource reads the dependency manifests in a project (go.mod,
+// package.json) into Dependency nodes the project depends on, optionally
+// flagging versions with known vulnerabilities from OSV.
+type DependencySource struct {
+	rootPath  string
+	projectID string
+	osv       string // OSV batch endpoint ("" = no vulnerability check)
+	osvCache  string // File OSV answers are kept in between loads ("" = none)
+	client    *http.Client
+	report    Reporter // Takes failed vulnerability checks (nil = standard error)
+
+	mu    sync.Mutex
+	spent Telemetry // Requests and bytes sent to OSV since creation
+}
+
+// dependency is one requirement read from a manifest
+type dependency struct {
+	name, version, ecosystem string
+	manifest                 string // Slash-separated path relative to the project
+	indirect                 bool   // go.mod "// indirect"
+	dev                      bool   // package.json devDependencies
+	vulns                    []string
+}
+
+// NewDependencySource creates a source for the manifests under rootPath,
+// linked to the project node projectID
+func NewDependencySource(rootPath, projectID string) *DependencySource {
+	return &DependencySource{
+		rootPath:  rootPath,
+		projectID: projectID,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// SetOSV turns the vulnerability check on against endpoint (DefaultOSVEndpoint,
+// or a fake server); "" turns it off
+func (d *DependencySource) SetOSV(endpoint string) {
+	d.osv = endpoint
+}
+
+// SetOSVCache keeps OSV's answers in the file at path for a day, so
+// unchanged dependencies are not sent again on every load
+func (d *DependencySource) SetOSVCache(path string) {
+	d.osvCache = path
+}
+
+// Name returns the data source identifier
+func (d *DependencySource) Name() string {
+	return "deps:" + filepath.Base(d.rootPath)
+}
+
+// SupportsRefresh returns true
+func (d *DependencySource) SupportsRefresh() bool {
+	return true
+}
+
+// Telemetry returns what the source has spent on OSV so far
+func (d *DependencySource) Telemetry() Telemetry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.spent
+}
+
+// SetReporter has a failed vulnerability check reported to report
+func (d *DependencySource) SetReporter(report Reporter) {
+	d.report = report
+}
+
+// Load reads every manifest under the project and returns its dependencies.
+// A failed vulnerability check is reported, leaving the dependencies unflagged.
+func (d *DependencySource) Load(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
+	deps, err := d.scan()
+	if err != nil {
+		return nil, nil, err
+	}
+	if d.osv != "" {
+		if err := d.checkVulnerabilities(ctx, deps); err != nil {
+			d.report.warnf("Warning: %s: vulnerability check failed: %v", d.Name(), err)
+		}
+	}
+
+	now := time.Now()
+	var nodes []graph.Node
+	var edges []graph.Edge
+	seen := make(map[string]bool)
+	for _, dep := range deps {
+		nodeID := fmt.Sprintf("dependency:%s:%s", strings.ToLower(dep.ecosystem), sanitizeID(dep.name))
+		if seen[nodeID] {
+			continue // The same module in two manifests: the first one read wins
+		}
+		seen[nodeID] = true
+
+		labels := []string{dep.ecosystem}
+		if dep.indirect {
+			labels = append(labels, "indirect")
+		}
+		if dep.dev {
+			labels = append(labels, "dev")
+		}
+		description := "Required by " + dep.manifest
+		if len(dep.vulns) > 0 {
+			labels = append(labels, "vulnerable")
+			description += ". Known vulnerabilities: " + strings.Join(dep.vulns, ", ")
+		}
+		data := map[string]interface{}{
+			"title":           dep.name + " " + dep.version,
+			"name":            dep.name,
+			"version":         dep.version,
+			"ecosystem":       dep.ecosystem,
+			"manifest":        dep.manifest,
+			"labels":          labels,
+			"description":     description,
+			"vulnerabilities": dep.vulns,
+		}
+		if len(dep.vulns) > 0 && dep.ecosystem == ecosystemGo {
+			data["url"] = "https://pkg.go.dev/vuln/" + dep.vulns[0]
+		} else if len(dep.vulns) > 0 {
+			data["url"] = "https://osv.dev/vulnerability/" + dep.vulns[0]
+		}
+		dataJSON, _ := json.Marshal(data)
+
+		nodes = append(nodes, graph.Node{
+			ID:     nodeID,
+			Type:   graph.NodeTypeDependency,
+			Source: d.Name(),
+			Data:   dataJSON,
+			Metadata: graph.NodeMetadata{
+				CreatedAt:   now,
+				UpdatedAt:   now,
+				CreatedBy:   "dependency-scanner",
+				AccessLevel: graph.RoleIC,
+				SyncedAt:    now,
+			},
+		})
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:depends-on:%s-%s", sanitizeID(d.projectID), sanitizeID(nodeID)),
+			FromID:   d.projectID,
+			ToID:     nodeID,
+			Relation: graph.EdgeDependsOn,
+			Metadata: graph.EdgeMetadata{CreatedAt: now},
+		})
+	}
+	return nodes, edges, nil
+}
+
+// scan reads the go.mod and package.json files under the project, skipping
+// the directories the file scanner skips (vendor, node_modules, ...)
+func (d *DependencySource) scan() ([]dependency, error) {
+	skip := NewFileScanner(d.rootPath, d.projectID)
+	var deps []dependency
+	err := filepath.WalkDir(d.rootPath, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip errors, continue walking
+		}
+		if entry.IsDir() {
+			base := entry.Name()
+			if path != d.rootPath && (strings.HasPrefix(base, ".") || skip.shouldSkipDir(base)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		var parse func([]byte, string) []dependency
+		switch entry.Name() {
+		case "go.mod":
+			parse = parseGoMod
+		case "package.json":
+			parse = parsePackageJSON
+		default:
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		relPath, _ := filepath.Rel(d.rootPath, path)
+		deps = append(deps, parse(content, filepath.ToSlash(relPath))...)
+		return nil
+	})
+	return deps, err
+}
+
+// parseGoMod reads the requirements of a go.mod, in single-line and block form
+func parseGoMod(content []byte, manifest string) []dependency {
+	var deps []dependency
+	inBlock := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line, comment, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(line)
+		switch {
+		case inBlock && len(fields) == 1 && fields[0] == ")":
+			inBlock = false
+			continue
+		case len(fields) == 2 && fields[0] == "require" && fields[1] == "(":
+			inBlock = true
+			continue
+		case len(fields) == 3 && fields[0] == "require":
+			fields = fields[1:]
+		case !inBlock:
+			continue
+		}
+		if len(fields) != 2 {
+			continue
+		}
+		deps = append(deps, dependency{
+			name:      fields[0],
+			version:   fields[1],
+			ecosystem: ecosystemGo,
+			manifest:  manifest,
+			indirect:  strings.TrimSpace(comment) == "indirect",
+		})
+	}
+	return deps
+}
+
+// parsePackageJSON reads the dependencies and devDependencies of a
+// package.json, sorted by name
+func parsePackageJSON(content []byte, manifest string) []dependency {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil
+	}
+	var deps []dependency
+	for _, group := range []struct {
+		deps map[string]string
+		dev  bool
+	}{{pkg.Dependencies, false}, {pkg.DevDependencies, true}} {
+		names := make([]string, 0, len(group.deps))
+		for name := range group.deps {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			deps = append(deps, dependency{
+				name:      name,
+				version:   group.deps[name],
+				ecosystem: ecosystemNPM,
+				manifest:  manifest,
+				dev:       group.dev,
+			})
+		}
+	}
+	return deps
+}
+
+// osvVersion is the exact version OSV is asked about: Go versions without
+// their "v", npm ranges by their lower bound ("^1.2.3" is 1.2.3). Ranges
+// naming no version (tags, URLs, "*") can't be checked.
+func osvVersion(dep dependency) (string, bool) {
+	version := strings.TrimPrefix(dep.version, "v")
+	if dep.ecosystem == ecosystemNPM {
+		version = strings.TrimLeft(version, "^~>=< ")
+	}
+	if version == "" || version[0] < '0' || version[0] > '9' || strings.ContainsAny(version, " |") {
+		return "", false
+	}
+	return version, true
+}
+
+// checkVulnerabilities asks OSV which dependency versions have known
+// vulnerabilities, recording their IDs on deps
+func (d *DependencySource) checkVulnerabilities(ctx context.Context, deps []dependency) error {
+	type osvQuery struct {
+		Package struct {
+			Name      string `json:"name"`
+			Ecosystem string `json:"ecosystem"`
+		} `json:"package"`
+		Version string `json:"version"`
+	}
+	cache := d.loadOSVCache()
+	now := time.Now()
+	var queries []osvQuery
+	var queried []int // Index in deps of each query
+	for i, dep := range deps {
+		version, ok := osvVersion(dep)
+		if !ok {
+			continue
+		}
+		if answer, ok := cache[osvKey(dep, version)]; ok && now.Sub(answer.CheckedAt) < osvCacheTTL {
+			deps[i].vulns = append(deps[i].vulns, answer.Vulns...)
+			continue
+		}
+		var query osvQuery
+		query.Package.Name = dep.name
+		query.Package.Ecosystem = dep.ecosystem
+		query.Version = version
+		queries = append(queries, query)
+		queried = append(queried, i)
+	}
+
+	for start := 0; start < len(queries); start += osvBatchSize {
+		end := min(start+osvBatchSize, len(queries))
+		body, err := json.Marshal(map[string]interface{}{"queries": queries[start:end]})
+		if err != nil {
+			return err
+		}
+		var result struct {
+			Results []struct {
+				Vulns []struct {
+					ID string `json:"id"`
+				} `json:"vulns"`
+			} `json:"results"`
+		}
+		if err := d.post(ctx, body, &result); err != nil {
+			return err
+		}
+		for i, answer := range result.Results {
+			if start+i >= end {
+				break
+			}
+			dep := &deps[queried[start+i]]
+			for _, vuln := range answer.Vulns {
+				dep.vulns = append(dep.vulns, vuln.ID)
+			}
+			cache[osvKey(*dep, queries[start+i].Version)] = osvAnswer{Vulns: dep.vulns, CheckedAt: now}
+		}
+	}
+	if len(queries) > 0 {
+		d.saveOSVCache(cache, now)
+	}
+	return nil
+}
+
+// osvAnswer is OSV's answer about one dependency version, as cached
+type osvAnswer struct {
+	Vulns     []string  `json:"vulns,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// osvKey names a dependency version in the OSV cache
+func osvKey(dep dependency, version string) string {
+	return dep.ecosystem + "/" + dep.name + "@" + version
+}
+
+// loadOSVCache reads the cached OSV answers. The cache can be rebuilt at
+// any time, so a missing or unreadable one is just empty.
+func (d *DependencySource) loadOSVCache() map[string]osvAnswer {
+	cache := make(map[string]osvAnswer)
+	if d.osvCache == "" {
+		return cache
+	}
+	if data, err := os.ReadFile(d.osvCache); err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+	return cache
+}
+
+// saveOSVCache writes the answers still fresh at now back. Failing to is
+// not worth failing the load over: the next one asks OSV again.
+func (d *DependencySource) saveOSVCache(cache map[string]osvAnswer, now time.Time) {
+	if d.osvCache == "" {
+		return
+	}
+	for key, answer := range cache {
+		if now.Sub(answer.CheckedAt) >= osvCacheTTL {
+			delete(cache, key)
+		}
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(d.osvCache), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(d.osvCache, data, 0o600)
+}
+
+// post sends one OSV query batch and decodes the answer into result
+func (d *DependencySource) post(ctx context.Context, body []byte, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.osv, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	answer, err := io.ReadAll(resp.Body)
+	d.meter(len(body) + len(answer))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("OSV returned %s", resp.Status)
+	}
+	return json.Unmarshal(answer, result)
+}
+
+// meter counts one OSV request and the bytes sent and received
+func (d *DependencySource) meter(bytes int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.spent.Requests++
+	d.spent.Bytes += int64(bytes)
+}
@@ -0,0 +1,112 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// TestDependencySourceReadsManifestsAndFlagsVulnerabilities checks go.mod
+// and package.json requirements become dependencies of the project, vendored
+// manifests are skipped, and OSV's answers flag the vulnerable versions.
+func TestDependencySourceReadsManifestsAndFlagsVulnerabilities(t *testing.T) {
+	dir := t.TempDir()
+	write := func(path, content string) {
+		t.Helper()
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module example.com/app\n\ngo 1.22\n\nrequire golang.org/x/net v0.1.0\n\nrequire (\n\tgithub.com/lib/pq v1.10.0\n\tgolang.org/x/text v0.3.0 // indirect\n)\n")
+	write("web/package.json", `{"dependencies":{"left-pad":"^1.3.0","local":"file:../local"},"devDependencies":{"jest":"~29.0.0"}}`)
+	write("web/node_modules/left-pad/package.json", `{"dependencies":{"ignored":"1.0.0"}}`)
+
+	var asked []string
+	osv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch struct {
+			Queries []struct {
+				Package struct {
+					Name string `json:"name"`
+				} `json:"package"`
+				Version string `json:"version"`
+			} `json:"queries"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&batch)
+		results := make([]map[string]interface{}, len(batch.Queries))
+		for i, query := range batch.Queries {
+			asked = append(asked, query.Package.Name+"@"+query.Version)
+			results[i] = map[string]interface{}{}
+			if query.Package.Name == "golang.org/x/net" {
+				results[i]["vulns"] = []map[string]string{{"id": "GO-2023-1571"}}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+	defer osv.Close()
+
+	cache := filepath.Join(t.TempDir(), "osv.json")
+	source := NewDependencySource(dir, "project:app")
+	source.SetOSV(osv.URL)
+	source.SetOSVCache(cache)
+	nodes, edges, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byName := map[string]map[string]interface{}{}
+	for _, node := range nodes {
+		var data map[string]interface{}
+		_ = json.Unmarshal(node.Data, &data)
+		byName[data["name"].(string)] = data
+	}
+	if len(nodes) != 6 || byName["ignored"] != nil {
+		t.Fatalf("got %d dependencies (%v), want the 6 outside node_modules", len(nodes), byName)
+	}
+	for _, edge := range edges {
+		if edge.FromID != "project:app" || edge.Relation != graph.EdgeDependsOn {
+			t.Errorf("edge %+v, want the project depending on each", edge)
+		}
+	}
+	if vulns := byName["golang.org/x/net"]["vulnerabilities"].([]interface{}); len(vulns) != 1 || vulns[0] != "GO-2023-1571" {
+		t.Errorf("golang.org/x/net vulnerabilities = %v", vulns)
+	}
+	if byName["github.com/lib/pq"]["vulnerabilities"] != nil {
+		t.Errorf("github.com/lib/pq flagged: %v", byName["github.com/lib/pq"]["vulnerabilities"])
+	}
+	// Versions as OSV expects them; the file: dependency has none to check
+	if len(asked) != 5 || asked[0] != "golang.org/x/net@0.1.0" || asked[3] != "left-pad@1.3.0" {
+		t.Errorf("asked OSV about %v", asked)
+	}
+	if got := source.Telemetry().Requests; got != 1 {
+		t.Errorf("%d OSV requests, want 1 batch", got)
+	}
+
+	// The next load answers from the cache
+	again := NewDependencySource(dir, "project:app")
+	again.SetOSV(osv.URL)
+	again.SetOSVCache(cache)
+	nodes, _, err = again.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := again.Telemetry().Requests; got != 0 {
+		t.Errorf("%d OSV requests with every answer cached, want 0", got)
+	}
+	for _, node := range nodes {
+		var data map[string]interface{}
+		_ = json.Unmarshal(node.Data, &data)
+		if data["name"] == "golang.org/x/net" && data["vulnerabilities"] == nil {
+			t.Error("golang.org/x/net lost its cached vulnerability")
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package datasource
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Reporter takes what a source has to say while it loads besides its
+// nodes: progress notes, and warnings about parts that failed without
+// failing the load. A nil Reporter writes them to standard error.
+type Reporter func(warning bool, message string)
+
+// notef reports progress
+func (r Reporter) notef(format string, args ...interface{}) {
+	r.send(false, fmt.Sprintf(format, args...))
+}
+
+// warnf reports something that went wrong without failing the load
+func (r Reporter) warnf(format string, args ...interface{}) {
+	r.send(true, fmt.Sprintf(format, args...))
+}
+
+func (r Reporter) send(warning bool, message string) {
+	if r == nil {
+		fmt.Fprintln(os.Stderr, message)
+		return
+	}
+	r(warning, message)
+}
+
+// ReportingSource is a DataSource that reports through a Reporter; the
+// Loader gives it one collecting its reports with the sync's diagnostics.
+type ReportingSource interface {
+	SetReporter(report Reporter)
+}
+
+// SetLog sets where diagnostics are written as they happen: standard error
+// by default, nil for nowhere (a TUI on the screen). Either way they are
+// kept for Diagnostics and Warnings.
+func (l *Loader) SetLog(w io.Writer) {
+	l.log = w
+}
+
+// report records a diagnostic of the running sync and writes it to the log
+func (l *Loader) report(warning bool, message string) {
+	l.diagnostics = append(l.diagnostics, message)
+	if warning {
+		l.warnings = append(l.warnings, message)
+	}
+	if l.log != nil {
+		fmt.Fprintln(l.log, message)
+	}
+}
+
+// notef records a progress note of the running sync
+func (l *Loader) notef(format string, args ...interface{}) {
+	l.report(false, fmt.Sprintf(format, args...))
+}
+
+// warnf records a warning of the running sync
+func (l *Loader) warnf(format string, args ...interface{}) {
+	l.report(true, fmt.Sprintf(format, args...))
+}
+
+// Diagnostics returns what the last LoadAll or RefreshNode reported as it
+// ran, in order: each source's progress and every warning
+func (l *Loader) Diagnostics() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.diagnostics
+}
+
+// Warnings returns the diagnostics of the last LoadAll or RefreshNode about
+// something that went wrong: failed sources, failed hooks, parts of a
+// source's load that failed without failing it
+func (l *Loader) Warnings() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.warnings
+}
@@ -1,6 +1,7 @@
 package datasource
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -14,18 +15,26 @@ import (
 
 // FileScanner scans a directory for source code files.
 type FileScanner struct {
-	rootPath   string
-	projectID  string
-	maxFiles   int
-	extensions []string
+	rootPath         string
+	projectID        string
+	maxFiles         int
+	truncated        bool // The last Load stopped at maxFiles
+	ownershipHistory int  // Recent commits read to infer owners (0 disables)
+	todos            bool // Capture TODO/FIXME comments as Issue nodes
+	tasks            bool // Capture Markdown checkbox items as Task nodes
+	extensions       []string
+	report           Reporter // Takes a malformed CODEOWNERS (nil = standard error)
 }
 
 // NewFileScanner creates a new file system scanner
 func NewFileScanner(rootPath, projectID string) *FileScanner {
 	return &FileScanner{
-		rootPath:  rootPath,
-		projectID: projectID,
-		maxFiles:  200, // Limit for performance
+		rootPath:         rootPath,
+		projectID:        projectID,
+		maxFiles:         200, // Limit for performance
+		ownershipHistory: defaultOwnershipHistory,
+		todos:            true,
+		tasks:            true,
 		extensions: []string{
 			".go", ".js", ".ts", ".tsx", ".jsx",
 			".py", ".rb", ".rs", ".java", ".kt",
@@ -41,6 +50,22 @@ func (f *FileScanner) SetMaxFiles(n int) {
 	f.maxFiles = n
 }
 
+// SetOwnershipHistory sets how many recent commits are read to infer
+// file and directory owners (0 disables inference)
+func (f *FileScanner) SetOwnershipHistory(n int) {
+	f.ownershipHistory = n
+}
+
+// SetTodos enables or disables capturing TODO/FIXME comments as Issue nodes
+func (f *FileScanner) SetTodos(enabled bool) {
+	f.todos = enabled
+}
+
+// SetTasks enables or disables capturing Markdown checkbox items as Task nodes
+func (f *FileScanner) SetTasks(enabled bool) {
+	f.tasks = enabled
+}
+
 // Name returns the data source identifier
 func (f *FileScanner) Name() string {
 	return "files:" + filepath.Base(f.rootPath)
@@ -51,6 +76,19 @@ func (f *FileScanner) SupportsRefresh() bool {
 	return true
 }
 
+// Truncation reports whether the last Load stopped at the file cap.
+func (f *FileScanner) Truncation() string {
+	if !f.truncated {
+		return ""
+	}
+	return fmt.Sprintf("truncated at %d files", f.maxFiles)
+}
+
+// SetReporter has a malformed CODEOWNERS reported to report
+func (f *FileScanner) SetReporter(report Reporter) {
+	f.report = report
+}
+
 // Load scans the directory and returns file nodes
 func (f *FileScanner) Load(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
 	var nodes []graph.Node
@@ -59,6 +97,16 @@ func (f *FileScanner) Load(ctx context.Context) ([]graph.Node, []graph.Edge, err
 	// Track directories for parent_of relationships
 	dirs := make(map[string]string) // dir path -> node ID
 	fileCount := 0
+	f.truncated = false
+
+	// Majority commit author per path (nil outside a git repository)
+	owners := inferOwnership(f.rootPath, f.ownershipHistory)
+
+	// Paths CODEOWNERS rules are matched against
+	var scanned []ownedPath
+
+	// People named in TODO(who) comments
+	todoAssignees := newPeople(todoSource)
 
 	err := filepath.Walk(f.rootPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -74,33 +122,51 @@ func (f *FileScanner) Load(ctx context.Context) ([]graph.Node, []graph.Edge, err
 			return nil
 		}
 
-		// Check file limit
-		if fileCount >= f.maxFiles {
-			return filepath.SkipAll
-		}
-
 		// Check extension
 		ext := strings.ToLower(filepath.Ext(path))
 		if !f.isValidExtension(ext) {
 			return nil
 		}
 
+		// Check file limit
+		if fileCount >= f.maxFiles {
+			f.truncated = true
+			return filepath.SkipAll
+		}
+
 		fileCount++
 
 		// Create file node
 		relPath, _ := filepath.Rel(f.rootPath, path)
-		node, edge := f.createFileNode(relPath, path, info)
+		content, _ := os.ReadFile(path) // nil when unreadable
+		node, edge := f.createFileNode(relPath, content, info, owners[filepath.ToSlash(relPath)])
 		nodes = append(nodes, node)
 		edges = append(edges, edge)
+		scanned = append(scanned, ownedPath{relPath: filepath.ToSlash(relPath), nodeID: node.ID})
+
+		// In-code debt as lightweight issues next to the tracked ones
+		if f.todos && todoLanguages[detectLanguage(ext)] {
+			todoNodes, todoEdges := f.todoNodes(filepath.ToSlash(relPath), node.ID, extractTodos(content), info.ModTime(), todoAssignees)
+			nodes = append(nodes, todoNodes...)
+			edges = append(edges, todoEdges...)
+		}
+
+		// Plaintext planning: checkbox lists in Markdown become tasks under the file
+		if f.tasks && detectLanguage(ext) == "Markdown" {
+			taskNodes, taskEdges := taskNodes(filepath.ToSlash(relPath), node.ID, extractTasks(content), info.ModTime())
+			nodes = append(nodes, taskNodes...)
+			edges = append(edges, taskEdges...)
+		}
 
 		// Track parent directory
 		dir := filepath.Dir(relPath)
 		if dir != "." && dir != "" {
 			if _, exists := dirs[dir]; !exists {
-				dirNode, dirEdge := f.createDirNode(dir)
+				dirNode, dirEdge := f.createDirNode(dir, owners[filepath.ToSlash(dir)])
 				nodes = append(nodes, dirNode)
 				edges = append(edges, dirEdge)
 				dirs[dir] = dirNode.ID
+				scanned = append(scanned, ownedPath{relPath: filepath.ToSlash(dir), nodeID: dirNode.ID, isDir: true})
 			}
 			// File belongs to directory
 			edges = append(edges, graph.Edge{
@@ -118,10 +184,64 @@ func (f *FileScanner) Load(ctx context.Context) ([]graph.Node, []graph.Edge, err
 	if err != nil {
 		return nil, nil, fmt.Errorf("walk failed: %w", err)
 	}
+	nodes = append(nodes, todoAssignees.nodes()...)
+
+	// Declared ownership from CODEOWNERS, kept separate from the inferred owner
+	co, err := loadCodeowners(f.rootPath)
+	if err != nil {
+		// A malformed CODEOWNERS shouldn't hide the files themselves
+		f.report.warnf("Warning: codeowners: %v", err)
+	}
+	if co != nil {
+		ownerNodes, ownerEdges := co.declaredOwnership(scanned)
+		nodes = append(nodes, ownerNodes...)
+		edges = append(edges, ownerEdges...)
+	}
 
 	return nodes, edges, nil
 }
 
+// maxPreviewSize caps the file contents read for previewing
+const maxPreviewSize = 1 << 20
+
+// Content reads a scanned file's contents. Paths outside the
+// scanned root, binary files and files over 1 MiB are refused.
+func (f *FileScanner) Content(ctx context.Context, node graph.Node) ([]byte, error) {
+	if node.Type != graph.NodeTypeFile || !strings.HasPrefix(node.ID, "file:") {
+		return nil, ErrNoContent
+	}
+	var data struct {
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal(node.Data, &data); err != nil || data.Path == "" || node.ID != "file:"+sanitizeID(data.Path) {
+		return nil, ErrNoContent
+	}
+	return readPreview(f.rootPath, data.Path)
+}
+
+// readPreview reads the text file at relPath under root for previewing
+func readPreview(root, relPath string) ([]byte, error) {
+	if !filepath.IsLocal(relPath) {
+		return nil, fmt.Errorf("%s is outside %s", relPath, root)
+	}
+	path := filepath.Join(root, relPath)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxPreviewSize {
+		return nil, fmt.Errorf("%s is too large to preview (%d KiB)", relPath, info.Size()>>10)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.IndexByte(content, 0) >= 0 {
+		return nil, fmt.Errorf("%s is a binary file", relPath)
+	}
+	return content, nil
+}
+
 // shouldSkipDir returns true for directories that should be ignored
 func (f *FileScanner) shouldSkipDir(name string) bool {
 	skipDirs := []string{
@@ -147,14 +267,14 @@ func (f *FileScanner) isValidExtension(ext string) bool {
 	return false
 }
 
-// createFileNode creates a graph node for a file
-func (f *FileScanner) createFileNode(relPath, fullPath string, info os.FileInfo) (graph.Node, graph.Edge) {
+// createFileNode creates a graph node for a file (content is nil when unreadable)
+func (f *FileScanner) createFileNode(relPath string, content []byte, info os.FileInfo, owner ownership) (graph.Node, graph.Edge) {
 	// Detect language from extension
 	lang := detectLanguage(filepath.Ext(relPath))
 
 	// Count lines (simple approach - count newlines)
 	lines := 0
-	if content, err := os.ReadFile(fullPath); err == nil {
+	if content != nil {
 		lines = strings.Count(string(content), "\n") + 1
 	}
 
@@ -164,6 +284,11 @@ func (f *FileScanner) createFileNode(relPath, fullPath string, info os.FileInfo)
 		"lines":    lines,
 		"size":     info.Size(),
 	}
+	addOwnership(data, owner)
+	addFileMetadata(data, relPath, content)
+	if lang == "Markdown" {
+		addFrontmatter(data, content)
+	}
 	dataJSON, _ := json.Marshal(data)
 
 	nodeID := fmt.Sprintf("file:%s", sanitizeID(relPath))
@@ -195,12 +320,14 @@ func (f *FileScanner) createFileNode(relPath, fullPath string, info os.FileInfo)
 }
 
 // createDirNode creates a service node for a directory
-func (f *FileScanner) createDirNode(dir string) (graph.Node, graph.Edge) {
+func (f *FileScanner) createDirNode(dir string, owner ownership) (graph.Node, graph.Edge) {
 	data := map[string]interface{}{
 		"name": filepath.Base(dir),
 		"path": dir,
 		"type": "directory",
 	}
+	addOwnership(data, owner)
+	addManifestMetadata(data, filepath.Join(f.rootPath, dir))
 	dataJSON, _ := json.Marshal(data)
 
 	nodeID := fmt.Sprintf("service:dir:%s", sanitizeID(dir))
@@ -3,9 +3,11 @@ package datasource
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,8 +17,9 @@ import (
 // GitScanner scans a local git repository for commits and branches.
 // Uses git CLI for simplicity and broad compatibility.
 type GitScanner struct {
-	repoPath string
+	repoPath   string
 	maxCommits int
+	unloaded   int // Commits the last Load left out at the cap
 }
 
 // NewGitScanner creates a new git repository scanner
@@ -42,7 +45,9 @@ func (g *GitScanner) SupportsRefresh() bool {
 	return true
 }
 
-// Load scans the git repository and returns nodes and edges
+// Load scans the git repository and returns nodes and edges. Commits hang
+// off the branch that holds them; the checked-out branch's recent history
+// comes first, and the rest waits for LoadBranchHistory.
 func (g *GitScanner) Load(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
 	var nodes []graph.Node
 	var edges []graph.Edge
@@ -56,21 +61,88 @@ func (g *GitScanner) Load(ctx context.Context) ([]graph.Node, []graph.Edge, erro
 	projectNode := g.createProjectNode()
 	nodes = append(nodes, projectNode)
 
-	// Load commits
-	commits, commitEdges, err := g.loadCommits(projectNode.ID)
+	// Load branches as service nodes
+	branches, err := g.listBranches()
 	if err == nil {
+		branchNodes, branchEdges := g.branchNodes(projectNode.ID, branches)
+		nodes = append(nodes, branchNodes...)
+		edges = append(edges, branchEdges...)
+	}
+
+	// Load commits into the branches that hold them, within the commit cap
+	authors := newPeople("git")
+	budget := g.maxCommits
+	g.unloaded = 0
+	for i, branch := range branches {
+		if budget <= 0 {
+			break
+		}
+		commits, commitEdges, err := g.loadCommits(ctx, branch, branches[:i], 0, budget, authors)
+		if err != nil {
+			continue
+		}
 		nodes = append(nodes, commits...)
 		edges = append(edges, commitEdges...)
+		budget -= len(commits)
+	}
+	if budget <= 0 {
+		g.unloaded = g.countCommits() - g.maxCommits
 	}
 
-	// Load branches as service nodes
-	branches, branchEdges, err := g.loadBranches(projectNode.ID)
+	// Load tags as releases pointing at the commits they mark
+	releases, releaseEdges, err := g.loadReleases(projectNode.ID)
 	if err == nil {
-		nodes = append(nodes, branches...)
-		edges = append(edges, branchEdges...)
+		nodes = append(nodes, releases...)
+		edges = append(edges, releaseEdges...)
 	}
 
-	return nodes, edges, nil
+	return append(nodes, authors.nodes()...), edges, nil
+}
+
+// Truncation reports whether the last Load stopped at the commit cap, e.g.
+// "truncated at 50 commits (120 more on branches)".
+func (g *GitScanner) Truncation() string {
+	if g.unloaded <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("truncated at %d commits (%d more on branches)", g.maxCommits, g.unloaded)
+}
+
+// countCommits counts the commits on every branch (0 when git fails)
+func (g *GitScanner) countCommits() int {
+	cmd := exec.Command("git", "-C", g.repoPath, "rev-list", "--count", "--branches", "--remotes")
+	output, err := cmd.Output()
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(string(output)))
+	return n
+}
+
+// ErrUnknownBranch is returned by LoadBranchHistory for a branch the
+// repository does not have.
+var ErrUnknownBranch = errors.New("unknown branch")
+
+// LoadBranchHistory loads up to limit more commits held by a branch node,
+// skipping the skip commits already loaded. It reads the same history Load
+// attached to the branch, so pages follow on from the initial load.
+func (g *GitScanner) LoadBranchHistory(ctx context.Context, branchID string, skip, limit int) ([]graph.Node, []graph.Edge, error) {
+	branches, err := g.listBranches()
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, branch := range branches {
+		if branch.id() != branchID {
+			continue
+		}
+		authors := newPeople("git")
+		nodes, edges, err := g.loadCommits(ctx, branch, branches[:i], skip, limit, authors)
+		if err != nil {
+			return nil, nil, err
+		}
+		return append(nodes, authors.nodes()...), edges, nil
+	}
+	return nil, nil, fmt.Errorf("%w %s in %s", ErrUnknownBranch, branchID, g.repoPath)
 }
 
 // isGitRepo checks if the path is a git repository
@@ -97,6 +169,7 @@ func (g *GitScanner) createProjectNode() graph.Node {
 		"remote":      remoteURL,
 		"path":        g.repoPath,
 	}
+	addManifestMetadata(data, g.repoPath)
 	dataJSON, _ := json.Marshal(data)
 
 	return graph.Node{
@@ -114,48 +187,151 @@ func (g *GitScanner) createProjectNode() graph.Node {
 	}
 }
 
-// loadCommits loads recent commits from the repository
-func (g *GitScanner) loadCommits(projectID string) ([]graph.Node, []graph.Edge, error) {
-	var nodes []graph.Node
-	var edges []graph.Edge
-
-	// Get commit log in a parseable format
-	// Format: hash|author|date|subject
-	cmd := exec.Command("git", "-C", g.repoPath, "log",
-		fmt.Sprintf("--max-count=%d", g.maxCommits),
-		"--format=%H|%an|%aI|%s",
-	)
+// Diff returns a commit's patch with its header and file stats (git show).
+// The hash comes from node data, which a shared store lets anyone write, so
+// only a hex object name is passed on, and never where git reads options.
+func (g *GitScanner) Diff(ctx context.Context, node graph.Node) (string, error) {
+	var data struct {
+		Hash string `json:"hash"`
+	}
+	if node.Type != graph.NodeTypeCommit || json.Unmarshal(node.Data, &data) != nil || !isCommitHash(data.Hash) {
+		return "", ErrNoDiff
+	}
+	cmd := exec.CommandContext(ctx, "git", "-C", g.repoPath, "show", "--no-color", "--stat", "--patch", "--end-of-options", data.Hash)
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, nil, fmt.Errorf("git log failed: %w", err)
+		// The commit belongs to another repository
+		return "", fmt.Errorf("%w: git show %s: %v", ErrNoDiff, data.Hash[:min(len(data.Hash), 8)], err)
 	}
+	return string(output), nil
+}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var prevCommitID string
+// isCommitHash reports whether s is a full or abbreviated hex object name
+// (SHA-1 or SHA-256)
+func isCommitHash(s string) bool {
+	if len(s) < 4 || len(s) > 64 {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// gitBranch is a local or remote-tracking branch
+type gitBranch struct {
+	ref     string // Full ref name (refs/heads/main), safe to pass to git
+	name    string // Short name shown to the user (main, origin/main)
+	current bool   // Checked out
+}
+
+// id returns the branch's node ID
+func (b gitBranch) id() string {
+	return fmt.Sprintf("service:branch:%s", sanitizeID(b.name))
+}
+
+// listBranches lists the repository's branches, the checked-out one first.
+// A commit belongs to the first branch in this order that holds it.
+func (g *GitScanner) listBranches() ([]gitBranch, error) {
+	cmd := exec.Command("git", "-C", g.repoPath, "branch", "-a", "--format=%(refname)|%(refname:short)|%(HEAD)")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git branch failed: %w", err)
+	}
 
-	for _, line := range lines {
-		if line == "" {
+	var branches []gitBranch
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "|", 3)
+		if len(parts) < 3 || strings.Contains(parts[1], "HEAD") {
 			continue
 		}
-		parts := strings.SplitN(line, "|", 4)
-		if len(parts) < 4 {
-			continue
+		branch := gitBranch{ref: parts[0], name: parts[1], current: parts[2] == "*"}
+		if branch.current {
+			branches = append([]gitBranch{branch}, branches...)
+		} else {
+			branches = append(branches, branch)
 		}
+	}
+	return branches, nil
+}
+
+// loadCommits loads up to limit commits a branch holds, skipping the first
+// skip. Commits also reachable from an earlier branch belong to that branch
+// and are left out.
+func (g *GitScanner) loadCommits(ctx context.Context, branch gitBranch, earlier []gitBranch, skip, limit int, authors *people) ([]graph.Node, []graph.Edge, error) {
+	var nodes []graph.Node
+	var edges []graph.Edge
 
-		hash := parts[0]
-		author := parts[1]
-		dateStr := parts[2]
-		message := parts[3]
+	// A later page starts one commit early, so its first commit links to the
+	// last one already loaded
+	from := skip
+	if skip > 0 {
+		from, limit = skip-1, limit+1
+	}
+
+	args := []string{"-C", g.repoPath, "log",
+		fmt.Sprintf("--skip=%d", from),
+		fmt.Sprintf("--max-count=%d", limit),
+		commitFormat,
+		"--shortstat",
+		branch.ref,
+	}
+	if len(earlier) > 0 {
+		args = append(args, "--not")
+		for _, other := range earlier {
+			args = append(args, other.ref)
+		}
+	}
+	output, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	var prevCommitID string
+	branchID := branch.id()
+
+	for i, commit := range parseCommitLog(string(output)) {
+		hash := commit.hash
+		author := commit.author
+		email := commit.email
+		dateStr := commit.date
+		message := commit.subject
 
 		commitID := fmt.Sprintf("commit:%s", hash[:8])
 
 		commitDate, _ := time.Parse(time.RFC3339, dateStr)
 
+		// Edge: commit parent relationship (sequential)
+		if prevCommitID != "" {
+			edges = append(edges, graph.Edge{
+				ID:       fmt.Sprintf("edge:commit-parent:%s-%s", hash[:8], prevCommitID[7:]),
+				FromID:   prevCommitID,
+				ToID:     commitID,
+				Relation: graph.EdgeParentOf,
+				Metadata: graph.EdgeMetadata{CreatedAt: commitDate},
+			})
+		}
+		prevCommitID = commitID
+		if skip > 0 && i == 0 {
+			continue // Loaded by the previous page
+		}
+
 		data := map[string]interface{}{
-			"message": message,
-			"author":  author,
-			"hash":    hash,
-			"date":    dateStr,
+			"title":         message,
+			"message":       message,
+			"author":        author,
+			"email":         email,
+			"hash":          hash,
+			"date":          dateStr,
+			"body":          commit.body,
+			"files_changed": commit.filesChanged,
+			"insertions":    commit.insertions,
+			"deletions":     commit.deletions,
+			"tags":          commit.tags,
+			"signed_off_by": commit.signedOffBy,
+			"co_authors":    commit.coAuthors,
 		}
 		dataJSON, _ := json.Marshal(data)
 
@@ -174,27 +350,20 @@ func (g *GitScanner) loadCommits(projectID string) ([]graph.Node, []graph.Edge,
 		}
 		nodes = append(nodes, node)
 
-		// Edge: project owns commit
+		// Edge: author wrote commit
+		if authorID := authors.personFrom(graph.PersonData{Name: author, Emails: []string{email}}); authorID != "" {
+			edges = append(edges, authoredEdge(authorID, commitID, commitDate))
+		}
+
+		// Edge: branch owns commit
 		edges = append(edges, graph.Edge{
-			ID:       fmt.Sprintf("edge:project-commit:%s", hash[:8]),
-			FromID:   projectID,
+			ID:       fmt.Sprintf("edge:branch-commit:%s-%s", sanitizeID(branch.name), hash[:8]),
+			FromID:   branchID,
 			ToID:     commitID,
 			Relation: graph.EdgeOwns,
 			Metadata: graph.EdgeMetadata{CreatedAt: commitDate},
 		})
 
-		// Edge: commit parent relationship (sequential)
-		if prevCommitID != "" {
-			edges = append(edges, graph.Edge{
-				ID:       fmt.Sprintf("edge:commit-parent:%s-%s", hash[:8], prevCommitID[7:]),
-				FromID:   prevCommitID,
-				ToID:     commitID,
-				Relation: graph.EdgeParentOf,
-				Metadata: graph.EdgeMetadata{CreatedAt: commitDate},
-			})
-		}
-		prevCommitID = commitID
-
 		// Check for issue references in commit message (e.g., #123, fixes #456)
 		issueRefs := extractIssueReferences(message)
 		for _, issueNum := range issueRefs {
@@ -211,30 +380,95 @@ func (g *GitScanner) loadCommits(projectID string) ([]graph.Node, []graph.Edge,
 	return nodes, edges, nil
 }
 
-// loadBranches loads git branches as service nodes
-func (g *GitScanner) loadBranches(projectID string) ([]graph.Node, []graph.Edge, error) {
-	var nodes []graph.Node
-	var edges []graph.Edge
-
-	// Get all branches
-	cmd := exec.Command("git", "-C", g.repoPath, "branch", "-a", "--format=%(refname:short)")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, nil, fmt.Errorf("git branch failed: %w", err)
-	}
+// commitFormat separates commits with \x1e and fields with \x1f, and ends
+// the free-form body with \x1d so the --shortstat line after it can be told
+// apart: hash, author, email, date, subject, ref names, body
+const commitFormat = "--format=%x1e%H%x1f%an%x1f%ae%x1f%aI%x1f%s%x1f%D%x1f%b%x1d"
+
+// gitCommit is one commit parsed from git log
+type gitCommit struct {
+	hash, author, email, date, subject string
+
+	body         string   // Message after the subject, trailers removed
+	tags         []string // Tags pointing at the commit
+	signedOffBy  []string // Signed-off-by trailers
+	coAuthors    []string // Co-authored-by trailers
+	filesChanged int
+	insertions   int
+	deletions    int
+}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	for _, branch := range lines {
-		branch = strings.TrimSpace(branch)
-		if branch == "" || strings.Contains(branch, "HEAD") {
+// parseCommitLog parses git log output written with commitFormat and --shortstat
+func parseCommitLog(output string) []gitCommit {
+	var commits []gitCommit
+	for _, record := range strings.Split(output, "\x1e") {
+		message, stat, _ := strings.Cut(record, "\x1d")
+		fields := strings.SplitN(message, "\x1f", 7)
+		if len(fields) < 7 || len(fields[0]) < 8 {
 			continue
 		}
+		commit := gitCommit{
+			hash:    fields[0],
+			author:  fields[1],
+			email:   fields[2],
+			date:    fields[3],
+			subject: fields[4],
+		}
+		for _, ref := range strings.Split(fields[5], ", ") {
+			if tag, ok := strings.CutPrefix(ref, "tag: "); ok {
+				commit.tags = append(commit.tags, tag)
+			}
+		}
+
+		// Trailers are pulled out of the body into their own fields
+		var body []string
+		for _, line := range strings.Split(fields[6], "\n") {
+			key, value, found := strings.Cut(line, ":")
+			switch {
+			case found && strings.EqualFold(key, "Signed-off-by"):
+				commit.signedOffBy = append(commit.signedOffBy, strings.TrimSpace(value))
+			case found && strings.EqualFold(key, "Co-authored-by"):
+				commit.coAuthors = append(commit.coAuthors, strings.TrimSpace(value))
+			default:
+				body = append(body, line)
+			}
+		}
+		commit.body = strings.TrimSpace(strings.Join(body, "\n"))
+
+		// " 3 files changed, 10 insertions(+), 2 deletions(-)"; absent for
+		// empty commits and merges
+		for _, part := range strings.Split(strings.TrimSpace(stat), ", ") {
+			var n int
+			var what string
+			if _, err := fmt.Sscanf(part, "%d %s", &n, &what); err != nil {
+				continue
+			}
+			switch {
+			case strings.HasPrefix(what, "file"):
+				commit.filesChanged = n
+			case strings.HasPrefix(what, "insertion"):
+				commit.insertions = n
+			case strings.HasPrefix(what, "deletion"):
+				commit.deletions = n
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
 
-		branchID := fmt.Sprintf("service:branch:%s", sanitizeID(branch))
+// branchNodes turns branches into service nodes owned by the project
+func (g *GitScanner) branchNodes(projectID string, branches []gitBranch) ([]graph.Node, []graph.Edge) {
+	var nodes []graph.Node
+	var edges []graph.Edge
+
+	for _, branch := range branches {
+		branchID := branch.id()
 
 		data := map[string]interface{}{
-			"name": branch,
-			"type": "branch",
+			"name":    branch.name,
+			"type":    "branch",
+			"current": branch.current,
 		}
 		dataJSON, _ := json.Marshal(data)
 
@@ -255,7 +489,7 @@ func (g *GitScanner) loadBranches(projectID string) ([]graph.Node, []graph.Edge,
 
 		// Edge: project owns branch
 		edges = append(edges, graph.Edge{
-			ID:       fmt.Sprintf("edge:project-branch:%s", sanitizeID(branch)),
+			ID:       fmt.Sprintf("edge:project-branch:%s", sanitizeID(branch.name)),
 			FromID:   projectID,
 			ToID:     branchID,
 			Relation: graph.EdgeOwns,
@@ -263,6 +497,88 @@ func (g *GitScanner) loadBranches(projectID string) ([]graph.Node, []graph.Edge,
 		})
 	}
 
+	return nodes, edges
+}
+
+// maxReleases caps how many tags load as releases, newest first
+const maxReleases = 50
+
+// loadReleases loads the newest tags as Release nodes owned by the project,
+// each tagging the commit it points at
+func (g *GitScanner) loadReleases(projectID string) ([]graph.Node, []graph.Edge, error) {
+	var nodes []graph.Node
+	var edges []graph.Edge
+
+	// Annotated tags point at a tag object: *objectname peels it to the
+	// commit. Format: tag, object, peeled commit, date, subject, tagger
+	cmd := exec.Command("git", "-C", g.repoPath, "for-each-ref", "refs/tags",
+		"--sort=-creatordate",
+		fmt.Sprintf("--count=%d", maxReleases),
+		"--format=%(refname:short)%1f%(objectname)%1f%(*objectname)%1f%(creatordate:iso-strict)%1f%(contents:subject)%1f%(taggername)",
+	)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("git for-each-ref failed: %w", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "\x1f")
+		if len(parts) < 6 || parts[0] == "" {
+			continue
+		}
+		tag, hash, dateStr, message, tagger := parts[0], parts[1], parts[3], parts[4], parts[5]
+		if parts[2] != "" {
+			hash = parts[2]
+		}
+		if len(hash) < 8 {
+			continue
+		}
+		releaseID := fmt.Sprintf("release:%s", sanitizeID(tag))
+		tagDate, _ := time.Parse(time.RFC3339, dateStr)
+
+		data := map[string]interface{}{
+			"title":   tag,
+			"tag":     tag,
+			"hash":    hash,
+			"date":    dateStr,
+			"message": message, // Annotated tag subject, or the commit's for a lightweight tag
+			"tagger":  tagger,
+		}
+		dataJSON, _ := json.Marshal(data)
+
+		nodes = append(nodes, graph.Node{
+			ID:     releaseID,
+			Type:   graph.NodeTypeRelease,
+			Source: "git",
+			Data:   dataJSON,
+			Metadata: graph.NodeMetadata{
+				CreatedAt:   tagDate,
+				UpdatedAt:   tagDate,
+				CreatedBy:   "git-scanner",
+				AccessLevel: graph.RoleExec,
+				SyncedAt:    time.Now(),
+			},
+		})
+
+		// Edge: project owns release
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:project-release:%s", sanitizeID(tag)),
+			FromID:   projectID,
+			ToID:     releaseID,
+			Relation: graph.EdgeOwns,
+			Metadata: graph.EdgeMetadata{CreatedAt: tagDate},
+		})
+
+		// Edge: release tags commit
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:release-commit:%s", sanitizeID(tag)),
+			FromID:   releaseID,
+			ToID:     fmt.Sprintf("commit:%s", hash[:8]),
+			Relation: graph.EdgeTagged,
+			Metadata: graph.EdgeMetadata{CreatedAt: tagDate},
+		})
+	}
+
 	return nodes, edges, nil
 }
 
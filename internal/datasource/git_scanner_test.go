@@ -0,0 +1,153 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// TestBranchHistoryPagesFollowTheInitialLoad checks commits hang off the
+// branch holding them and that later pages continue where Load stopped.
+func TestBranchHistoryPagesFollowTheInitialLoad(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Ann", "-c", "user.email=ann@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	for i := 1; i <= 3; i++ {
+		git("commit", "-q", "--allow-empty", "-m", fmt.Sprintf("main %d", i))
+	}
+	git("checkout", "-q", "-b", "feature")
+	git("commit", "-q", "--allow-empty", "-m", "feature 1")
+	git("checkout", "-q", "main")
+
+	scanner := NewGitScanner(dir)
+	scanner.SetMaxCommits(2)
+	_, edges, err := scanner.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	owned := func(edges []graph.Edge, branchID string) int {
+		n := 0
+		for _, edge := range edges {
+			if edge.FromID == branchID && edge.Relation == graph.EdgeOwns {
+				n++
+			}
+		}
+		return n
+	}
+	// The checked-out branch takes the whole cap
+	if got := owned(edges, "service:branch:main"); got != 2 {
+		t.Errorf("main owns %d commits, want 2", got)
+	}
+	if got := owned(edges, "service:branch:feature"); got != 0 {
+		t.Errorf("feature owns %d commits, want 0", got)
+	}
+	if got, want := scanner.Truncation(), "truncated at 2 commits (2 more on branches)"; got != want {
+		t.Errorf("Truncation() = %q, want %q", got, want)
+	}
+
+	nodes, edges, err := scanner.LoadBranchHistory(context.Background(), "service:branch:main", 2, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := owned(edges, "service:branch:main"); got != 1 {
+		t.Errorf("next page of main owns %d commits, want 1", got)
+	}
+	linked := false
+	for _, edge := range edges {
+		linked = linked || edge.Relation == graph.EdgeParentOf
+	}
+	if !linked {
+		t.Error("page is not linked to the last commit already loaded")
+	}
+	for _, node := range nodes {
+		if node.Type == graph.NodeTypeCommit && !strings.Contains(string(node.Data), `"message":"main 1"`) {
+			t.Errorf("page holds %s", node.Data)
+		}
+	}
+
+	// Commits main also holds belong to main, not feature
+	_, edges, err = scanner.LoadBranchHistory(context.Background(), "service:branch:feature", 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := owned(edges, "service:branch:feature"); got != 1 {
+		t.Errorf("feature owns %d commits, want 1", got)
+	}
+	if _, _, err := scanner.LoadBranchHistory(context.Background(), "service:branch:gone", 0, 10); err == nil {
+		t.Error("loading an unknown branch succeeded")
+	}
+}
+
+func TestParseCommitLogReadsBodyStatsTagsAndTrailers(t *testing.T) {
+	output := "\x1eabcdef0123\x1fAnn\x1fann@example.com\x1f2026-01-02T03:04:05Z\x1fFix login | retry\x1fHEAD -> main, tag: v1.2, tag: stable\x1f" +
+		"Retry once on timeout.\n\nSigned-off-by: Ann <ann@example.com>\nCo-authored-by: Bob <bob@example.com>\n\x1d\n 3 files changed, 10 insertions(+), 1 deletion(-)\n" +
+		"\x1e0123abcdef\x1fBob\x1fbob@example.com\x1f2026-01-01T00:00:00Z\x1fEmpty\x1f\x1f\x1d\n"
+
+	commits := parseCommitLog(output)
+	if len(commits) != 2 {
+		t.Fatalf("parsed %d commits, want 2", len(commits))
+	}
+	c := commits[0]
+	if c.subject != "Fix login | retry" || c.body != "Retry once on timeout." {
+		t.Errorf("subject %q, body %q", c.subject, c.body)
+	}
+	if c.filesChanged != 3 || c.insertions != 10 || c.deletions != 1 {
+		t.Errorf("stats %d files +%d -%d", c.filesChanged, c.insertions, c.deletions)
+	}
+	if strings.Join(c.tags, ",") != "v1.2,stable" {
+		t.Errorf("tags %v", c.tags)
+	}
+	if len(c.signedOffBy) != 1 || len(c.coAuthors) != 1 || c.coAuthors[0] != "Bob <bob@example.com>" {
+		t.Errorf("trailers %v %v", c.signedOffBy, c.coAuthors)
+	}
+	if empty := commits[1]; empty.body != "" || empty.insertions != 0 || len(empty.tags) != 0 {
+		t.Errorf("empty commit %+v", empty)
+	}
+}
+
+// TestDiffOnlyShowsHexHashes checks Diff shows a commit by its hash and
+// refuses a hash that git would read as an option or a revision expression.
+func TestDiffOnlyShowsHexHashes(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{{"init", "-q", "-b", "main"}, {"commit", "-q", "--allow-empty", "-m", "first"}} {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Ann", "-c", "user.email=ann@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := strings.TrimSpace(string(out))
+
+	scanner := NewGitScanner(dir)
+	commit := func(hash string) graph.Node {
+		return graph.Node{ID: "commit:x", Type: graph.NodeTypeCommit, Data: []byte(`{"hash":"` + hash + `"}`)}
+	}
+	if diff, err := scanner.Diff(context.Background(), commit(hash)); err != nil || !strings.Contains(diff, "first") {
+		t.Errorf("Diff(%s) = %q, %v", hash, diff, err)
+	}
+	for _, bad := range []string{"--output=" + dir + "/x", "HEAD", hash[:7] + "^", ""} {
+		if _, err := scanner.Diff(context.Background(), commit(bad)); !errors.Is(err, ErrNoDiff) {
+			t.Errorf("Diff(%q) error = %v, want ErrNoDiff", bad, err)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package datasource
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// hookTimeout bounds each hook command so a stuck script cannot stall loading
+const hookTimeout = 30 * time.Second
+
+// Sync events passed to hooks (on stdin as "event" and in $MAAT_EVENT)
+const (
+	HookPreSync       = "pre-sync"
+	HookPostSync      = "post-sync"
+	HookOnNodeChanged = "node-changed"
+)
+
+// Node changes reported to on-node-changed hooks
+const (
+	NodeAdded   = "added"
+	NodeUpdated = "updated"
+	NodeRemoved = "removed"
+)
+
+// NodeChange is a node that differs from the previous sync.
+// Removed nodes carry only their ID.
+type NodeChange struct {
+	Change string     `json:"change"`
+	Node   graph.Node `json:"node"`
+}
+
+// SetHooks configures the shell commands run around LoadAll. statePath is
+// where the project's node digests are kept between syncs for
+// on-node-changed hooks; read-only commands leave hooks unset.
+func (l *Loader) SetHooks(hooks config.HooksConfig, statePath string) {
+	l.hooks = hooks
+	l.hookStatePath = statePath
+}
+
+// runPreSyncHooks tells pre-sync hooks which sources are about to load
+func (l *Loader) runPreSyncHooks(ctx context.Context) {
+	if len(l.hooks.PreSync) == 0 {
+		return
+	}
+	sources := make([]string, len(l.sources))
+	for i, source := range l.sources {
+		sources[i] = source.Name()
+	}
+	l.runHooks(ctx, l.hooks.PreSync, map[string]interface{}{
+		"event":   HookPreSync,
+		"sources": sources,
+	}, nil)
+}
+
+// runPostSyncHooks diffs each source's nodes against the previous sync of
+// the project, runs on-node-changed hooks for each change, then post-sync
+// hooks with a summary.
+func (l *Loader) runPostSyncHooks(ctx context.Context, nodes []graph.Node, edges []graph.Edge) {
+	var changes []NodeChange
+	if len(l.hooks.OnNodeChanged) > 0 && l.hookStatePath != "" {
+		previous, err := config.LoadSyncState(l.hookStatePath)
+		if err != nil {
+			l.warnf("Warning: %v", err)
+		}
+		state := l.nodeDigests(previous)
+		changes = l.diffNodes(previous, state, nodes)
+		if err := config.WriteSyncState(l.hookStatePath, state); err != nil {
+			l.warnf("Warning: %v", err)
+		}
+	}
+
+	for _, change := range changes {
+		l.runHooks(ctx, l.hooks.OnNodeChanged, map[string]interface{}{
+			"event":  HookOnNodeChanged,
+			"change": change.Change,
+			"node":   change.Node,
+		}, []string{"MAAT_NODE_ID=" + change.Node.ID, "MAAT_CHANGE=" + change.Change})
+	}
+
+	if len(l.hooks.PostSync) == 0 {
+		return
+	}
+	summary := map[string]int{}
+	for _, change := range changes {
+		summary[change.Change]++
+	}
+	l.runHooks(ctx, l.hooks.PostSync, map[string]interface{}{
+		"event":   HookPostSync,
+		"nodes":   len(nodes),
+		"edges":   len(edges),
+		"changes": summary,
+	}, nil)
+}
+
+// nodeDigests fingerprints each source's nodes by type and data. A source
+// that failed this time, or is no longer configured, keeps its previous
+// digests so its nodes don't all read as removed.
+func (l *Loader) nodeDigests(previous config.SyncState) config.SyncState {
+	failed := make(map[string]bool)
+	for _, run := range l.runs {
+		if run.Error != "" {
+			failed[run.Source] = true
+		}
+	}
+	state := make(config.SyncState, len(previous)+len(l.sources))
+	for name, digests := range previous {
+		state[name] = digests
+	}
+	for i, load := range l.loads {
+		name := l.sources[i].Name()
+		if failed[name] {
+			continue
+		}
+		digests := make(map[string]string, len(load.nodes))
+		for _, node := range load.nodes {
+			digests[node.ID] = nodeDigest(node)
+		}
+		state[name] = digests
+	}
+	return state
+}
+
+// nodeDigest fingerprints a node's type and data
+func nodeDigest(node graph.Node) string {
+	sum := sha1.Sum(append([]byte(string(node.Type)+"\x00"), node.Data...))
+	return hex.EncodeToString(sum[:8])
+}
+
+// diffNodes compares this sync's digests against the previous sync's. A
+// source's first sync only records a baseline - everything would be
+// "added". Changes carry the merged node when there is one.
+func (l *Loader) diffNodes(previous, current config.SyncState, nodes []graph.Node) []NodeChange {
+	merged := make(map[string]graph.Node, len(nodes))
+	for _, node := range nodes {
+		merged[node.ID] = node
+	}
+	known := make(map[string]string) // Node ID -> digest under any source last time
+	for _, digests := range previous {
+		for id, digest := range digests {
+			known[id] = digest
+		}
+	}
+
+	var changes []NodeChange
+	reported := make(map[string]bool)
+	for i, load := range l.loads {
+		name := l.sources[i].Name()
+		if _, ok := previous[name]; !ok {
+			continue
+		}
+		for _, node := range load.nodes {
+			digest, seen := known[node.ID]
+			change := NodeAdded
+			switch {
+			case reported[node.ID]:
+				continue
+			case seen && digest == current[name][node.ID]:
+				continue
+			case seen:
+				change = NodeUpdated
+			}
+			reported[node.ID] = true
+			if m, ok := merged[node.ID]; ok {
+				node = m
+			} else {
+				node = l.redactor.Redact(node)
+			}
+			changes = append(changes, NodeChange{Change: change, Node: node})
+		}
+	}
+
+	present := make(map[string]bool)
+	for _, digests := range current {
+		for id := range digests {
+			present[id] = true
+		}
+	}
+	var removed []string
+	for id := range known {
+		if !present[id] {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+	for _, id := range removed {
+		changes = append(changes, NodeChange{Change: NodeRemoved, Node: graph.Node{ID: id}})
+	}
+	return changes
+}
+
+// runHooks runs each command with the event JSON on stdin. Failures are
+// reported as warnings, with the command's output in the log, and skipped -
+// a broken hook never fails the sync.
+func (l *Loader) runHooks(ctx context.Context, commands []string, event map[string]interface{}, env []string) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		l.warnf("Warning: encoding %s hook input: %v", event["event"], err)
+		return
+	}
+	for _, command := range commands {
+		hookCtx, cancel := context.WithTimeout(ctx, hookTimeout)
+		cmd := exec.CommandContext(hookCtx, "sh", "-c", command)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Env = append(os.Environ(), append([]string{fmt.Sprintf("MAAT_EVENT=%s", event["event"])}, env...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			l.warnf("Warning: %s hook %q failed: %v", event["event"], command, err)
+			if l.log != nil {
+				_, _ = l.log.Write(output)
+			}
+		}
+		cancel()
+	}
+}
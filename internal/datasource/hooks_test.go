@@ -0,0 +1,95 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// TestNodeChangedHooksDiffEachSource checks changes are reported against
+// each source's previous sync, and that a failed source's nodes don't read
+// as removed.
+func TestNodeChangedHooksDiffEachSource(t *testing.T) {
+	node := func(id, data string) graph.Node {
+		return graph.Node{ID: id, Type: graph.NodeTypeCommit, Data: []byte(data)}
+	}
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "changes.log")
+	git := &stubSource{name: "git", nodes: []graph.Node{node("project:a", `{}`), node("commit:1", `{}`)}}
+	linear := &stubSource{name: "linear", nodes: []graph.Node{node("project:b", `{}`), node("issue:1", `{}`)}}
+	loader := NewLoader(git, linear)
+	loader.SetHooks(config.HooksConfig{
+		OnNodeChanged: []string{`echo "$MAAT_CHANGE $MAAT_NODE_ID" >> ` + logPath},
+	}, filepath.Join(dir, "state.json"))
+	sync := func() []string {
+		t.Helper()
+		_ = os.Remove(logPath)
+		if _, _, err := loader.LoadAll(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		data, _ := os.ReadFile(logPath)
+		if len(data) == 0 {
+			return nil
+		}
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+
+	if got := sync(); len(got) != 0 {
+		t.Errorf("first sync should only record a baseline, got %v", got)
+	}
+
+	git.nodes = []graph.Node{node("project:a", `{}`), node("commit:1", `{"x":1}`), node("commit:2", `{}`)}
+	linear.err = errors.New("offline")
+	want := []string{"updated commit:1", "added commit:2"}
+	if got := sync(); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+
+	linear.err = nil
+	linear.nodes = []graph.Node{node("project:b", `{}`)}
+	want = []string{"removed issue:1"}
+	if got := sync(); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+}
+
+// TestSyncDiagnosticsAreKept checks a failed source and a failed hook are
+// kept as warnings of the sync, written only to the log set.
+func TestSyncDiagnosticsAreKept(t *testing.T) {
+	node := graph.Node{ID: "project:a", Type: graph.NodeTypeProject, Data: []byte(`{}`)}
+	loader := NewLoader(
+		&stubSource{name: "git", nodes: []graph.Node{node}},
+		&stubSource{name: "linear", err: errors.New("offline")},
+	)
+	loader.SetHooks(config.HooksConfig{PostSync: []string{"echo broken; exit 3"}}, "")
+	var log strings.Builder
+	loader.SetLog(&log)
+	if _, _, err := loader.LoadAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"Error loading from linear: offline", `Warning: post-sync hook "echo broken; exit 3" failed: exit status 3`}
+	if got := loader.Warnings(); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("warnings = %q, want %q", got, want)
+	}
+	if got := loader.Diagnostics(); len(got) != 3 || got[0] != "Loaded 1 nodes from git" {
+		t.Errorf("diagnostics = %q, want the git load then the warnings", got)
+	}
+	if !strings.Contains(log.String(), "broken\n") {
+		t.Errorf("log = %q, want the hook's output", log.String())
+	}
+
+	loader.SetLog(nil)
+	if _, _, err := loader.LoadAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(loader.Warnings()) != 2 {
+		t.Errorf("warnings = %q after a second sync, want only its own", loader.Warnings())
+	}
+}
@@ -0,0 +1,298 @@
+package datasource
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// Identity unification: the same human shows up as a git author ("Alice
+// Smith <alice@corp.com>"), a Linear assignee and a GitHub login ("@asmith").
+// Person nodes sharing an identity key are merged into one so workload views
+// aren't split by account. Keys come from the configured people mapping and
+// from heuristics: equal emails, an email's local part matching a GitHub
+// login, and GitHub noreply addresses ("123+asmith@users.noreply.github.com").
+
+// identityKeys returns the keys a person is known by, and the weaker hints
+// that only link them to someone already holding that key: the username
+// guessed from an email matches a GitHub login, but two emails sharing a
+// local part ("sam@a.com", "sam@b.com") don't make one person.
+func identityKeys(data graph.PersonData) (keys, hints []string) {
+	norm := func(value string) string {
+		return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(value, "@")))
+	}
+	add := func(prefix, value string) {
+		if value = norm(value); value != "" {
+			keys = append(keys, prefix+value)
+		}
+	}
+
+	add("name:", data.Name)
+	for _, alias := range data.Aliases {
+		add("name:", alias)
+	}
+	add("login:", data.GitHub)
+	add("linear:", data.Linear)
+	for _, email := range data.Emails {
+		add("email:", email)
+		if login := emailLogin(email); login != "" {
+			hints = append(hints, "login:"+login)
+		}
+	}
+	return keys, hints
+}
+
+// emailLogin guesses the username behind an email address: the local part,
+// or the login embedded in a GitHub noreply address
+func emailLogin(email string) string {
+	local, domain, ok := strings.Cut(strings.ToLower(email), "@")
+	if !ok {
+		return ""
+	}
+	if domain == "users.noreply.github.com" {
+		if _, login, found := strings.Cut(local, "+"); found {
+			return login
+		}
+	}
+	return local
+}
+
+// configuredKeys returns the keys of a person from the config mapping
+func configuredKeys(p config.Person) (keys, hints []string) {
+	return identityKeys(graph.PersonData{
+		Name:    p.Name,
+		Emails:  p.Emails,
+		GitHub:  p.GitHub,
+		Linear:  p.Linear,
+		Aliases: p.Aliases,
+	})
+}
+
+// unionFind groups items that share identity keys
+type unionFind []int
+
+func newUnionFind(n int) unionFind {
+	uf := make(unionFind, n)
+	for i := range uf {
+		uf[i] = i
+	}
+	return uf
+}
+
+func (uf unionFind) find(i int) int {
+	for uf[i] != i {
+		uf[i] = uf[uf[i]]
+		i = uf[i]
+	}
+	return i
+}
+
+func (uf unionFind) union(a, b int) {
+	uf[uf.find(a)] = uf.find(b)
+}
+
+// unifyPeople merges Person nodes that belong to the same human and rewrites
+// edges to point at the merged node. Configured people name the merged node;
+// otherwise the longest name wins (full names over usernames).
+func unifyPeople(nodes []graph.Node, edges []graph.Edge, configured []config.Person) ([]graph.Node, []graph.Edge) {
+	var personIdx []int // Indexes into nodes of Person nodes
+	var datas []graph.PersonData
+	for i, node := range nodes {
+		if node.Type != graph.NodeTypePerson {
+			continue
+		}
+		var data graph.PersonData
+		if err := json.Unmarshal(node.Data, &data); err != nil {
+			continue
+		}
+		personIdx = append(personIdx, i)
+		datas = append(datas, data)
+	}
+	if len(personIdx) == 0 {
+		return nodes, edges
+	}
+
+	// Union-find over people followed by configured entries
+	uf := newUnionFind(len(datas) + len(configured))
+	owner := make(map[string]int) // Identity key -> first item holding it
+	claim := func(item int, keys []string) {
+		for _, key := range keys {
+			if first, ok := owner[key]; ok {
+				uf.union(item, first)
+			} else {
+				owner[key] = item
+			}
+		}
+	}
+	hints := make([][]string, len(datas)+len(configured))
+	for i, p := range configured {
+		keys, h := configuredKeys(p)
+		claim(len(datas)+i, keys)
+		hints[len(datas)+i] = h
+	}
+	for i, data := range datas {
+		keys, h := identityKeys(data)
+		claim(i, keys)
+		hints[i] = h
+	}
+	for item, h := range hints {
+		for _, key := range h {
+			if first, ok := owner[key]; ok {
+				uf.union(item, first)
+			}
+		}
+	}
+
+	// Collect each group's members and configured entry (if any)
+	type group struct {
+		members []int
+		config  *config.Person
+	}
+	groups := make(map[int]*group)
+	var roots []int
+	groupOf := func(item int) *group {
+		root := uf.find(item)
+		g, ok := groups[root]
+		if !ok {
+			g = &group{}
+			groups[root] = g
+			roots = append(roots, root)
+		}
+		return g
+	}
+	for i := range datas {
+		g := groupOf(i)
+		g.members = append(g.members, i)
+	}
+	for i := range configured {
+		if g, ok := groups[uf.find(len(datas)+i)]; ok && g.config == nil {
+			g.config = &configured[i]
+		}
+	}
+
+	// Build merged nodes and the old -> new ID mapping
+	rename := make(map[string]string)
+	merged := make(map[int]graph.Node) // Index of the group's first node -> merged node
+	for _, root := range roots {
+		g := groups[root]
+		data := mergePersonData(datas, g.members, g.config)
+		node := nodes[personIdx[g.members[0]]]
+		mergedNode := graph.NewPersonNodeFrom(data, node.Source)
+		mergedNode.Metadata = node.Metadata
+		for _, m := range g.members {
+			rename[nodes[personIdx[m]].ID] = mergedNode.ID
+		}
+		merged[personIdx[g.members[0]]] = mergedNode
+	}
+
+	// Replace each group by its merged node at the first member's position
+	isPerson := make(map[int]bool, len(personIdx))
+	for _, i := range personIdx {
+		isPerson[i] = true
+	}
+	unified := make([]graph.Node, 0, len(nodes))
+	seen := make(map[string]bool)
+	for i, node := range nodes {
+		if !isPerson[i] {
+			unified = append(unified, node)
+			continue
+		}
+		if m, ok := merged[i]; ok && !seen[m.ID] {
+			seen[m.ID] = true
+			unified = append(unified, m)
+		}
+	}
+
+	// Point edges at merged people, dropping duplicates the merge creates
+	rewritten := make([]graph.Edge, 0, len(edges))
+	seenEdge := make(map[string]bool)
+	for _, edge := range edges {
+		if id, ok := rename[edge.FromID]; ok {
+			edge.FromID = id
+		}
+		if id, ok := rename[edge.ToID]; ok {
+			edge.ToID = id
+		}
+		key := edge.FromID + "|" + string(edge.Relation) + "|" + edge.ToID
+		if seenEdge[key] {
+			continue
+		}
+		seenEdge[key] = true
+		rewritten = append(rewritten, edge)
+	}
+	return unified, rewritten
+}
+
+// mergeSamePerson folds the identifiers another source knows a person by
+// into the node kept for them. Their name, ID and metadata stay the kept
+// node's; a differing name becomes an alias.
+func mergeSamePerson(kept, other graph.Node) graph.Node {
+	var data, more graph.PersonData
+	if json.Unmarshal(kept.Data, &data) != nil || json.Unmarshal(other.Data, &more) != nil {
+		return kept
+	}
+	for _, name := range append([]string{more.Name}, more.Aliases...) {
+		if name != "" && !strings.EqualFold(name, data.Name) {
+			data.Aliases = appendUnique(data.Aliases, name)
+		}
+	}
+	for _, email := range more.Emails {
+		data.Emails = appendUnique(data.Emails, email)
+	}
+	if data.GitHub == "" {
+		data.GitHub = more.GitHub
+	}
+	if data.Linear == "" {
+		data.Linear = more.Linear
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return kept
+	}
+	kept.Data = encoded
+	return kept
+}
+
+// mergePersonData combines the identifiers of a group of people
+func mergePersonData(datas []graph.PersonData, members []int, configured *config.Person) graph.PersonData {
+	var merged graph.PersonData
+	var names []string
+	for _, m := range members {
+		d := datas[m]
+		names = appendUnique(names, d.Name)
+		for _, alias := range d.Aliases {
+			names = appendUnique(names, alias)
+		}
+		for _, email := range d.Emails {
+			merged.Emails = appendUnique(merged.Emails, email)
+		}
+		if merged.GitHub == "" {
+			merged.GitHub = d.GitHub
+		}
+		if merged.Linear == "" {
+			merged.Linear = d.Linear
+		}
+	}
+
+	if configured != nil {
+		merged.Name = configured.Name
+		if configured.GitHub != "" {
+			merged.GitHub = strings.TrimPrefix(configured.GitHub, "@")
+		}
+		if configured.Linear != "" {
+			merged.Linear = configured.Linear
+		}
+	} else if len(names) > 0 {
+		sort.SliceStable(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+		merged.Name = names[0]
+	}
+	for _, name := range names {
+		if !strings.EqualFold(name, merged.Name) {
+			merged.Aliases = appendUnique(merged.Aliases, name)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,136 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// personData decodes a Person node's identifiers
+func personData(t *testing.T, node graph.Node) graph.PersonData {
+	t.Helper()
+	var data graph.PersonData
+	if err := json.Unmarshal(node.Data, &data); err != nil {
+		t.Fatalf("decoding %s: %v", node.ID, err)
+	}
+	return data
+}
+
+// personNodes returns the Person nodes among nodes, by ID
+func personNodes(nodes []graph.Node) map[string]graph.Node {
+	persons := make(map[string]graph.Node)
+	for _, node := range nodes {
+		if node.Type == graph.NodeTypePerson {
+			persons[node.ID] = node
+		}
+	}
+	return persons
+}
+
+// TestUnifyPeopleMergesAccounts checks a git author, a Linear assignee and a
+// GitHub login become one person, with edges pointing at the merged node.
+func TestUnifyPeopleMergesAccounts(t *testing.T) {
+	nodes := []graph.Node{
+		graph.NewPersonNodeFrom(graph.PersonData{Name: "Alice Smith", Emails: []string{"asmith@corp.com"}}, "git"),
+		graph.NewPersonNodeFrom(graph.PersonData{Name: "alice", Emails: []string{"ASmith@corp.com"}, Linear: "alice"}, "linear"),
+		graph.NewPersonNodeFrom(graph.PersonData{Name: "asmith", GitHub: "asmith"}, "github"),
+		graph.NewPersonNodeFrom(graph.PersonData{Name: "Bob", Emails: []string{"bob@corp.com"}}, "git"),
+	}
+	edges := []graph.Edge{
+		{FromID: "commit:1", ToID: nodes[0].ID, Relation: graph.EdgeAuthored},
+		{FromID: "commit:1", ToID: nodes[1].ID, Relation: graph.EdgeAuthored},
+		{FromID: "issue:1", ToID: nodes[2].ID, Relation: graph.EdgeAssignedTo},
+	}
+
+	unified, rewritten := unifyPeople(nodes, edges, nil)
+	persons := personNodes(unified)
+	if len(persons) != 2 {
+		t.Fatalf("got %d people, want Alice and Bob: %v", len(persons), persons)
+	}
+	alice, ok := persons[graph.PersonID("Alice Smith")]
+	if !ok {
+		t.Fatalf("merged person should take the longest name: %v", persons)
+	}
+	data := personData(t, alice)
+	if data.GitHub != "asmith" || data.Linear != "alice" || len(data.Emails) != 1 {
+		t.Errorf("merged identifiers = %+v", data)
+	}
+	sort.Strings(data.Aliases)
+	if !reflect.DeepEqual(data.Aliases, []string{"alice", "asmith"}) {
+		t.Errorf("aliases = %v, want [alice asmith]", data.Aliases)
+	}
+	if len(rewritten) != 2 {
+		t.Fatalf("edges = %v, want the duplicate authored edge dropped", rewritten)
+	}
+	for _, edge := range rewritten {
+		if edge.ToID != alice.ID {
+			t.Errorf("edge %s -> %s should point at %s", edge.FromID, edge.ToID, alice.ID)
+		}
+	}
+}
+
+// TestUnifyPeopleKeepsNamesakesApart checks two emails sharing only a local
+// part stay two people, and that configured people name the merged node.
+func TestUnifyPeopleKeepsNamesakesApart(t *testing.T) {
+	nodes := []graph.Node{
+		graph.NewPersonNodeFrom(graph.PersonData{Name: "Sam A", Emails: []string{"sam@a.com"}}, "git"),
+		graph.NewPersonNodeFrom(graph.PersonData{Name: "Sam B", Emails: []string{"sam@b.com"}}, "git"),
+		graph.NewPersonNodeFrom(graph.PersonData{Name: "sb", GitHub: "sambee"}, "github"),
+	}
+	configured := []config.Person{{Name: "Samantha Bee", Emails: []string{"sam@b.com"}, GitHub: "@sambee"}}
+
+	unified, _ := unifyPeople(nodes, nil, configured)
+	persons := personNodes(unified)
+	if len(persons) != 2 {
+		t.Fatalf("got %d people, want 2: %v", len(persons), persons)
+	}
+	if _, ok := persons[graph.PersonID("Sam A")]; !ok {
+		t.Errorf("Sam A was merged away: %v", persons)
+	}
+	bee, ok := persons[graph.PersonID("Samantha Bee")]
+	if !ok {
+		t.Fatalf("configured name should win: %v", persons)
+	}
+	if data := personData(t, bee); data.GitHub != "sambee" {
+		t.Errorf("GitHub = %q, want sambee", data.GitHub)
+	}
+}
+
+// TestLoadAllMergesAPersonsIdentifiers checks a person two sources both
+// produce keeps the identifiers each source knows them by.
+func TestLoadAllMergesAPersonsIdentifiers(t *testing.T) {
+	git := &stubSource{name: "git", nodes: []graph.Node{
+		graph.NewPersonNodeFrom(graph.PersonData{Name: "Dana", Emails: []string{"dana@corp.com"}}, "git"),
+	}}
+	linear := &stubSource{name: "linear", nodes: []graph.Node{
+		graph.NewPersonNodeFrom(graph.PersonData{Name: "dana", Linear: "dana.k"}, "linear"),
+	}}
+	nodes, _, err := NewLoader(git, linear).LoadAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	persons := personNodes(nodes)
+	if len(persons) != 1 {
+		t.Fatalf("got %d people, want 1: %v", len(persons), persons)
+	}
+	for _, node := range persons {
+		data := personData(t, node)
+		if data.Linear != "dana.k" || len(data.Emails) != 1 {
+			t.Errorf("identifiers = %+v, want the email and the Linear account", data)
+		}
+	}
+}
+
+// TestMergePersonDataWithoutNames checks a group with no names yields an
+// empty name rather than panicking.
+func TestMergePersonDataWithoutNames(t *testing.T) {
+	merged := mergePersonData([]graph.PersonData{{GitHub: "x"}, {Emails: []string{"x@y.z"}}}, []int{0, 1}, nil)
+	if merged.Name != "" || merged.GitHub != "x" {
+		t.Errorf("merged = %+v", merged)
+	}
+}
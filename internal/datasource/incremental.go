@@ -0,0 +1,181 @@
+package datasource
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// SetSyncStore lets incremental sources load only what changed since their
+// cursor in store (sync_state), laid over what they stored before. The
+// graph loaded must be written back to store, as startup and syncs do,
+// before SaveSyncCursors moves the cursors past it. With full set, every
+// source loads in full, still moving the cursors.
+func (l *Loader) SetSyncStore(store graph.GraphStore, full bool) {
+	l.syncStore = store
+	l.fullSync = full
+}
+
+// loadChanges loads an incremental source: only what changed since its
+// cursor, over what the store holds for it, or everything when it has no
+// cursor or nothing stored yet. Once the load succeeds its new cursor is
+// kept for SaveSyncCursors.
+func (l *Loader) loadChanges(ctx context.Context, source DataSource, incremental IncrementalSource) ([]graph.Node, []graph.Edge, error) {
+	cursors, ok := l.syncStore.(graph.SyncCursors)
+	if !ok {
+		return source.Load(ctx)
+	}
+	started := time.Now()
+
+	nodes, edges, err := l.loadSince(ctx, source, incremental, cursors)
+	if err != nil {
+		return nil, nil, err
+	}
+	if l.syncedUntil == nil {
+		l.syncedUntil = make(map[string]time.Time)
+	}
+	l.syncedUntil[cursorKey(source)] = started
+	return nodes, edges, nil
+}
+
+// cursorKey is the sync_state row of a source's cursor: its name, and its
+// scope when it has one ("linear:<team ID>")
+func cursorKey(source DataSource) string {
+	if scoped, ok := source.(ScopedSource); ok && scoped.SyncScope() != "" {
+		return source.Name() + ":" + scoped.SyncScope()
+	}
+	return source.Name()
+}
+
+// SaveSyncCursors moves the cursors of the incremental sources the last
+// LoadAll or RefreshNode loaded up to when they started. Call it once what
+// they loaded is in the sync store: a cursor past a graph that was never
+// stored would skip those changes for good. A cursor that cannot be saved
+// is reported as a warning; the next sync loads more than it needs to,
+// nothing worse.
+func (l *Loader) SaveSyncCursors(ctx context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cursors, ok := l.syncStore.(graph.SyncCursors)
+	if !ok {
+		return
+	}
+	keys := make([]string, 0, len(l.syncedUntil))
+	for key := range l.syncedUntil {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := cursors.SetSyncCursor(ctx, key, l.syncedUntil[key]); err != nil {
+			l.warnf("Warning: %s: %v", key, err)
+		}
+	}
+	l.syncedUntil = nil
+}
+
+// loadSince runs the incremental load itself, falling back to a full one
+func (l *Loader) loadSince(ctx context.Context, source DataSource, incremental IncrementalSource, cursors graph.SyncCursors) ([]graph.Node, []graph.Edge, error) {
+	if l.fullSync {
+		return source.Load(ctx)
+	}
+	since, found, err := cursors.SyncCursor(ctx, cursorKey(source))
+	if err != nil || !found {
+		return source.Load(ctx)
+	}
+	base, err := l.storedLoad(source.Name())
+	if err != nil || len(base.nodes) == 0 {
+		return source.Load(ctx)
+	}
+	changes, err := incremental.LoadSince(ctx, since.Add(-syncCursorOverlap))
+	if err != nil {
+		return nil, nil, err
+	}
+	l.notef("%s: fetched changes since %s (%d nodes, %d removed)", source.Name(), since.Local().Format("2006-01-02 15:04"), len(changes.Nodes), len(changes.Removed))
+	nodes, edges := overlay(base, changes)
+	return nodes, edges, nil
+}
+
+// storedLoad reads back what a source loaded before: its nodes in the sync
+// store and the edges they declared (hand-made edges left out)
+func (l *Loader) storedLoad(name string) (sourceLoad, error) {
+	nodes, err := l.syncStore.ListNodes(&graph.NodeFilter{Sources: []string{name}})
+	if err != nil || len(nodes) == 0 {
+		return sourceLoad{}, err
+	}
+	ids := make([]string, len(nodes))
+	for i, node := range nodes {
+		ids[i] = node.ID
+	}
+	byNode, err := l.syncStore.GetEdgesForNodes(ids)
+	if err != nil {
+		return sourceLoad{}, err
+	}
+
+	own := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		own[id] = true
+	}
+	seen := make(map[string]bool)
+	var edges []graph.Edge
+	for _, id := range ids {
+		for _, edge := range byNode[id] {
+			if !own[edge.FromID] || edge.Metadata.CreatedBy != "" || seen[edge.ID] {
+				continue
+			}
+			seen[edge.ID] = true
+			edges = append(edges, edge)
+		}
+	}
+	return sourceLoad{nodes: nodes, edges: edges}, nil
+}
+
+// overlay lays changes over an earlier load: changed nodes replace their
+// old copies, removed ones go with their edges, and restated nodes' old
+// edges give way to the new ones
+func overlay(base sourceLoad, changes Changes) ([]graph.Node, []graph.Edge) {
+	changed := make(map[string]graph.Node, len(changes.Nodes))
+	for _, node := range changes.Nodes {
+		changed[node.ID] = node
+	}
+	removed := make(map[string]bool, len(changes.Removed))
+	for _, id := range changes.Removed {
+		if _, ok := changed[id]; !ok {
+			removed[id] = true
+		}
+	}
+	nodes := make([]graph.Node, 0, len(base.nodes)+len(changes.Nodes))
+	for _, node := range base.nodes {
+		if removed[node.ID] {
+			continue
+		}
+		if update, ok := changed[node.ID]; ok {
+			node = update
+			delete(changed, node.ID)
+		}
+		nodes = append(nodes, node)
+	}
+	for _, node := range changes.Nodes {
+		if _, added := changed[node.ID]; added {
+			nodes = append(nodes, node)
+		}
+	}
+
+	restated := make(map[string]bool, len(changes.Restated))
+	for _, id := range changes.Restated {
+		restated[id] = true
+	}
+	fresh := make(map[string]bool, len(changes.Edges))
+	for _, edge := range changes.Edges {
+		fresh[edge.ID] = true
+	}
+	edges := make([]graph.Edge, 0, len(base.edges)+len(changes.Edges))
+	for _, edge := range base.edges {
+		if restated[edge.FromID] || restated[edge.ToID] || removed[edge.FromID] || removed[edge.ToID] || fresh[edge.ID] {
+			continue
+		}
+		edges = append(edges, edge)
+	}
+	return nodes, append(edges, changes.Edges...)
+}
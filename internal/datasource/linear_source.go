@@ -3,39 +3,110 @@ package datasource
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/manutej/maat-terminal/internal/graph"
 )
 
+// DefaultLinearEndpoint is the Linear GraphQL API URL
+const DefaultLinearEndpoint = "https://api.linear.app/graphql"
+
+const (
+	linearPageSize    = 50   // Keeps each page under Linear's complexity limit
+	linearMaxIssues   = 2000 // Default cap across all pages (0 = every issue)
+	linearMaxRetries  = 3
+	linearBaseBackoff = time.Second
+)
+
 // LinearSource fetches issues and projects from Linear API.
 // Following Commandment #7 (Composition): Thin API client only.
 type LinearSource struct {
-	apiKey string
-	teamID string
-	client *http.Client
+	apiKey    string
+	teamID    string
+	endpoint  string
+	pageSize  int
+	maxIssues int
+	client    *http.Client
+	truncated bool     // The last load stopped at maxIssues with issues left
+	report    Reporter // Takes page progress and partial failures (nil = standard error)
+
+	mu    sync.Mutex
+	spent Telemetry // Requests, bytes and complexity points since creation
 }
 
 // NewLinearSource creates a Linear data source
 // API key is read from LINEAR_API_KEY environment variable
 func NewLinearSource(teamID string) *LinearSource {
 	return &LinearSource{
-		apiKey: os.Getenv("LINEAR_API_KEY"),
-		teamID: teamID,
-		client: &http.Client{Timeout: 30 * time.Second},
+		apiKey:    os.Getenv("LINEAR_API_KEY"),
+		teamID:    teamID,
+		endpoint:  DefaultLinearEndpoint,
+		pageSize:  linearPageSize,
+		maxIssues: linearMaxIssues,
+		client:    &http.Client{Timeout: 30 * time.Second},
 	}
 }
 
+// SetEndpoint points the source at a different GraphQL URL (e.g. a fake server)
+func (l *LinearSource) SetEndpoint(url string) {
+	l.endpoint = url
+}
+
+// SetAPIKey overrides the API key read from the environment
+func (l *LinearSource) SetAPIKey(key string) {
+	l.apiKey = key
+}
+
+// SetHTTPClient replaces the client requests go through (e.g. one whose
+// transport is a Cassette, or a proxy-aware client)
+func (l *LinearSource) SetHTTPClient(client *http.Client) {
+	l.client = client
+}
+
+// SetPageSize sets how many issues or projects are requested per page
+func (l *LinearSource) SetPageSize(n int) {
+	l.pageSize = n
+}
+
+// SetMaxIssues sets the maximum number of issues to load across all pages
+// (0 loads every issue)
+func (l *LinearSource) SetMaxIssues(n int) {
+	l.maxIssues = n
+}
+
 // Name returns the data source identifier
 func (l *LinearSource) Name() string {
 	return "linear"
 }
 
+// Truncation reports whether the last load stopped at the issue cap, e.g.
+// "truncated at 2000 issues (more on Linear)".
+func (l *LinearSource) Truncation() string {
+	if !l.truncated {
+		return ""
+	}
+	return fmt.Sprintf("truncated at %d issues (more on Linear)", l.maxIssues)
+}
+
+// SyncScope is the team synced: its cursor is kept apart from other teams'
+func (l *LinearSource) SyncScope() string {
+	return l.teamID
+}
+
+// SetReporter has page progress and the parts of a load that failed
+// (issue details, projects) reported to report
+func (l *LinearSource) SetReporter(report Reporter) {
+	l.report = report
+}
+
 // SupportsRefresh returns true - Linear can be refreshed
 func (l *LinearSource) SupportsRefresh() bool {
 	return true
@@ -43,61 +114,117 @@ func (l *LinearSource) SupportsRefresh() bool {
 
 // Load fetches issues and projects from Linear
 func (l *LinearSource) Load(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
+	changes, err := l.LoadSince(ctx, time.Time{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return changes.Nodes, changes.Edges, nil
+}
+
+// LoadSince fetches the issues updated after since (all of them for the
+// zero time), the ones archived or deleted since, and every project, which
+// are few and cheap
+func (l *LinearSource) LoadSince(ctx context.Context, since time.Time) (Changes, error) {
 	if l.apiKey == "" {
-		return nil, nil, fmt.Errorf("LINEAR_API_KEY environment variable not set")
+		return Changes{}, fmt.Errorf("LINEAR_API_KEY environment variable not set")
 	}
 
-	var nodes []graph.Node
-	var edges []graph.Edge
+	var changes Changes
 
 	// Fetch issues
-	issues, err := l.fetchIssues(ctx)
+	issues, err := l.fetchIssues(ctx, since)
 	if err != nil {
-		return nil, nil, fmt.Errorf("fetching issues: %w", err)
+		return Changes{}, fmt.Errorf("fetching issues: %w", err)
+	}
+	if err := l.fetchIssueDetails(ctx, issues); err != nil {
+		// Log but continue - the issues load without descriptions or relations
+		l.report.warnf("Warning: failed to fetch issue details: %v", err)
 	}
 
 	// Convert issues to nodes and collect edges
+	assignees := newPeople("linear")
+	seenEdges := make(map[string]bool)
 	for _, issue := range issues {
 		node, issueEdges := l.issueToNode(issue)
-		nodes = append(nodes, node)
-		edges = append(edges, issueEdges...)
+		changes.Nodes = append(changes.Nodes, node)
+		for _, edge := range issueEdges {
+			// Both ends of a relation list it; keep it once
+			if !seenEdges[edge.ID] {
+				seenEdges[edge.ID] = true
+				changes.Edges = append(changes.Edges, edge)
+			}
+		}
+		changes.Restated = append(changes.Restated, node.ID)
+
+		// Edge: issue assigned to person
+		assignee := graph.PersonData{Name: issue.Assignee, Linear: issue.Assignee}
+		if issue.AssigneeEmail != "" {
+			assignee.Emails = []string{issue.AssigneeEmail}
+		}
+		if assigneeID := assignees.personFrom(assignee); assigneeID != "" {
+			changes.Edges = append(changes.Edges, assignedEdge(node.ID, assigneeID, node.Metadata.UpdatedAt))
+		}
+	}
+	changes.Nodes = append(changes.Nodes, assignees.nodes()...)
+
+	if !since.IsZero() {
+		removed, err := l.fetchRemovedIssues(ctx, since)
+		if err != nil {
+			return Changes{}, fmt.Errorf("fetching removed issues: %w", err)
+		}
+		for _, identifier := range removed {
+			changes.Removed = append(changes.Removed, fmt.Sprintf("linear:%s", identifier))
+		}
 	}
 
 	// Fetch projects
 	projects, err := l.fetchProjects(ctx)
 	if err != nil {
 		// Log but continue - issues are more important
-		fmt.Fprintf(os.Stderr, "Warning: failed to fetch projects: %v\n", err)
+		l.report.warnf("Warning: failed to fetch projects: %v", err)
 	} else {
 		for _, project := range projects {
 			node := l.projectToNode(project)
-			nodes = append(nodes, node)
+			changes.Nodes = append(changes.Nodes, node)
 		}
 	}
 
-	return nodes, edges, nil
+	return changes, nil
 }
 
 // LinearIssue represents the issue data from Linear API
 type LinearIssue struct {
-	ID          string   `json:"id"`
-	Identifier  string   `json:"identifier"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Priority    int      `json:"priority"`
-	Status      string   `json:"status"`
-	Labels      []string `json:"labels"`
-	ProjectID   string   `json:"projectId"`
-	ProjectName string   `json:"project"`
-	CreatedAt   string   `json:"createdAt"`
-	UpdatedAt   string   `json:"updatedAt"`
-	URL         string   `json:"url"`
+	ID            string       `json:"id"`
+	Identifier    string       `json:"identifier"`
+	Title         string       `json:"title"`
+	Description   string       `json:"description"`
+	Priority      int          `json:"priority"`
+	Status        string       `json:"status"`
+	Labels        []string     `json:"labels"`
+	ProjectID     string       `json:"projectId"`
+	ProjectName   string       `json:"project"`
+	Assignee      string       `json:"assignee"`
+	AssigneeEmail string       `json:"assigneeEmail"`
+	CreatedAt     string       `json:"createdAt"`
+	UpdatedAt     string       `json:"updatedAt"`
+	CompletedAt   string       `json:"completedAt"`
+	Estimate      float64      `json:"estimate"`
+	URL           string       `json:"url"`
+	Cycle         *LinearCycle `json:"cycle,omitempty"`
 	// Relations
 	BlockedBy []string `json:"blockedBy,omitempty"`
 	Blocks    []string `json:"blocks,omitempty"`
 	Related   []string `json:"relatedTo,omitempty"`
 }
 
+// LinearCycle is the sprint an issue is planned in
+type LinearCycle struct {
+	Number   int    `json:"number"`
+	Name     string `json:"name"`
+	StartsAt string `json:"startsAt"`
+	EndsAt   string `json:"endsAt"`
+}
+
 // LinearProject represents the project data from Linear API
 type LinearProject struct {
 	ID          string `json:"id"`
@@ -109,14 +236,31 @@ type LinearProject struct {
 	UpdatedAt   string `json:"updatedAt"`
 }
 
-// fetchIssues fetches issues from Linear GraphQL API
-func (l *LinearSource) fetchIssues(ctx context.Context) ([]LinearIssue, error) {
-	// Simplified query to stay under Linear's 10000 complexity limit
-	// Removed: relations (high complexity), reduced first to 50
+// pageInfo is the Relay-style cursor block Linear returns with every connection
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// graphqlError is a single entry of a GraphQL "errors" array
+type graphqlError struct {
+	Message    string `json:"message"`
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
+}
+
+// fetchIssues fetches issues from Linear GraphQL API, following page cursors
+// until the team has no more issues or maxIssues is reached, reporting its
+// progress on stderr past the first page. A non-zero since fetches only
+// issues updated after it.
+func (l *LinearSource) fetchIssues(ctx context.Context, since time.Time) ([]LinearIssue, error) {
+	// Simplified query to stay under Linear's 10000 complexity limit, paged
+	// by $first. Descriptions and relations come from fetchIssueDetails.
 	query := `
-	query IssuesByTeam($teamId: String!) {
+	query IssuesByTeam($teamId: String!, $first: Int!, $after: String, $filter: IssueFilter) {
 		team(id: $teamId) {
-			issues(first: 50) {
+			issues(first: $first, after: $after, filter: $filter) {
 				nodes {
 					id
 					identifier
@@ -125,104 +269,315 @@ func (l *LinearSource) fetchIssues(ctx context.Context) ([]LinearIssue, error) {
 					state { name }
 					labels { nodes { name } }
 					project { id name }
+					assignee { name email }
+					cycle { number name startsAt endsAt }
+					estimate
 					createdAt
 					updatedAt
+					completedAt
 					url
 				}
+				pageInfo { hasNextPage endCursor }
 			}
 		}
 	}`
 
-	variables := map[string]interface{}{
-		"teamId": l.teamID,
+	var filter interface{} // nil = every issue
+	if !since.IsZero() {
+		filter = map[string]interface{}{
+			"updatedAt": map[string]interface{}{"gt": since.UTC().Format(time.RFC3339)},
+		}
 	}
 
-	resp, err := l.graphqlRequest(ctx, query, variables)
-	if err != nil {
-		return nil, err
-	}
+	l.truncated = false
+	var issues []LinearIssue
+	var after interface{} // nil on the first page
+	for pages := 1; ; pages++ {
+		variables := map[string]interface{}{
+			"teamId": l.teamID,
+			"first":  l.pageSize,
+			"after":  after,
+			"filter": filter,
+		}
 
-	// Parse response (simplified - no description or relations to stay under complexity limit)
-	var result struct {
-		Data struct {
-			Team struct {
-				Issues struct {
-					Nodes []struct {
-						ID         string `json:"id"`
-						Identifier string `json:"identifier"`
-						Title      string `json:"title"`
-						Priority   int    `json:"priority"`
-						State      struct {
-							Name string `json:"name"`
-						} `json:"state"`
-						Labels struct {
-							Nodes []struct {
+		resp, err := l.graphqlRequest(ctx, query, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse response (simplified - descriptions and relations are backfilled)
+		var result struct {
+			Data struct {
+				Team struct {
+					Issues struct {
+						Nodes []struct {
+							ID         string `json:"id"`
+							Identifier string `json:"identifier"`
+							Title      string `json:"title"`
+							Priority   int    `json:"priority"`
+							State      struct {
 								Name string `json:"name"`
-							} `json:"nodes"`
-						} `json:"labels"`
-						Project *struct {
-							ID   string `json:"id"`
-							Name string `json:"name"`
-						} `json:"project"`
-						CreatedAt string `json:"createdAt"`
-						UpdatedAt string `json:"updatedAt"`
-						URL       string `json:"url"`
-					} `json:"nodes"`
-				} `json:"issues"`
-			} `json:"team"`
-		} `json:"data"`
-		Errors []struct {
-			Message string `json:"message"`
-		} `json:"errors"`
-	}
+							} `json:"state"`
+							Labels struct {
+								Nodes []struct {
+									Name string `json:"name"`
+								} `json:"nodes"`
+							} `json:"labels"`
+							Project *struct {
+								ID   string `json:"id"`
+								Name string `json:"name"`
+							} `json:"project"`
+							Assignee *struct {
+								Name  string `json:"name"`
+								Email string `json:"email"`
+							} `json:"assignee"`
+							Cycle       *LinearCycle `json:"cycle"`
+							Estimate    float64      `json:"estimate"`
+							CreatedAt   string       `json:"createdAt"`
+							UpdatedAt   string       `json:"updatedAt"`
+							CompletedAt string       `json:"completedAt"`
+							URL         string       `json:"url"`
+						} `json:"nodes"`
+						PageInfo pageInfo `json:"pageInfo"`
+					} `json:"issues"`
+				} `json:"team"`
+			} `json:"data"`
+			Errors []graphqlError `json:"errors"`
+		}
 
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+
+		if len(result.Errors) > 0 {
+			return nil, fmt.Errorf("Linear API error: %s", result.Errors[0].Message)
+		}
+
+		// Convert to LinearIssue slice
+		page := result.Data.Team.Issues
+		for i, n := range page.Nodes {
+			issue := LinearIssue{
+				ID:          n.ID,
+				Identifier:  n.Identifier,
+				Title:       n.Title,
+				Priority:    n.Priority,
+				Status:      n.State.Name,
+				CreatedAt:   n.CreatedAt,
+				UpdatedAt:   n.UpdatedAt,
+				CompletedAt: n.CompletedAt,
+				Estimate:    n.Estimate,
+				URL:         n.URL,
+				Cycle:       n.Cycle,
+			}
+
+			// Extract labels
+			for _, label := range n.Labels.Nodes {
+				issue.Labels = append(issue.Labels, label.Name)
+			}
+
+			// Extract project
+			if n.Project != nil {
+				issue.ProjectID = n.Project.ID
+				issue.ProjectName = n.Project.Name
+			}
+			if n.Assignee != nil {
+				issue.Assignee = n.Assignee.Name
+				issue.AssigneeEmail = n.Assignee.Email
+			}
+
+			issues = append(issues, issue)
+			if l.maxIssues > 0 && len(issues) >= l.maxIssues {
+				l.truncated = i < len(page.Nodes)-1 || page.PageInfo.HasNextPage
+				return issues, nil
+			}
+		}
+
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return issues, nil
+		}
+		l.report.notef("%s: fetched %d issues (page %d), continuing", l.Name(), len(issues), pages)
+		after = page.PageInfo.EndCursor
 	}
+}
 
-	if len(result.Errors) > 0 {
-		return nil, fmt.Errorf("Linear API error: %s", result.Errors[0].Message)
+// fetchRemovedIssues returns the identifiers of issues archived after
+// since. The issues query leaves archived issues out, so an incremental load
+// would never see them go; deleting an issue archives it too (it sits in
+// the trash), so deleted issues are among them.
+func (l *LinearSource) fetchRemovedIssues(ctx context.Context, since time.Time) ([]string, error) {
+	query := `
+	query RemovedIssues($teamId: String!, $first: Int!, $after: String, $filter: IssueFilter) {
+		team(id: $teamId) {
+			issues(first: $first, after: $after, filter: $filter, includeArchived: true) {
+				nodes {
+					identifier
+				}
+				pageInfo { hasNextPage endCursor }
+			}
+		}
+	}`
+	filter := map[string]interface{}{
+		"archivedAt": map[string]interface{}{"gt": since.UTC().Format(time.RFC3339)},
 	}
 
-	// Convert to LinearIssue slice
-	var issues []LinearIssue
-	for _, n := range result.Data.Team.Issues.Nodes {
-		issue := LinearIssue{
-			ID:         n.ID,
-			Identifier: n.Identifier,
-			Title:      n.Title,
-			Priority:   n.Priority,
-			Status:     n.State.Name,
-			CreatedAt:  n.CreatedAt,
-			UpdatedAt:  n.UpdatedAt,
-			URL:        n.URL,
+	var removed []string
+	var after interface{} // nil on the first page
+	for {
+		variables := map[string]interface{}{
+			"teamId": l.teamID,
+			"first":  l.pageSize,
+			"after":  after,
+			"filter": filter,
+		}
+		resp, err := l.graphqlRequest(ctx, query, variables)
+		if err != nil {
+			return nil, err
 		}
 
-		// Extract labels
-		for _, label := range n.Labels.Nodes {
-			issue.Labels = append(issue.Labels, label.Name)
+		var result struct {
+			Data struct {
+				Team struct {
+					Issues struct {
+						Nodes []struct {
+							Identifier string `json:"identifier"`
+						} `json:"nodes"`
+						PageInfo pageInfo `json:"pageInfo"`
+					} `json:"issues"`
+				} `json:"team"`
+			} `json:"data"`
+			Errors []graphqlError `json:"errors"`
+		}
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+		if len(result.Errors) > 0 {
+			return nil, fmt.Errorf("Linear API error: %s", result.Errors[0].Message)
 		}
 
-		// Extract project
-		if n.Project != nil {
-			issue.ProjectID = n.Project.ID
-			issue.ProjectName = n.Project.Name
+		page := result.Data.Team.Issues
+		for _, n := range page.Nodes {
+			removed = append(removed, n.Identifier)
+		}
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return removed, nil
 		}
+		after = page.PageInfo.EndCursor
+	}
+}
 
-		// Note: Relations fetched separately if needed to avoid query complexity limits
+// fetchIssueDetails backfills the descriptions and relations (blocks,
+// blocked by, related) the issue query leaves out, asking for the issues by
+// ID a page at a time. Issues in batches fetched before a failure keep theirs.
+func (l *LinearSource) fetchIssueDetails(ctx context.Context, issues []LinearIssue) error {
+	query := `
+	query IssueDetails($teamId: String!, $first: Int!, $filter: IssueFilter) {
+		team(id: $teamId) {
+			issues(first: $first, filter: $filter) {
+				nodes {
+					id
+					description
+					relations { nodes { type relatedIssue { identifier } } }
+					inverseRelations { nodes { type issue { identifier } } }
+				}
+			}
+		}
+	}`
 
-		issues = append(issues, issue)
+	byID := make(map[string]*LinearIssue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
 	}
+	for start := 0; start < len(issues); start += l.pageSize {
+		end := min(start+l.pageSize, len(issues))
+		ids := make([]string, 0, end-start)
+		for _, issue := range issues[start:end] {
+			ids = append(ids, issue.ID)
+		}
+		variables := map[string]interface{}{
+			"teamId": l.teamID,
+			"first":  len(ids),
+			"filter": map[string]interface{}{
+				"id": map[string]interface{}{"in": ids},
+			},
+		}
+
+		resp, err := l.graphqlRequest(ctx, query, variables)
+		if err != nil {
+			return err
+		}
+
+		type relatedRef struct {
+			Identifier string `json:"identifier"`
+		}
+		var result struct {
+			Data struct {
+				Team struct {
+					Issues struct {
+						Nodes []struct {
+							ID          string `json:"id"`
+							Description string `json:"description"`
+							Relations   struct {
+								Nodes []struct {
+									Type         string     `json:"type"`
+									RelatedIssue relatedRef `json:"relatedIssue"`
+								} `json:"nodes"`
+							} `json:"relations"`
+							InverseRelations struct {
+								Nodes []struct {
+									Type  string     `json:"type"`
+									Issue relatedRef `json:"issue"`
+								} `json:"nodes"`
+							} `json:"inverseRelations"`
+						} `json:"nodes"`
+					} `json:"issues"`
+				} `json:"team"`
+			} `json:"data"`
+			Errors []graphqlError `json:"errors"`
+		}
+
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		if len(result.Errors) > 0 {
+			return fmt.Errorf("Linear API error: %s", result.Errors[0].Message)
+		}
 
-	return issues, nil
+		for _, n := range result.Data.Team.Issues.Nodes {
+			issue, ok := byID[n.ID]
+			if !ok {
+				continue
+			}
+			issue.Description = n.Description
+			// A relation is declared by one issue: "A blocks B" is one of
+			// A's relations and one of B's inverse relations
+			for _, relation := range n.Relations.Nodes {
+				switch relation.Type {
+				case "blocks":
+					issue.Blocks = append(issue.Blocks, relation.RelatedIssue.Identifier)
+				case "related":
+					issue.Related = append(issue.Related, relation.RelatedIssue.Identifier)
+				}
+			}
+			for _, relation := range n.InverseRelations.Nodes {
+				switch relation.Type {
+				case "blocks":
+					issue.BlockedBy = append(issue.BlockedBy, relation.Issue.Identifier)
+				case "related":
+					issue.Related = append(issue.Related, relation.Issue.Identifier)
+				}
+			}
+		}
+	}
+	return nil
 }
 
-// fetchProjects fetches projects from Linear GraphQL API
+// fetchProjects fetches projects from Linear GraphQL API, following page cursors
 func (l *LinearSource) fetchProjects(ctx context.Context) ([]LinearProject, error) {
 	query := `
-	query ProjectsByTeam($teamId: String!) {
+	query ProjectsByTeam($teamId: String!, $first: Int!, $after: String) {
 		team(id: $teamId) {
-			projects(first: 50) {
+			projects(first: $first, after: $after) {
 				nodes {
 					id
 					name
@@ -232,37 +587,218 @@ func (l *LinearSource) fetchProjects(ctx context.Context) ([]LinearProject, erro
 					createdAt
 					updatedAt
 				}
+				pageInfo { hasNextPage endCursor }
 			}
 		}
 	}`
 
-	variables := map[string]interface{}{
-		"teamId": l.teamID,
+	var projects []LinearProject
+	var after interface{}
+	for {
+		variables := map[string]interface{}{
+			"teamId": l.teamID,
+			"first":  l.pageSize,
+			"after":  after,
+		}
+
+		resp, err := l.graphqlRequest(ctx, query, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Data struct {
+				Team struct {
+					Projects struct {
+						Nodes    []LinearProject `json:"nodes"`
+						PageInfo pageInfo        `json:"pageInfo"`
+					} `json:"projects"`
+				} `json:"team"`
+			} `json:"data"`
+			Errors []graphqlError `json:"errors"`
+		}
+
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+
+		if len(result.Errors) > 0 {
+			return nil, fmt.Errorf("Linear API error: %s", result.Errors[0].Message)
+		}
+
+		projects = append(projects, result.Data.Team.Projects.Nodes...)
+
+		page := result.Data.Team.Projects.PageInfo
+		if !page.HasNextPage || page.EndCursor == "" {
+			return projects, nil
+		}
+		after = page.EndCursor
 	}
+}
 
-	resp, err := l.graphqlRequest(ctx, query, variables)
+// PostComment adds a comment to a Linear issue, returning ErrNoComments for
+// nodes that are not one
+func (l *LinearSource) PostComment(ctx context.Context, node graph.Node, body string) error {
+	identifier, ok := strings.CutPrefix(node.ID, "linear:")
+	if !ok || node.Type != graph.NodeTypeIssue {
+		return ErrNoComments
+	}
+	if l.apiKey == "" {
+		return fmt.Errorf("LINEAR_API_KEY environment variable not set")
+	}
+	query := `
+	mutation CommentCreate($issueId: String!, $body: String!) {
+		commentCreate(input: {issueId: $issueId, body: $body}) {
+			success
+			comment { id }
+		}
+	}`
+	resp, err := l.graphqlRequest(ctx, query, map[string]interface{}{
+		"issueId": identifier,
+		"body":    body,
+	})
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	var result struct {
+		Data struct {
+			CommentCreate struct {
+				Success bool `json:"success"`
+			} `json:"commentCreate"`
+		} `json:"data"`
+		Errors []graphqlError `json:"errors"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("Linear API error: %s", result.Errors[0].Message)
+	}
+	if !result.Data.CommentCreate.Success {
+		return fmt.Errorf("Linear did not create the comment on %s", identifier)
+	}
+	return nil
+}
+
+// editableFields are the issue fields UpdateFields writes
+var editableFields = []string{"title", "description"}
+
+// RemoteFields re-reads a Linear issue's title and description, returning
+// ErrNotEditable for nodes that are not one
+func (l *LinearSource) RemoteFields(ctx context.Context, node graph.Node) (time.Time, map[string]string, error) {
+	identifier, ok := strings.CutPrefix(node.ID, "linear:")
+	if !ok || node.Type != graph.NodeTypeIssue {
+		return time.Time{}, nil, ErrNotEditable
+	}
+	if l.apiKey == "" {
+		return time.Time{}, nil, fmt.Errorf("LINEAR_API_KEY environment variable not set")
+	}
+	query := `
+	query IssueFields($id: String!) {
+		issue(id: $id) {
+			title
+			description
+			updatedAt
+		}
+	}`
+	resp, err := l.graphqlRequest(ctx, query, map[string]interface{}{"id": identifier})
+	if err != nil {
+		return time.Time{}, nil, err
 	}
 
 	var result struct {
 		Data struct {
-			Team struct {
-				Projects struct {
-					Nodes []LinearProject `json:"nodes"`
-				} `json:"projects"`
-			} `json:"team"`
+			Issue *struct {
+				Title       string    `json:"title"`
+				Description string    `json:"description"`
+				UpdatedAt   time.Time `json:"updatedAt"`
+			} `json:"issue"`
 		} `json:"data"`
+		Errors []graphqlError `json:"errors"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return time.Time{}, nil, fmt.Errorf("parsing response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return time.Time{}, nil, fmt.Errorf("Linear API error: %s", result.Errors[0].Message)
+	}
+	issue := result.Data.Issue
+	if issue == nil {
+		return time.Time{}, nil, fmt.Errorf("issue %s not found", identifier)
+	}
+	return issue.UpdatedAt, map[string]string{"title": issue.Title, "description": issue.Description}, nil
+}
+
+// UpdateFields writes a Linear issue's title and description (whichever
+// are given), returning ErrNotEditable for nodes that are not one
+func (l *LinearSource) UpdateFields(ctx context.Context, node graph.Node, fields map[string]string) error {
+	identifier, ok := strings.CutPrefix(node.ID, "linear:")
+	if !ok || node.Type != graph.NodeTypeIssue {
+		return ErrNotEditable
+	}
+	if l.apiKey == "" {
+		return fmt.Errorf("LINEAR_API_KEY environment variable not set")
+	}
+	input := make(map[string]interface{}, len(fields))
+	for _, field := range editableFields {
+		if value, ok := fields[field]; ok {
+			input[field] = value
+		}
+	}
+	if len(input) == 0 {
+		return nil
+	}
+	query := `
+	mutation IssueUpdate($id: String!, $input: IssueUpdateInput!) {
+		issueUpdate(id: $id, input: $input) {
+			success
+		}
+	}`
+	resp, err := l.graphqlRequest(ctx, query, map[string]interface{}{
+		"id":    identifier,
+		"input": input,
+	})
+	if err != nil {
+		return err
 	}
 
+	var result struct {
+		Data struct {
+			IssueUpdate struct {
+				Success bool `json:"success"`
+			} `json:"issueUpdate"`
+		} `json:"data"`
+		Errors []graphqlError `json:"errors"`
+	}
 	if err := json.Unmarshal(resp, &result); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
+		return fmt.Errorf("parsing response: %w", err)
 	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("Linear API error: %s", result.Errors[0].Message)
+	}
+	if !result.Data.IssueUpdate.Success {
+		return fmt.Errorf("Linear did not update %s", identifier)
+	}
+	return nil
+}
 
-	return result.Data.Team.Projects.Nodes, nil
+// rateLimitError is returned when Linear throttles a request
+type rateLimitError struct {
+	status     int
+	body       string
+	retryAfter time.Duration
+	hinted     bool // Server sent a usable Retry-After header
 }
 
-// graphqlRequest makes a GraphQL request to Linear API
+func (e *rateLimitError) Error() string {
+	return fmt.Sprintf("Linear API rate limited (%d): %s", e.status, e.body)
+}
+
+// graphqlRequest makes a GraphQL request to Linear API.
+// Rate-limited requests (HTTP 429 or a RATELIMITED error code) are retried
+// up to linearMaxRetries times, honouring Retry-After when present and
+// backing off exponentially otherwise.
 func (l *LinearSource) graphqlRequest(ctx context.Context, query string, variables map[string]interface{}) ([]byte, error) {
 	body := map[string]interface{}{
 		"query":     query,
@@ -274,7 +810,28 @@ func (l *LinearSource) graphqlRequest(ctx context.Context, query string, variabl
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.linear.app/graphql", strings.NewReader(string(jsonBody)))
+	for attempt := 0; ; attempt++ {
+		respBody, err := l.doRequest(ctx, jsonBody)
+		var limited *rateLimitError
+		if err == nil || !errors.As(err, &limited) || attempt >= linearMaxRetries {
+			return respBody, err
+		}
+
+		wait := linearBaseBackoff << attempt
+		if limited.hinted {
+			wait = limited.retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// doRequest performs a single POST against the configured endpoint
+func (l *LinearSource) doRequest(ctx context.Context, jsonBody []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", l.endpoint, strings.NewReader(string(jsonBody)))
 	if err != nil {
 		return nil, err
 	}
@@ -288,12 +845,70 @@ func (l *LinearSource) graphqlRequest(ctx context.Context, query string, variabl
 	}
 	defer resp.Body.Close()
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	l.meter(len(jsonBody)+len(body), resp.Header.Get("X-Complexity"))
+
+	if resp.StatusCode == http.StatusTooManyRequests || isRateLimited(body) {
+		retryAfter, hinted := parseRetryAfter(resp.Header.Get("Retry-After"))
+		return nil, &rateLimitError{
+			status:     resp.StatusCode,
+			body:       string(body),
+			retryAfter: retryAfter,
+			hinted:     hinted,
+		}
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("Linear API returned %d: %s", resp.StatusCode, string(body))
 	}
 
-	return io.ReadAll(resp.Body)
+	return body, nil
+}
+
+// meter counts one request: the bytes sent and received, and the
+// complexity points Linear charged for it (its X-Complexity header)
+func (l *LinearSource) meter(bytes int, complexity string) {
+	points, _ := strconv.Atoi(strings.TrimSpace(complexity))
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.spent.Requests++
+	l.spent.Bytes += int64(bytes)
+	l.spent.Cost += points
+}
+
+// Telemetry returns what the source has spent on the Linear API so far
+func (l *LinearSource) Telemetry() Telemetry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.spent
+}
+
+// isRateLimited reports whether a response body carries Linear's RATELIMITED error code
+func isRateLimited(body []byte) bool {
+	var result struct {
+		Errors []graphqlError `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return false
+	}
+	for _, e := range result.Errors {
+		if e.Extensions.Code == "RATELIMITED" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds
+func parseRetryAfter(value string) (time.Duration, bool) {
+	secs, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return time.Duration(secs) * time.Second, true
 }
 
 // issueToNode converts a Linear issue to a graph node and edges
@@ -307,8 +922,24 @@ func (l *LinearSource) issueToNode(issue LinearIssue) (graph.Node, []graph.Edge)
 		"status":      issue.Status,
 		"labels":      issue.Labels,
 		"project":     issue.ProjectName,
+		"assignee":    issue.Assignee,
 		"url":         issue.URL,
 	}
+	if issue.Estimate > 0 {
+		data["estimate"] = issue.Estimate
+	}
+	if issue.CompletedAt != "" {
+		data["completed_at"] = issue.CompletedAt
+	}
+	if cycle := issue.Cycle; cycle != nil {
+		name := cycle.Name
+		if name == "" {
+			name = fmt.Sprintf("Cycle %d", cycle.Number)
+		}
+		data["cycle"] = name
+		data["cycle_starts_at"] = cycle.StartsAt
+		data["cycle_ends_at"] = cycle.EndsAt
+	}
 	dataJSON, _ := json.Marshal(data)
 
 	// Parse timestamps
@@ -341,12 +972,27 @@ func (l *LinearSource) issueToNode(issue LinearIssue) (graph.Node, []graph.Edge)
 		})
 	}
 
-	// Related edges
+	// Blocked-by edges: the blocker's blocks edge, so an issue loaded
+	// without its blocker (an incremental sync) keeps it
+	for _, blockerID := range issue.BlockedBy {
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:%s-blocks-%s", blockerID, issue.Identifier),
+			FromID:   fmt.Sprintf("linear:%s", blockerID),
+			ToID:     node.ID,
+			Relation: graph.EdgeBlocks,
+		})
+	}
+
+	// Related edges, one per pair whichever side lists it
 	for _, relatedID := range issue.Related {
+		from, to := issue.Identifier, relatedID
+		if to < from {
+			from, to = to, from
+		}
 		edges = append(edges, graph.Edge{
-			ID:       fmt.Sprintf("edge:%s-related-%s", issue.Identifier, relatedID),
-			FromID:   node.ID,
-			ToID:     fmt.Sprintf("linear:%s", relatedID),
+			ID:       fmt.Sprintf("edge:%s-related-%s", from, to),
+			FromID:   fmt.Sprintf("linear:%s", from),
+			ToID:     fmt.Sprintf("linear:%s", to),
 			Relation: graph.EdgeRelated,
 		})
 	}
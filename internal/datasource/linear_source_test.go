@@ -0,0 +1,531 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/datasource/linearfake"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// newFakeLinear starts a fake Linear server and a source pointed at it
+func newFakeLinear(t *testing.T) (*linearfake.Server, *LinearSource) {
+	t.Helper()
+	srv := linearfake.NewServer()
+	t.Cleanup(srv.Close)
+
+	src := NewLinearSource(srv.TeamID())
+	src.SetEndpoint(srv.URL)
+	src.SetAPIKey(srv.APIKey())
+	return srv, src
+}
+
+func countType(nodes []graph.Node, t graph.NodeType) int {
+	n := 0
+	for _, node := range nodes {
+		if node.Type == t {
+			n++
+		}
+	}
+	return n
+}
+
+func countRelation(edges []graph.Edge, r graph.EdgeType) int {
+	n := 0
+	for _, edge := range edges {
+		if edge.Relation == r {
+			n++
+		}
+	}
+	return n
+}
+
+func TestLinearSourcePaginates(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(5, 7)
+	src.SetPageSize(3)
+
+	nodes, edges, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := countType(nodes, graph.NodeTypeIssue); got != 7 {
+		t.Errorf("issues = %d, want 7", got)
+	}
+	if got := countType(nodes, graph.NodeTypeProject); got != 5 {
+		t.Errorf("projects = %d, want 5", got)
+	}
+	if got := countRelation(edges, graph.EdgeOwns); got != 7 {
+		t.Errorf("owns edges = %d, want 7 project ownership edges", got)
+	}
+	// Seeded assignees rotate Ada, Grace and unassigned
+	if got := countRelation(edges, graph.EdgeAssignedTo); got != 5 {
+		t.Errorf("assigned_to edges = %d, want 5", got)
+	}
+	if got := countType(nodes, graph.NodeTypePerson); got != 2 {
+		t.Errorf("people = %d, want 2", got)
+	}
+	// 3 issue pages (3+3+1), 3 detail batches and 2 project pages (3+2)
+	if got := srv.Requests(); got != 8 {
+		t.Errorf("requests = %d, want 8", got)
+	}
+}
+
+// TestLinearSourceBackfillsDetails checks the second pass fills in
+// descriptions and turns relations into edges, each once, whichever side
+// of it was loaded
+func TestLinearSourceBackfillsDetails(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(1, 7)
+	src.SetPageSize(3)
+
+	nodes, edges, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for _, node := range nodes {
+		if node.ID == "linear:FAKE-2" {
+			var data struct {
+				Description string `json:"description"`
+			}
+			if err := json.Unmarshal(node.Data, &data); err != nil || data.Description != "Description of seeded issue 2" {
+				t.Errorf("FAKE-2 description = %q, %v", data.Description, err)
+			}
+		}
+	}
+	// Seeded: FAKE-4 blocks FAKE-5, FAKE-6 is related to FAKE-5
+	want := map[string]graph.EdgeType{
+		"linear:FAKE-4>linear:FAKE-5": graph.EdgeBlocks,
+		"linear:FAKE-5>linear:FAKE-6": graph.EdgeRelated,
+	}
+	for _, edge := range edges {
+		if edge.Relation != graph.EdgeBlocks && edge.Relation != graph.EdgeRelated {
+			continue
+		}
+		key := edge.FromID + ">" + edge.ToID
+		if want[key] != edge.Relation {
+			t.Errorf("unexpected %s edge %s", edge.Relation, key)
+		}
+		delete(want, key)
+	}
+	for key, relation := range want {
+		t.Errorf("missing %s edge %s", relation, key)
+	}
+
+	// Loaded alone (an incremental sync), the blocked issue still carries
+	// its blocker's edge
+	srv.AddIssue(linearfake.Issue{ID: "issue-5", Identifier: "FAKE-5", Title: "Seeded issue 5", UpdatedAt: time.Now()})
+	changes, err := src.LoadSince(context.Background(), time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("LoadSince: %v", err)
+	}
+	if got := countType(changes.Nodes, graph.NodeTypeIssue); got != 1 {
+		t.Fatalf("changed issues = %d, want 1", got)
+	}
+	found := false
+	for _, edge := range changes.Edges {
+		found = found || edge.ID == "edge:FAKE-4-blocks-FAKE-5" && edge.FromID == "linear:FAKE-4"
+	}
+	if !found {
+		t.Errorf("incremental load lost FAKE-4 blocks FAKE-5: %+v", changes.Edges)
+	}
+}
+
+// TestLoaderRecordsLinearCost checks a sync run carries the requests, bytes
+// and complexity points the Linear source spent
+func TestLoaderRecordsLinearCost(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(5, 7)
+	src.SetPageSize(3)
+	loader := NewLoader(src)
+
+	for sync := 0; sync < 2; sync++ {
+		if _, _, err := loader.LoadAll(context.Background()); err != nil {
+			t.Fatalf("LoadAll: %v", err)
+		}
+		runs := loader.SyncRuns()
+		if len(runs) != 1 {
+			t.Fatalf("runs = %+v, want one", runs)
+		}
+		// Each sync on its own: 5 pages of 3 at 1+3 points each, and detail
+		// batches of 3, 3 and 1 issues
+		run := runs[0]
+		if run.Source != "linear" || run.Requests != 8 || run.Cost != 30 || run.Bytes == 0 || run.Nodes != 14 || run.Error != "" {
+			t.Errorf("sync %d run = %+v", sync, run)
+		}
+	}
+}
+
+func TestLinearSourceMaxIssues(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(0, 20)
+	src.SetPageSize(4)
+	src.SetMaxIssues(6)
+
+	nodes, _, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := countType(nodes, graph.NodeTypeIssue); got != 6 {
+		t.Errorf("issues = %d, want 6", got)
+	}
+	if got, want := src.Truncation(), "truncated at 6 issues (more on Linear)"; got != want {
+		t.Errorf("Truncation() = %q, want %q", got, want)
+	}
+
+	// A cap the team doesn't reach loads every issue, untruncated
+	src.SetMaxIssues(20)
+	nodes, _, err = src.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := countType(nodes, graph.NodeTypeIssue); got != 20 || src.Truncation() != "" {
+		t.Errorf("issues = %d, truncation %q; want 20, none", got, src.Truncation())
+	}
+}
+
+func TestLinearSourceErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(*linearfake.Server, *LinearSource)
+		wantErr string
+	}{
+		{
+			name:    "missing api key",
+			setup:   func(_ *linearfake.Server, src *LinearSource) { src.SetAPIKey("") },
+			wantErr: "LINEAR_API_KEY",
+		},
+		{
+			name:    "rejected api key",
+			setup:   func(_ *linearfake.Server, src *LinearSource) { src.SetAPIKey("wrong") },
+			wantErr: "returned 401",
+		},
+		{
+			name:    "server error",
+			setup:   func(srv *linearfake.Server, _ *LinearSource) { srv.FailNext(1) },
+			wantErr: "returned 500",
+		},
+		{
+			name:    "graphql error",
+			setup:   func(srv *linearfake.Server, _ *LinearSource) { srv.GraphQLErrorNext("Query too complex") },
+			wantErr: "Linear API error: Query too complex",
+		},
+		{
+			name:    "unknown team",
+			setup:   func(_ *linearfake.Server, src *LinearSource) { src.teamID = "team-missing" },
+			wantErr: "Entity not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, src := newFakeLinear(t)
+			srv.Seed(1, 3)
+			tt.setup(srv, src)
+
+			_, _, err := src.Load(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLinearSourcePostsComment(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(1, 3)
+	ctx := context.Background()
+
+	issue := graph.Node{ID: "linear:FAKE-2", Type: graph.NodeTypeIssue, Source: "linear"}
+	if err := src.PostComment(ctx, issue, "Looks good to me"); err != nil {
+		t.Fatalf("PostComment: %v", err)
+	}
+	if got := srv.Comments("FAKE-2"); len(got) != 1 || got[0] != "Looks good to me" {
+		t.Errorf("comments = %q, want the one posted", got)
+	}
+
+	// Other nodes are left to other sources
+	project := graph.Node{ID: "linear:project:proj-1", Type: graph.NodeTypeProject, Source: "linear"}
+	if err := src.PostComment(ctx, project, "hi"); !errors.Is(err, ErrNoComments) {
+		t.Errorf("PostComment(project) = %v, want ErrNoComments", err)
+	}
+	loader := NewLoader(src)
+	if err := loader.PostComment(ctx, graph.Node{ID: "file:main.go", Type: graph.NodeTypeFile}, "hi"); !errors.Is(err, ErrNoComments) {
+		t.Errorf("Loader.PostComment(file) = %v, want ErrNoComments", err)
+	}
+
+	missing := graph.Node{ID: "linear:FAKE-99", Type: graph.NodeTypeIssue, Source: "linear"}
+	if err := loader.PostComment(ctx, missing, "hi"); err == nil || !strings.Contains(err.Error(), "Entity not found") {
+		t.Errorf("PostComment(missing) = %v, want Entity not found", err)
+	}
+}
+
+// TestLinearSourceUpdatesFields checks an issue's fields are re-read and
+// written back, and that a field left out stays as it was.
+func TestLinearSourceUpdatesFields(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(1, 3)
+	ctx := context.Background()
+	loader := NewLoader(src)
+
+	issue := graph.Node{ID: "linear:FAKE-2", Type: graph.NodeTypeIssue, Source: "linear"}
+	before, fields, err := loader.RemoteFields(ctx, issue)
+	if err != nil {
+		t.Fatalf("RemoteFields: %v", err)
+	}
+	if fields["title"] != "Seeded issue 2" || fields["description"] != "Description of seeded issue 2" {
+		t.Errorf("fields = %q", fields)
+	}
+
+	if err := loader.UpdateFields(ctx, issue, map[string]string{"title": "Renamed"}); err != nil {
+		t.Fatalf("UpdateFields: %v", err)
+	}
+	after, fields, err := loader.RemoteFields(ctx, issue)
+	if err != nil {
+		t.Fatalf("RemoteFields: %v", err)
+	}
+	if fields["title"] != "Renamed" || fields["description"] != "Description of seeded issue 2" {
+		t.Errorf("fields after the update = %q", fields)
+	}
+	if !after.After(before) {
+		t.Errorf("updatedAt %v did not move past %v", after, before)
+	}
+
+	if err := loader.UpdateFields(ctx, graph.Node{ID: "file:main.go", Type: graph.NodeTypeFile}, fields); !errors.Is(err, ErrNotEditable) {
+		t.Errorf("UpdateFields(file) = %v, want ErrNotEditable", err)
+	}
+}
+
+func TestLinearSourceRetriesRateLimit(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(1, 3)
+	srv.RateLimitNext(2, "0")
+
+	nodes, _, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := countType(nodes, graph.NodeTypeIssue); got != 3 {
+		t.Errorf("issues = %d, want 3", got)
+	}
+	// 2 throttled + 1 issue page + 1 detail batch + 1 project page
+	if got := srv.Requests(); got != 5 {
+		t.Errorf("requests = %d, want 5", got)
+	}
+}
+
+func TestLinearSourceGivesUpOnRateLimit(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(1, 3)
+	srv.RateLimitNext(100, "0")
+
+	_, _, err := src.Load(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "rate limited") {
+		t.Fatalf("err = %v, want rate limit error", err)
+	}
+	if got := srv.Requests(); got != linearMaxRetries+1 {
+		t.Errorf("requests = %d, want %d", got, linearMaxRetries+1)
+	}
+}
+
+func TestLinearSourceRateLimitRespectsContext(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(1, 3)
+	srv.RateLimitNext(1, "") // No Retry-After: falls back to a one second backoff
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err := src.Load(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// TestLinearSourceReconcilesStore syncs twice into a store, changing the
+// upstream data in between, and checks the store converges on the new state.
+func TestLinearSourceReconcilesStore(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(2, 4)
+
+	store, err := graph.NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	sync := func() {
+		t.Helper()
+		nodes, edges, err := src.Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		// A complete load: what it no longer has goes from the store
+		if _, _, skipped, err := graph.Persist(store, nodes, edges, true); err != nil || skipped != 0 {
+			t.Fatalf("Persist: skipped=%d err=%v", skipped, err)
+		}
+	}
+
+	sync()
+	node, err := store.GetNode("linear:FAKE-1")
+	if err != nil {
+		t.Fatalf("GetNode: %v", err)
+	}
+	if node.Status() != "In Progress" {
+		t.Fatalf("initial status = %q, want In Progress", node.Status())
+	}
+
+	// Upstream change: FAKE-1 is closed and moved to project 2
+	srv.AddIssue(linearfake.Issue{
+		ID:          "issue-1",
+		Identifier:  "FAKE-1",
+		Title:       "Seeded issue 1 (renamed)",
+		State:       "Done",
+		ProjectID:   "proj-2",
+		ProjectName: "Project 2",
+		UpdatedAt:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	})
+	sync()
+
+	node, err = store.GetNode("linear:FAKE-1")
+	if err != nil {
+		t.Fatalf("GetNode: %v", err)
+	}
+	if node.Status() != "Done" || node.Title() != "Seeded issue 1 (renamed)" {
+		t.Errorf("after resync got status %q title %q", node.Status(), node.Title())
+	}
+
+	owners, err := store.GetNeighbors("linear:FAKE-1")
+	if err != nil {
+		t.Fatalf("GetNeighbors: %v", err)
+	}
+	found := false
+	for _, owner := range owners {
+		switch owner.ID {
+		case "linear:project:proj-2":
+			found = true
+		case "linear:project:proj-1":
+			t.Errorf("FAKE-1 still linked to proj-1 after moving to proj-2")
+		}
+	}
+	if !found {
+		t.Errorf("FAKE-1 not linked to proj-2 after resync")
+	}
+}
+
+// TestLoaderSyncsLinearIncrementally checks that once a sync is stored, the
+// next one asks Linear only for updated issues and lays them over the rest.
+func TestLoaderSyncsLinearIncrementally(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.Seed(2, 7)
+	src.SetPageSize(3)
+
+	store, err := graph.NewStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	loader := NewLoader(src)
+	loader.SetSyncStore(store, false)
+
+	sync := func() ([]graph.Node, []graph.Edge) {
+		t.Helper()
+		nodes, edges, err := loader.LoadAll(context.Background())
+		if err != nil {
+			t.Fatalf("LoadAll: %v", err)
+		}
+		if nodes, edges, _, err = graph.Persist(store, nodes, edges, true); err != nil {
+			t.Fatalf("Persist: %v", err)
+		}
+		loader.SaveSyncCursors(context.Background())
+		return nodes, edges
+	}
+
+	sync()
+	if got := loader.SyncRuns()[0].Requests; got != 7 {
+		t.Fatalf("first sync requests = %d, want 7 (a full load)", got)
+	}
+	cursor, found, _ := store.SyncCursor(context.Background(), "linear:"+srv.TeamID())
+	if !found {
+		t.Fatalf("no cursor kept for team %s", srv.TeamID())
+	}
+	// A load that is never stored leaves the cursor where it was
+	if _, _, err := loader.LoadAll(context.Background()); err != nil {
+		t.Fatalf("LoadAll: %v", err)
+	}
+	if moved, _, _ := store.SyncCursor(context.Background(), "linear:"+srv.TeamID()); !moved.Equal(cursor) {
+		t.Errorf("cursor moved to %v by a load never stored", moved)
+	}
+
+	srv.AddIssue(linearfake.Issue{
+		ID:          "issue-1",
+		Identifier:  "FAKE-1",
+		Title:       "Seeded issue 1",
+		State:       "Done",
+		ProjectID:   "proj-2",
+		ProjectName: "Project 2",
+		UpdatedAt:   time.Now(),
+	})
+	nodes, edges := sync()
+	if got := loader.SyncRuns()[0].Requests; got != 4 {
+		t.Errorf("second sync requests = %d, want 4 (one issue page, its details, one removed-issues page, one project page)", got)
+	}
+	if got := countType(nodes, graph.NodeTypeIssue); got != 7 {
+		t.Errorf("issues = %d, want all 7 after the incremental sync", got)
+	}
+	for _, edge := range edges {
+		if edge.ToID == "linear:FAKE-1" && edge.Relation == graph.EdgeOwns && edge.FromID != "linear:project:proj-2" {
+			t.Errorf("FAKE-1 still owned by %s", edge.FromID)
+		}
+	}
+	node, err := store.GetNode("linear:FAKE-1")
+	if err != nil || node.Status() != "Done" {
+		t.Errorf("stored FAKE-1 = %+v, %v; want it Done", node, err)
+	}
+
+	// Archiving (or deleting) FAKE-3 leaves it out of the issues query; the
+	// next sync must still drop it
+	srv.AddIssue(linearfake.Issue{
+		ID:          "issue-3",
+		Identifier:  "FAKE-3",
+		Title:       "Seeded issue 3",
+		State:       "In Review",
+		ProjectID:   "proj-1",
+		ProjectName: "Project 1",
+		UpdatedAt:   time.Now(),
+		ArchivedAt:  time.Now(),
+	})
+	nodes, edges = sync()
+	if got := countType(nodes, graph.NodeTypeIssue); got != 6 {
+		t.Errorf("issues = %d, want 6 after FAKE-3 was archived", got)
+	}
+	for _, edge := range edges {
+		if edge.FromID == "linear:FAKE-3" || edge.ToID == "linear:FAKE-3" {
+			t.Errorf("edge %s -> %s outlived the archived FAKE-3", edge.FromID, edge.ToID)
+		}
+	}
+	if _, err := store.GetNode("linear:FAKE-3"); err == nil {
+		t.Error("archived FAKE-3 is still stored")
+	}
+}
+
+// TestLinearSourceBlankAssignee checks an issue whose assignee is only
+// whitespace loads as unassigned
+func TestLinearSourceBlankAssignee(t *testing.T) {
+	srv, src := newFakeLinear(t)
+	srv.AddIssue(linearfake.Issue{ID: "issue-blank", Identifier: "FAKE-99", Title: "Blank assignee", Assignee: "  ", UpdatedAt: time.Now()})
+
+	_, edges, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for _, edge := range edges {
+		if edge.FromID == "linear:FAKE-99" && edge.Relation == graph.EdgeAssignedTo {
+			t.Errorf("blank assignee produced %s -> %s", edge.FromID, edge.ToID)
+		}
+	}
+}
@@ -0,0 +1,624 @@
+// Package linearfake provides an in-process fake of the Linear GraphQL API.
+//
+// It serves the queries LinearSource issues (team issues, optionally
+// filtered by update time, archive time or ID, single issues by identifier,
+// and team projects) with Relay-style cursor pagination, records comments
+// posted with commentCreate, applies issueUpdate, and can be told to fail,
+// reject the API key or rate limit upcoming requests. It backs both the
+// datasource tests and `maat --mock-linear`.
+package linearfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAPIKey is the key the fake accepts unless APIKey is changed
+const DefaultAPIKey = "lin_fake_key"
+
+// DefaultTeamID is the team the seeded data belongs to
+const DefaultTeamID = "team-fake"
+
+// Issue is an issue served by the fake
+type Issue struct {
+	ID          string
+	Identifier  string
+	Title       string
+	Description string
+	Priority    int
+	State       string
+	Labels      []string
+	ProjectID   string
+	ProjectName string
+	Assignee    string // Display name ("" = unassigned)
+	Estimate    float64
+	Cycle       *Cycle // nil = not in a cycle
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt time.Time // Zero = not completed
+	ArchivedAt  time.Time // Archived or trashed (zero = neither); served only with includeArchived
+	Blocks      []string  // Identifiers of the issues this one blocks
+	Related     []string  // Identifiers of issues related to this one
+}
+
+// Cycle is a sprint issues are planned in
+type Cycle struct {
+	Number   int
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// Project is a project served by the fake
+type Project struct {
+	ID          string
+	Name        string
+	Description string
+	State       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Server is a fake Linear GraphQL endpoint backed by httptest.Server.
+// All fields and methods are safe to use while requests are in flight.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	apiKey   string
+	teamID   string
+	pageSize int // Caps "first" when non-zero
+	issues   []Issue
+	projects []Project
+	comments map[string][]string // Comment bodies by issue identifier
+
+	rateLimitNext  int // Requests still to be throttled
+	retryAfter     string
+	failNext       int // Requests still to fail with HTTP 500
+	graphqlErrNext string
+	requests       int
+}
+
+// NewServer starts a fake Linear server with no data.
+// Callers must Close it when done.
+func NewServer() *Server {
+	s := &Server{apiKey: DefaultAPIKey, teamID: DefaultTeamID, retryAfter: "0"}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// APIKey returns the key the server currently accepts
+func (s *Server) APIKey() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.apiKey
+}
+
+// TeamID returns the team whose issues the server serves
+func (s *Server) TeamID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.teamID
+}
+
+// SetPageSize caps every page at n items regardless of the requested "first"
+func (s *Server) SetPageSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pageSize = n
+}
+
+// AddIssue appends an issue, or replaces an existing one with the same identifier
+func (s *Server) AddIssue(issue Issue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.issues {
+		if s.issues[i].Identifier == issue.Identifier {
+			s.issues[i] = issue
+			return
+		}
+	}
+	s.issues = append(s.issues, issue)
+}
+
+// RemoveIssue deletes the issue with the given identifier
+func (s *Server) RemoveIssue(identifier string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.issues {
+		if s.issues[i].Identifier == identifier {
+			s.issues = append(s.issues[:i], s.issues[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddProject appends a project
+func (s *Server) AddProject(project Project) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projects = append(s.projects, project)
+}
+
+// Seed fills the server with projects and issues spread across them.
+// Identifiers run FAKE-1..FAKE-n so tests can address them; every fourth
+// issue blocks the next and every sixth is related to the one before.
+func (s *Server) Seed(projects, issues int) {
+	states := []string{"Todo", "In Progress", "In Review", "Done", "Backlog"}
+	assignees := []string{"Ada Lovelace", "Grace Hopper", ""}
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	cycle := &Cycle{Number: 1, StartsAt: base, EndsAt: base.Add(14 * 24 * time.Hour)}
+
+	for p := 1; p <= projects; p++ {
+		s.AddProject(Project{
+			ID:          fmt.Sprintf("proj-%d", p),
+			Name:        fmt.Sprintf("Project %d", p),
+			Description: fmt.Sprintf("Seeded project %d", p),
+			State:       "started",
+			CreatedAt:   base,
+			UpdatedAt:   base.Add(time.Duration(p) * time.Hour),
+		})
+	}
+
+	for i := 1; i <= issues; i++ {
+		issue := Issue{
+			ID:          fmt.Sprintf("issue-%d", i),
+			Identifier:  fmt.Sprintf("FAKE-%d", i),
+			Title:       fmt.Sprintf("Seeded issue %d", i),
+			Description: fmt.Sprintf("Description of seeded issue %d", i),
+			Priority:    i % 5,
+			State:       states[i%len(states)],
+			Labels:      []string{"seed"},
+			Assignee:    assignees[i%len(assignees)],
+			Estimate:    float64(i%3 + 1),
+			Cycle:       cycle,
+			CreatedAt:   base.Add(time.Duration(i) * time.Minute),
+			UpdatedAt:   base.Add(time.Duration(i) * time.Hour),
+		}
+		if i%4 == 0 && i < issues {
+			issue.Blocks = []string{fmt.Sprintf("FAKE-%d", i+1)}
+		}
+		if i%6 == 0 {
+			issue.Related = []string{fmt.Sprintf("FAKE-%d", i-1)}
+		}
+		if issue.State == "Done" {
+			issue.CompletedAt = issue.UpdatedAt
+		}
+		if projects > 0 {
+			p := (i-1)%projects + 1
+			issue.ProjectID = fmt.Sprintf("proj-%d", p)
+			issue.ProjectName = fmt.Sprintf("Project %d", p)
+		}
+		s.AddIssue(issue)
+	}
+}
+
+// Comments returns the bodies of the comments posted on an issue, oldest first
+func (s *Server) Comments(identifier string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.comments[identifier]...)
+}
+
+// Issue returns the issue with the given identifier as the fake now has it
+func (s *Server) Issue(identifier string) (Issue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.find(identifier)
+	if !ok {
+		return Issue{}, false
+	}
+	return s.issues[i], true
+}
+
+// RateLimitNext throttles the next n requests. retryAfter is sent as the
+// Retry-After header; pass "" to omit it.
+func (s *Server) RateLimitNext(n int, retryAfter string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimitNext = n
+	s.retryAfter = retryAfter
+}
+
+// FailNext makes the next n requests fail with HTTP 500
+func (s *Server) FailNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failNext = n
+}
+
+// GraphQLErrorNext makes the next request return HTTP 200 with a GraphQL error
+func (s *Server) GraphQLErrorNext(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.graphqlErrNext = message
+}
+
+// Requests returns how many requests the server has received
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// request is the JSON body of a GraphQL POST
+type request struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// handle serves a single GraphQL request
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "POST required", "")
+		return
+	}
+	if r.Header.Get("Authorization") != s.apiKey {
+		writeError(w, http.StatusUnauthorized, "Authentication required, not authenticated", "AUTHENTICATION_ERROR")
+		return
+	}
+	if s.rateLimitNext > 0 {
+		s.rateLimitNext--
+		if s.retryAfter != "" {
+			w.Header().Set("Retry-After", s.retryAfter)
+		}
+		writeError(w, http.StatusTooManyRequests, "Rate limit exceeded", "RATELIMITED")
+		return
+	}
+	if s.failNext > 0 {
+		s.failNext--
+		writeError(w, http.StatusInternalServerError, "Internal server error", "INTERNAL_SERVER_ERROR")
+		return
+	}
+	if s.graphqlErrNext != "" {
+		msg := s.graphqlErrNext
+		s.graphqlErrNext = ""
+		writeError(w, http.StatusOK, msg, "INVALID_INPUT")
+		return
+	}
+
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body", "BAD_REQUEST")
+		return
+	}
+	if strings.Contains(req.Query, "commentCreate(") {
+		s.createComment(w, req.Variables)
+		return
+	}
+	if strings.Contains(req.Query, "issueUpdate(") {
+		s.updateIssue(w, req.Variables)
+		return
+	}
+	if strings.Contains(req.Query, "issue(id:") {
+		s.issue(w, req.Variables)
+		return
+	}
+	if teamID, _ := req.Variables["teamId"].(string); teamID != s.teamID {
+		writeError(w, http.StatusOK, fmt.Sprintf("Entity not found: Team %q", teamID), "INVALID_INPUT")
+		return
+	}
+
+	first, _ := req.Variables["first"].(float64)
+	after, _ := req.Variables["after"].(string)
+	// Linear charges a connection by the page size asked for, not returned
+	w.Header().Set("X-Complexity", strconv.Itoa(1+int(first)))
+
+	switch {
+	case strings.Contains(req.Query, "issues("):
+		var issues []Issue
+		for _, issue := range s.issues {
+			if issue.ArchivedAt.IsZero() || strings.Contains(req.Query, "includeArchived: true") {
+				issues = append(issues, issue)
+			}
+		}
+		if since, ok := timeAfter(req.Variables["filter"], "updatedAt"); ok {
+			issues = keepIssues(issues, func(issue Issue) bool { return issue.UpdatedAt.After(since) })
+		}
+		if since, ok := timeAfter(req.Variables["filter"], "archivedAt"); ok {
+			issues = keepIssues(issues, func(issue Issue) bool { return issue.ArchivedAt.After(since) })
+		}
+		if ids, ok := idIn(req.Variables["filter"]); ok {
+			issues = keepIssues(issues, func(issue Issue) bool { return ids[issue.ID] })
+		}
+		start, end, hasNext := s.page(len(issues), int(first), after)
+		nodes := make([]map[string]interface{}, 0, end-start)
+		for _, issue := range issues[start:end] {
+			nodes = append(nodes, s.issueJSON(issue))
+		}
+		writeConnection(w, "issues", nodes, end, hasNext)
+	case strings.Contains(req.Query, "projects("):
+		start, end, hasNext := s.page(len(s.projects), int(first), after)
+		nodes := make([]map[string]interface{}, 0, end-start)
+		for _, project := range s.projects[start:end] {
+			nodes = append(nodes, projectJSON(project))
+		}
+		writeConnection(w, "projects", nodes, end, hasNext)
+	default:
+		writeError(w, http.StatusBadRequest, "unsupported query", "GRAPHQL_VALIDATION_FAILED")
+	}
+}
+
+// createComment records a comment on an issue addressed by ID or identifier
+func (s *Server) createComment(w http.ResponseWriter, variables map[string]interface{}) {
+	issueID, _ := variables["issueId"].(string)
+	body, _ := variables["body"].(string)
+	for _, issue := range s.issues {
+		if issue.ID != issueID && issue.Identifier != issueID {
+			continue
+		}
+		if s.comments == nil {
+			s.comments = make(map[string][]string)
+		}
+		s.comments[issue.Identifier] = append(s.comments[issue.Identifier], body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"commentCreate": map[string]interface{}{
+					"success": true,
+					"comment": map[string]string{"id": fmt.Sprintf("comment-%s-%d", issue.Identifier, len(s.comments[issue.Identifier]))},
+				},
+			},
+		})
+		return
+	}
+	writeError(w, http.StatusOK, fmt.Sprintf("Entity not found: Issue %q", issueID), "INVALID_INPUT")
+}
+
+// issue serves one issue's title, description and update time, by ID or
+// identifier
+func (s *Server) issue(w http.ResponseWriter, variables map[string]interface{}) {
+	id, _ := variables["id"].(string)
+	i, ok := s.find(id)
+	if !ok {
+		writeError(w, http.StatusOK, fmt.Sprintf("Entity not found: Issue %q", id), "INVALID_INPUT")
+		return
+	}
+	issue := s.issues[i]
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"issue": map[string]interface{}{
+				"title":       issue.Title,
+				"description": issue.Description,
+				"updatedAt":   issue.UpdatedAt.Format(time.RFC3339),
+			},
+		},
+	})
+}
+
+// updateIssue applies an issueUpdate of the title and description
+func (s *Server) updateIssue(w http.ResponseWriter, variables map[string]interface{}) {
+	id, _ := variables["id"].(string)
+	i, ok := s.find(id)
+	if !ok {
+		writeError(w, http.StatusOK, fmt.Sprintf("Entity not found: Issue %q", id), "INVALID_INPUT")
+		return
+	}
+	input, _ := variables["input"].(map[string]interface{})
+	if title, ok := input["title"].(string); ok {
+		s.issues[i].Title = title
+	}
+	if description, ok := input["description"].(string); ok {
+		s.issues[i].Description = description
+	}
+	s.issues[i].UpdatedAt = time.Now()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"issueUpdate": map[string]interface{}{"success": true},
+		},
+	})
+}
+
+// find returns the index of the issue with the given ID or identifier
+func (s *Server) find(id string) (int, bool) {
+	for i, issue := range s.issues {
+		if issue.ID == id || issue.Identifier == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// timeAfter reads the issue filter {<field>: {gt: <RFC 3339 time>}} for
+// updatedAt or archivedAt
+func timeAfter(filter interface{}, field string) (time.Time, bool) {
+	fields, _ := filter.(map[string]interface{})
+	comparator, _ := fields[field].(map[string]interface{})
+	gt, _ := comparator["gt"].(string)
+	since, err := time.Parse(time.RFC3339, gt)
+	return since, err == nil
+}
+
+// keepIssues returns the issues keep accepts
+func keepIssues(issues []Issue, keep func(Issue) bool) []Issue {
+	var kept []Issue
+	for _, issue := range issues {
+		if keep(issue) {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// idIn reads the issue filter {id: {in: [<ID>, ...]}}
+func idIn(filter interface{}) (map[string]bool, bool) {
+	fields, _ := filter.(map[string]interface{})
+	id, _ := fields["id"].(map[string]interface{})
+	in, ok := id["in"].([]interface{})
+	if !ok {
+		return nil, false
+	}
+	ids := make(map[string]bool, len(in))
+	for _, value := range in {
+		if s, ok := value.(string); ok {
+			ids[s] = true
+		}
+	}
+	return ids, true
+}
+
+// page resolves a cursor window. Cursors are the decimal offset of the next item.
+func (s *Server) page(total, first int, after string) (int, int, bool) {
+	start, _ := strconv.Atoi(after)
+	if start < 0 || start > total {
+		start = total
+	}
+	if first <= 0 {
+		first = 50
+	}
+	if s.pageSize > 0 && first > s.pageSize {
+		first = s.pageSize
+	}
+	end := start + first
+	if end > total {
+		end = total
+	}
+	return start, end, end < total
+}
+
+// issueJSON renders an issue in the shape of Linear's Issue type, its
+// relations included (the ones other issues declare as inverse relations)
+func (s *Server) issueJSON(issue Issue) map[string]interface{} {
+	labels := make([]map[string]string, 0, len(issue.Labels))
+	for _, l := range issue.Labels {
+		labels = append(labels, map[string]string{"name": l})
+	}
+	node := map[string]interface{}{
+		"id":          issue.ID,
+		"identifier":  issue.Identifier,
+		"title":       issue.Title,
+		"description": issue.Description,
+		"priority":    issue.Priority,
+		"state":       map[string]string{"name": issue.State},
+		"labels":      map[string]interface{}{"nodes": labels},
+		"project":     nil,
+		"assignee":    nil,
+		"cycle":       nil,
+		"estimate":    nil,
+		"createdAt":   issue.CreatedAt.Format(time.RFC3339),
+		"updatedAt":   issue.UpdatedAt.Format(time.RFC3339),
+		"completedAt": nil,
+		"url":         "https://linear.app/fake/issue/" + issue.Identifier,
+	}
+	relations := []map[string]interface{}{}
+	for _, relation := range relationsOf(issue) {
+		relations = append(relations, map[string]interface{}{
+			"type":         relation.kind,
+			"relatedIssue": map[string]string{"identifier": relation.target},
+		})
+	}
+	inverse := []map[string]interface{}{}
+	for _, other := range s.issues {
+		for _, relation := range relationsOf(other) {
+			if relation.target == issue.Identifier {
+				inverse = append(inverse, map[string]interface{}{
+					"type":  relation.kind,
+					"issue": map[string]string{"identifier": other.Identifier},
+				})
+			}
+		}
+	}
+	node["relations"] = map[string]interface{}{"nodes": relations}
+	node["inverseRelations"] = map[string]interface{}{"nodes": inverse}
+	if issue.Cycle != nil {
+		node["cycle"] = map[string]interface{}{
+			"number":   issue.Cycle.Number,
+			"name":     nil,
+			"startsAt": issue.Cycle.StartsAt.Format(time.RFC3339),
+			"endsAt":   issue.Cycle.EndsAt.Format(time.RFC3339),
+		}
+	}
+	if issue.Estimate > 0 {
+		node["estimate"] = issue.Estimate
+	}
+	if !issue.CompletedAt.IsZero() {
+		node["completedAt"] = issue.CompletedAt.Format(time.RFC3339)
+	}
+	if issue.ProjectID != "" {
+		node["project"] = map[string]string{"id": issue.ProjectID, "name": issue.ProjectName}
+	}
+	if fields := strings.Fields(issue.Assignee); len(fields) > 0 {
+		node["assignee"] = map[string]string{
+			"name":  issue.Assignee,
+			"email": strings.ToLower(fields[0]) + "@example.com",
+		}
+	}
+	return node
+}
+
+// relation is one relation an issue declares on another
+type relation struct {
+	kind   string // Linear's relation type: "blocks" or "related"
+	target string // The other issue's identifier
+}
+
+// relationsOf lists the relations an issue declares
+func relationsOf(issue Issue) []relation {
+	var relations []relation
+	for _, target := range issue.Blocks {
+		relations = append(relations, relation{"blocks", target})
+	}
+	for _, target := range issue.Related {
+		relations = append(relations, relation{"related", target})
+	}
+	return relations
+}
+
+// projectJSON renders a project in the shape of Linear's Project type
+func projectJSON(project Project) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          project.ID,
+		"name":        project.Name,
+		"description": project.Description,
+		"state":       project.State,
+		"url":         "https://linear.app/fake/project/" + project.ID,
+		"createdAt":   project.CreatedAt.Format(time.RFC3339),
+		"updatedAt":   project.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// writeConnection writes a team-scoped connection response with pageInfo
+func writeConnection(w http.ResponseWriter, field string, nodes []map[string]interface{}, next int, hasNext bool) {
+	cursor := ""
+	if hasNext {
+		cursor = strconv.Itoa(next)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"team": map[string]interface{}{
+				field: map[string]interface{}{
+					"nodes": nodes,
+					"pageInfo": map[string]interface{}{
+						"hasNextPage": hasNext,
+						"endCursor":   cursor,
+					},
+				},
+			},
+		},
+	})
+}
+
+// writeError writes a GraphQL-style error body with the given status
+func writeError(w http.ResponseWriter, status int, message, code string) {
+	entry := map[string]interface{}{"message": message}
+	if code != "" {
+		entry["extensions"] = map[string]string{"code": code}
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []interface{}{entry},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
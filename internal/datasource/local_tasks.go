@@ -0,0 +1,335 @@
+package datasource
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// LocalTasksSource reads personal tasks kept outside Linear: a todo.txt file
+// or a Taskwarrior export (`task export > tasks.json`). Tasks become Issue
+// nodes under Project nodes named after their todo.txt +project or
+// Taskwarrior project ("Home.Garden" nests under "Home").
+type LocalTasksSource struct {
+	path string
+}
+
+// NewLocalTasksSource creates a source for a todo.txt file or Taskwarrior
+// JSON export; files ending in .json (or starting with "[") are Taskwarrior
+func NewLocalTasksSource(path string) *LocalTasksSource {
+	return &LocalTasksSource{path: path}
+}
+
+// Name returns the data source identifier
+func (t *LocalTasksSource) Name() string {
+	return "tasks:" + filepath.Base(t.path)
+}
+
+// SupportsRefresh returns true - the file is re-read from disk
+func (t *LocalTasksSource) SupportsRefresh() bool {
+	return true
+}
+
+// localTask is a task in either format, normalized
+type localTask struct {
+	key        string // Stable within the file: Taskwarrior UUID or todo.txt line
+	identifier string
+	title      string
+	status     string
+	priority   int // Linear scale: 1 urgent ... 4 low, 0 none
+	project    string
+	labels     []string
+	due        string
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+// Load reads the tasks file
+func (t *LocalTasksSource) Load(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
+	content, err := os.ReadFile(t.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading tasks: %w", err)
+	}
+	modTime := time.Now()
+	if info, err := os.Stat(t.path); err == nil {
+		modTime = info.ModTime()
+	}
+
+	var tasks []localTask
+	source := "todo.txt"
+	if strings.EqualFold(filepath.Ext(t.path), ".json") || bytes.HasPrefix(bytes.TrimSpace(content), []byte("[")) {
+		source = "taskwarrior"
+		if tasks, err = parseTaskwarrior(content); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		tasks = parseTodoTxt(content, filepath.Base(t.path), modTime)
+	}
+
+	// Root project for the file: tasks without a project land here
+	rootName := filepath.Base(t.path)
+	rootID := fmt.Sprintf("project:%s:%s", source, sanitizeID(rootName))
+	nodes := []graph.Node{localProjectNode(rootID, rootName, source, "Personal tasks from "+t.path)}
+	var edges []graph.Edge
+
+	// Project nodes by dotted path, created on first use
+	projectIDs := make(map[string]string)
+	var projectFor func(name string) string
+	projectFor = func(name string) string {
+		if name == "" {
+			return rootID
+		}
+		if id, ok := projectIDs[name]; ok {
+			return id
+		}
+		parentID := rootID
+		short := name
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			parentID = projectFor(name[:i])
+			short = name[i+1:]
+		}
+		id := fmt.Sprintf("%s:%s", rootID, sanitizeID(name))
+		projectIDs[name] = id
+		nodes = append(nodes, localProjectNode(id, short, source, ""))
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:%s-owns-%s", parentID, id),
+			FromID:   parentID,
+			ToID:     id,
+			Relation: graph.EdgeOwns,
+			Metadata: graph.EdgeMetadata{CreatedAt: time.Now()},
+		})
+		return id
+	}
+
+	for _, task := range tasks {
+		data := map[string]interface{}{
+			"identifier": task.identifier,
+			"title":      task.title,
+			"status":     task.status,
+			"priority":   task.priority,
+			"labels":     task.labels,
+			"project":    task.project,
+		}
+		if task.due != "" {
+			data["due"] = task.due
+		}
+		dataJSON, _ := json.Marshal(data)
+
+		nodeID := fmt.Sprintf("issue:%s:%s", source, sanitizeID(task.key))
+		nodes = append(nodes, graph.Node{
+			ID:     nodeID,
+			Type:   graph.NodeTypeIssue,
+			Source: source,
+			Data:   dataJSON,
+			Metadata: graph.NodeMetadata{
+				CreatedAt:   task.createdAt,
+				UpdatedAt:   task.updatedAt,
+				AccessLevel: graph.RoleIC,
+				SyncedAt:    time.Now(),
+			},
+		})
+
+		// Edge: project owns task
+		parentID := projectFor(task.project)
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:%s-owns-%s", parentID, nodeID),
+			FromID:   parentID,
+			ToID:     nodeID,
+			Relation: graph.EdgeOwns,
+			Metadata: graph.EdgeMetadata{CreatedAt: task.createdAt},
+		})
+	}
+
+	return nodes, edges, nil
+}
+
+// localProjectNode creates a Project node for a task file or task project
+func localProjectNode(id, name, source, description string) graph.Node {
+	data := map[string]interface{}{
+		"name":        name,
+		"description": description,
+	}
+	dataJSON, _ := json.Marshal(data)
+
+	return graph.Node{
+		ID:     id,
+		Type:   graph.NodeTypeProject,
+		Source: source,
+		Data:   dataJSON,
+		Metadata: graph.NodeMetadata{
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+			AccessLevel: graph.RoleIC,
+			SyncedAt:    time.Now(),
+		},
+	}
+}
+
+// taskwarriorTask is the subset of `task export` fields MAAT reads
+type taskwarriorTask struct {
+	ID          int      `json:"id"`
+	UUID        string   `json:"uuid"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Project     string   `json:"project"`
+	Tags        []string `json:"tags"`
+	Priority    string   `json:"priority"`
+	Entry       string   `json:"entry"`
+	Modified    string   `json:"modified"`
+	Start       string   `json:"start"`
+	Due         string   `json:"due"`
+}
+
+// taskwarriorTime is Taskwarrior's compact UTC timestamp format
+const taskwarriorTime = "20060102T150405Z"
+
+// parseTaskwarrior converts a `task export` JSON array
+func parseTaskwarrior(content []byte) ([]localTask, error) {
+	var exported []taskwarriorTask
+	if err := json.Unmarshal(content, &exported); err != nil {
+		return nil, fmt.Errorf("parsing Taskwarrior export: %w", err)
+	}
+
+	tasks := make([]localTask, 0, len(exported))
+	for _, tw := range exported {
+		if tw.UUID == "" || tw.Status == "recurring" {
+			continue // Recurring templates spawn the real pending tasks
+		}
+
+		// Working IDs only exist for pending tasks; the UUID prefix is stable
+		identifier := "TW-" + tw.UUID[:min(8, len(tw.UUID))]
+		if tw.ID > 0 {
+			identifier = fmt.Sprintf("TW-%d", tw.ID)
+		}
+
+		var status string
+		switch tw.Status {
+		case "completed":
+			status = "Done"
+		case "deleted":
+			status = "Canceled"
+		case "waiting":
+			status = "Backlog"
+		default:
+			status = "Todo"
+			if tw.Start != "" {
+				status = "In Progress" // Started with `task start`
+			}
+		}
+
+		priority := 0
+		switch tw.Priority {
+		case "H":
+			priority = 2
+		case "M":
+			priority = 3
+		case "L":
+			priority = 4
+		}
+
+		createdAt, _ := time.Parse(taskwarriorTime, tw.Entry)
+		updatedAt, err := time.Parse(taskwarriorTime, tw.Modified)
+		if err != nil {
+			updatedAt = createdAt
+		}
+		var due string
+		if dueAt, err := time.Parse(taskwarriorTime, tw.Due); err == nil {
+			due = dueAt.Format("2006-01-02")
+		}
+
+		tasks = append(tasks, localTask{
+			key:        tw.UUID,
+			identifier: identifier,
+			title:      tw.Description,
+			status:     status,
+			priority:   priority,
+			project:    tw.Project,
+			labels:     tw.Tags,
+			due:        due,
+			createdAt:  createdAt,
+			updatedAt:  updatedAt,
+		})
+	}
+	return tasks, nil
+}
+
+// todoTxtDate matches a todo.txt date at the start of the remaining line
+var todoTxtDate = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}) `)
+
+// parseTodoTxt converts todo.txt lines:
+// "x 2024-01-02 2024-01-01 (A) Call mom +Family @phone due:2024-01-05".
+// modTime stands in for creation and update times the line doesn't record.
+func parseTodoTxt(content []byte, fileName string, modTime time.Time) []localTask {
+	var tasks []localTask
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		task := localTask{
+			key:        fmt.Sprintf("%s-%d", fileName, lineNo),
+			identifier: fmt.Sprintf("%s:%d", fileName, lineNo),
+			status:     "Todo",
+			createdAt:  modTime,
+			updatedAt:  modTime,
+		}
+
+		// Completion marker and completion date
+		if strings.HasPrefix(line, "x ") {
+			task.status = "Done"
+			line = strings.TrimSpace(line[2:])
+			if m := todoTxtDate.FindStringSubmatch(line); m != nil {
+				if done, err := time.Parse("2006-01-02", m[1]); err == nil {
+					task.updatedAt = done
+				}
+				line = line[len(m[0]):]
+			}
+		}
+		// Priority: (A) is most urgent
+		if len(line) >= 4 && line[0] == '(' && line[2] == ')' && line[3] == ' ' && line[1] >= 'A' && line[1] <= 'Z' {
+			task.priority = min(int(line[1]-'A')+1, 4)
+			line = line[4:]
+		}
+		// Creation date
+		if m := todoTxtDate.FindStringSubmatch(line); m != nil {
+			if created, err := time.Parse("2006-01-02", m[1]); err == nil {
+				task.createdAt = created
+			}
+			line = line[len(m[0]):]
+		}
+
+		// +project and @context tags; key:value extensions
+		var words []string
+		for _, word := range strings.Fields(line) {
+			switch {
+			case len(word) > 1 && word[0] == '+':
+				if task.project == "" {
+					task.project = word[1:]
+				} else {
+					task.labels = appendUnique(task.labels, word)
+				}
+			case len(word) > 1 && word[0] == '@':
+				task.labels = appendUnique(task.labels, word)
+			case strings.HasPrefix(word, "due:"):
+				task.due = strings.TrimPrefix(word, "due:")
+			default:
+				words = append(words, word)
+			}
+		}
+		task.title = strings.Join(words, " ")
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
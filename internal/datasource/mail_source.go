@@ -0,0 +1,391 @@
+package datasource
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// defaultMailLabel is the label that marks decision-relevant threads
+const defaultMailLabel = "decision"
+
+// mailLabelHeaders carry labels/keywords in Gmail exports, mutt and notmuch
+var mailLabelHeaders = []string{"X-Gmail-Labels", "X-Label", "X-Keywords", "Keywords"}
+
+// issueKeyPattern matches issue identifiers such as CET-352 in mail text
+var issueKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]{1,9}-\d+\b`)
+
+// replyPrefix matches the "Re:"/"Fwd:" chains clients prepend to subjects
+var replyPrefix = regexp.MustCompile(`(?i)^\s*((re|fw|fwd|aw|sv)(\[\d+\])?:\s*)+`)
+
+// MailSource reads a maildir or mbox and turns threads carrying a label into
+// Discussion nodes. Issue identifiers mentioned in a thread become "mentions"
+// references that the Loader resolves against tracked issues.
+type MailSource struct {
+	path  string
+	label string // Only threads with a message carrying this label ("" = all)
+}
+
+// NewMailSource creates a source for a maildir directory or mbox file
+func NewMailSource(path string) *MailSource {
+	return &MailSource{path: path, label: defaultMailLabel}
+}
+
+// SetLabel sets the label decision threads carry ("" ingests every thread)
+func (s *MailSource) SetLabel(label string) {
+	s.label = label
+}
+
+// Name returns the data source identifier
+func (s *MailSource) Name() string {
+	return "mail:" + filepath.Base(s.path)
+}
+
+// SupportsRefresh returns true - the mailbox is re-read from disk
+func (s *MailSource) SupportsRefresh() bool {
+	return true
+}
+
+// mailMessage is the part of a message a discussion needs
+type mailMessage struct {
+	id         string
+	references []string
+	subject    string
+	from       string
+	date       time.Time
+	labels     []string
+	body       string
+}
+
+// Load reads the mailbox and groups labelled messages into discussions
+func (s *MailSource) Load(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading mail: %w", err)
+	}
+
+	var raw [][]byte
+	if info.IsDir() {
+		raw, err = readMaildir(s.path)
+	} else {
+		raw, err = readMbox(s.path)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var messages []mailMessage
+	for _, r := range raw {
+		if msg, ok := parseMailMessage(r); ok {
+			messages = append(messages, msg)
+		}
+	}
+
+	var nodes []graph.Node
+	var edges []graph.Edge
+	for _, thread := range groupThreads(messages) {
+		if s.label != "" && !threadHasLabel(thread, s.label) {
+			continue
+		}
+		node, threadEdges := discussionNode(thread)
+		nodes = append(nodes, node)
+		edges = append(edges, threadEdges...)
+	}
+	return nodes, edges, nil
+}
+
+// readMaildir returns the messages in a maildir's cur and new folders
+func readMaildir(dir string) ([][]byte, error) {
+	var raw [][]byte
+	found := false
+	for _, sub := range []string{"cur", "new"} {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if err != nil {
+			continue
+		}
+		found = true
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			if data, err := os.ReadFile(filepath.Join(dir, sub, entry.Name())); err == nil {
+				raw = append(raw, data)
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("not a maildir (no cur/ or new/): %s", dir)
+	}
+	return raw, nil
+}
+
+// readMbox splits an mbox file on its "From " separator lines
+func readMbox(path string) ([][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading mbox: %w", err)
+	}
+	defer f.Close()
+
+	var raw [][]byte
+	var current bytes.Buffer
+	flush := func() {
+		if current.Len() > 0 {
+			raw = append(raw, append([]byte(nil), current.Bytes()...))
+			current.Reset()
+		}
+	}
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if bytes.HasPrefix(line, []byte("From ")) {
+			flush()
+		} else if len(line) > 0 {
+			// mboxrd quotes body lines starting with "From " as ">From "
+			if bytes.HasPrefix(bytes.TrimLeft(line, ">"), []byte("From ")) {
+				line = line[1:]
+			}
+			current.Write(line)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading mbox: %w", err)
+		}
+	}
+	flush()
+	return raw, nil
+}
+
+// parseMailMessage extracts headers and the plain-text body of a message
+func parseMailMessage(raw []byte) (mailMessage, bool) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return mailMessage{}, false
+	}
+	decoder := new(mime.WordDecoder)
+	decode := func(s string) string {
+		if decoded, err := decoder.DecodeHeader(s); err == nil {
+			return decoded
+		}
+		return s
+	}
+
+	m := mailMessage{
+		id:      strings.Trim(msg.Header.Get("Message-ID"), "<> "),
+		subject: decode(msg.Header.Get("Subject")),
+	}
+	m.references = messageIDs(msg.Header.Get("References") + " " + msg.Header.Get("In-Reply-To"))
+	if from, err := mail.ParseAddress(msg.Header.Get("From")); err == nil {
+		m.from = from.Name
+		if m.from == "" {
+			m.from = from.Address
+		}
+	} else {
+		m.from = decode(msg.Header.Get("From"))
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		m.date = date
+	}
+	for _, header := range mailLabelHeaders {
+		for _, label := range strings.FieldsFunc(decode(msg.Header.Get(header)), func(r rune) bool { return r == ',' }) {
+			m.labels = appendUnique(m.labels, strings.TrimSpace(label))
+		}
+	}
+	m.body = plainBody(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	return m, true
+}
+
+// messageIDs extracts the <id> tokens of a References/In-Reply-To header
+func messageIDs(header string) []string {
+	var ids []string
+	for _, field := range strings.Fields(header) {
+		if id := strings.Trim(field, "<>,"); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// plainBody returns the text/plain content of a (possibly multipart) body
+func plainBody(contentType, encoding string, body io.Reader) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		var text []string
+		parts := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := parts.NextPart()
+			if err != nil {
+				break
+			}
+			if t := plainBody(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part); t != "" {
+				text = append(text, t)
+			}
+		}
+		return strings.Join(text, "\n")
+	}
+	if mediaType != "text/plain" {
+		return ""
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	data, _ := io.ReadAll(io.LimitReader(body, 1<<20))
+	return string(data)
+}
+
+// normalizeSubject drops reply prefixes so replies share their thread's subject
+func normalizeSubject(subject string) string {
+	return strings.TrimSpace(replyPrefix.ReplaceAllString(subject, ""))
+}
+
+// groupThreads groups messages that reference each other or share a
+// normalized subject, ordering each thread oldest first
+func groupThreads(messages []mailMessage) [][]mailMessage {
+	uf := newUnionFind(len(messages))
+	byKey := make(map[string]int)
+	link := func(i int, key string) {
+		if j, ok := byKey[key]; ok {
+			uf.union(i, j)
+		} else {
+			byKey[key] = i
+		}
+	}
+	for i, m := range messages {
+		if m.id != "" {
+			link(i, "id:"+m.id)
+		}
+		if subject := strings.ToLower(normalizeSubject(m.subject)); subject != "" {
+			link(i, "subject:"+subject)
+		}
+	}
+	for i, m := range messages {
+		for _, ref := range m.references {
+			link(i, "id:"+ref)
+		}
+	}
+
+	groups := make(map[int][]mailMessage)
+	var roots []int
+	for i, m := range messages {
+		root := uf.find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], m)
+	}
+	threads := make([][]mailMessage, 0, len(roots))
+	for _, root := range roots {
+		thread := groups[root]
+		sort.SliceStable(thread, func(i, j int) bool { return thread[i].date.Before(thread[j].date) })
+		threads = append(threads, thread)
+	}
+	return threads
+}
+
+// threadHasLabel reports whether any message in the thread carries label
+func threadHasLabel(thread []mailMessage, label string) bool {
+	for _, m := range thread {
+		for _, l := range m.labels {
+			if strings.EqualFold(l, label) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// discussionNode creates a Discussion node for a thread and "mentions"
+// references to the issue identifiers it contains
+func discussionNode(thread []mailMessage) (graph.Node, []graph.Edge) {
+	first, last := thread[0], thread[len(thread)-1]
+
+	var participants, labels []string
+	mentioned := make(map[string]bool)
+	var keys []string
+	for _, m := range thread {
+		participants = appendUnique(participants, m.from)
+		for _, label := range m.labels {
+			labels = appendUnique(labels, label)
+		}
+		for _, key := range issueKeyPattern.FindAllString(m.subject+"\n"+m.body, -1) {
+			if !mentioned[key] {
+				mentioned[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	count := fmt.Sprintf("%d messages", len(thread))
+	if len(thread) == 1 {
+		count = "1 message"
+	}
+	data := map[string]interface{}{
+		"title":        normalizeSubject(first.subject),
+		"description":  count + " · " + strings.Join(participants, ", "),
+		"participants": participants,
+		"messages":     len(thread),
+		"labels":       labels,
+	}
+	dataJSON, _ := json.Marshal(data)
+
+	// Thread identity: the first message's ID, or its subject when it has none
+	rootKey := first.id
+	if rootKey == "" {
+		rootKey = normalizeSubject(first.subject)
+	}
+	sum := sha1.Sum([]byte(rootKey))
+	nodeID := "discussion:mail:" + hex.EncodeToString(sum[:6])
+
+	node := graph.Node{
+		ID:     nodeID,
+		Type:   graph.NodeTypeDiscussion,
+		Source: "mail",
+		Data:   dataJSON,
+		Metadata: graph.NodeMetadata{
+			CreatedAt:   first.date,
+			UpdatedAt:   last.date,
+			CreatedBy:   "mail",
+			AccessLevel: graph.RoleIC,
+			SyncedAt:    time.Now(),
+		},
+	}
+
+	edges := make([]graph.Edge, 0, len(keys))
+	for _, key := range keys {
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:%s-mentions-%s", nodeID, key),
+			FromID:   nodeID,
+			ToID:     RefID(key),
+			Relation: graph.EdgeMentions,
+			Metadata: graph.EdgeMetadata{CreatedAt: last.date},
+		})
+	}
+	return node, edges
+}
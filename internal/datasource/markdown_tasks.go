@@ -0,0 +1,142 @@
+package datasource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+	"gopkg.in/yaml.v3"
+)
+
+// markdownSource marks Task nodes read from Markdown checkbox lists
+const markdownSource = "markdown"
+
+// taskPattern matches a checkbox list item: "- [ ] write docs", "* [x] ship"
+var taskPattern = regexp.MustCompile(`^(\s*)[-*+] \[([ xX])\] +(.+?)\s*$`)
+
+// markdownTask is one checkbox item in a Markdown file
+type markdownTask struct {
+	Line   int // 1-based
+	Indent int // Leading whitespace width; deeper items are subtasks
+	Done   bool
+	Text   string
+}
+
+// parseFrontmatter reads a leading "---" YAML block. It returns nil when the
+// file has none or the block doesn't parse - frontmatter is optional metadata.
+func parseFrontmatter(content []byte) map[string]interface{} {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(content, []byte("---\n")) {
+		return nil
+	}
+	end := bytes.Index(content[4:], []byte("\n---"))
+	if end < 0 {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(content[4:4+end], &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// addFrontmatter records a Markdown file's frontmatter status in node data
+func addFrontmatter(data map[string]interface{}, content []byte) {
+	if status, ok := parseFrontmatter(content)["status"].(string); ok && status != "" {
+		data["status"] = status
+	}
+}
+
+// extractTasks finds checkbox items outside fenced code blocks
+func extractTasks(content []byte) []markdownTask {
+	var tasks []markdownTask
+	inFence := false
+	for i, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		match := taskPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		tasks = append(tasks, markdownTask{
+			Line:   i + 1,
+			Indent: len(strings.ReplaceAll(match[1], "\t", "    ")),
+			Done:   match[2] != " ",
+			Text:   match[3],
+		})
+	}
+	return tasks
+}
+
+// taskNodes turns a file's checkbox items into Task nodes. Top-level items
+// hang off the file; indented items off the item above them.
+func taskNodes(relPath, fileID string, tasks []markdownTask, modTime time.Time) ([]graph.Node, []graph.Edge) {
+	var nodes []graph.Node
+	var edges []graph.Edge
+
+	// Open ancestors, innermost last
+	type ancestor struct {
+		id     string
+		indent int
+	}
+	var stack []ancestor
+
+	for _, task := range tasks {
+		status := "Todo"
+		if task.Done {
+			status = "Done"
+		}
+		data := map[string]interface{}{
+			"title":      task.Text,
+			"identifier": fmt.Sprintf("%s:%d", relPath, task.Line),
+			"status":     status,
+			"path":       relPath,
+			"line":       task.Line,
+		}
+		dataJSON, _ := json.Marshal(data)
+
+		nodeID := fmt.Sprintf("task:%s:%d", sanitizeID(relPath), task.Line)
+		nodes = append(nodes, graph.Node{
+			ID:     nodeID,
+			Type:   graph.NodeTypeTask,
+			Source: markdownSource,
+			Data:   dataJSON,
+			Metadata: graph.NodeMetadata{
+				CreatedAt:   modTime,
+				UpdatedAt:   modTime,
+				CreatedBy:   "file-scanner",
+				AccessLevel: graph.RoleIC,
+				SyncedAt:    time.Now(),
+			},
+		})
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= task.Indent {
+			stack = stack[:len(stack)-1]
+		}
+		parentID := fileID
+		if len(stack) > 0 {
+			parentID = stack[len(stack)-1].id
+		}
+		stack = append(stack, ancestor{id: nodeID, indent: task.Indent})
+
+		// Edge: file (or parent task) owns the task
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:task:%s:%d", sanitizeID(relPath), task.Line),
+			FromID:   parentID,
+			ToID:     nodeID,
+			Relation: graph.EdgeOwns,
+			Metadata: graph.EdgeMetadata{CreatedAt: modTime},
+		})
+	}
+	return nodes, edges
+}
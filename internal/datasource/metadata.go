@@ -0,0 +1,144 @@
+package datasource
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// licenseSignatures identify a license text by phrases only it contains,
+// most specific first (the LGPL text mentions the GPL, BSD-3 extends BSD-2)
+var licenseSignatures = []struct {
+	id      string
+	phrases []string
+}{
+	{"AGPL-3.0", []string{"gnu affero general public license"}},
+	{"LGPL-3.0", []string{"gnu lesser general public license", "version 3"}},
+	{"LGPL-2.1", []string{"gnu lesser general public license", "version 2.1"}},
+	{"GPL-3.0", []string{"gnu general public license", "version 3"}},
+	{"GPL-2.0", []string{"gnu general public license", "version 2"}},
+	{"Apache-2.0", []string{"apache license", "version 2.0"}},
+	{"MPL-2.0", []string{"mozilla public license", "2.0"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{"BSD-2-Clause", []string{"redistribution and use in source and binary forms"}},
+	{"ISC", []string{"permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{"MIT", []string{"permission is hereby granted, free of charge"}},
+	{"Unlicense", []string{"this is free and unencumbered software released into the public domain"}},
+}
+
+// licenseFile matches the files a project keeps its license in
+var licenseFile = regexp.MustCompile(`(?i)^(?:licen[cs]e|copying)(?:[.-][\w.-]+)?$`)
+
+// spdxHeader matches a source file's SPDX license tag
+var spdxHeader = regexp.MustCompile(`SPDX-License-Identifier:\s*([A-Za-z0-9.+-]+(?:\s+(?:AND|OR|WITH)\s+[A-Za-z0-9.+-]+)*)`)
+
+// spdxHeaderWindow is how much of a file is searched for an SPDX tag
+const spdxHeaderWindow = 2048
+
+// detectLicense names the license a license text grants (an SPDX ID), or ""
+func detectLicense(text []byte) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(string(text)), " "))
+	for _, signature := range licenseSignatures {
+		matches := true
+		for _, phrase := range signature.phrases {
+			if !strings.Contains(normalized, phrase) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return signature.id
+		}
+	}
+	return ""
+}
+
+// addFileMetadata records what a scanned file declares: the SPDX tag in its
+// header, the license a license file grants, a package.json's package
+func addFileMetadata(data map[string]interface{}, relPath string, content []byte) {
+	name := filepath.Base(relPath)
+	switch {
+	case name == "package.json":
+		addPackageJSON(data, content)
+	case licenseFile.MatchString(name):
+		if license := detectLicense(content); license != "" {
+			data["license"] = license
+		}
+	default:
+		if match := spdxHeader.FindSubmatch(content[:min(len(content), spdxHeaderWindow)]); match != nil {
+			data["license"] = string(match[1])
+		}
+	}
+}
+
+// addManifestMetadata records what a project or directory declares about
+// itself: its license (LICENSE, COPYING), Go module path and npm package
+func addManifestMetadata(data map[string]interface{}, dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		switch {
+		case name == "go.mod":
+			if content, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+				if module := goModulePath(content); module != "" {
+					data["module"] = module
+				}
+			}
+		case name == "package.json":
+			if content, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+				addPackageJSON(data, content)
+			}
+		case licenseFile.MatchString(name) && data["license"] == nil:
+			if content, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+				if license := detectLicense(content); license != "" {
+					data["license"] = license
+					data["license_file"] = name
+				}
+			}
+		}
+	}
+}
+
+// addPackageJSON records a package.json's name, version and declared
+// license (a license file found alongside takes precedence)
+func addPackageJSON(data map[string]interface{}, content []byte) {
+	var pkg struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		License string `json:"license"`
+	}
+	if json.Unmarshal(content, &pkg) != nil {
+		return
+	}
+	if pkg.Name != "" {
+		data["package"] = pkg.Name
+	}
+	if pkg.Version != "" {
+		data["package_version"] = pkg.Version
+	}
+	if pkg.License != "" && data["license"] == nil {
+		data["license"] = pkg.License
+	}
+}
+
+// goModulePath reads the module path a go.mod declares
+func goModulePath(content []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
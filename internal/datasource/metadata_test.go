@@ -0,0 +1,48 @@
+package datasource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestManifestMetadataDetectsLicenseModuleAndPackage checks a directory's
+// license text, go.mod and package.json are read, and that source files
+// report the SPDX tag in their header.
+func TestManifestMetadataDetectsLicenseModuleAndPackage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"LICENSE":      "MIT License\n\nCopyright (c) 2024 Ann\n\nPermission is hereby granted, free of charge,\nto any person obtaining a copy",
+		"go.mod":       "module github.com/example/app\n\ngo 1.22\n",
+		"package.json": `{"name":"@example/web","version":"2.1.0","license":"Apache-2.0"}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data := map[string]interface{}{}
+	addManifestMetadata(data, dir)
+	want := map[string]interface{}{
+		"license":         "MIT", // The license file wins over package.json's field
+		"license_file":    "LICENSE",
+		"module":          "github.com/example/app",
+		"package":         "@example/web",
+		"package_version": "2.1.0",
+	}
+	for key, value := range want {
+		if data[key] != value {
+			t.Errorf("%s = %v, want %v", key, data[key], value)
+		}
+	}
+
+	source := map[string]interface{}{}
+	addFileMetadata(source, "cmd/main.go", []byte("// SPDX-License-Identifier: Apache-2.0 OR MIT\npackage main\n"))
+	if source["license"] != "Apache-2.0 OR MIT" {
+		t.Errorf("SPDX license = %v", source["license"])
+	}
+	if got := detectLicense([]byte("GNU LESSER GENERAL PUBLIC LICENSE\n   Version 3, 29 June 2007 ... GNU General Public License")); got != "LGPL-3.0" {
+		t.Errorf("detectLicense(LGPL) = %q", got)
+	}
+}
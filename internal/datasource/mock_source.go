@@ -4,15 +4,18 @@ import (
 	"context"
 
 	"github.com/manutej/maat-terminal/internal/graph"
-	"github.com/manutej/maat-terminal/internal/tui"
+	"github.com/manutej/maat-terminal/internal/mockgraph"
 )
 
-// MockSource provides the existing mock data for testing/demo purposes.
-type MockSource struct{}
+// MockSource provides a generated graph for demos and benchmarks.
+type MockSource struct {
+	opts mockgraph.Options
+}
 
-// NewMockSource creates a mock data source
-func NewMockSource() *MockSource {
-	return &MockSource{}
+// NewMockSource creates a mock data source generating a graph shaped by opts
+// (zero fields take the demo defaults)
+func NewMockSource(opts mockgraph.Options) *MockSource {
+	return &MockSource{opts: opts}
 }
 
 // Name returns the data source identifier
@@ -25,8 +28,11 @@ func (m *MockSource) SupportsRefresh() bool {
 	return false
 }
 
-// Load returns the existing mock graph data
+// Load generates the mock graph
 func (m *MockSource) Load(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
-	nodes, edges := tui.GetMockGraph()
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	nodes, edges := mockgraph.Generate(m.opts)
 	return nodes, edges, nil
 }
@@ -0,0 +1,285 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// wikiLinkPattern matches [[target]], [[target#heading]], [[target|alias]]
+// and embeds (![[target]]); the first group is the link target
+var wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]|#^]+)(?:[#^][^\[\]|]*)?(?:\|[^\[\]]*)?\]\]`)
+
+// tagPattern matches inline #tags; a tag needs at least one non-digit
+var tagPattern = regexp.MustCompile(`(?:^|\s)#([\w/-]*[A-Za-z_/-][\w/-]*)`)
+
+// ObsidianSource reads an Obsidian vault: each note becomes a Document node,
+// [[wiki-links]] between notes become "related" edges and #tags become labels.
+// Links to things outside the vault ([[CET-352]], [[internal/tui/view.go]])
+// become "mentions" references that the Loader resolves against other sources.
+type ObsidianSource struct {
+	vaultPath string
+	maxNotes  int
+}
+
+// NewObsidianSource creates a source for the vault at vaultPath
+func NewObsidianSource(vaultPath string) *ObsidianSource {
+	return &ObsidianSource{
+		vaultPath: vaultPath,
+		maxNotes:  1000, // Limit for performance
+	}
+}
+
+// SetMaxNotes sets the maximum number of notes to read
+func (o *ObsidianSource) SetMaxNotes(n int) {
+	o.maxNotes = n
+}
+
+// Name returns the data source identifier
+func (o *ObsidianSource) Name() string {
+	return "obsidian:" + filepath.Base(o.vaultPath)
+}
+
+// SupportsRefresh returns true - the vault is re-read from disk
+func (o *ObsidianSource) SupportsRefresh() bool {
+	return true
+}
+
+// obsidianNote is a parsed note before links are resolved
+type obsidianNote struct {
+	relPath string // Slash-separated, without the .md extension
+	nodeID  string
+	links   []string
+}
+
+// Load reads every note in the vault
+func (o *ObsidianSource) Load(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
+	info, err := os.Stat(o.vaultPath)
+	if err != nil || !info.IsDir() {
+		return nil, nil, fmt.Errorf("not a vault directory: %s", o.vaultPath)
+	}
+
+	vaultName := filepath.Base(o.vaultPath)
+	vaultID := fmt.Sprintf("project:obsidian:%s", sanitizeID(vaultName))
+	nodes := []graph.Node{o.createVaultNode(vaultID, vaultName)}
+	var edges []graph.Edge
+
+	var notes []obsidianNote
+	byName := make(map[string]string) // Lowercased note name, path or alias -> node ID
+
+	err = filepath.Walk(o.vaultPath, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip errors, continue walking
+		}
+		if info.IsDir() {
+			// .obsidian holds settings, .trash deleted notes
+			if p != o.vaultPath && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if len(notes) >= o.maxNotes {
+			return filepath.SkipAll
+		}
+		if !strings.EqualFold(filepath.Ext(p), ".md") {
+			return nil
+		}
+
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(o.vaultPath, p)
+		rel = filepath.ToSlash(strings.TrimSuffix(rel, filepath.Ext(rel)))
+
+		node, aliases, links := o.createNoteNode(vaultName, rel, content, info)
+		nodes = append(nodes, node)
+		notes = append(notes, obsidianNote{relPath: rel, nodeID: node.ID, links: links})
+
+		// Obsidian resolves links by note name, full path or alias
+		for _, name := range append([]string{path.Base(rel), rel}, aliases...) {
+			if _, taken := byName[strings.ToLower(name)]; !taken {
+				byName[strings.ToLower(name)] = node.ID
+			}
+		}
+
+		// Edge: vault owns note
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:vault-note:%s", node.ID),
+			FromID:   vaultID,
+			ToID:     node.ID,
+			Relation: graph.EdgeOwns,
+			Metadata: graph.EdgeMetadata{CreatedAt: info.ModTime()},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("walk failed: %w", err)
+	}
+
+	for _, note := range notes {
+		seen := make(map[string]bool)
+		for _, link := range note.links {
+			target := strings.TrimSuffix(strings.TrimSpace(link), ".md")
+			if target == "" || seen[strings.ToLower(target)] {
+				continue
+			}
+			seen[strings.ToLower(target)] = true
+
+			if targetID, ok := byName[strings.ToLower(target)]; ok {
+				if targetID == note.nodeID {
+					continue
+				}
+				// Edge: note links to another note
+				edges = append(edges, graph.Edge{
+					ID:       fmt.Sprintf("edge:wikilink:%s:%s", note.nodeID, targetID),
+					FromID:   note.nodeID,
+					ToID:     targetID,
+					Relation: graph.EdgeRelated,
+					Metadata: graph.EdgeMetadata{CreatedAt: time.Now()},
+				})
+				continue
+			}
+			// Not a note: maybe an issue key or file path from another source
+			edges = append(edges, graph.Edge{
+				ID:       fmt.Sprintf("edge:wikilink:%s:%s", note.nodeID, sanitizeID(target)),
+				FromID:   note.nodeID,
+				ToID:     RefID(target),
+				Relation: graph.EdgeMentions,
+				Metadata: graph.EdgeMetadata{CreatedAt: time.Now()},
+			})
+		}
+	}
+
+	return nodes, edges, nil
+}
+
+// Content reads a note in the vault. Notes of other vaults return ErrNoContent.
+func (o *ObsidianSource) Content(ctx context.Context, node graph.Node) ([]byte, error) {
+	if node.Type != graph.NodeTypeDocument {
+		return nil, ErrNoContent
+	}
+	var data struct {
+		Path  string `json:"path"`
+		Vault string `json:"vault"`
+	}
+	if err := json.Unmarshal(node.Data, &data); err != nil || data.Path == "" || data.Vault != filepath.Base(o.vaultPath) {
+		return nil, ErrNoContent
+	}
+	return readPreview(o.vaultPath, filepath.FromSlash(data.Path))
+}
+
+// createVaultNode creates the project node notes hang off
+func (o *ObsidianSource) createVaultNode(vaultID, vaultName string) graph.Node {
+	data := map[string]interface{}{
+		"name":        vaultName,
+		"description": fmt.Sprintf("Obsidian vault at %s", o.vaultPath),
+		"path":        o.vaultPath,
+	}
+	dataJSON, _ := json.Marshal(data)
+
+	return graph.Node{
+		ID:     vaultID,
+		Type:   graph.NodeTypeProject,
+		Source: "obsidian",
+		Data:   dataJSON,
+		Metadata: graph.NodeMetadata{
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+			CreatedBy:   "obsidian",
+			AccessLevel: graph.RoleIC,
+			SyncedAt:    time.Now(),
+		},
+	}
+}
+
+// createNoteNode creates a Document node for a note and returns its aliases
+// and the raw targets of its wiki-links
+func (o *ObsidianSource) createNoteNode(vaultName, rel string, content []byte, info os.FileInfo) (graph.Node, []string, []string) {
+	frontmatter := parseFrontmatter(content)
+	aliases := frontmatterList(frontmatter, "aliases")
+
+	// Tags from frontmatter and inline #tags outside code blocks
+	var tags []string
+	for _, tag := range frontmatterList(frontmatter, "tags") {
+		tags = appendUnique(tags, strings.TrimPrefix(tag, "#"))
+	}
+	var links []string
+	inFence := false
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, match := range wikiLinkPattern.FindAllStringSubmatch(line, -1) {
+			links = append(links, match[1])
+		}
+		for _, match := range tagPattern.FindAllStringSubmatch(line, -1) {
+			tags = appendUnique(tags, match[1])
+		}
+	}
+	sort.Strings(tags)
+
+	data := map[string]interface{}{
+		"title":  path.Base(rel),
+		"path":   rel + ".md",
+		"vault":  vaultName,
+		"labels": tags,
+		"url":    fmt.Sprintf("obsidian://open?vault=%s&file=%s", url.QueryEscape(vaultName), url.QueryEscape(rel)),
+	}
+	if len(aliases) > 0 {
+		data["aliases"] = aliases
+	}
+	if status, ok := frontmatter["status"].(string); ok && status != "" {
+		data["status"] = status
+	}
+	dataJSON, _ := json.Marshal(data)
+
+	node := graph.Node{
+		ID:     fmt.Sprintf("doc:%s:%s", sanitizeID(vaultName), sanitizeID(rel)),
+		Type:   graph.NodeTypeDocument,
+		Source: "obsidian",
+		Data:   dataJSON,
+		Metadata: graph.NodeMetadata{
+			CreatedAt:   info.ModTime(),
+			UpdatedAt:   info.ModTime(),
+			CreatedBy:   "obsidian",
+			AccessLevel: graph.RoleIC,
+			SyncedAt:    time.Now(),
+		},
+	}
+	return node, aliases, links
+}
+
+// frontmatterList reads a frontmatter field that may be a list or a single
+// (comma-separated) string, as Obsidian accepts both for tags and aliases
+func frontmatterList(frontmatter map[string]interface{}, key string) []string {
+	var values []string
+	switch v := frontmatter[key].(type) {
+	case string:
+		for _, s := range strings.Split(v, ",") {
+			values = appendUnique(values, strings.TrimSpace(s))
+		}
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = appendUnique(values, strings.TrimSpace(s))
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,111 @@
+package datasource
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+)
+
+// defaultOwnershipHistory is how many recent commits ownership inference reads
+const defaultOwnershipHistory = 200
+
+// authorCounts tallies commits per author for one file or directory
+type authorCounts map[string]int
+
+// ownership is the inferred owner of a path: the author of most recent commits
+type ownership struct {
+	Owner   string
+	Share   float64 // Fraction of the path's commits by Owner
+	Commits int     // Commits touching the path within the history window
+}
+
+// owner picks the majority author (ties broken alphabetically)
+func (c authorCounts) owner() ownership {
+	authors := make([]string, 0, len(c))
+	total := 0
+	for author, n := range c {
+		authors = append(authors, author)
+		total += n
+	}
+	if total == 0 {
+		return ownership{}
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if c[authors[i]] != c[authors[j]] {
+			return c[authors[i]] > c[authors[j]]
+		}
+		return authors[i] < authors[j]
+	})
+	return ownership{
+		Owner:   authors[0],
+		Share:   float64(c[authors[0]]) / float64(total),
+		Commits: total,
+	}
+}
+
+// inferOwnership reads the last n commits touching rootPath and returns the
+// inferred owner of every file and directory they touched, keyed by
+// slash-separated path relative to rootPath. It returns nil outside a git
+// repository - ownership is best-effort metadata.
+func inferOwnership(rootPath string, n int) map[string]ownership {
+	if n <= 0 {
+		return nil
+	}
+
+	// Each commit is "\x00author" followed by the paths it touched
+	cmd := exec.Command("git", "-C", rootPath, "log", "--relative", "--no-merges",
+		fmt.Sprintf("--max-count=%d", n), "--format=%x00%an", "--name-only")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	counts := make(map[string]authorCounts)
+	tally := func(p, author string) {
+		if counts[p] == nil {
+			counts[p] = make(authorCounts)
+		}
+		counts[p][author]++
+	}
+
+	for _, commit := range strings.Split(string(output), "\x00") {
+		lines := strings.Split(strings.TrimSpace(commit), "\n")
+		author := strings.TrimSpace(lines[0])
+		if author == "" {
+			continue
+		}
+
+		// A commit counts once per directory, however many files it touched there
+		dirs := make(map[string]bool)
+		for _, file := range lines[1:] {
+			file = strings.TrimSpace(file)
+			if file == "" {
+				continue
+			}
+			tally(file, author)
+			for dir := path.Dir(file); dir != "." && dir != "/" && !dirs[dir]; dir = path.Dir(dir) {
+				dirs[dir] = true
+				tally(dir, author)
+			}
+		}
+	}
+
+	owners := make(map[string]ownership, len(counts))
+	for p, c := range counts {
+		owners[p] = c.owner()
+	}
+	return owners
+}
+
+// addOwnership records an inferred owner in node data (no-op when unknown)
+func addOwnership(data map[string]interface{}, o ownership) {
+	if o.Owner == "" {
+		return
+	}
+	data["owner"] = o.Owner
+	data["owner_share"] = math.Round(o.Share*100) / 100
+	data["owner_commits"] = o.Commits
+}
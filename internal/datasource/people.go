@@ -0,0 +1,131 @@
+package datasource
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// people collects the Person and Team nodes a source references, once each.
+// IDs are shared across sources (see graph.PersonID), so the loader merges
+// the same person seen by git, Linear and GitHub into a single node; the
+// account identifiers collected here let it also merge differing names.
+type people struct {
+	source  string
+	order   []string                     // Node IDs in first-seen order
+	persons map[string]*graph.PersonData // Person ID -> merged identifiers
+	teams   map[string]string            // Team ID -> name
+}
+
+// newPeople creates an empty collector for the named source
+func newPeople(source string) *people {
+	return &people{
+		source:  source,
+		persons: make(map[string]*graph.PersonData),
+		teams:   make(map[string]string),
+	}
+}
+
+// person registers a person and returns their node ID ("" for an empty name)
+func (p *people) person(name string) string {
+	return p.personFrom(graph.PersonData{Name: name})
+}
+
+// personFrom registers a person with account identifiers, merging them into
+// any earlier entry for the same name
+func (p *people) personFrom(data graph.PersonData) string {
+	if strings.TrimSpace(data.Name) == "" {
+		return ""
+	}
+	data.Name = strings.TrimPrefix(data.Name, "@")
+	id := graph.PersonID(data.Name)
+	existing, ok := p.persons[id]
+	if !ok {
+		p.order = append(p.order, id)
+		p.persons[id] = &data
+		return id
+	}
+	for _, email := range data.Emails {
+		existing.Emails = appendUnique(existing.Emails, email)
+	}
+	if existing.GitHub == "" {
+		existing.GitHub = data.GitHub
+	}
+	if existing.Linear == "" {
+		existing.Linear = data.Linear
+	}
+	return id
+}
+
+// team registers a team and returns its node ID
+func (p *people) team(name string) string {
+	id := graph.TeamID(name)
+	if _, ok := p.teams[id]; !ok {
+		p.order = append(p.order, id)
+		p.teams[id] = name
+	}
+	return id
+}
+
+// login registers a GitHub-style owner: "@org/team" is a team, "@login" a
+// GitHub user and anything else an email address
+func (p *people) login(login string) string {
+	switch {
+	case graph.IsTeamRef(login):
+		return p.team(login)
+	case strings.HasPrefix(login, "@"):
+		return p.personFrom(graph.PersonData{Name: login, GitHub: strings.TrimPrefix(login, "@")})
+	default:
+		return p.personFrom(graph.PersonData{Name: login, Emails: []string{login}})
+	}
+}
+
+// nodes returns the collected Person and Team nodes
+func (p *people) nodes() []graph.Node {
+	nodes := make([]graph.Node, 0, len(p.order))
+	for _, id := range p.order {
+		if data, ok := p.persons[id]; ok {
+			nodes = append(nodes, graph.NewPersonNodeFrom(*data, p.source))
+		} else {
+			nodes = append(nodes, graph.NewTeamNode(p.teams[id], p.source))
+		}
+	}
+	return nodes
+}
+
+// appendUnique appends s unless it is empty or already present (case-insensitive)
+func appendUnique(list []string, s string) []string {
+	if s == "" {
+		return list
+	}
+	for _, existing := range list {
+		if strings.EqualFold(existing, s) {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// authoredEdge links a person to a commit or PR they wrote
+func authoredEdge(personID, workID string, at time.Time) graph.Edge {
+	return graph.Edge{
+		ID:       fmt.Sprintf("edge:authored:%s:%s", personID, workID),
+		FromID:   personID,
+		ToID:     workID,
+		Relation: graph.EdgeAuthored,
+		Metadata: graph.EdgeMetadata{CreatedAt: at},
+	}
+}
+
+// assignedEdge links an issue or PR to the person or team responsible for it
+func assignedEdge(workID, assigneeID string, at time.Time) graph.Edge {
+	return graph.Edge{
+		ID:       fmt.Sprintf("edge:assigned:%s:%s", workID, assigneeID),
+		FromID:   workID,
+		ToID:     assigneeID,
+		Relation: graph.EdgeAssignedTo,
+		Metadata: graph.EdgeMetadata{CreatedAt: at},
+	}
+}
@@ -0,0 +1,171 @@
+package datasource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// defaultRedaction replaces masked text unless a rule says otherwise
+const defaultRedaction = "[redacted]"
+
+// redactPresets are named pattern sets for rules that do not spell one out
+var redactPresets = map[string]string{
+	"secrets": strings.Join([]string{
+		`gh[pousr]_[A-Za-z0-9]{36,}`,   // GitHub tokens
+		`github_pat_[A-Za-z0-9_]{22,}`, // GitHub fine-grained tokens
+		`lin_api_[A-Za-z0-9]{32,}`,     // Linear API keys
+		`xox[abprs]-[A-Za-z0-9-]{10,}`, // Slack tokens
+		`(?:AKIA|ASIA)[0-9A-Z]{16}`,    // AWS access key IDs
+		`sk-[A-Za-z0-9_-]{20,}`,        // OpenAI-style secret keys
+		`(?i)bearer\s+[A-Za-z0-9._~+/-]{20,}=*`,
+		`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+	}, "|"),
+}
+
+// redactRule is a compiled config.RedactRule
+type redactRule struct {
+	pattern *regexp.Regexp // nil replaces whole values
+	fields  map[string]bool
+	types   map[graph.NodeType]bool
+	replace string
+}
+
+// Redactor masks sensitive text in node data before it leaves the loader.
+type Redactor struct {
+	rules []redactRule
+}
+
+// NewRedactor compiles redaction rules. Invalid rules are an error rather
+// than skipped: a typo must not quietly let secrets through.
+func NewRedactor(rules []config.RedactRule) (*Redactor, error) {
+	r := &Redactor{}
+	for i, rule := range rules {
+		compiled := redactRule{replace: rule.Replace}
+		if compiled.replace == "" {
+			compiled.replace = defaultRedaction
+		}
+
+		pattern := rule.Pattern
+		if rule.Preset != "" {
+			preset, ok := redactPresets[rule.Preset]
+			if !ok {
+				return nil, fmt.Errorf("redact rule %d: unknown preset %q", i+1, rule.Preset)
+			}
+			if pattern != "" {
+				pattern = preset + "|" + pattern
+			} else {
+				pattern = preset
+			}
+		}
+		if pattern != "" {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("redact rule %d: %w", i+1, err)
+			}
+			compiled.pattern = re
+		} else if len(rule.Fields) == 0 {
+			return nil, fmt.Errorf("redact rule %d: needs a pattern, a preset or fields", i+1)
+		}
+
+		if len(rule.Fields) > 0 {
+			compiled.fields = make(map[string]bool, len(rule.Fields))
+			for _, field := range rule.Fields {
+				compiled.fields[strings.ToLower(field)] = true
+			}
+		}
+		if len(rule.Types) > 0 {
+			compiled.types = make(map[graph.NodeType]bool, len(rule.Types))
+			for _, t := range rule.Types {
+				compiled.types[graph.NodeType(t)] = true
+			}
+		}
+		r.rules = append(r.rules, compiled)
+	}
+	return r, nil
+}
+
+// Redact returns node with every rule applied to its data. Data that no rule
+// changes is returned byte for byte.
+func (r *Redactor) Redact(node graph.Node) graph.Node {
+	if r == nil || len(r.rules) == 0 || len(node.Data) == 0 {
+		return node
+	}
+	dec := json.NewDecoder(bytes.NewReader(node.Data))
+	dec.UseNumber() // Keep large IDs and timestamps exact
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return node
+	}
+
+	changed := false
+	for _, rule := range r.rules {
+		if rule.types != nil && !rule.types[node.Type] {
+			continue
+		}
+		var ruleChanged bool
+		data, ruleChanged = rule.apply(data, len(rule.fields) == 0)
+		changed = changed || ruleChanged
+	}
+	if !changed {
+		return node
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(data); err != nil {
+		return node
+	}
+	node.Data = json.RawMessage(bytes.TrimRight(buf.Bytes(), "\n"))
+	return node
+}
+
+// apply masks the strings in value; selected is whether value sits under a
+// field the rule covers (every field, for rules without a field list)
+func (rule redactRule) apply(value interface{}, selected bool) (interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		changed := false
+		for key, child := range v {
+			var childChanged bool
+			v[key], childChanged = rule.apply(child, selected || rule.fields[strings.ToLower(key)])
+			changed = changed || childChanged
+		}
+		return v, changed
+	case []interface{}:
+		changed := false
+		for i, child := range v {
+			var childChanged bool
+			v[i], childChanged = rule.apply(child, selected)
+			changed = changed || childChanged
+		}
+		return v, changed
+	case string:
+		if !selected {
+			return v, false
+		}
+		if rule.pattern == nil {
+			return rule.replace, v != rule.replace
+		}
+		masked := rule.pattern.ReplaceAllString(v, rule.replace)
+		return masked, masked != v
+	case json.Number:
+		if selected && rule.pattern == nil {
+			return rule.replace, true
+		}
+		return v, false
+	}
+	return value, false
+}
+
+// SetRedactor masks node data from every source as it loads, before people
+// are merged, hooks run or anything is stored.
+func (l *Loader) SetRedactor(r *Redactor) {
+	l.redactor = r
+}
@@ -0,0 +1,55 @@
+package datasource
+
+import (
+	"strings"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// refPrefix marks an edge endpoint a source could only name, not identify:
+// an issue key or file path written in free text. The Loader resolves these
+// once every source has loaded.
+const refPrefix = "ref:"
+
+// RefID returns a placeholder node ID for a reference to resolve later
+func RefID(ref string) string {
+	return refPrefix + ref
+}
+
+// resolveRefs points edges at the nodes their references name - by node ID,
+// identifier (CET-352) or file path - and drops references nothing matches.
+func resolveRefs(nodes []graph.Node, edges []graph.Edge) []graph.Edge {
+	var index map[string]string // Lowercased ID, identifier or path -> node ID
+	resolve := func(id string) (string, bool) {
+		if !strings.HasPrefix(id, refPrefix) {
+			return id, true
+		}
+		if index == nil {
+			index = make(map[string]string, len(nodes))
+			for i := range nodes {
+				node := &nodes[i]
+				index[strings.ToLower(node.ID)] = node.ID
+				if identifier := node.Identifier(); identifier != "" {
+					index[strings.ToLower(identifier)] = node.ID
+				}
+				if node.Type == graph.NodeTypeFile {
+					index[strings.ToLower(node.Title())] = node.ID
+				}
+			}
+		}
+		target, ok := index[strings.ToLower(strings.TrimPrefix(id, refPrefix))]
+		return target, ok
+	}
+
+	resolved := edges[:0:0]
+	for _, edge := range edges {
+		from, fromOK := resolve(edge.FromID)
+		to, toOK := resolve(edge.ToID)
+		if !fromOK || !toOK {
+			continue
+		}
+		edge.FromID, edge.ToID = from, to
+		resolved = append(resolved, edge)
+	}
+	return resolved
+}
@@ -0,0 +1,126 @@
+package datasource
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// todoSource marks Issue nodes captured from comments rather than a tracker
+const todoSource = "code-todo"
+
+// todoPattern matches a TODO or FIXME that opens a comment, with an optional
+// "(who)" and ":" before the text, e.g. "// FIXME(alice): handle EOF"
+var todoPattern = regexp.MustCompile(`(?:^|\s)(?://+|#+|/\*+|<!--|\*)\s*(TODO|FIXME)\b(?:\(([^)]*)\))?:?\s*(.*)$`)
+
+// todoLanguages are the languages whose comments are scanned for TODOs.
+// Prose and data formats are left out: a Markdown "# TODO" is a heading.
+var todoLanguages = map[string]bool{
+	"Go": true, "JavaScript": true, "TypeScript": true, "Python": true,
+	"Ruby": true, "Rust": true, "Java": true, "Kotlin": true, "C": true,
+	"C++": true, "YAML": true, "TOML": true, "HTML": true, "CSS": true,
+	"SCSS": true,
+}
+
+// codeTodo is one TODO/FIXME comment in a source file
+type codeTodo struct {
+	Line int    // 1-based
+	Kind string // TODO | FIXME
+	Who  string // Name in "TODO(who)", if any
+	Text string
+}
+
+// extractTodos finds TODO and FIXME comments in file content
+func extractTodos(content []byte) []codeTodo {
+	var todos []codeTodo
+	for i, line := range strings.Split(string(content), "\n") {
+		match := todoPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		text := strings.TrimSpace(match[3])
+		text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(text, "*/"), "-->"))
+		todos = append(todos, codeTodo{
+			Line: i + 1,
+			Kind: match[1],
+			Who:  strings.TrimSpace(match[2]),
+			Text: text,
+		})
+	}
+	return todos
+}
+
+// todoNodes turns a file's TODOs into lightweight Issue nodes owned by the
+// project (so they sit next to tracked issues) that mention the file.
+// A comment naming someone in parentheses is assigned to them.
+func (f *FileScanner) todoNodes(relPath, fileID string, todos []codeTodo, modTime time.Time, assignees *people) ([]graph.Node, []graph.Edge) {
+	var nodes []graph.Node
+	var edges []graph.Edge
+	for _, todo := range todos {
+		title := todo.Text
+		if title == "" {
+			title = todo.Kind
+		}
+		priority := 0
+		if todo.Kind == "FIXME" {
+			priority = 2 // High: FIXMEs flag known breakage
+		}
+
+		data := map[string]interface{}{
+			"title":      title,
+			"identifier": fmt.Sprintf("%s:%d", relPath, todo.Line),
+			"status":     "Todo",
+			"priority":   priority,
+			"labels":     []string{strings.ToLower(todo.Kind)},
+			"assignee":   todo.Who,
+			"path":       relPath,
+			"line":       todo.Line,
+		}
+		dataJSON, _ := json.Marshal(data)
+
+		nodeID := fmt.Sprintf("issue:todo:%s:%d", sanitizeID(relPath), todo.Line)
+		nodes = append(nodes, graph.Node{
+			ID:     nodeID,
+			Type:   graph.NodeTypeIssue,
+			Source: todoSource,
+			Data:   dataJSON,
+			Metadata: graph.NodeMetadata{
+				CreatedAt:   modTime,
+				UpdatedAt:   modTime,
+				CreatedBy:   "file-scanner",
+				AccessLevel: graph.RoleIC,
+				SyncedAt:    time.Now(),
+			},
+		})
+
+		// Edge: project owns the TODO, like any tracked issue
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:project-todo:%s:%d", sanitizeID(relPath), todo.Line),
+			FromID:   f.projectID,
+			ToID:     nodeID,
+			Relation: graph.EdgeOwns,
+			Metadata: graph.EdgeMetadata{CreatedAt: modTime},
+		})
+
+		// Edge: TODO mentions the file it lives in, at its line
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:todo-file:%s:%d", sanitizeID(relPath), todo.Line),
+			FromID:   nodeID,
+			ToID:     fileID,
+			Relation: graph.EdgeMentions,
+			Metadata: graph.EdgeMetadata{
+				CreatedAt: modTime,
+				Data:      map[string]interface{}{"line": todo.Line},
+			},
+		})
+
+		if assigneeID := assignees.person(todo.Who); assigneeID != "" {
+			edges = append(edges, assignedEdge(nodeID, assigneeID, modTime))
+		}
+	}
+	return nodes, edges
+}
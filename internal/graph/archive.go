@@ -0,0 +1,375 @@
+package graph
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Archiver is implemented by stores that keep expired nodes out of the
+// working graph but still queryable (archived_nodes, archived_edges).
+type Archiver interface {
+	// Archive moves nodes and edges past their TTL into the archive.
+	Archive(ctx context.Context, nodes []Node, edges []Edge) error
+	// ListArchived returns archived nodes whose ID or data contains search
+	// (case-insensitive; "" lists all), most recently archived first.
+	ListArchived(ctx context.Context, search string, limit int) ([]Node, error)
+	// Restore moves archived nodes back into the working graph and returns
+	// them with the archived edges that came back along with them.
+	Restore(ctx context.Context, ids []string) ([]Node, []Edge, error)
+	// Restored returns when each restored node was last brought back.
+	Restored(ctx context.Context) (map[string]time.Time, error)
+}
+
+var (
+	_ Archiver = (*SQLiteStore)(nil)
+	_ Archiver = (*PostgresStore)(nil)
+)
+
+// archiveDialect adapts the archive's SQL to a database
+type archiveDialect struct {
+	bind  func(string) string               // Turns "?" placeholders into the native ones
+	value func(json.RawMessage) interface{} // Binds node data the way the store writes it
+	data  string                            // Reads the data column as JSON bytes
+	match string                            // Case-insensitive search with two placeholders (ID, data)
+}
+
+var (
+	sqliteArchive = archiveDialect{
+		bind:  func(query string) string { return query },
+		value: func(data json.RawMessage) interface{} { return []byte(data) },
+		data:  "CAST(data AS BLOB)", // Early archives stored it as text
+		match: "(id LIKE ? ESCAPE '\\' OR data LIKE ? ESCAPE '\\')",
+	}
+	postgresArchive = archiveDialect{
+		bind:  rebind,
+		value: func(data json.RawMessage) interface{} { return string(data) },
+		data:  "data",
+		match: "(id ILIKE ? ESCAPE '\\' OR data::text ILIKE ? ESCAPE '\\')",
+	}
+)
+
+// Archive moves nodes and edges past their TTL into the archive tables.
+func (s *SQLiteStore) Archive(ctx context.Context, nodes []Node, edges []Edge) error {
+	if err := archive(ctx, s.db, sqliteArchive, nodes, edges); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// Archive moves nodes and edges past their TTL into the archive tables.
+func (s *PostgresStore) Archive(ctx context.Context, nodes []Node, edges []Edge) error {
+	return archive(ctx, s.db, postgresArchive, nodes, edges)
+}
+
+// ListArchived searches the archive; SQLite's LIKE already ignores ASCII case.
+func (s *SQLiteStore) ListArchived(ctx context.Context, search string, limit int) ([]Node, error) {
+	return listArchived(ctx, s.db, sqliteArchive, search, limit)
+}
+
+// ListArchived searches the archive, matching against the JSONB data as text.
+func (s *PostgresStore) ListArchived(ctx context.Context, search string, limit int) ([]Node, error) {
+	return listArchived(ctx, s.db, postgresArchive, search, limit)
+}
+
+// Restore moves archived nodes back into the working graph.
+func (s *SQLiteStore) Restore(ctx context.Context, ids []string) ([]Node, []Edge, error) {
+	nodes, edges, err := restore(ctx, s.db, sqliteArchive, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nodes, edges, s.Flush()
+}
+
+// Restore moves archived nodes back into the working graph.
+func (s *PostgresStore) Restore(ctx context.Context, ids []string) ([]Node, []Edge, error) {
+	return restore(ctx, s.db, postgresArchive, ids)
+}
+
+// Restored reads the restored_nodes table.
+func (s *SQLiteStore) Restored(ctx context.Context) (map[string]time.Time, error) {
+	return restoredAt(ctx, s.db)
+}
+
+// Restored reads the restored_nodes table.
+func (s *PostgresStore) Restored(ctx context.Context) (map[string]time.Time, error) {
+	return restoredAt(ctx, s.db)
+}
+
+// ApplyRestored moves the update time of nodes restored from store's archive
+// up to when they were restored, so their TTL counts from the restore rather
+// than from the older update their source reports. Stores without an archive
+// leave nodes as they are.
+func ApplyRestored(ctx context.Context, store GraphStore, nodes []Node) ([]Node, error) {
+	archiver, ok := store.(Archiver)
+	if !ok {
+		return nodes, nil
+	}
+	restored, err := archiver.Restored(ctx)
+	if err != nil || len(restored) == 0 {
+		return nodes, err
+	}
+	out := make([]Node, len(nodes))
+	for i, node := range nodes {
+		if at, ok := restored[node.ID]; ok && at.After(node.Metadata.UpdatedAt) {
+			node.Metadata.UpdatedAt = at
+		}
+		out[i] = node
+	}
+	return out, nil
+}
+
+// archive copies nodes and edges into the archive tables and removes them
+// from the working ones, in one transaction.
+func archive(ctx context.Context, db *sql.DB, dialect archiveDialect, nodes []Node, edges []Edge) error {
+	bind := dialect.bind
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin archive transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, node := range nodes {
+		metadataJSON, err := nodeMetadataJSON(node)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, bind(`
+			INSERT INTO archived_nodes (id, type, source, data, metadata)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(id) DO UPDATE SET
+				type = excluded.type,
+				source = excluded.source,
+				data = excluded.data,
+				metadata = excluded.metadata
+		`), node.ID, node.Type, node.Source, dialect.value(node.Data), metadataJSON)
+		if err != nil {
+			return fmt.Errorf("failed to archive node: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, bind("DELETE FROM nodes WHERE id = ?"), node.ID); err != nil {
+			return fmt.Errorf("failed to remove archived node: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, bind("DELETE FROM restored_nodes WHERE id = ?"), node.ID); err != nil {
+			return fmt.Errorf("failed to remove archived node: %w", err)
+		}
+	}
+	for _, edge := range edges {
+		edge, metadataJSON, err := prepareEdge(edge)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, bind(`
+			INSERT INTO archived_edges (id, from_id, to_id, relation, metadata)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(id) DO UPDATE SET
+				metadata = excluded.metadata
+		`), edge.ID, edge.FromID, edge.ToID, edge.Relation, metadataJSON)
+		if err != nil {
+			return fmt.Errorf("failed to archive edge: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, bind("DELETE FROM edges WHERE id = ?"), edge.ID); err != nil {
+			return fmt.Errorf("failed to remove archived edge: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// listArchived runs the archive search
+func listArchived(ctx context.Context, db *sql.DB, dialect archiveDialect, search string, limit int) ([]Node, error) {
+	query := "SELECT id, type, source, " + dialect.data + ", metadata FROM archived_nodes"
+	var args []interface{}
+	if search != "" {
+		pattern := "%" + likeEscaper.Replace(search) + "%"
+		query += " WHERE " + dialect.match
+		args = append(args, pattern, pattern)
+	}
+	query += " ORDER BY archived_at DESC, id"
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := db.QueryContext(ctx, dialect.bind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search archive: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanNodes(rows)
+}
+
+// likeEscaper makes a search term match literally inside a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// restore moves archived nodes back, in one transaction. Restoring counts as
+// activity: the node's update time is reset, and the restore recorded in
+// restored_nodes, so it does not expire again straight away (see
+// ApplyRestored). An archived edge comes back once neither endpoint is archived any more;
+// it is re-inserted into the working edges only where both endpoints are
+// stored there, but returned either way so callers can rejoin it with
+// nodes they hold in memory.
+func restore(ctx context.Context, db *sql.DB, dialect archiveDialect, ids []string) ([]Node, []Edge, error) {
+	bind := dialect.bind
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin restore transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	var nodes []Node
+	for _, id := range uniqueIDs(ids) {
+		rows, err := tx.QueryContext(ctx, bind("SELECT id, type, source, "+dialect.data+", metadata FROM archived_nodes WHERE id = ?"), id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read archived node: %w", err)
+		}
+		found, err := scanNodes(rows)
+		_ = rows.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(found) == 0 {
+			return nil, nil, fmt.Errorf("node not archived: %s", id)
+		}
+
+		node := found[0]
+		node.Metadata.UpdatedAt = now
+		metadataJSON, err := nodeMetadataJSON(node)
+		if err != nil {
+			return nil, nil, err
+		}
+		_, err = tx.ExecContext(ctx, bind(`
+			INSERT INTO nodes (id, type, source, data, metadata)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(id) DO UPDATE SET
+				type = excluded.type,
+				source = excluded.source,
+				data = excluded.data,
+				metadata = excluded.metadata
+		`), node.ID, node.Type, node.Source, dialect.value(node.Data), metadataJSON)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to restore node: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, bind("DELETE FROM archived_nodes WHERE id = ?"), node.ID); err != nil {
+			return nil, nil, fmt.Errorf("failed to remove restored node from the archive: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, bind(`
+			INSERT INTO restored_nodes (id, restored_at) VALUES (?, ?)
+			ON CONFLICT(id) DO UPDATE SET restored_at = excluded.restored_at
+		`), node.ID, now.UTC())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to record restored node: %w", err)
+		}
+		nodes = append(nodes, node)
+	}
+
+	var edges []Edge
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		rows, err := tx.QueryContext(ctx, bind(`
+			SELECT id, from_id, to_id, relation, metadata
+			FROM archived_edges
+			WHERE from_id = ? OR to_id = ?
+		`), node.ID, node.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read archived edges: %w", err)
+		}
+		found, err := scanEdges(rows)
+		_ = rows.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, edge := range found {
+			if seen[edge.ID] {
+				continue
+			}
+			seen[edge.ID] = true
+			archived, err := rowExists(ctx, tx, bind("SELECT 1 FROM archived_nodes WHERE id = ? OR id = ?"), edge.FromID, edge.ToID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if archived {
+				continue // Comes back with its other endpoint
+			}
+			if err := restoreEdge(ctx, tx, bind, edge); err != nil {
+				return nil, nil, err
+			}
+			edges = append(edges, edge)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit restore: %w", err)
+	}
+	return nodes, edges, nil
+}
+
+// restoredAt reads when each node was last restored
+func restoredAt(ctx context.Context, db *sql.DB) (map[string]time.Time, error) {
+	rows, err := db.QueryContext(ctx, "SELECT id, restored_at FROM restored_nodes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read restored nodes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	restored := make(map[string]time.Time)
+	for rows.Next() {
+		var id string
+		var at time.Time
+		if err := rows.Scan(&id, &at); err != nil {
+			return nil, fmt.Errorf("failed to read restored nodes: %w", err)
+		}
+		restored[id] = at
+	}
+	return restored, rows.Err()
+}
+
+// restoreEdge takes an edge out of the archive, re-inserting it into the
+// working edges when the foreign keys allow
+func restoreEdge(ctx context.Context, tx *sql.Tx, bind func(string) string, edge Edge) error {
+	if _, err := tx.ExecContext(ctx, bind("DELETE FROM archived_edges WHERE id = ?"), edge.ID); err != nil {
+		return fmt.Errorf("failed to remove restored edge from the archive: %w", err)
+	}
+	stored := 0
+	for _, id := range []string{edge.FromID, edge.ToID} {
+		ok, err := rowExists(ctx, tx, bind("SELECT 1 FROM nodes WHERE id = ?"), id)
+		if err != nil {
+			return err
+		}
+		if ok {
+			stored++
+		}
+	}
+	if stored < 2 {
+		return nil
+	}
+
+	edge, metadataJSON, err := prepareEdge(edge)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, bind(`
+		INSERT INTO edges (id, from_id, to_id, relation, metadata)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING
+	`), edge.ID, edge.FromID, edge.ToID, edge.Relation, metadataJSON)
+	if err != nil {
+		return fmt.Errorf("failed to restore edge: %w", err)
+	}
+	return nil
+}
+
+// rowExists reports whether query returns any row
+func rowExists(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (bool, error) {
+	var one int
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&one)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check the archive: %w", err)
+	}
+	return true, nil
+}
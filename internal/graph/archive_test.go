@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRestoreBringsArchivedNodesBack checks the archive search and that an
+// edge only leaves the archive once neither endpoint is archived.
+func TestRestoreBringsArchivedNodesBack(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	old := time.Now().Add(-400 * 24 * time.Hour)
+	node := func(id, title string) Node {
+		return Node{ID: id, Type: NodeTypeIssue, Source: "linear", Data: []byte(`{"title":"` + title + `"}`), Metadata: NodeMetadata{UpdatedAt: old}}
+	}
+	nodes := []Node{node("issue:a", "Flaky_test 100%"), node("issue:b", "Login page")}
+	edges := []Edge{{FromID: "issue:a", ToID: "issue:b", Relation: EdgeBlocks}}
+	for _, n := range nodes {
+		if err := store.AddNode(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.AddEdge(edges[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Archive(ctx, nodes, edges); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := store.ListArchived(ctx, "", 0)
+	if err != nil || len(all) != 2 {
+		t.Fatalf("ListArchived(\"\") = %d nodes, %v", len(all), err)
+	}
+	for search, want := range map[string]int{"LOGIN": 1, "issue:": 2, "_test 100%": 1, "t 1%": 0} {
+		found, err := store.ListArchived(ctx, search, 0)
+		if err != nil || len(found) != want {
+			t.Errorf("ListArchived(%q) = %d nodes, %v; want %d", search, len(found), err, want)
+		}
+	}
+
+	restored, restoredEdges, err := store.Restore(ctx, []string{"issue:a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restored) != 1 || !restored[0].Metadata.UpdatedAt.After(old) {
+		t.Fatalf("restored %v", restored)
+	}
+	if len(restoredEdges) != 0 {
+		t.Errorf("edge to a still archived node came back: %v", restoredEdges)
+	}
+	if _, err := store.GetNode("issue:a"); err != nil {
+		t.Errorf("restored node is not in the working graph: %v", err)
+	}
+
+	// The next load reports the source's old update time again; the restore
+	// still keeps the node inside its TTL
+	reloaded, err := ApplyRestored(ctx, store, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := []TTLRule{{Type: NodeTypeIssue, MaxAge: 90 * 24 * time.Hour}}
+	kept, _, expired, _ := ApplyTTL(reloaded, nil, rules, time.Now())
+	if len(kept) != 1 || kept[0].ID != "issue:a" || len(expired) != 1 {
+		t.Errorf("after restoring issue:a, TTL kept %v and expired %v", kept, expired)
+	}
+	if !nodes[0].Metadata.UpdatedAt.Equal(old) {
+		t.Error("ApplyRestored changed its input")
+	}
+
+	_, restoredEdges, err = store.Restore(ctx, []string{"issue:b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restoredEdges) != 1 {
+		t.Fatalf("restored edges %v", restoredEdges)
+	}
+	if edges, _ := store.GetEdges("issue:a"); len(edges) != 1 {
+		t.Errorf("restored edge is not in the working graph: %v", edges)
+	}
+	if left, _ := store.ListArchived(ctx, "", 0); len(left) != 0 {
+		t.Errorf("archive still holds %v", left)
+	}
+	if _, _, err := store.Restore(ctx, []string{"issue:a"}); err == nil {
+		t.Error("restoring a node that is not archived succeeded")
+	}
+}
@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// GraphStore is persistent storage for the knowledge graph. The TUI and
+// commands depend on this interface only; SQLiteStore (a local file) is the
+// default backend, PostgresStore shares one graph across a team and
+// MemoryStore keeps everything in process for tests and throwaway runs.
+type GraphStore interface {
+	AddNode(node Node) error
+	UpsertNode(node Node) error
+	AddEdge(edge Edge) error
+	UpsertEdge(edge Edge) error
+	GetNode(id string) (*Node, error)
+	GetNeighbors(nodeID string) ([]Node, error)
+	GetEdges(nodeID string) ([]Edge, error)
+	GetNodesByIDs(ids []string) ([]Node, error)               // In the order of ids; unknown IDs are skipped
+	GetEdgesForNodes(ids []string) (map[string][]Edge, error) // Each node's incoming and outgoing edges
+	DeleteNode(id string) error
+	DeleteEdge(id string) error
+	ListNodes(filter *NodeFilter) ([]Node, error)
+	ListEdges() ([]Edge, error)
+	Query(query string) (*QueryResult, error) // Read-only SQL; ErrNoSQL for backends without it
+	QueryContext(ctx context.Context, query string) (*QueryResult, error)
+	Close() error
+}
+
+// Flusher is implemented by stores that hold writes in memory until they are
+// flushed to disk (an encrypted SQLite store).
+type Flusher interface {
+	Flush() error
+}
+
+// Flush writes a store's pending writes to disk. Call it after each batch
+// of writes, so a process that exits without closing the store keeps them.
+func Flush(store GraphStore) error {
+	if flusher, ok := store.(Flusher); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// Compile-time checks that the built-in backends implement GraphStore
+var (
+	_ GraphStore = (*SQLiteStore)(nil)
+	_ GraphStore = (*PostgresStore)(nil)
+	_ GraphStore = (*MemoryStore)(nil)
+)
+
+// Open opens the named backend (the database.backend config value) at
+// location: a file path for SQLite, a connection URL for Postgres.
+func Open(backend, location string) (GraphStore, error) {
+	switch backend {
+	case "sqlite":
+		store, err := NewStore(location)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case "postgres":
+		store, err := NewPostgresStore(location)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown database backend %q (want memory, postgres or sqlite)", backend)
+	}
+}
+
+// prepareEdge validates an edge, fills in its ID and creation time, and
+// encodes its metadata
+func prepareEdge(edge Edge) (Edge, string, error) {
+	if !ValidateEdgeType(string(edge.Relation)) {
+		return edge, "", fmt.Errorf("invalid edge relation: %s", edge.Relation)
+	}
+	if edge.ID == "" {
+		edge.ID = fmt.Sprintf("%s-%s-%s", edge.FromID, edge.Relation, edge.ToID)
+	}
+	if edge.Metadata.CreatedAt.IsZero() {
+		edge.Metadata.CreatedAt = time.Now()
+	}
+	metadataJSON, err := json.Marshal(edge.Metadata)
+	if err != nil {
+		return edge, "", fmt.Errorf("failed to marshal edge metadata: %w", err)
+	}
+	return edge, string(metadataJSON), nil
+}
+
+// batchSize bounds the IDs bound into one IN (...) list, well under
+// SQLite's host parameter limit
+const batchSize = 500
+
+// uniqueIDs drops repeated IDs, keeping the first occurrence
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// orderNodes returns the found nodes in the order of ids
+func orderNodes(ids []string, found map[string]Node) []Node {
+	nodes := make([]Node, 0, len(found))
+	for _, id := range ids {
+		if node, ok := found[id]; ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// groupEdges files each edge under whichever of its endpoints were requested
+func groupEdges(ids []string, edges []Edge) map[string][]Edge {
+	requested := make(map[string]bool, len(ids))
+	grouped := make(map[string][]Edge, len(ids))
+	for _, id := range ids {
+		requested[id] = true
+		grouped[id] = nil
+	}
+	for _, edge := range edges {
+		if requested[edge.FromID] {
+			grouped[edge.FromID] = append(grouped[edge.FromID], edge)
+		}
+		if requested[edge.ToID] && edge.ToID != edge.FromID {
+			grouped[edge.ToID] = append(grouped[edge.ToID], edge)
+		}
+	}
+	return grouped
+}
@@ -0,0 +1,167 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestGraphStoreBackends runs the same behaviour checks against every
+// backend that needs no server, so they stay interchangeable.
+func TestGraphStoreBackends(t *testing.T) {
+	for _, backend := range []string{"sqlite", "memory"} {
+		t.Run(backend, func(t *testing.T) {
+			store, err := Open(backend, ":memory:")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = store.Close() }()
+			checkGraphStore(t, store)
+		})
+	}
+}
+
+// TestPostgresStore runs the same checks against the Postgres database in
+// MAAT_TEST_POSTGRES, a scratch database whose graph tables it empties.
+func TestPostgresStore(t *testing.T) {
+	url := os.Getenv("MAAT_TEST_POSTGRES")
+	if url == "" {
+		t.Skip("MAAT_TEST_POSTGRES is not set")
+	}
+	store, err := NewPostgresStore(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+	if _, err := store.db.Exec("TRUNCATE nodes, edges CASCADE"); err != nil {
+		t.Fatal(err)
+	}
+	checkGraphStore(t, store)
+}
+
+func checkGraphStore(t *testing.T, store GraphStore) {
+	t.Helper()
+	issue := Node{ID: "issue:1", Type: NodeTypeIssue, Source: "linear", Data: []byte(`{"title":"Fix login"}`)}
+	file := Node{ID: "file:auth.go", Type: NodeTypeFile, Source: "git", Data: []byte(`{"path":"auth.go"}`)}
+	for _, node := range []Node{issue, file} {
+		if err := store.AddNode(node); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.AddNode(issue); err == nil {
+		t.Error("duplicate AddNode succeeded")
+	}
+	if err := store.AddNode(Node{ID: "x", Type: "Bogus", Data: []byte(`{}`)}); err == nil {
+		t.Error("AddNode accepted an unknown type")
+	}
+
+	edge := Edge{FromID: issue.ID, ToID: file.ID, Relation: EdgeModifies}
+	if err := store.AddEdge(edge); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddEdge(edge); err == nil {
+		t.Error("duplicate AddEdge succeeded")
+	}
+	if err := store.UpsertEdge(edge); err != nil {
+		t.Errorf("UpsertEdge of an existing edge: %v", err)
+	}
+	if err := store.AddEdge(Edge{FromID: issue.ID, ToID: "file:missing", Relation: EdgeModifies}); err == nil {
+		t.Error("AddEdge accepted a missing endpoint")
+	}
+
+	issue.Data = []byte(`{"title":"Fix login redirect"}`)
+	if err := store.UpsertNode(issue); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.GetNode(issue.ID)
+	if err != nil || got.Title() != "Fix login redirect" {
+		t.Errorf("GetNode = %v, %v", got, err)
+	}
+	if neighbors, _ := store.GetNeighbors(issue.ID); len(neighbors) != 1 || neighbors[0].ID != file.ID {
+		t.Errorf("GetNeighbors = %v", neighbors)
+	}
+	if files, _ := store.ListNodes(&NodeFilter{Types: []NodeType{NodeTypeFile}}); len(files) != 1 {
+		t.Errorf("ListNodes(File) returned %d nodes", len(files))
+	}
+
+	// Batch lookups keep the requested order and key edges by node
+	batch, err := store.GetNodesByIDs([]string{file.ID, "issue:missing", issue.ID, file.ID})
+	if err != nil || len(batch) != 2 || batch[0].ID != file.ID || batch[1].ID != issue.ID {
+		t.Errorf("GetNodesByIDs = %v, %v", batch, err)
+	}
+	byNode, err := store.GetEdgesForNodes([]string{issue.ID, file.ID, "issue:missing"})
+	if err != nil || len(byNode[issue.ID]) != 1 || len(byNode[file.ID]) != 1 || len(byNode["issue:missing"]) != 0 {
+		t.Errorf("GetEdgesForNodes = %v, %v", byNode, err)
+	}
+
+	// Deleting a node takes its edges with it
+	if err := store.DeleteNode(file.ID); err != nil {
+		t.Fatal(err)
+	}
+	if edges, _ := store.ListEdges(); len(edges) != 0 {
+		t.Errorf("%d edges left after cascade delete", len(edges))
+	}
+	if err := store.DeleteNode(file.ID); err == nil {
+		t.Error("deleting a missing node succeeded")
+	}
+
+	if _, err := store.Query("SELECT id FROM nodes"); err != nil && !errors.Is(err, ErrNoSQL) {
+		t.Errorf("Query: %v", err)
+	}
+
+	// Ad-hoc SQL is read-only, including statements chained after a query
+	for _, query := range []string{
+		"DELETE FROM nodes",
+		"SELECT 1; DELETE FROM nodes",
+		"COMMIT; DELETE FROM nodes",
+	} {
+		if _, err := store.Query(query); err == nil {
+			t.Errorf("Query(%q) succeeded", query)
+		}
+	}
+	if _, err := store.GetNode(issue.ID); err != nil {
+		t.Errorf("a read-only query deleted %s: %v", issue.ID, err)
+	}
+}
+
+// TestSQLiteBatchesLargeLookups checks lookups spanning several IN batches.
+func TestSQLiteBatchesLargeLookups(t *testing.T) {
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	ids := make([]string, 2*batchSize+7)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("file:%04d", i)
+		if err := store.AddNode(Node{ID: ids[i], Type: NodeTypeFile, Source: "git", Data: []byte(`{}`)}); err != nil {
+			t.Fatal(err)
+		}
+		if i > 0 {
+			if err := store.AddEdge(Edge{FromID: ids[i-1], ToID: ids[i], Relation: EdgeRelated}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	nodes, err := store.GetNodesByIDs(ids)
+	if err != nil || len(nodes) != len(ids) || nodes[len(ids)-1].ID != ids[len(ids)-1] {
+		t.Fatalf("GetNodesByIDs returned %d nodes, %v", len(nodes), err)
+	}
+	byNode, err := store.GetEdgesForNodes(ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The chain's ends have one edge, everything between has two
+	for i, id := range ids {
+		want := 2
+		if i == 0 || i == len(ids)-1 {
+			want = 1
+		}
+		if got := len(byNode[id]); got != want {
+			t.Fatalf("%s has %d edges, want %d", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"crypto/rand"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LocalPrefix starts the ID of every node or edge created locally (by hand
+// or by an agent) rather than loaded from a source. Sources never use it,
+// so local entities cannot collide with source-derived ones when graphs are
+// merged or shared.
+const LocalPrefix = "local:"
+
+// IsLocalID reports whether id belongs to the reserved local namespace.
+func IsLocalID(id string) bool {
+	return strings.HasPrefix(id, LocalPrefix)
+}
+
+// NewLocalID allocates a fresh local ID ("local:01J9…") from the default
+// allocator.
+func NewLocalID() string {
+	return defaultAllocator.NewID()
+}
+
+var defaultAllocator = NewIDAllocator()
+
+// IDAllocator hands out local IDs built on ULIDs: a millisecond timestamp
+// followed by 80 random bits, in Crockford base32. IDs sort by creation
+// time, and IDs from one allocator keep increasing within a millisecond.
+// It is safe for concurrent use.
+type IDAllocator struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	entropy io.Reader
+	lastMs  uint64
+	last    [10]byte // Random part of the last ID, incremented within a millisecond
+}
+
+// NewIDAllocator returns an allocator using the system clock and crypto/rand.
+func NewIDAllocator() *IDAllocator {
+	return &IDAllocator{now: time.Now, entropy: rand.Reader}
+}
+
+// NewID returns a new local ID.
+func (a *IDAllocator) NewID() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ms := uint64(a.now().UnixMilli())
+	if ms <= a.lastMs {
+		// Same millisecond (or the clock stepped back): stay monotonic,
+		// borrowing the next millisecond once the random part runs out
+		if !increment(&a.last) {
+			a.lastMs++
+		}
+		ms = a.lastMs
+	} else {
+		if _, err := io.ReadFull(a.entropy, a.last[:]); err != nil {
+			// crypto/rand does not fail in practice; fall back to counting
+			increment(&a.last)
+		}
+		a.lastMs = ms
+	}
+	return LocalPrefix + encodeULID(ms, a.last)
+}
+
+// increment adds one to a big-endian counter, reporting false when it
+// wrapped around to zero
+func increment(b *[10]byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// crockford is the base32 alphabet ULIDs use (no I, L, O or U)
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// encodeULID renders a 48-bit timestamp and 80 random bits as 26 characters
+func encodeULID(ms uint64, random [10]byte) string {
+	var out [26]byte
+	for i := 9; i >= 0; i-- {
+		out[i] = crockford[ms&31]
+		ms >>= 5
+	}
+	// 80 random bits are exactly 16 characters of 5 bits
+	var acc uint64
+	bits := 0
+	pos := 10
+	for _, b := range random {
+		acc = acc<<8 | uint64(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = crockford[(acc>>uint(bits))&31]
+			pos++
+		}
+	}
+	return string(out[:])
+}
@@ -0,0 +1,43 @@
+package graph
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestIDAllocatorIsMonotonic checks the ULID layout and that IDs allocated
+// within one millisecond, or after the clock stepped back, still increase.
+func TestIDAllocatorIsMonotonic(t *testing.T) {
+	now := time.UnixMilli(1_750_000_000_000)
+	a := &IDAllocator{now: func() time.Time { return now }, entropy: bytes.NewReader(bytes.Repeat([]byte{0xff}, 64))}
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		ids = append(ids, a.NewID())
+	}
+	now = now.Add(-time.Second)
+	ids = append(ids, a.NewID())
+	now = now.Add(time.Hour)
+	ids = append(ids, a.NewID())
+
+	for _, id := range ids {
+		if !IsLocalID(id) || len(id) != len(LocalPrefix)+26 {
+			t.Fatalf("malformed local ID %q", id)
+		}
+	}
+	if !sort.StringsAreSorted(ids) {
+		t.Errorf("IDs are not increasing: %v", ids)
+	}
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if seen[id] {
+			t.Errorf("duplicate ID %s", id)
+		}
+		seen[id] = true
+	}
+	if IsLocalID("linear:CET-352") || NewLocalID() == NewLocalID() {
+		t.Error("namespace check or default allocator is wrong")
+	}
+}
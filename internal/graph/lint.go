@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Checks run by Lint
+const (
+	LintInvalidType     = "invalid-type"     // Node type the schema does not know
+	LintInvalidRelation = "invalid-relation" // Edge relation the schema does not know
+	LintDanglingEdge    = "dangling-edge"    // Edge to or from a node that does not exist
+	LintSchema          = "schema"           // Node missing a source, or data that is not a JSON object
+	LintReservedID      = "reserved-id"      // Source-loaded node in the local: namespace
+	LintDuplicate       = "duplicate"        // Two nodes that describe the same entity
+)
+
+// LintIssue is one problem found in a stored graph.
+type LintIssue struct {
+	Check   string // One of the Lint* checks
+	Subject string // ID of the node or edge at fault
+	Problem string
+	Fix     string // Suggested repair
+
+	edgeID string // Edge that --fix may delete ("" = needs a human)
+}
+
+// Fixable reports whether FixLint can repair the issue safely.
+func (i LintIssue) Fixable() bool {
+	return i.edgeID != ""
+}
+
+// Lint checks a stored graph for schema violations, dangling edges and
+// duplicate entities. It reads through the GraphStore interface, so it
+// works on every backend.
+func Lint(store GraphStore) ([]LintIssue, error) {
+	nodes, err := store.ListNodes(nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading nodes: %w", err)
+	}
+	edges, err := store.ListEdges()
+	if err != nil {
+		return nil, fmt.Errorf("reading edges: %w", err)
+	}
+
+	var issues []LintIssue
+	exists := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		exists[node.ID] = true
+		issues = append(issues, lintNode(node)...)
+	}
+
+	for _, edge := range edges {
+		if !ValidateEdgeType(string(edge.Relation)) {
+			issues = append(issues, LintIssue{
+				Check:   LintInvalidRelation,
+				Subject: edge.ID,
+				Problem: fmt.Sprintf("relation %q is not part of the schema", edge.Relation),
+				Fix:     "delete the edge (--fix), or add the relation to the schema",
+				edgeID:  edge.ID,
+			})
+			continue
+		}
+		var missing []string
+		for _, id := range []string{edge.FromID, edge.ToID} {
+			if !exists[id] {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) > 0 {
+			issues = append(issues, LintIssue{
+				Check:   LintDanglingEdge,
+				Subject: edge.ID,
+				Problem: fmt.Sprintf("%s %s %s points at missing %s", edge.FromID, edge.Relation, edge.ToID, strings.Join(missing, ", ")),
+				Fix:     "delete the edge (--fix or maat db maintain)",
+				edgeID:  edge.ID,
+			})
+		}
+	}
+
+	issues = append(issues, lintDuplicates(nodes)...)
+	return issues, nil
+}
+
+// lintNode checks a node on its own
+func lintNode(node Node) []LintIssue {
+	var issues []LintIssue
+	if !ValidateNodeType(string(node.Type)) {
+		issues = append(issues, LintIssue{
+			Check:   LintInvalidType,
+			Subject: node.ID,
+			Problem: fmt.Sprintf("type %q is not part of the schema", node.Type),
+			Fix:     "re-sync the source that wrote it, or delete the node",
+		})
+	}
+	if node.Source == "" {
+		issues = append(issues, LintIssue{
+			Check:   LintSchema,
+			Subject: node.ID,
+			Problem: "node has no source",
+			Fix:     "re-sync it from its source so provenance is recorded",
+		})
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(node.Data, &fields); err != nil || fields == nil {
+		issues = append(issues, LintIssue{
+			Check:   LintSchema,
+			Subject: node.ID,
+			Problem: "data is not a JSON object",
+			Fix:     "re-sync the node, or delete it if its source is gone",
+		})
+	}
+	if IsLocalID(node.ID) && node.Metadata.CreatedBy == "" {
+		issues = append(issues, LintIssue{
+			Check:   LintReservedID,
+			Subject: node.ID,
+			Problem: fmt.Sprintf("node from %q uses the reserved %q prefix", node.Source, LocalPrefix),
+			Fix:     "fix the source's IDs; local IDs are for entities created by hand",
+		})
+	}
+	return issues
+}
+
+// lintDuplicates finds nodes of one type sharing an identifier (CET-352
+// loaded under two IDs) and people sharing an email or account that the
+// people config has not unified
+func lintDuplicates(nodes []Node) []LintIssue {
+	type semanticKey struct {
+		kind  string // "identifier", or the account kind for people
+		scope NodeType
+		value string
+	}
+	owners := make(map[semanticKey][]string)
+	var order []semanticKey
+	add := func(key semanticKey, id string) {
+		ids := owners[key]
+		if len(ids) == 0 {
+			order = append(order, key)
+		} else if ids[len(ids)-1] == id {
+			return // Listed twice on the same node
+		}
+		owners[key] = append(ids, id)
+	}
+	for i := range nodes {
+		node := &nodes[i]
+		if identifier := node.Identifier(); identifier != "" {
+			add(semanticKey{"identifier", node.Type, strings.ToLower(identifier)}, node.ID)
+		}
+		if node.Type != NodeTypePerson {
+			continue
+		}
+		var person PersonData
+		if err := json.Unmarshal(node.Data, &person); err != nil {
+			continue
+		}
+		for _, email := range person.Emails {
+			add(semanticKey{"email", node.Type, strings.ToLower(email)}, node.ID)
+		}
+		if person.GitHub != "" {
+			add(semanticKey{"GitHub login", node.Type, strings.ToLower(person.GitHub)}, node.ID)
+		}
+		if person.Linear != "" {
+			add(semanticKey{"Linear account", node.Type, strings.ToLower(person.Linear)}, node.ID)
+		}
+	}
+
+	var issues []LintIssue
+	for _, key := range order {
+		ids := owners[key]
+		if len(ids) < 2 {
+			continue
+		}
+		fix := "keep one node and delete the others, or give them distinct identifiers"
+		if key.kind != "identifier" {
+			fix = "list the accounts under one entry in the people config so they are unified"
+		}
+		issues = append(issues, LintIssue{
+			Check:   LintDuplicate,
+			Subject: ids[0],
+			Problem: fmt.Sprintf("%s nodes %s share %s %q", key.scope, strings.Join(ids, ", "), key.kind, key.value),
+			Fix:     fix,
+		})
+	}
+	return issues
+}
+
+// FixLint applies the safe repairs: deleting edges with unknown relations or
+// missing endpoints. Everything else needs a human. It returns how many
+// issues it fixed.
+func FixLint(store GraphStore, issues []LintIssue) (int, error) {
+	fixed := 0
+	for _, issue := range issues {
+		if !issue.Fixable() {
+			continue
+		}
+		if err := store.DeleteEdge(issue.edgeID); err != nil {
+			return fixed, fmt.Errorf("fixing %s: %w", issue.Subject, err)
+		}
+		fixed++
+	}
+	return fixed, Flush(store)
+}
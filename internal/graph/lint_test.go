@@ -0,0 +1,64 @@
+package graph
+
+import "testing"
+
+// TestLintFindsAndFixesProblems plants one problem per check and checks
+// that --fix repairs only the edges.
+func TestLintFindsAndFixesProblems(t *testing.T) {
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	nodes := []Node{
+		{ID: "linear:CET-1", Type: NodeTypeIssue, Source: "linear", Data: []byte(`{"identifier":"CET-1"}`)},
+		{ID: "github:CET-1", Type: NodeTypeIssue, Source: "github", Data: []byte(`{"identifier":"cet-1"}`)},
+		{ID: "person:a", Type: NodeTypePerson, Source: "git", Data: []byte(`{"name":"A","emails":["a@x.io","a@x.io"]}`)},
+		{ID: "person:b", Type: NodeTypePerson, Source: "linear", Data: []byte(`{"name":"B","emails":["A@x.io"]}`)},
+		{ID: "local:01J", Type: NodeTypeTask, Source: "todo", Data: []byte(`[]`)},
+	}
+	for _, n := range nodes {
+		if err := store.AddNode(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Problems the store's own checks would refuse, as older builds or
+	// other tools could have left them
+	for _, stmt := range []string{
+		"PRAGMA foreign_keys = OFF",
+		`INSERT INTO nodes (id, type, source, data, metadata) VALUES ('x', 'Widget', '', CAST('{}' AS BLOB), '{}')`,
+		`INSERT INTO edges (id, from_id, to_id, relation) VALUES ('e1', 'linear:CET-1', 'gone', 'blocks')`,
+		`INSERT INTO edges (id, from_id, to_id, relation) VALUES ('e2', 'linear:CET-1', 'person:a', 'likes')`,
+	} {
+		if _, err := store.db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	issues, err := Lint(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		counts[issue.Check]++
+	}
+	want := map[string]int{
+		LintInvalidType: 1, LintSchema: 2, LintReservedID: 1,
+		LintDanglingEdge: 1, LintInvalidRelation: 1, LintDuplicate: 2,
+	}
+	for check, n := range want {
+		if counts[check] != n {
+			t.Errorf("%s: %d issues, want %d (%v)", check, counts[check], n, issues)
+		}
+	}
+
+	fixed, err := FixLint(store, issues)
+	if err != nil || fixed != 2 {
+		t.Fatalf("FixLint = %d, %v", fixed, err)
+	}
+	if edges, _ := store.ListEdges(); len(edges) != 0 {
+		t.Errorf("edges left after --fix: %v", edges)
+	}
+}
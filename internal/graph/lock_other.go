@@ -0,0 +1,32 @@
+//go:build !unix
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// lockFile takes an exclusive lock on path by creating it; the lock is
+// released by closing and removing the file. A lock file left by a crash
+// has to be removed by hand.
+func lockFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return nil, fmt.Errorf("%s is in use by another maat (remove it if none is running)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening lock file: %w", err)
+	}
+	return f, nil
+}
+
+// unlockFile releases a lock taken by lockFile.
+func unlockFile(f *os.File) error {
+	name := f.Name()
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Remove(name)
+}
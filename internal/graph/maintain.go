@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Maintainer is implemented by stores that benefit from periodic upkeep.
+type Maintainer interface {
+	Maintain(ctx context.Context) (MaintenanceReport, error)
+}
+
+// MaintenanceReport describes one maintenance run.
+type MaintenanceReport struct {
+	SizeBefore  int64    // Bytes used by the graph before
+	SizeAfter   int64    // and after
+	OrphanEdges int64    // Edges removed because an endpoint no longer exists
+	FTSTables   []string // Full-text indexes merged
+	Duration    time.Duration
+}
+
+// orphanEdgesSQL removes edges left behind when a node was deleted without
+// foreign keys enforced (older databases, external tools)
+const orphanEdgesSQL = `
+	DELETE FROM edges
+	WHERE from_id NOT IN (SELECT id FROM nodes)
+	OR to_id NOT IN (SELECT id FROM nodes)`
+
+// Maintain removes orphaned edges, merges full-text index segments, refreshes
+// the query planner's statistics and rebuilds the file to reclaim free pages.
+// VACUUM needs up to the database's size again in temporary space.
+func (s *SQLiteStore) Maintain(ctx context.Context) (MaintenanceReport, error) {
+	start := time.Now()
+	var report MaintenanceReport
+	var err error
+	if report.SizeBefore, err = s.size(ctx); err != nil {
+		return report, err
+	}
+
+	result, err := s.db.ExecContext(ctx, orphanEdgesSQL)
+	if err != nil {
+		return report, fmt.Errorf("removing orphaned edges: %w", err)
+	}
+	report.OrphanEdges, _ = result.RowsAffected()
+
+	tables, err := s.ftsTables(ctx)
+	if err != nil {
+		return report, err
+	}
+	for _, table := range tables {
+		quoted := `"` + strings.ReplaceAll(table, `"`, `""`) + `"`
+		_, err := s.db.ExecContext(ctx, "INSERT INTO "+quoted+"("+quoted+") VALUES('optimize')")
+		if err != nil && strings.Contains(err.Error(), "no such module") {
+			continue // Created by a build with FTS5 compiled in; this one cannot touch it
+		}
+		if err != nil {
+			return report, fmt.Errorf("optimizing %s: %w", table, err)
+		}
+		report.FTSTables = append(report.FTSTables, table)
+	}
+
+	for _, statement := range []string{"ANALYZE", "VACUUM"} {
+		if _, err := s.db.ExecContext(ctx, statement); err != nil {
+			return report, fmt.Errorf("%s: %w", strings.ToLower(statement), err)
+		}
+	}
+	if err := s.Flush(); err != nil {
+		return report, err
+	}
+
+	if report.SizeAfter, err = s.size(ctx); err != nil {
+		return report, err
+	}
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+// size is the database's allocated size, which also works for in-memory
+// and encrypted stores where there is no plaintext file to stat
+func (s *SQLiteStore) size(ctx context.Context) (int64, error) {
+	var pages, pageSize int64
+	if err := s.db.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pages); err != nil {
+		return 0, fmt.Errorf("reading database size: %w", err)
+	}
+	if err := s.db.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("reading database size: %w", err)
+	}
+	return pages * pageSize, nil
+}
+
+// ftsTables lists the FTS5 full-text tables in the database
+func (s *SQLiteStore) ftsTables(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type = 'table' AND sql LIKE '%USING fts5%'
+		ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("listing full-text indexes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("listing full-text indexes: %w", err)
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
+
+// Maintain removes orphaned edges and vacuums and analyzes the graph tables.
+// Plain VACUUM does not lock out teammates reading or syncing meanwhile.
+func (s *PostgresStore) Maintain(ctx context.Context) (MaintenanceReport, error) {
+	start := time.Now()
+	var report MaintenanceReport
+	var err error
+	if report.SizeBefore, err = s.size(ctx); err != nil {
+		return report, err
+	}
+
+	result, err := s.db.ExecContext(ctx, orphanEdgesSQL)
+	if err != nil {
+		return report, fmt.Errorf("removing orphaned edges: %w", err)
+	}
+	report.OrphanEdges, _ = result.RowsAffected()
+
+	if _, err := s.db.ExecContext(ctx, "VACUUM (ANALYZE) nodes, edges"); err != nil {
+		return report, fmt.Errorf("vacuum: %w", err)
+	}
+
+	if report.SizeAfter, err = s.size(ctx); err != nil {
+		return report, err
+	}
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+// size is the on-disk size of the graph tables and their indexes
+func (s *PostgresStore) size(ctx context.Context) (int64, error) {
+	var size int64
+	err := s.db.QueryRowContext(ctx, "SELECT pg_total_relation_size('nodes') + pg_total_relation_size('edges')").Scan(&size)
+	if err != nil {
+		return 0, fmt.Errorf("reading database size: %w", err)
+	}
+	return size, nil
+}
@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// People are shared across sources: a git author, a Linear assignee and a
+// GitHub reviewer with the same name resolve to the same Person node.
+
+// PersonID returns the graph ID for a person ("Alice Smith" → "person:alice-smith").
+// A leading "@" (GitHub login) is ignored.
+func PersonID(name string) string {
+	return "person:" + peopleSlug(name)
+}
+
+// TeamID returns the graph ID for a team ("@acme/platform" → "team:acme-platform").
+func TeamID(name string) string {
+	return "team:" + peopleSlug(name)
+}
+
+// IsTeamRef reports whether a login refers to a team ("@org/team" or "org/team").
+func IsTeamRef(login string) bool {
+	return strings.Contains(login, "/")
+}
+
+// peopleSlug normalizes a name or login for use in an ID
+func peopleSlug(name string) string {
+	s := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "@")))
+	return strings.NewReplacer(" ", "-", "/", "-").Replace(s)
+}
+
+// PersonData is the JSON payload of a Person node: the name plus whatever
+// account identifiers the source knows, used to unify identities later.
+type PersonData struct {
+	Name    string   `json:"name"`
+	Emails  []string `json:"emails,omitempty"`
+	GitHub  string   `json:"github,omitempty"`
+	Linear  string   `json:"linear,omitempty"`
+	Aliases []string `json:"aliases,omitempty"` // Other names merged into this person
+}
+
+// NewPersonNode creates a Person node named as the source knows them.
+func NewPersonNode(name, source string) Node {
+	return NewPersonNodeFrom(PersonData{Name: name}, source)
+}
+
+// NewPersonNodeFrom creates a Person node carrying account identifiers.
+func NewPersonNodeFrom(data PersonData, source string) Node {
+	data.Name = strings.TrimPrefix(data.Name, "@")
+	return newPeopleNode(PersonID(data.Name), NodeTypePerson, data, source)
+}
+
+// NewTeamNode creates a Team node named as the source knows it.
+func NewTeamNode(name, source string) Node {
+	return newPeopleNode(TeamID(name), NodeTypeTeam, map[string]interface{}{"name": strings.TrimPrefix(name, "@")}, source)
+}
+
+func newPeopleNode(id string, t NodeType, data interface{}, source string) Node {
+	dataJSON, _ := json.Marshal(data)
+	now := time.Now()
+	return Node{
+		ID:     id,
+		Type:   t,
+		Source: source,
+		Data:   dataJSON,
+		Metadata: NodeMetadata{
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			AccessLevel: RoleIC,
+			SyncedAt:    now,
+		},
+	}
+}
+
+// Handles returns every identifier a Person node is known by, lower-cased:
+// name, aliases, GitHub login, Linear name and emails. Nil for other nodes.
+func (n *Node) Handles() []string {
+	if n.Type != NodeTypePerson {
+		return nil
+	}
+	var data PersonData
+	if err := json.Unmarshal(n.Data, &data); err != nil {
+		return nil
+	}
+	var handles []string
+	for _, h := range append(append([]string{data.Name, data.GitHub, data.Linear}, data.Aliases...), data.Emails...) {
+		if h = strings.ToLower(strings.TrimPrefix(h, "@")); h != "" {
+			handles = append(handles, h)
+		}
+	}
+	return handles
+}
@@ -0,0 +1,120 @@
+package graph
+
+import "fmt"
+
+// Persist saves a loaded graph in this machine's store and returns the graph
+// the store now holds: the load, hand-made nodes and edges, and whatever a
+// source that failed this time loaded before. When complete (every source
+// loaded) stored nodes and edges the sources no longer report are removed
+// first, so deleted files and closed-out issues don't linger. Only the
+// sources in this load are pruned (by the nodes' Source), so what another
+// project or a source left out this time stored stays; hand-made nodes and
+// edges (local IDs, edges with a creator or a local end) are kept. Nodes and edges the store
+// rejects are counted in skipped.
+func Persist(store GraphStore, nodes []Node, edges []Edge, complete bool) (storedNodes []Node, storedEdges []Edge, skipped int, err error) {
+	if complete {
+		if err := pruneStale(store, nodes, edges); err != nil {
+			return nodes, edges, 0, err
+		}
+	}
+	return MergeShared(store, nodes, edges)
+}
+
+// ReadStored returns the whole graph a store holds, to start from without
+// loading any source.
+func ReadStored(store GraphStore) ([]Node, []Edge, error) {
+	nodes, err := store.ListNodes(nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading stored nodes: %w", err)
+	}
+	edges, err := store.ListEdges()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading stored edges: %w", err)
+	}
+	return nodes, edges, nil
+}
+
+// pruneStale deletes stored source-derived nodes and edges missing from a
+// complete load, among those of the sources the load came from: nodes with
+// their Source, and edges from one of those nodes. Deleting a node takes its
+// edges with it.
+func pruneStale(store GraphStore, nodes []Node, edges []Edge) error {
+	loaded := make(map[string]bool, len(nodes))
+	var sources []string
+	seenSource := make(map[string]bool)
+	for _, node := range nodes {
+		loaded[node.ID] = true
+		if !seenSource[node.Source] {
+			seenSource[node.Source] = true
+			sources = append(sources, node.Source)
+		}
+	}
+	if len(sources) == 0 {
+		return nil // An empty filter would list every source's nodes
+	}
+	loadedEdges := make(map[string]bool, len(edges))
+	for _, edge := range edges {
+		loadedEdges[edgeKey(edge)] = true
+	}
+
+	stored, err := store.ListNodes(&NodeFilter{Sources: sources})
+	if err != nil {
+		return fmt.Errorf("reading stored nodes: %w", err)
+	}
+	storedEdges, err := store.ListEdges()
+	if err != nil {
+		return fmt.Errorf("reading stored edges: %w", err)
+	}
+	owned := make(map[string]bool, len(stored))
+	gone := make(map[string]bool)
+	for _, node := range stored {
+		owned[node.ID] = true
+		if loaded[node.ID] || IsLocalID(node.ID) {
+			continue
+		}
+		if err := store.DeleteNode(node.ID); err != nil {
+			return fmt.Errorf("removing stale node %s: %w", node.ID, err)
+		}
+		gone[node.ID] = true
+	}
+	for _, edge := range storedEdges {
+		if !owned[edge.FromID] || loadedEdges[edgeKey(edge)] || handMadeEdge(edge) || gone[edge.FromID] || gone[edge.ToID] {
+			continue
+		}
+		if err := store.DeleteEdge(edge.ID); err != nil {
+			return fmt.Errorf("removing stale edge %s: %w", edge.ID, err)
+		}
+	}
+	return nil
+}
+
+// handMadeEdge reports whether no source derived edge: it names its creator
+// or touches a node in the local namespace
+func handMadeEdge(edge Edge) bool {
+	return edge.Metadata.CreatedBy != "" || IsLocalID(edge.FromID) || IsLocalID(edge.ToID)
+}
+
+// PersistRefresh saves a partial reload in the store: the nodes and edges
+// it loaded, less the nodes it found gone. Nodes and edges the store
+// rejects are counted in skipped, as in MergeShared.
+func PersistRefresh(store GraphStore, nodes []Node, edges []Edge, removed []string) (skipped int, err error) {
+	for _, id := range removed {
+		if _, err := store.GetNode(id); err != nil {
+			continue // Never stored
+		}
+		if err := store.DeleteNode(id); err != nil {
+			return 0, fmt.Errorf("removing node %s: %w", id, err)
+		}
+	}
+	for _, node := range nodes {
+		if err := store.UpsertNode(node); err != nil {
+			skipped++
+		}
+	}
+	for _, edge := range edges {
+		if err := store.UpsertEdge(edge); err != nil {
+			skipped++
+		}
+	}
+	return skipped, Flush(store)
+}
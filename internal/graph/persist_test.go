@@ -0,0 +1,93 @@
+package graph
+
+import "testing"
+
+// TestPersistKeepsHandMadeAndPrunesStale checks a complete load replaces what
+// sources stored before, while hand-made nodes and edges survive it, and that
+// a partial load leaves everything stored in place.
+func TestPersistKeepsHandMadeAndPrunesStale(t *testing.T) {
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	first := []Node{
+		{ID: "issue:A-1", Type: NodeTypeIssue, Source: "linear", Data: []byte(`{"title":"Login"}`)},
+		{ID: "file:old.go", Type: NodeTypeFile, Source: "git", Data: []byte(`{"path":"old.go"}`)},
+		{ID: "file:a.go", Type: NodeTypeFile, Source: "git", Data: []byte(`{"path":"a.go"}`)},
+	}
+	firstEdges := []Edge{
+		{FromID: "issue:A-1", ToID: "file:old.go", Relation: EdgeModifies},
+		{FromID: "issue:A-1", ToID: "file:a.go", Relation: EdgeModifies},
+	}
+	if _, _, skipped, err := Persist(store, first, firstEdges, true); err != nil || skipped != 0 {
+		t.Fatalf("first load: skipped=%d err=%v", skipped, err)
+	}
+	handMade := Node{ID: LocalPrefix + "1", Type: NodeTypeIssue, Source: "local", Data: []byte(`{"title":"Mine"}`)}
+	if err := store.UpsertNode(handMade); err != nil {
+		t.Fatal(err)
+	}
+	link := Edge{ID: "e-user", FromID: "file:a.go", ToID: "issue:A-1", Relation: EdgeRelated, Metadata: EdgeMetadata{CreatedBy: "user"}}
+	if err := store.UpsertEdge(link); err != nil {
+		t.Fatal(err)
+	}
+
+	// old.go was deleted and A-1 no longer touches a.go
+	second := []Node{first[0], first[2]}
+	nodes, edges, _, err := Persist(store, second, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 3 {
+		t.Errorf("got %d nodes, want the two loaded and the hand-made one", len(nodes))
+	}
+	if len(edges) != 1 || edges[0].Metadata.CreatedBy != "user" {
+		t.Errorf("edges = %+v, want only the hand-made link", edges)
+	}
+
+	// A failed source leaves what it stored before
+	nodes, _, _, err = Persist(store, []Node{first[2]}, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 3 {
+		t.Errorf("partial load: got %d nodes, want the stored issue kept", len(nodes))
+	}
+}
+
+// TestPersistPrunesOnlyTheLoadedSources checks a complete load leaves nodes
+// and edges of sources it did not come from alone.
+func TestPersistPrunesOnlyTheLoadedSources(t *testing.T) {
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	first := []Node{
+		{ID: "file:a.go", Type: NodeTypeFile, Source: "git", Data: []byte(`{"path":"a.go"}`)},
+		{ID: "file:old.go", Type: NodeTypeFile, Source: "git", Data: []byte(`{"path":"old.go"}`)},
+		{ID: "doc:notes", Type: NodeTypeDocument, Source: "obsidian", Data: []byte(`{"title":"Notes"}`)},
+	}
+	firstEdges := []Edge{{FromID: "doc:notes", ToID: "file:a.go", Relation: EdgeRelated}}
+	if _, _, _, err := Persist(store, first, firstEdges, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// The vault is not loaded this time; old.go was deleted
+	nodes, edges, _, err := Persist(store, first[:1], nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]bool)
+	for _, node := range nodes {
+		ids[node.ID] = true
+	}
+	if len(nodes) != 2 || !ids["file:a.go"] || !ids["doc:notes"] {
+		t.Errorf("stored nodes = %v, want a.go and the note", ids)
+	}
+	if len(edges) != 1 {
+		t.Errorf("stored edges = %+v, want the note's link kept", edges)
+	}
+}
@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// QueryResult holds the tabular output of an ad-hoc SQL query.
+// All values are rendered as strings for display.
+type QueryResult struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Query runs a read-only SQL statement against the store and returns the rows.
+// This is the escape hatch for advanced users: the connection is switched to
+// query_only mode for the duration of the call, so INSERT/UPDATE/DELETE fail.
+func (s *SQLiteStore) Query(query string) (*QueryResult, error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is Query, abandoned when ctx is cancelled.
+func (s *SQLiteStore) QueryContext(ctx context.Context, query string) (*QueryResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return nil, fmt.Errorf("failed to enable read-only mode: %w", err)
+	}
+	// Restore the pooled connection to writable before it is returned, even
+	// when the query was cancelled
+	defer func() { _, _ = conn.ExecContext(context.WithoutCancel(ctx), "PRAGMA query_only = OFF") }()
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanResult(rows)
+}
+
+// scanResult renders every row of an ad-hoc query as strings
+func scanResult(rows *sql.Rows) (*QueryResult, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	result := &QueryResult{Columns: columns}
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		row := make([]string, len(columns))
+		for i, v := range values {
+			if v.Valid {
+				row[i] = v.String
+			} else {
+				row[i] = "NULL"
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return result, nil
+}
@@ -9,12 +9,19 @@ import (
 type NodeType string
 
 const (
-	NodeTypeIssue   NodeType = "Issue"
-	NodeTypePR      NodeType = "PR"
-	NodeTypeCommit  NodeType = "Commit"
-	NodeTypeFile    NodeType = "File"
-	NodeTypeProject NodeType = "Project"
-	NodeTypeService NodeType = "Service"
+	NodeTypeIssue      NodeType = "Issue"
+	NodeTypePR         NodeType = "PR"
+	NodeTypeCommit     NodeType = "Commit"
+	NodeTypeFile       NodeType = "File"
+	NodeTypeProject    NodeType = "Project"
+	NodeTypeService    NodeType = "Service"
+	NodeTypePerson     NodeType = "Person"
+	NodeTypeTeam       NodeType = "Team"
+	NodeTypeTask       NodeType = "Task"       // Checkbox item from a Markdown planning file
+	NodeTypeDocument   NodeType = "Document"   // Note from a knowledge base (Obsidian vault)
+	NodeTypeDiscussion NodeType = "Discussion" // Decision thread (email)
+	NodeTypeRelease    NodeType = "Release"    // Git tag marking what shipped
+	NodeTypeDependency NodeType = "Dependency" // Module or package a project's manifest requires
 )
 
 // EdgeType represents the relationship between nodes
@@ -29,6 +36,10 @@ const (
 	EdgeModifies   EdgeType = "modifies"
 	EdgeMentions   EdgeType = "mentions"
 	EdgeParentOf   EdgeType = "parent_of"
+	EdgeAssignedTo EdgeType = "assigned_to" // Work → person/team responsible for it
+	EdgeAuthored   EdgeType = "authored"    // Person → commit/PR they wrote
+	EdgeTagged     EdgeType = "tagged"      // Release → the commit its tag points at
+	EdgeDependsOn  EdgeType = "depends_on"  // Project → dependency its manifest requires
 )
 
 // Role represents access level (from ADR-006 IDP spec)
@@ -40,6 +51,38 @@ const (
 	RoleIC   Role = "ic"
 )
 
+// roleRank orders roles from the broadest view (exec) to the most detailed (ic)
+func roleRank(r Role) int {
+	switch r {
+	case RoleExec:
+		return 0
+	case RoleLead:
+		return 1
+	default:
+		return 2 // Unset access levels are treated as IC-level detail
+	}
+}
+
+// CanSee reports whether a viewer with role r sees nodes at the given access level.
+// Exec sees exec-level nodes only, leads see exec+lead, ICs see everything.
+// Nodes without an access level are visible to every role.
+func (r Role) CanSee(level Role) bool {
+	if level == "" {
+		return true
+	}
+	return roleRank(level) <= roleRank(r)
+}
+
+// ValidateRole checks if a string is a valid Role
+func ValidateRole(r string) bool {
+	switch Role(r) {
+	case RoleExec, RoleLead, RoleIC:
+		return true
+	default:
+		return false
+	}
+}
+
 // Node represents a graph node with arbitrary JSON data
 type Node struct {
 	ID       string          `json:"id"`
@@ -53,9 +96,9 @@ type Node struct {
 type NodeMetadata struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
-	CreatedBy   string    `json:"created_by"`    // user | ai:<session_id>
-	AccessLevel Role      `json:"access_level"`  // exec | lead | ic
-	SyncedAt    time.Time `json:"synced_at"`     // Last API sync
+	CreatedBy   string    `json:"created_by"`   // user | ai:<session_id>
+	AccessLevel Role      `json:"access_level"` // exec | lead | ic
+	SyncedAt    time.Time `json:"synced_at"`    // Last API sync
 }
 
 // Edge represents a directed relationship between two nodes
@@ -70,6 +113,7 @@ type Edge struct {
 // EdgeMetadata contains optional relationship metadata
 type EdgeMetadata struct {
 	CreatedAt time.Time              `json:"created_at,omitempty"`
+	CreatedBy string                 `json:"created_by,omitempty"` // user | ai:<session_id>; empty when derived from a source
 	Data      map[string]interface{} `json:"data,omitempty"`
 }
 
@@ -83,7 +127,9 @@ type NodeFilter struct {
 // ValidateNodeType checks if a string is a valid NodeType
 func ValidateNodeType(t string) bool {
 	switch NodeType(t) {
-	case NodeTypeIssue, NodeTypePR, NodeTypeCommit, NodeTypeFile, NodeTypeProject, NodeTypeService:
+	case NodeTypeIssue, NodeTypePR, NodeTypeCommit, NodeTypeFile, NodeTypeProject, NodeTypeService,
+		NodeTypePerson, NodeTypeTeam, NodeTypeTask, NodeTypeDocument, NodeTypeDiscussion, NodeTypeRelease,
+		NodeTypeDependency:
 		return true
 	default:
 		return false
@@ -93,7 +139,8 @@ func ValidateNodeType(t string) bool {
 // ValidateEdgeType checks if a string is a valid EdgeType
 func ValidateEdgeType(t string) bool {
 	switch EdgeType(t) {
-	case EdgeBlocks, EdgeRelated, EdgeImplements, EdgeCalls, EdgeOwns, EdgeModifies, EdgeMentions, EdgeParentOf:
+	case EdgeBlocks, EdgeRelated, EdgeImplements, EdgeCalls, EdgeOwns, EdgeModifies, EdgeMentions, EdgeParentOf,
+		EdgeAssignedTo, EdgeAuthored, EdgeTagged, EdgeDependsOn:
 		return true
 	default:
 		return false
@@ -136,6 +183,30 @@ func (n *Node) Description() string {
 	return ""
 }
 
+// Identifier extracts the short reference from node data (e.g. CET-352)
+func (n *Node) Identifier() string {
+	var data map[string]interface{}
+	if err := json.Unmarshal(n.Data, &data); err != nil {
+		return ""
+	}
+	if identifier, ok := data["identifier"].(string); ok {
+		return identifier
+	}
+	return ""
+}
+
+// URL extracts the link to the node in its source from node data
+func (n *Node) URL() string {
+	var data map[string]interface{}
+	if err := json.Unmarshal(n.Data, &data); err != nil {
+		return ""
+	}
+	if url, ok := data["url"].(string); ok {
+		return url
+	}
+	return ""
+}
+
 // Status extracts the status field from node data
 func (n *Node) Status() string {
 	var data map[string]interface{}
@@ -160,6 +231,18 @@ func (n *Node) Priority() int {
 	return 0
 }
 
+// Owner extracts the owner field from node data (inferred for files and directories)
+func (n *Node) Owner() string {
+	var data map[string]interface{}
+	if err := json.Unmarshal(n.Data, &data); err != nil {
+		return ""
+	}
+	if owner, ok := data["owner"].(string); ok {
+		return owner
+	}
+	return ""
+}
+
 // Labels extracts the labels field from node data
 func (n *Node) Labels() []string {
 	var data map[string]interface{}
@@ -0,0 +1,53 @@
+package graph
+
+import "fmt"
+
+// MergeShared publishes a locally loaded graph to a store the team shares and
+// returns it together with everything teammates have published. Local copies
+// win over stored ones, since they were just loaded from the source. Nodes
+// and edges the store rejects (unknown types, edges to nodes nobody has
+// published) are left out of the store and counted in skipped.
+func MergeShared(store GraphStore, nodes []Node, edges []Edge) (mergedNodes []Node, mergedEdges []Edge, skipped int, err error) {
+	local := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		local[node.ID] = true
+		if err := store.UpsertNode(node); err != nil {
+			skipped++
+		}
+	}
+	localEdges := make(map[string]bool, len(edges))
+	for _, edge := range edges {
+		localEdges[edgeKey(edge)] = true
+		if err := store.UpsertEdge(edge); err != nil {
+			skipped++
+		}
+	}
+
+	stored, err := store.ListNodes(nil)
+	if err != nil {
+		return nodes, edges, skipped, fmt.Errorf("reading shared nodes: %w", err)
+	}
+	storedEdges, err := store.ListEdges()
+	if err != nil {
+		return nodes, edges, skipped, fmt.Errorf("reading shared edges: %w", err)
+	}
+
+	mergedNodes = append(mergedNodes, nodes...)
+	for _, node := range stored {
+		if !local[node.ID] {
+			mergedNodes = append(mergedNodes, node)
+		}
+	}
+	mergedEdges = append(mergedEdges, edges...)
+	for _, edge := range storedEdges {
+		if !localEdges[edgeKey(edge)] {
+			mergedEdges = append(mergedEdges, edge)
+		}
+	}
+	return mergedNodes, mergedEdges, skipped, Flush(store)
+}
+
+// edgeKey identifies an edge the way the store's unique constraint does
+func edgeKey(edge Edge) string {
+	return edge.FromID + "\x00" + string(edge.Relation) + "\x00" + edge.ToID
+}
@@ -0,0 +1,41 @@
+package graph
+
+import "testing"
+
+// TestMergeSharedCombinesTeamGraphs checks that two clients publishing to the
+// same store each see the other's nodes, with their own copies winning.
+func TestMergeSharedCombinesTeamGraphs(t *testing.T) {
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	alice := []Node{
+		{ID: "issue:A-1", Type: NodeTypeIssue, Source: "linear", Data: []byte(`{"title":"Old title"}`)},
+		{ID: "file:a.go", Type: NodeTypeFile, Source: "git", Data: []byte(`{"path":"a.go"}`)},
+	}
+	aliceEdges := []Edge{{FromID: "issue:A-1", ToID: "file:a.go", Relation: EdgeModifies}}
+	if _, _, skipped, err := MergeShared(store, alice, aliceEdges); err != nil || skipped != 0 {
+		t.Fatalf("alice: skipped=%d err=%v", skipped, err)
+	}
+
+	bob := []Node{{ID: "issue:A-1", Type: NodeTypeIssue, Source: "linear", Data: []byte(`{"title":"New title"}`)}}
+	bobEdges := []Edge{{FromID: "issue:A-1", ToID: "file:missing.go", Relation: EdgeModifies}}
+	nodes, edges, skipped, err := MergeShared(store, bob, bobEdges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 1 {
+		t.Errorf("skipped = %d, want the dangling edge", skipped)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("got %d nodes, want bob's issue and alice's file", len(nodes))
+	}
+	if title := nodes[0].Title(); title != "New title" {
+		t.Errorf("issue title = %q, want the local copy", title)
+	}
+	if len(edges) != 2 {
+		t.Errorf("got %d edges, want bob's local edge and alice's shared one", len(edges))
+	}
+}
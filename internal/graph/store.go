@@ -4,19 +4,21 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
-// Store provides persistent storage for the knowledge graph using SQLite
-type Store struct {
-	db *sql.DB
+// SQLiteStore provides persistent storage for the knowledge graph using SQLite
+type SQLiteStore struct {
+	db        *sql.DB
+	encrypted *encryptedFile // Set for a store encrypted at rest (see NewEncryptedStore)
 }
 
 // NewStore creates a new graph store at the specified database path
 // If dbPath is ":memory:", an in-memory database is used
-func NewStore(dbPath string) (*Store, error) {
+func NewStore(dbPath string) (*SQLiteStore, error) {
 	db, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
@@ -28,7 +30,7 @@ func NewStore(dbPath string) (*Store, error) {
 		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
 	}
 
-	store := &Store{db: db}
+	store := &SQLiteStore{db: db}
 
 	if err := store.CreateTables(); err != nil {
 		_ = db.Close()
@@ -39,7 +41,7 @@ func NewStore(dbPath string) (*Store, error) {
 }
 
 // CreateTables initializes the database schema per ADR-003
-func (s *Store) CreateTables() error {
+func (s *SQLiteStore) CreateTables() error {
 	schema := `
 	-- Core nodes table
 	CREATE TABLE IF NOT EXISTS nodes (
@@ -64,12 +66,66 @@ func (s *Store) CreateTables() error {
 		UNIQUE(from_id, to_id, relation)
 	);
 
+	-- Nodes and edges past their TTL: out of the working graph, still queryable
+	CREATE TABLE IF NOT EXISTS archived_nodes (
+		id TEXT PRIMARY KEY,
+		type TEXT NOT NULL,
+		source TEXT NOT NULL,
+		data JSON NOT NULL,
+		metadata JSON NOT NULL,
+		archived_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS archived_edges (
+		id TEXT PRIMARY KEY,
+		from_id TEXT NOT NULL,
+		to_id TEXT NOT NULL,
+		relation TEXT NOT NULL,
+		metadata JSON,
+		archived_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+
+	-- When each node was last restored from the archive, which its TTL
+	-- counts from even though its source reports an older update
+	CREATE TABLE IF NOT EXISTS restored_nodes (
+		id TEXT PRIMARY KEY,
+		restored_at TIMESTAMP NOT NULL
+	);
+
+	-- One row per source per sync: what each refresh cost
+	CREATE TABLE IF NOT EXISTS sync_runs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		source TEXT NOT NULL,
+		started_at TIMESTAMP NOT NULL,
+		duration_ms INTEGER NOT NULL,
+		nodes INTEGER NOT NULL,
+		edges INTEGER NOT NULL,
+		requests INTEGER NOT NULL,
+		bytes INTEGER NOT NULL,
+		cost INTEGER NOT NULL,
+		error TEXT NOT NULL DEFAULT ''
+	);
+
+	CREATE TABLE IF NOT EXISTS sync_state (
+		source TEXT PRIMARY KEY,
+		synced_until TIMESTAMP NOT NULL
+	);
+
 	-- Indexes for graph traversal performance
 	CREATE INDEX IF NOT EXISTS idx_nodes_type ON nodes(type);
 	CREATE INDEX IF NOT EXISTS idx_nodes_source ON nodes(source);
-	CREATE INDEX IF NOT EXISTS idx_edges_from ON edges(from_id);
-	CREATE INDEX IF NOT EXISTS idx_edges_to ON edges(to_id);
 	CREATE INDEX IF NOT EXISTS idx_edges_relation ON edges(relation);
+	-- Covering indexes for edge lookups by endpoint: they hold every column
+	-- the lookups select, so they need no table read, and they serve plain
+	-- from_id / to_id lookups (and cascading deletes) in place of the
+	-- single-column indexes earlier versions created
+	DROP INDEX IF EXISTS idx_edges_from;
+	DROP INDEX IF EXISTS idx_edges_to;
+	DROP INDEX IF EXISTS idx_edges_from_cover;
+	DROP INDEX IF EXISTS idx_edges_to_cover;
+	CREATE INDEX IF NOT EXISTS idx_edges_from_covering ON edges(from_id, relation, to_id, id, metadata);
+	CREATE INDEX IF NOT EXISTS idx_edges_to_covering ON edges(to_id, relation, from_id, id, metadata);
+	CREATE INDEX IF NOT EXISTS idx_sync_runs_started ON sync_runs(started_at);
 
 	-- Graph views for common queries
 	CREATE VIEW IF NOT EXISTS issue_dependencies AS
@@ -93,6 +149,20 @@ func (s *Store) CreateTables() error {
 	JOIN edges e ON n1.id = e.from_id AND e.relation = 'modifies'
 	JOIN nodes n2 ON e.to_id = n2.id
 	WHERE n1.type = 'PR' AND n2.type = 'File';
+
+	CREATE VIEW IF NOT EXISTS license_report AS
+	SELECT
+		id as node_id,
+		type,
+		COALESCE(json_extract(data, '$.path'), json_extract(data, '$.name')) as path,
+		json_extract(data, '$.license') as license,
+		json_extract(data, '$.module') as module_path,
+		json_extract(data, '$.package') as package_name,
+		json_extract(data, '$.package_version') as package_version
+	FROM nodes
+	WHERE json_extract(data, '$.license') IS NOT NULL
+		OR json_extract(data, '$.module') IS NOT NULL
+		OR json_extract(data, '$.package') IS NOT NULL;
 	`
 
 	_, err := s.db.Exec(schema)
@@ -105,7 +175,7 @@ func (s *Store) CreateTables() error {
 
 // AddNode inserts a new node into the graph
 // Returns error if node with same ID already exists
-func (s *Store) AddNode(node Node) error {
+func (s *SQLiteStore) AddNode(node Node) error {
 	// Set default metadata if not provided
 	if node.Metadata.CreatedAt.IsZero() {
 		node.Metadata.CreatedAt = time.Now()
@@ -139,7 +209,7 @@ func (s *Store) AddNode(node Node) error {
 }
 
 // UpsertNode inserts or updates a node (idempotent operation)
-func (s *Store) UpsertNode(node Node) error {
+func (s *SQLiteStore) UpsertNode(node Node) error {
 	// Update timestamp
 	node.Metadata.UpdatedAt = time.Now()
 	if node.Metadata.CreatedAt.IsZero() {
@@ -177,7 +247,7 @@ func (s *Store) UpsertNode(node Node) error {
 
 // AddEdge inserts a new edge into the graph
 // Returns error if edge with same (from_id, to_id, relation) already exists
-func (s *Store) AddEdge(edge Edge) error {
+func (s *SQLiteStore) AddEdge(edge Edge) error {
 	// Validate edge type
 	if !ValidateEdgeType(string(edge.Relation)) {
 		return fmt.Errorf("invalid edge relation: %s", edge.Relation)
@@ -217,7 +287,7 @@ func (s *Store) AddEdge(edge Edge) error {
 }
 
 // UpsertEdge inserts or updates an edge (idempotent operation)
-func (s *Store) UpsertEdge(edge Edge) error {
+func (s *SQLiteStore) UpsertEdge(edge Edge) error {
 	// Validate edge type
 	if !ValidateEdgeType(string(edge.Relation)) {
 		return fmt.Errorf("invalid edge relation: %s", edge.Relation)
@@ -259,7 +329,7 @@ func (s *Store) UpsertEdge(edge Edge) error {
 }
 
 // GetNode retrieves a node by ID
-func (s *Store) GetNode(id string) (*Node, error) {
+func (s *SQLiteStore) GetNode(id string) (*Node, error) {
 	var node Node
 	var metadataJSON []byte
 
@@ -286,7 +356,7 @@ func (s *Store) GetNode(id string) (*Node, error) {
 
 // GetNeighbors returns all nodes connected to the given node
 // regardless of edge direction or relation type
-func (s *Store) GetNeighbors(nodeID string) ([]Node, error) {
+func (s *SQLiteStore) GetNeighbors(nodeID string) ([]Node, error) {
 	rows, err := s.db.Query(`
 		SELECT DISTINCT n.id, n.type, n.source, n.data, n.metadata
 		FROM nodes n
@@ -300,33 +370,11 @@ func (s *Store) GetNeighbors(nodeID string) ([]Node, error) {
 	}
 	defer func() { _ = rows.Close() }()
 
-	var neighbors []Node
-	for rows.Next() {
-		var node Node
-		var metadataJSON []byte
-
-		err := rows.Scan(&node.ID, &node.Type, &node.Source, &node.Data, &metadataJSON)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan node: %w", err)
-		}
-
-		// Unmarshal metadata
-		if err := json.Unmarshal(metadataJSON, &node.Metadata); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-		}
-
-		neighbors = append(neighbors, node)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
-	}
-
-	return neighbors, nil
+	return scanNodes(rows)
 }
 
 // GetEdges returns all edges connected to a node (both incoming and outgoing)
-func (s *Store) GetEdges(nodeID string) ([]Edge, error) {
+func (s *SQLiteStore) GetEdges(nodeID string) ([]Edge, error) {
 	rows, err := s.db.Query(`
 		SELECT id, from_id, to_id, relation, metadata
 		FROM edges
@@ -337,7 +385,11 @@ func (s *Store) GetEdges(nodeID string) ([]Edge, error) {
 		return nil, fmt.Errorf("failed to query edges: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
+	return scanEdges(rows)
+}
 
+// scanEdges reads edge rows (id, from_id, to_id, relation, metadata)
+func scanEdges(rows *sql.Rows) ([]Edge, error) {
 	var edges []Edge
 	for rows.Next() {
 		var edge Edge
@@ -365,8 +417,84 @@ func (s *Store) GetEdges(nodeID string) ([]Edge, error) {
 	return edges, nil
 }
 
+// ListEdges returns every edge in the graph
+func (s *SQLiteStore) ListEdges() ([]Edge, error) {
+	rows, err := s.db.Query("SELECT id, from_id, to_id, relation, metadata FROM edges")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query edges: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanEdges(rows)
+}
+
+// GetNodesByIDs retrieves many nodes at once, in the order of ids.
+// Unknown IDs are skipped rather than reported.
+func (s *SQLiteStore) GetNodesByIDs(ids []string) ([]Node, error) {
+	ids = uniqueIDs(ids)
+	found := make(map[string]Node, len(ids))
+	for start := 0; start < len(ids); start += batchSize {
+		batch := ids[start:min(start+batchSize, len(ids))]
+		placeholders, args := inList(batch)
+		rows, err := s.db.Query("SELECT id, type, source, data, metadata FROM nodes WHERE id IN ("+placeholders+")", args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query nodes: %w", err)
+		}
+		nodes, err := scanNodes(rows)
+		_ = rows.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			found[node.ID] = node
+		}
+	}
+	return orderNodes(ids, found), nil
+}
+
+// GetEdgesForNodes returns the edges of many nodes at once, keyed by node ID.
+// An edge between two requested nodes is listed under both.
+func (s *SQLiteStore) GetEdgesForNodes(ids []string) (map[string][]Edge, error) {
+	ids = uniqueIDs(ids)
+	seen := make(map[string]bool)
+	var edges []Edge
+	for start := 0; start < len(ids); start += batchSize {
+		batch := ids[start:min(start+batchSize, len(ids))]
+		placeholders, args := inList(batch)
+		rows, err := s.db.Query(`
+			SELECT id, from_id, to_id, relation, metadata FROM edges WHERE from_id IN (`+placeholders+`)
+			UNION
+			SELECT id, from_id, to_id, relation, metadata FROM edges WHERE to_id IN (`+placeholders+`)
+		`, append(args, args...)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query edges: %w", err)
+		}
+		batchEdges, err := scanEdges(rows)
+		_ = rows.Close()
+		if err != nil {
+			return nil, err
+		}
+		// Edges spanning two batches come back twice
+		for _, edge := range batchEdges {
+			if !seen[edge.ID] {
+				seen[edge.ID] = true
+				edges = append(edges, edge)
+			}
+		}
+	}
+	return groupEdges(ids, edges), nil
+}
+
+// inList returns "?,?,?" and the arguments for an IN clause
+func inList(ids []string) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","), args
+}
+
 // DeleteNode removes a node and all connected edges (cascade delete)
-func (s *Store) DeleteNode(id string) error {
+func (s *SQLiteStore) DeleteNode(id string) error {
 	result, err := s.db.Exec("DELETE FROM nodes WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("failed to delete node: %w", err)
@@ -385,7 +513,7 @@ func (s *Store) DeleteNode(id string) error {
 }
 
 // DeleteEdge removes a specific edge by ID
-func (s *Store) DeleteEdge(id string) error {
+func (s *SQLiteStore) DeleteEdge(id string) error {
 	result, err := s.db.Exec("DELETE FROM edges WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("failed to delete edge: %w", err)
@@ -404,7 +532,20 @@ func (s *Store) DeleteEdge(id string) error {
 }
 
 // ListNodes returns all nodes, optionally filtered
-func (s *Store) ListNodes(filter *NodeFilter) ([]Node, error) {
+func (s *SQLiteStore) ListNodes(filter *NodeFilter) ([]Node, error) {
+	query, args := listNodesQuery(filter, "json_extract(metadata, '$.updated_at')")
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nodes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanNodes(rows)
+}
+
+// listNodesQuery builds the ListNodes statement; updatedAt is the
+// dialect's expression for the metadata's updated_at field
+func listNodesQuery(filter *NodeFilter, updatedAt string) (string, []interface{}) {
 	query := "SELECT id, type, source, data, metadata FROM nodes WHERE 1=1"
 	args := []interface{}{}
 
@@ -434,17 +575,15 @@ func (s *Store) ListNodes(filter *NodeFilter) ([]Node, error) {
 		}
 
 		if !filter.UpdatedAfter.IsZero() {
-			query += " AND json_extract(metadata, '$.updated_at') > ?"
+			query += " AND " + updatedAt + " > ?"
 			args = append(args, filter.UpdatedAfter.Format(time.RFC3339))
 		}
 	}
+	return query, args
+}
 
-	rows, err := s.db.Query(query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query nodes: %w", err)
-	}
-	defer func() { _ = rows.Close() }()
-
+// scanNodes reads node rows (id, type, source, data, metadata)
+func scanNodes(rows *sql.Rows) ([]Node, error) {
 	var nodes []Node
 	for rows.Next() {
 		var node Node
@@ -469,10 +608,18 @@ func (s *Store) ListNodes(filter *NodeFilter) ([]Node, error) {
 	return nodes, nil
 }
 
-// Close closes the database connection
-func (s *Store) Close() error {
-	if s.db != nil {
-		return s.db.Close()
+// Close closes the database connection, first writing an encrypted store
+// back to disk
+func (s *SQLiteStore) Close() error {
+	if s.db == nil {
+		return nil
 	}
-	return nil
+	if s.encrypted != nil {
+		defer func() { _ = unlockFile(s.encrypted.lock) }()
+	}
+	if err := s.Flush(); err != nil {
+		_ = s.db.Close()
+		return err
+	}
+	return s.db.Close()
 }
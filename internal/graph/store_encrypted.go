@@ -0,0 +1,253 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// An encrypted store is a SQLite database kept in memory while open and
+// written to disk only as AES-256-GCM ciphertext, so issue data never
+// touches the disk in the clear. The file layout is:
+//
+//	magic (8) | salt (16) | nonce (12) | sealed database image
+//
+// The key is derived from the passphrase with PBKDF2-SHA256 and a per-file
+// salt; the magic is authenticated as additional data.
+const (
+	encryptedMagic  = "MAATENC1"
+	saltSize        = 16
+	pbkdf2Rounds    = 600_000
+	sqliteMagic     = "SQLite format 3\x00"
+	mainSchema      = "main"
+	encryptedHeader = len(encryptedMagic) + saltSize
+)
+
+// IsEncrypted reports whether the file at path is an encrypted graph store.
+func IsEncrypted(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	magic := make([]byte, len(encryptedMagic))
+	_, err = f.Read(magic)
+	return err == nil && string(magic) == encryptedMagic
+}
+
+// NewEncryptedStore opens the graph store at path, encrypted with a key
+// derived from passphrase. A missing file starts an empty store; an existing
+// plaintext database is loaded and written back encrypted on the next Flush,
+// which migrates it in place. The store holds an exclusive lock on path (in
+// path.lock) until closed, so a second maat can't overwrite its writes.
+func NewEncryptedStore(path, passphrase string) (store *SQLiteStore, err error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("encrypted store needs a non-empty key")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("creating database directory: %w", err)
+	}
+	lock, err := lockFile(path + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = unlockFile(lock)
+		}
+	}()
+
+	image, file, err := readEncrypted(path, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	file.lock = lock
+
+	// One connection holds the in-memory database; the pool must not open
+	// a second (empty) one or drop it while idle
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	if image != nil {
+		err := withRawConn(db, func(conn *sqlite3.SQLiteConn) error {
+			return conn.Deserialize(image, mainSchema)
+		})
+		if err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("loading database: %w", err)
+		}
+	}
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
+	}
+
+	store = &SQLiteStore{db: db, encrypted: file}
+	if err := store.CreateTables(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create tables: %w", err)
+	}
+	return store, nil
+}
+
+// encryptedFile is where an encrypted store is persisted
+type encryptedFile struct {
+	path string
+	key  []byte
+	salt []byte
+	lock *os.File // Held until the store is closed
+}
+
+// Flush writes an encrypted store's current contents to disk. It replaces
+// the file atomically, so a crash mid-write leaves the previous version.
+// Plain stores write through already; Flush is a no-op for them.
+func (s *SQLiteStore) Flush() error {
+	if s.encrypted == nil {
+		return nil
+	}
+	var image []byte
+	err := withRawConn(s.db, func(conn *sqlite3.SQLiteConn) error {
+		var err error
+		image, err = conn.Serialize(mainSchema)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("serializing database: %w", err)
+	}
+
+	sealed, err := seal(image, s.encrypted.key, s.encrypted.salt)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(s.encrypted.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating database directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".graph-*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing encrypted database: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(sealed); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing encrypted database: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing encrypted database: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.encrypted.path); err != nil {
+		return fmt.Errorf("writing encrypted database: %w", err)
+	}
+	return nil
+}
+
+// withRawConn runs fn on the driver connection behind the pool
+func withRawConn(db *sql.DB, fn func(*sqlite3.SQLiteConn) error) error {
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	return conn.Raw(func(driverConn any) error {
+		sqliteConn, ok := driverConn.(*sqlite3.SQLiteConn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		return fn(sqliteConn)
+	})
+}
+
+// readEncrypted returns the database image at path (nil when there is none
+// yet) and the key and salt to keep encrypting it with
+func readEncrypted(path, passphrase string) ([]byte, *encryptedFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("reading database: %w", err)
+	}
+
+	var image, salt []byte
+	switch {
+	case len(data) == 0:
+		// No store yet
+	case bytes.HasPrefix(data, []byte(sqliteMagic)):
+		// A plaintext store from before encryption was enabled
+		image = data
+	case !bytes.HasPrefix(data, []byte(encryptedMagic)) || len(data) < encryptedHeader:
+		return nil, nil, fmt.Errorf("%s is not a graph database", path)
+	default:
+		salt = data[len(encryptedMagic):encryptedHeader]
+	}
+	if salt == nil {
+		salt = make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, nil, fmt.Errorf("generating salt: %w", err)
+		}
+	}
+
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Rounds, 32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("deriving database key: %w", err)
+	}
+	if image == nil && len(data) > 0 {
+		if image, err = open(data, key); err != nil {
+			return nil, nil, fmt.Errorf("decrypting %s: wrong key or damaged file", path)
+		}
+	}
+	return image, &encryptedFile{path: path, key: key, salt: salt}, nil
+}
+
+// seal encrypts a database image into the file layout
+func seal(image, key, salt []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	out := make([]byte, 0, encryptedHeader+len(nonce)+len(image)+gcm.Overhead())
+	out = append(out, encryptedMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, image, []byte(encryptedMagic)), nil
+}
+
+// open decrypts the image in an encrypted file
+func open(data, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	rest := data[encryptedHeader:]
+	if len(rest) < gcm.NonceSize() {
+		return nil, fmt.Errorf("truncated file")
+	}
+	nonce, sealed := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, []byte(encryptedMagic))
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
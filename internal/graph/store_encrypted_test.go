@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestEncryptedStoreRoundTrip checks that an encrypted store persists its
+// data, keeps it unreadable on disk and refuses the wrong key.
+func TestEncryptedStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.db")
+
+	// Start from a plaintext store, which the first encrypted open migrates
+	plain, err := NewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := Node{ID: "issue:SEC-1", Type: NodeTypeIssue, Source: "linear", Data: []byte(`{"title":"Customer Globex churn risk"}`)}
+	if err := plain.AddNode(secret); err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewEncryptedStore(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(path) || bytes.Contains(data, []byte("Globex")) {
+		t.Fatal("store is readable on disk after closing")
+	}
+
+	if _, err := NewEncryptedStore(path, "wrong key"); err == nil {
+		t.Error("opened with the wrong key")
+	}
+	store, err = NewEncryptedStore(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+	node, err := store.GetNode(secret.ID)
+	if err != nil || node == nil || node.ID != secret.ID {
+		t.Fatalf("GetNode after reopening = %+v, %v", node, err)
+	}
+	if result, err := store.Query("SELECT count(*) FROM nodes"); err != nil || result.Rows[0][0] != "1" {
+		t.Errorf("Query = %+v, %v", result, err)
+	}
+}
+
+// TestEncryptedStoreFlushesBatchesAndLocks checks a persisted load is on
+// disk before the store is closed, and that a second open of the same file
+// is refused while the first holds it.
+func TestEncryptedStoreFlushesBatchesAndLocks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.db")
+	store, err := NewEncryptedStore(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewEncryptedStore(path, "correct horse"); err == nil {
+		t.Fatal("opened a store another one holds")
+	}
+
+	node := Node{ID: "issue:SEC-2", Type: NodeTypeIssue, Source: "linear", Data: []byte(`{"title":"Flushed"}`)}
+	if _, _, _, err := Persist(store, []Node{node}, nil, true); err != nil {
+		t.Fatal(err)
+	}
+	image, _, err := readEncrypted(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(image, []byte(node.ID)) {
+		t.Error("persisted node is not on disk until the store closes")
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := NewEncryptedStore(path, "correct horse")
+	if err != nil {
+		t.Fatalf("reopening after close: %v", err)
+	}
+	_ = reopened.Close()
+}
@@ -0,0 +1,336 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNoSQL is returned by Query on backends that cannot run SQL.
+var ErrNoSQL = errors.New("this graph store does not support SQL queries")
+
+// MemoryStore keeps the knowledge graph in process, with the same semantics
+// as the SQL stores (unique edges, cascade deletes, "not found" errors) but
+// no SQL. Nothing survives Close; it suits tests and throwaway runs.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	nodes     map[string]Node
+	nodeOrder []string // Insertion order, which list results follow
+	edges     map[string]Edge
+	edgeOrder []string
+}
+
+// NewMemoryStore returns an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{nodes: make(map[string]Node), edges: make(map[string]Edge)}
+}
+
+// AddNode inserts a new node into the graph
+// Returns error if node with same ID already exists
+func (s *MemoryStore) AddNode(node Node) error {
+	if node.Metadata.CreatedAt.IsZero() {
+		node.Metadata.CreatedAt = time.Now()
+	}
+	if node.Metadata.UpdatedAt.IsZero() {
+		node.Metadata.UpdatedAt = time.Now()
+	}
+	if !ValidateNodeType(string(node.Type)) {
+		return fmt.Errorf("invalid node type: %s", node.Type)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.nodes[node.ID]; ok {
+		return fmt.Errorf("failed to insert node: %s already exists", node.ID)
+	}
+	s.putNode(node)
+	return nil
+}
+
+// UpsertNode inserts or updates a node (idempotent operation)
+func (s *MemoryStore) UpsertNode(node Node) error {
+	node.Metadata.UpdatedAt = time.Now()
+	if node.Metadata.CreatedAt.IsZero() {
+		node.Metadata.CreatedAt = time.Now()
+	}
+	if !ValidateNodeType(string(node.Type)) {
+		return fmt.Errorf("invalid node type: %s", node.Type)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.putNode(node)
+	return nil
+}
+
+// putNode stores a copy of node; the caller holds the lock
+func (s *MemoryStore) putNode(node Node) {
+	if _, ok := s.nodes[node.ID]; !ok {
+		s.nodeOrder = append(s.nodeOrder, node.ID)
+	}
+	node.Data = bytes.Clone(node.Data)
+	s.nodes[node.ID] = node
+}
+
+// AddEdge inserts a new edge into the graph
+// Returns error if edge with same (from_id, to_id, relation) already exists
+func (s *MemoryStore) AddEdge(edge Edge) error {
+	edge, _, err := prepareEdge(edge)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkEndpoints(edge); err != nil {
+		return fmt.Errorf("failed to insert edge: %w", err)
+	}
+	if _, ok := s.edges[edge.ID]; ok || s.findEdge(edge) != "" {
+		return fmt.Errorf("failed to insert edge: %s already exists", edge.ID)
+	}
+	s.edges[edge.ID] = edge
+	s.edgeOrder = append(s.edgeOrder, edge.ID)
+	return nil
+}
+
+// UpsertEdge inserts or updates an edge (idempotent operation)
+func (s *MemoryStore) UpsertEdge(edge Edge) error {
+	edge, _, err := prepareEdge(edge)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkEndpoints(edge); err != nil {
+		return fmt.Errorf("failed to upsert edge: %w", err)
+	}
+	if existing := s.findEdge(edge); existing != "" {
+		// Like ON CONFLICT(from_id, to_id, relation): only metadata changes
+		stored := s.edges[existing]
+		stored.Metadata = edge.Metadata
+		s.edges[existing] = stored
+		return nil
+	}
+	if _, ok := s.edges[edge.ID]; ok {
+		return fmt.Errorf("failed to upsert edge: %s already exists", edge.ID)
+	}
+	s.edges[edge.ID] = edge
+	s.edgeOrder = append(s.edgeOrder, edge.ID)
+	return nil
+}
+
+// checkEndpoints is the foreign key check; the caller holds the lock
+func (s *MemoryStore) checkEndpoints(edge Edge) error {
+	for _, id := range []string{edge.FromID, edge.ToID} {
+		if _, ok := s.nodes[id]; !ok {
+			return fmt.Errorf("node not found: %s", id)
+		}
+	}
+	return nil
+}
+
+// findEdge returns the ID of the stored edge with the same endpoints and
+// relation, or ""; the caller holds the lock
+func (s *MemoryStore) findEdge(edge Edge) string {
+	for id, stored := range s.edges {
+		if edgeKey(stored) == edgeKey(edge) {
+			return id
+		}
+	}
+	return ""
+}
+
+// GetNode retrieves a node by ID
+func (s *MemoryStore) GetNode(id string) (*Node, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	node, ok := s.nodes[id]
+	if !ok {
+		return nil, fmt.Errorf("node not found: %s", id)
+	}
+	node.Data = bytes.Clone(node.Data)
+	return &node, nil
+}
+
+// GetNeighbors returns all nodes connected to the given node
+// regardless of edge direction or relation type
+func (s *MemoryStore) GetNeighbors(nodeID string) ([]Node, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	connected := make(map[string]bool)
+	for _, edge := range s.edges {
+		switch nodeID {
+		case edge.FromID:
+			connected[edge.ToID] = true
+		case edge.ToID:
+			connected[edge.FromID] = true
+		}
+	}
+	delete(connected, nodeID)
+
+	var neighbors []Node
+	for _, id := range s.nodeOrder {
+		if connected[id] {
+			node := s.nodes[id]
+			node.Data = bytes.Clone(node.Data)
+			neighbors = append(neighbors, node)
+		}
+	}
+	return neighbors, nil
+}
+
+// GetEdges returns all edges connected to a node (both incoming and outgoing)
+func (s *MemoryStore) GetEdges(nodeID string) ([]Edge, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var edges []Edge
+	for _, id := range s.edgeOrder {
+		if edge := s.edges[id]; edge.FromID == nodeID || edge.ToID == nodeID {
+			edges = append(edges, edge)
+		}
+	}
+	return edges, nil
+}
+
+// GetNodesByIDs retrieves many nodes at once, in the order of ids.
+// Unknown IDs are skipped rather than reported.
+func (s *MemoryStore) GetNodesByIDs(ids []string) ([]Node, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids = uniqueIDs(ids)
+	nodes := make([]Node, 0, len(ids))
+	for _, id := range ids {
+		if node, ok := s.nodes[id]; ok {
+			node.Data = bytes.Clone(node.Data)
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+// GetEdgesForNodes returns the edges of many nodes at once, keyed by node ID.
+// An edge between two requested nodes is listed under both.
+func (s *MemoryStore) GetEdgesForNodes(ids []string) (map[string][]Edge, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	edges := make([]Edge, 0, len(s.edgeOrder))
+	for _, id := range s.edgeOrder {
+		edges = append(edges, s.edges[id])
+	}
+	return groupEdges(uniqueIDs(ids), edges), nil
+}
+
+// ListEdges returns every edge in the graph
+func (s *MemoryStore) ListEdges() ([]Edge, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	edges := make([]Edge, 0, len(s.edgeOrder))
+	for _, id := range s.edgeOrder {
+		edges = append(edges, s.edges[id])
+	}
+	return edges, nil
+}
+
+// DeleteNode removes a node and all connected edges (cascade delete)
+func (s *MemoryStore) DeleteNode(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.nodes[id]; !ok {
+		return fmt.Errorf("node not found: %s", id)
+	}
+	delete(s.nodes, id)
+	s.nodeOrder = without(s.nodeOrder, func(nodeID string) bool { return nodeID == id })
+	for edgeID, edge := range s.edges {
+		if edge.FromID == id || edge.ToID == id {
+			delete(s.edges, edgeID)
+		}
+	}
+	s.edgeOrder = without(s.edgeOrder, func(edgeID string) bool {
+		_, ok := s.edges[edgeID]
+		return !ok
+	})
+	return nil
+}
+
+// DeleteEdge removes a specific edge by ID
+func (s *MemoryStore) DeleteEdge(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.edges[id]; !ok {
+		return fmt.Errorf("edge not found: %s", id)
+	}
+	delete(s.edges, id)
+	s.edgeOrder = without(s.edgeOrder, func(edgeID string) bool { return edgeID == id })
+	return nil
+}
+
+// ListNodes returns all nodes, optionally filtered
+func (s *MemoryStore) ListNodes(filter *NodeFilter) ([]Node, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var nodes []Node
+	for _, id := range s.nodeOrder {
+		node := s.nodes[id]
+		if filter != nil && !filter.matches(node) {
+			continue
+		}
+		node.Data = bytes.Clone(node.Data)
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// matches applies the filter the way ListNodes' SQL does
+func (f *NodeFilter) matches(node Node) bool {
+	if len(f.Types) > 0 && !contains(f.Types, node.Type) {
+		return false
+	}
+	if len(f.Sources) > 0 && !contains(f.Sources, node.Source) {
+		return false
+	}
+	return f.UpdatedAfter.IsZero() || node.Metadata.UpdatedAt.After(f.UpdatedAfter)
+}
+
+// Query reports ErrNoSQL: the memory store has no SQL engine.
+func (s *MemoryStore) Query(query string) (*QueryResult, error) {
+	return nil, ErrNoSQL
+}
+
+// QueryContext reports ErrNoSQL, like Query.
+func (s *MemoryStore) QueryContext(ctx context.Context, query string) (*QueryResult, error) {
+	return nil, ErrNoSQL
+}
+
+// Close drops the graph.
+func (s *MemoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nodes, s.nodeOrder = make(map[string]Node), nil
+	s.edges, s.edgeOrder = make(map[string]Edge), nil
+	return nil
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// without returns ids minus those drop matches, reusing the backing array
+func without(ids []string, drop func(string) bool) []string {
+	kept := ids[:0]
+	for _, id := range ids {
+		if !drop(id) {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
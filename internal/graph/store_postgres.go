@@ -0,0 +1,491 @@
+package graph
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// schemaLockID serializes schema setup when several clients connect to a
+// fresh database at once (any constant shared by every MAAT client works)
+const schemaLockID = 0x6d616174
+
+// PostgresStore keeps the knowledge graph in a PostgreSQL database that a
+// whole team can share. Node data and metadata are JSONB, so ad-hoc queries
+// use Postgres JSON operators (data->>'title') rather than json_extract.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore connects to the database at url ("postgres://user@host/db"
+// or a key=value connection string) and creates the schema if needed.
+// The password may come from the URL, $PGPASSWORD or ~/.pgpass.
+func NewPostgresStore(url string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	store := &PostgresStore{db: db}
+	if err := store.CreateTables(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create tables: %w", err)
+	}
+	return store, nil
+}
+
+// CreateTables initializes the same schema as the SQLite store, with
+// Postgres types. It runs under an advisory lock so concurrent first
+// connections do not race each other.
+func (s *PostgresStore) CreateTables() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS nodes (
+		id TEXT PRIMARY KEY,
+		type TEXT NOT NULL,
+		source TEXT NOT NULL,
+		data JSONB NOT NULL,
+		metadata JSONB NOT NULL,
+		created_at TIMESTAMPTZ DEFAULT now()
+	);
+
+	CREATE TABLE IF NOT EXISTS edges (
+		id TEXT PRIMARY KEY,
+		from_id TEXT NOT NULL REFERENCES nodes(id) ON DELETE CASCADE,
+		to_id TEXT NOT NULL REFERENCES nodes(id) ON DELETE CASCADE,
+		relation TEXT NOT NULL,
+		metadata JSONB,
+		created_at TIMESTAMPTZ DEFAULT now(),
+		UNIQUE(from_id, to_id, relation)
+	);
+
+	CREATE TABLE IF NOT EXISTS archived_nodes (
+		id TEXT PRIMARY KEY,
+		type TEXT NOT NULL,
+		source TEXT NOT NULL,
+		data JSONB NOT NULL,
+		metadata JSONB NOT NULL,
+		archived_at TIMESTAMPTZ DEFAULT now()
+	);
+
+	CREATE TABLE IF NOT EXISTS archived_edges (
+		id TEXT PRIMARY KEY,
+		from_id TEXT NOT NULL,
+		to_id TEXT NOT NULL,
+		relation TEXT NOT NULL,
+		metadata JSONB,
+		archived_at TIMESTAMPTZ DEFAULT now()
+	);
+
+	CREATE TABLE IF NOT EXISTS restored_nodes (
+		id TEXT PRIMARY KEY,
+		restored_at TIMESTAMPTZ NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS sync_runs (
+		id BIGSERIAL PRIMARY KEY,
+		source TEXT NOT NULL,
+		started_at TIMESTAMPTZ NOT NULL,
+		duration_ms BIGINT NOT NULL,
+		nodes INTEGER NOT NULL,
+		edges INTEGER NOT NULL,
+		requests INTEGER NOT NULL,
+		bytes BIGINT NOT NULL,
+		cost INTEGER NOT NULL,
+		error TEXT NOT NULL DEFAULT ''
+	);
+
+	CREATE TABLE IF NOT EXISTS sync_state (
+		source TEXT PRIMARY KEY,
+		synced_until TIMESTAMPTZ NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_nodes_type ON nodes(type);
+	CREATE INDEX IF NOT EXISTS idx_nodes_source ON nodes(source);
+	CREATE INDEX IF NOT EXISTS idx_edges_relation ON edges(relation);
+	DROP INDEX IF EXISTS idx_edges_from;
+	DROP INDEX IF EXISTS idx_edges_to;
+	DROP INDEX IF EXISTS idx_edges_from_cover;
+	DROP INDEX IF EXISTS idx_edges_to_cover;
+	CREATE INDEX IF NOT EXISTS idx_edges_from_covering ON edges(from_id) INCLUDE (relation, to_id, id, metadata);
+	CREATE INDEX IF NOT EXISTS idx_edges_to_covering ON edges(to_id) INCLUDE (relation, from_id, id, metadata);
+	CREATE INDEX IF NOT EXISTS idx_sync_runs_started ON sync_runs(started_at);
+
+	CREATE OR REPLACE VIEW issue_dependencies AS
+	SELECT
+		n1.id as issue_id,
+		n1.data->>'title' as issue_title,
+		n2.id as blocks_id,
+		n2.data->>'title' as blocks_title
+	FROM nodes n1
+	JOIN edges e ON n1.id = e.from_id AND e.relation = 'blocks'
+	JOIN nodes n2 ON e.to_id = n2.id
+	WHERE n1.type = 'Issue';
+
+	CREATE OR REPLACE VIEW pr_file_map AS
+	SELECT
+		n1.id as pr_id,
+		n1.data->'number' as pr_number,
+		n2.id as file_id,
+		n2.data->>'path' as file_path
+	FROM nodes n1
+	JOIN edges e ON n1.id = e.from_id AND e.relation = 'modifies'
+	JOIN nodes n2 ON e.to_id = n2.id
+	WHERE n1.type = 'PR' AND n2.type = 'File';
+
+	CREATE OR REPLACE VIEW license_report AS
+	SELECT
+		id as node_id,
+		type,
+		COALESCE(data->>'path', data->>'name') as path,
+		data->>'license' as license,
+		data->>'module' as module_path,
+		data->>'package' as package_name,
+		data->>'package_version' as package_version
+	FROM nodes
+	WHERE data->>'license' IS NOT NULL OR data->>'module' IS NOT NULL OR data->>'package' IS NOT NULL;
+	`
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin schema transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", schemaLockID); err != nil {
+		return fmt.Errorf("failed to lock schema: %w", err)
+	}
+	if _, err := tx.Exec(schema); err != nil {
+		return fmt.Errorf("failed to execute schema: %w", err)
+	}
+	return tx.Commit()
+}
+
+// AddNode inserts a new node into the graph
+// Returns error if node with same ID already exists
+func (s *PostgresStore) AddNode(node Node) error {
+	if node.Metadata.CreatedAt.IsZero() {
+		node.Metadata.CreatedAt = time.Now()
+	}
+	if node.Metadata.UpdatedAt.IsZero() {
+		node.Metadata.UpdatedAt = time.Now()
+	}
+	metadataJSON, err := nodeMetadataJSON(node)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO nodes (id, type, source, data, metadata)
+		VALUES ($1, $2, $3, $4, $5)
+	`, node.ID, node.Type, node.Source, string(node.Data), metadataJSON)
+	if err != nil {
+		return fmt.Errorf("failed to insert node: %w", err)
+	}
+	return nil
+}
+
+// UpsertNode inserts or updates a node (idempotent operation)
+func (s *PostgresStore) UpsertNode(node Node) error {
+	node.Metadata.UpdatedAt = time.Now()
+	if node.Metadata.CreatedAt.IsZero() {
+		node.Metadata.CreatedAt = time.Now()
+	}
+	metadataJSON, err := nodeMetadataJSON(node)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO nodes (id, type, source, data, metadata)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT(id) DO UPDATE SET
+			type = excluded.type,
+			source = excluded.source,
+			data = excluded.data,
+			metadata = excluded.metadata
+	`, node.ID, node.Type, node.Source, string(node.Data), metadataJSON)
+	if err != nil {
+		return fmt.Errorf("failed to upsert node: %w", err)
+	}
+	return nil
+}
+
+// AddEdge inserts a new edge into the graph
+// Returns error if edge with same (from_id, to_id, relation) already exists
+func (s *PostgresStore) AddEdge(edge Edge) error {
+	edge, metadataJSON, err := prepareEdge(edge)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO edges (id, from_id, to_id, relation, metadata)
+		VALUES ($1, $2, $3, $4, $5)
+	`, edge.ID, edge.FromID, edge.ToID, edge.Relation, metadataJSON)
+	if err != nil {
+		return fmt.Errorf("failed to insert edge: %w", err)
+	}
+	return nil
+}
+
+// UpsertEdge inserts or updates an edge (idempotent operation)
+func (s *PostgresStore) UpsertEdge(edge Edge) error {
+	edge, metadataJSON, err := prepareEdge(edge)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO edges (id, from_id, to_id, relation, metadata)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT(from_id, to_id, relation) DO UPDATE SET
+			metadata = excluded.metadata
+	`, edge.ID, edge.FromID, edge.ToID, edge.Relation, metadataJSON)
+	if err != nil {
+		return fmt.Errorf("failed to upsert edge: %w", err)
+	}
+	return nil
+}
+
+// GetNode retrieves a node by ID
+func (s *PostgresStore) GetNode(id string) (*Node, error) {
+	rows, err := s.db.Query(`
+		SELECT id, type, source, data, metadata
+		FROM nodes
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	nodes, err := scanNodes(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("node not found: %s", id)
+	}
+	return &nodes[0], nil
+}
+
+// GetNeighbors returns all nodes connected to the given node
+// regardless of edge direction or relation type
+func (s *PostgresStore) GetNeighbors(nodeID string) ([]Node, error) {
+	rows, err := s.db.Query(`
+		SELECT DISTINCT n.id, n.type, n.source, n.data, n.metadata
+		FROM nodes n
+		JOIN edges e ON (e.to_id = n.id OR e.from_id = n.id)
+		WHERE (e.from_id = $1 OR e.to_id = $1)
+		AND n.id != $1
+	`, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query neighbors: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanNodes(rows)
+}
+
+// GetEdges returns all edges connected to a node (both incoming and outgoing)
+func (s *PostgresStore) GetEdges(nodeID string) ([]Edge, error) {
+	rows, err := s.db.Query(`
+		SELECT id, from_id, to_id, relation, metadata
+		FROM edges
+		WHERE from_id = $1 OR to_id = $1
+	`, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query edges: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanEdges(rows)
+}
+
+// GetNodesByIDs retrieves many nodes in one query, in the order of ids.
+// Unknown IDs are skipped rather than reported.
+func (s *PostgresStore) GetNodesByIDs(ids []string) ([]Node, error) {
+	ids = uniqueIDs(ids)
+	rows, err := s.db.Query(`
+		SELECT id, type, source, data, metadata
+		FROM nodes
+		WHERE id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nodes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	nodes, err := scanNodes(rows)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]Node, len(nodes))
+	for _, node := range nodes {
+		found[node.ID] = node
+	}
+	return orderNodes(ids, found), nil
+}
+
+// GetEdgesForNodes returns the edges of many nodes in one query, keyed by
+// node ID. An edge between two requested nodes is listed under both.
+func (s *PostgresStore) GetEdgesForNodes(ids []string) (map[string][]Edge, error) {
+	ids = uniqueIDs(ids)
+	rows, err := s.db.Query(`
+		SELECT id, from_id, to_id, relation, metadata FROM edges WHERE from_id = ANY($1)
+		UNION
+		SELECT id, from_id, to_id, relation, metadata FROM edges WHERE to_id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query edges: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	edges, err := scanEdges(rows)
+	if err != nil {
+		return nil, err
+	}
+	return groupEdges(ids, edges), nil
+}
+
+// ListEdges returns every edge in the graph
+func (s *PostgresStore) ListEdges() ([]Edge, error) {
+	rows, err := s.db.Query("SELECT id, from_id, to_id, relation, metadata FROM edges")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query edges: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanEdges(rows)
+}
+
+// DeleteNode removes a node and all connected edges (cascade delete)
+func (s *PostgresStore) DeleteNode(id string) error {
+	return s.deleteOne("DELETE FROM nodes WHERE id = $1", id, "node")
+}
+
+// DeleteEdge removes a specific edge by ID
+func (s *PostgresStore) DeleteEdge(id string) error {
+	return s.deleteOne("DELETE FROM edges WHERE id = $1", id, "edge")
+}
+
+// deleteOne runs a delete by ID and reports a missing row
+func (s *PostgresStore) deleteOne(statement, id, kind string) error {
+	result, err := s.db.Exec(statement, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", kind, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s not found: %s", kind, id)
+	}
+	return nil
+}
+
+// ListNodes returns all nodes, optionally filtered
+func (s *PostgresStore) ListNodes(filter *NodeFilter) ([]Node, error) {
+	query, args := listNodesQuery(filter, "metadata->>'updated_at'")
+	rows, err := s.db.Query(rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nodes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanNodes(rows)
+}
+
+// Query runs a read-only SQL statement against the store and returns the rows.
+func (s *PostgresStore) Query(query string) (*QueryResult, error) {
+	return s.QueryContext(context.Background(), query)
+}
+
+// QueryContext is Query, abandoned when ctx is cancelled. The statement runs
+// in a read-only transaction on a session defaulting to read-only, so writes
+// fail as they do on SQLite. It is prepared rather than sent as plain text,
+// which Postgres refuses for more than one statement: a "COMMIT; DELETE ..."
+// cannot end the read-only transaction and write after it.
+func (s *PostgresStore) QueryContext(ctx context.Context, query string) (*QueryResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "SET SESSION default_transaction_read_only = on"); err != nil {
+		return nil, fmt.Errorf("failed to enable read-only mode: %w", err)
+	}
+	// Restore the pooled connection to writable before it is returned, even
+	// when the query was cancelled
+	defer func() {
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "RESET default_transaction_read_only")
+	}()
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin read-only transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanResult(rows)
+}
+
+// Close closes the connection pool
+func (s *PostgresStore) Close() error {
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// nodeMetadataJSON validates a node and encodes its metadata
+func nodeMetadataJSON(node Node) (string, error) {
+	if !ValidateNodeType(string(node.Type)) {
+		return "", fmt.Errorf("invalid node type: %s", node.Type)
+	}
+	metadataJSON, err := json.Marshal(node.Metadata)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	return string(metadataJSON), nil
+}
+
+// rebind turns "?" placeholders into Postgres's numbered "$1" form
+func rebind(query string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SyncRun is what one source's load cost during a sync.
+type SyncRun struct {
+	Source    string        `json:"source"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Nodes     int           `json:"nodes"`
+	Edges     int           `json:"edges"`
+	Requests  int           `json:"requests"`        // API requests sent (0 for local sources)
+	Bytes     int64         `json:"bytes"`           // Request and response bodies transferred
+	Cost      int           `json:"cost"`            // API cost reported by the service (Linear complexity points)
+	Error     string        `json:"error,omitempty"` // Why the load failed ("" = it succeeded)
+}
+
+// SyncRecorder is implemented by stores that keep a history of syncs
+// (sync_runs), so the cost of each refresh can be reviewed later.
+type SyncRecorder interface {
+	// RecordSyncRuns appends runs to the history.
+	RecordSyncRuns(ctx context.Context, runs []SyncRun) error
+	// ListSyncRuns returns up to limit runs (0 = all), most recent first.
+	ListSyncRuns(ctx context.Context, limit int) ([]SyncRun, error)
+}
+
+var (
+	_ SyncRecorder = (*SQLiteStore)(nil)
+	_ SyncRecorder = (*PostgresStore)(nil)
+)
+
+// RecordSyncRuns appends runs to the sync_runs table.
+func (s *SQLiteStore) RecordSyncRuns(ctx context.Context, runs []SyncRun) error {
+	if err := recordSyncRuns(ctx, s.db, func(query string) string { return query }, runs); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// RecordSyncRuns appends runs to the sync_runs table.
+func (s *PostgresStore) RecordSyncRuns(ctx context.Context, runs []SyncRun) error {
+	return recordSyncRuns(ctx, s.db, rebind, runs)
+}
+
+// ListSyncRuns returns the most recent runs first.
+func (s *SQLiteStore) ListSyncRuns(ctx context.Context, limit int) ([]SyncRun, error) {
+	return listSyncRuns(ctx, s.db, func(query string) string { return query }, limit)
+}
+
+// ListSyncRuns returns the most recent runs first.
+func (s *PostgresStore) ListSyncRuns(ctx context.Context, limit int) ([]SyncRun, error) {
+	return listSyncRuns(ctx, s.db, rebind, limit)
+}
+
+// recordSyncRuns inserts runs in one transaction
+func recordSyncRuns(ctx context.Context, db *sql.DB, bind func(string) string, runs []SyncRun) error {
+	if len(runs) == 0 {
+		return nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin sync run transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, run := range runs {
+		_, err := tx.ExecContext(ctx, bind(`
+			INSERT INTO sync_runs (source, started_at, duration_ms, nodes, edges, requests, bytes, cost, error)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`), run.Source, run.StartedAt.UTC(), run.Duration.Milliseconds(), run.Nodes, run.Edges, run.Requests, run.Bytes, run.Cost, run.Error)
+		if err != nil {
+			return fmt.Errorf("failed to record sync run: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// listSyncRuns reads the history, newest first
+func listSyncRuns(ctx context.Context, db *sql.DB, bind func(string) string, limit int) ([]SyncRun, error) {
+	query := `SELECT source, started_at, duration_ms, nodes, edges, requests, bytes, cost, error
+		FROM sync_runs ORDER BY started_at DESC, id DESC`
+	var args []interface{}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := db.QueryContext(ctx, bind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sync runs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var runs []SyncRun
+	for rows.Next() {
+		var run SyncRun
+		var millis int64
+		if err := rows.Scan(&run.Source, &run.StartedAt, &millis, &run.Nodes, &run.Edges, &run.Requests, &run.Bytes, &run.Cost, &run.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan sync run: %w", err)
+		}
+		run.StartedAt = run.StartedAt.Local()
+		run.Duration = time.Duration(millis) * time.Millisecond
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
@@ -0,0 +1,43 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestSyncRunsListNewestFirst checks runs round-trip through sync_runs.
+func TestSyncRunsListNewestFirst(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	runs := []SyncRun{
+		{Source: "linear", StartedAt: start, Duration: 1500 * time.Millisecond, Nodes: 40, Requests: 3, Bytes: 20480, Cost: 312},
+		{Source: "git", StartedAt: start.Add(time.Minute), Duration: 80 * time.Millisecond, Nodes: 50, Edges: 49},
+		{Source: "linear", StartedAt: start.Add(2 * time.Minute), Error: "Linear API returned 500"},
+	}
+	if err := store.RecordSyncRuns(ctx, runs); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.ListSyncRuns(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Error != runs[2].Error || got[1].Source != "git" {
+		t.Fatalf("ListSyncRuns(2) = %+v", got)
+	}
+	all, err := store.ListSyncRuns(ctx, 0)
+	if err != nil || len(all) != 3 {
+		t.Fatalf("ListSyncRuns(0) = %d runs, %v", len(all), err)
+	}
+	oldest := all[2]
+	if !oldest.StartedAt.Equal(start) || oldest.Duration != runs[0].Duration || oldest.Bytes != 20480 || oldest.Cost != 312 || oldest.Requests != 3 {
+		t.Errorf("oldest run = %+v, want %+v", oldest, runs[0])
+	}
+}
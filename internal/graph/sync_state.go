@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SyncCursors is implemented by stores that remember how far each source
+// has been synced (sync_state), so the next sync can fetch only what
+// changed since.
+type SyncCursors interface {
+	// SyncCursor returns when source was last synced up to; ok is false
+	// when it never was.
+	SyncCursor(ctx context.Context, source string) (cursor time.Time, ok bool, err error)
+	// SetSyncCursor records that source is synced up to cursor.
+	SetSyncCursor(ctx context.Context, source string, cursor time.Time) error
+}
+
+var (
+	_ SyncCursors = (*SQLiteStore)(nil)
+	_ SyncCursors = (*PostgresStore)(nil)
+)
+
+// SyncCursor reads source's row in the sync_state table.
+func (s *SQLiteStore) SyncCursor(ctx context.Context, source string) (time.Time, bool, error) {
+	return syncCursor(ctx, s.db, func(query string) string { return query }, source)
+}
+
+// SyncCursor reads source's row in the sync_state table.
+func (s *PostgresStore) SyncCursor(ctx context.Context, source string) (time.Time, bool, error) {
+	return syncCursor(ctx, s.db, rebind, source)
+}
+
+// SetSyncCursor writes source's row in the sync_state table.
+func (s *SQLiteStore) SetSyncCursor(ctx context.Context, source string, cursor time.Time) error {
+	if err := setSyncCursor(ctx, s.db, func(query string) string { return query }, source, cursor); err != nil {
+		return err
+	}
+	return s.Flush()
+}
+
+// SetSyncCursor writes source's row in the sync_state table.
+func (s *PostgresStore) SetSyncCursor(ctx context.Context, source string, cursor time.Time) error {
+	return setSyncCursor(ctx, s.db, rebind, source, cursor)
+}
+
+// syncCursor reads one source's cursor
+func syncCursor(ctx context.Context, db *sql.DB, bind func(string) string, source string) (time.Time, bool, error) {
+	var cursor time.Time
+	err := db.QueryRowContext(ctx, bind("SELECT synced_until FROM sync_state WHERE source = ?"), source).Scan(&cursor)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to read sync cursor: %w", err)
+	}
+	return cursor, true, nil
+}
+
+// setSyncCursor inserts or moves one source's cursor
+func setSyncCursor(ctx context.Context, db *sql.DB, bind func(string) string, source string, cursor time.Time) error {
+	_, err := db.ExecContext(ctx, bind(`
+		INSERT INTO sync_state (source, synced_until) VALUES (?, ?)
+		ON CONFLICT(source) DO UPDATE SET synced_until = excluded.synced_until
+	`), source, cursor.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to record sync cursor: %w", err)
+	}
+	return nil
+}
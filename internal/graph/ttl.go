@@ -0,0 +1,58 @@
+package graph
+
+import "time"
+
+// TTLRule drops nodes from the working graph once they have been idle for
+// MaxAge. Empty Type or Source match any; the first matching rule applies.
+type TTLRule struct {
+	Type   NodeType
+	Source string
+	MaxAge time.Duration
+}
+
+// matches reports whether the rule covers node
+func (r TTLRule) matches(node Node) bool {
+	return (r.Type == "" || r.Type == node.Type) && (r.Source == "" || r.Source == node.Source)
+}
+
+// ApplyTTL splits a graph into the nodes still within their TTL and the ones
+// that have expired, judged by when each was last updated. Nodes without an
+// update time never expire. Edges touching an expired node go with it.
+func ApplyTTL(nodes []Node, edges []Edge, rules []TTLRule, now time.Time) (kept []Node, keptEdges []Edge, expired []Node, expiredEdges []Edge) {
+	if len(rules) == 0 {
+		return nodes, edges, nil, nil
+	}
+	gone := make(map[string]bool)
+	for _, node := range nodes {
+		if expiredAt(node, rules, now) {
+			gone[node.ID] = true
+			expired = append(expired, node)
+		} else {
+			kept = append(kept, node)
+		}
+	}
+	if len(expired) == 0 {
+		return nodes, edges, nil, nil
+	}
+	for _, edge := range edges {
+		if gone[edge.FromID] || gone[edge.ToID] {
+			expiredEdges = append(expiredEdges, edge)
+		} else {
+			keptEdges = append(keptEdges, edge)
+		}
+	}
+	return kept, keptEdges, expired, expiredEdges
+}
+
+// expiredAt applies the first rule matching node
+func expiredAt(node Node, rules []TTLRule, now time.Time) bool {
+	if node.Metadata.UpdatedAt.IsZero() {
+		return false
+	}
+	for _, rule := range rules {
+		if rule.matches(node) {
+			return rule.MaxAge > 0 && now.Sub(node.Metadata.UpdatedAt) > rule.MaxAge
+		}
+	}
+	return false
+}
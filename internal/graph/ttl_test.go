@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestApplyTTLArchivesIdleNodes checks that the first matching rule decides,
+// that edges follow their expired nodes, and that the archive keeps them.
+func TestApplyTTLArchivesIdleNodes(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	node := func(id string, typ NodeType, source string, age time.Duration) Node {
+		return Node{ID: id, Type: typ, Source: source, Data: []byte(`{}`), Metadata: NodeMetadata{UpdatedAt: now.Add(-age)}}
+	}
+	day := 24 * time.Hour
+	nodes := []Node{
+		node("commit:old", NodeTypeCommit, "git", 120*day),
+		node("commit:new", NodeTypeCommit, "git", 10*day),
+		node("commit:pinned", NodeTypeCommit, "vendor", 400*day),
+		node("issue:old", NodeTypeIssue, "linear", 400*day),
+	}
+	edges := []Edge{
+		{FromID: "commit:old", ToID: "issue:old", Relation: EdgeMentions},
+		{FromID: "commit:new", ToID: "issue:old", Relation: EdgeMentions},
+	}
+	rules := []TTLRule{
+		{Source: "vendor"}, // Zero age: matching nodes never expire
+		{Type: NodeTypeCommit, MaxAge: 90 * day},
+	}
+
+	kept, keptEdges, expired, expiredEdges := ApplyTTL(nodes, edges, rules, now)
+	if len(kept) != 3 || len(expired) != 1 || expired[0].ID != "commit:old" {
+		t.Fatalf("kept %d, expired %v", len(kept), expired)
+	}
+	if len(keptEdges) != 1 || len(expiredEdges) != 1 || expiredEdges[0].FromID != "commit:old" {
+		t.Errorf("kept edges %v, expired edges %v", keptEdges, expiredEdges)
+	}
+
+	store, err := NewStore(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+	for _, n := range nodes {
+		if err := store.AddNode(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Archive(context.Background(), expired, expiredEdges); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.GetNode("commit:old"); err == nil {
+		t.Error("archived node is still in the working graph")
+	}
+	result, err := store.Query("SELECT id FROM archived_nodes")
+	if err != nil || len(result.Rows) != 1 || result.Rows[0][0] != "commit:old" {
+		t.Errorf("archived_nodes = %v, %v", result, err)
+	}
+}
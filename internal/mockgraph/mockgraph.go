@@ -0,0 +1,498 @@
+// Package mockgraph generates realistic, reproducible knowledge graphs for
+// demos, benchmarks and snapshot tests.
+// Following Commandment #1 (Immutable Truth): the same Options always
+// produce the same graph - randomness comes from a seeded source only.
+package mockgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// Options shape a generated graph. Zero fields take the demo defaults.
+type Options struct {
+	Seed             int64     // Same seed, same graph
+	Projects         int       // Projects, each with its own issues, PRs and files
+	IssuesPerProject int       // Issues owned by each project
+	PRsPerProject    int       // PRs implementing the project's issues
+	CommitsPerPR     int       // Commit history behind each PR
+	FilesPerProject  int       // Source files owned by each project
+	People           int       // Assignees, authors and reviewers to draw from
+	BlockRate        float64   // Chance an issue blocks an earlier issue in its project
+	Now              time.Time // Reference time; fix it for stable snapshots (default time.Now())
+}
+
+// DefaultOptions returns the demo graph: about a hundred nodes across five projects.
+func DefaultOptions() Options {
+	return Options{
+		Seed:             1,
+		Projects:         5,
+		IssuesPerProject: 4,
+		PRsPerProject:    3,
+		CommitsPerPR:     2,
+		FilesPerProject:  5,
+		People:           6,
+		BlockRate:        0.3,
+	}
+}
+
+// Sized returns options for a graph of roughly n nodes, for benchmarks:
+// projects of 10 issues, 4 PRs of 6 commits each and 11 files.
+func Sized(n int) Options {
+	opts := DefaultOptions()
+	opts.IssuesPerProject = 10
+	opts.PRsPerProject = 4
+	opts.CommitsPerPR = 6
+	opts.FilesPerProject = 11
+	opts.People = 12
+	opts.Projects = max(n/opts.projectSize(), 1)
+	return opts
+}
+
+// projectSize is how many work nodes one project contributes
+func (o Options) projectSize() int {
+	return 1 + o.IssuesPerProject + o.PRsPerProject*(1+o.CommitsPerPR) + o.FilesPerProject
+}
+
+// withDefaults fills zero fields from DefaultOptions
+func (o Options) withDefaults() Options {
+	d := DefaultOptions()
+	if o.Seed == 0 {
+		o.Seed = d.Seed
+	}
+	if o.Projects <= 0 {
+		o.Projects = d.Projects
+	}
+	if o.IssuesPerProject <= 0 {
+		o.IssuesPerProject = d.IssuesPerProject
+	}
+	if o.PRsPerProject <= 0 {
+		o.PRsPerProject = d.PRsPerProject
+	}
+	if o.CommitsPerPR <= 0 {
+		o.CommitsPerPR = d.CommitsPerPR
+	}
+	if o.FilesPerProject <= 0 {
+		o.FilesPerProject = d.FilesPerProject
+	}
+	if o.People <= 0 {
+		o.People = d.People
+	}
+	if o.BlockRate <= 0 {
+		o.BlockRate = d.BlockRate
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	return o
+}
+
+// Vocabulary for generated content
+var (
+	projectNames = []string{"MAAT", "Frontend", "Backend", "Infrastructure", "Design System", "Mobile", "Data Platform", "Billing", "Search", "Identity"}
+	personNames  = []string{"alice", "bob", "carol", "dave", "erin", "frank", "grace", "heidi", "ivan", "judy", "mallory", "niaj", "olivia", "peggy", "rupert", "sybil"}
+	verbs        = []string{"Add", "Fix", "Refactor", "Document", "Optimize", "Migrate", "Remove", "Support", "Test", "Simplify"}
+	subjects     = []string{
+		"graph rendering", "SQLite backend", "keyboard navigation", "search index", "status bar",
+		"Linear sync", "GitHub webhooks", "config loading", "session restore", "error reporting",
+		"node filtering", "detail view", "edge traversal", "auth tokens", "rate limiting",
+		"pagination", "cache invalidation", "CLI flags", "release pipeline", "telemetry",
+	}
+	fileNames = []string{"model.go", "view.go", "update.go", "store.go", "schema.go", "client.go", "handler.go",
+		"config.go", "parser.go", "cache.go", "server.go", "sync.go", "render.go", "commands.go", "keys.go"}
+	labels = []string{"bug", "feature", "performance", "testing", "docs", "ux", "tech-debt", "security"}
+
+	// Weighted so most work is open: triage and backlog through done
+	issueStatuses = []string{"triage", "backlog", "backlog", "todo", "todo", "todo", "in_progress", "in_progress",
+		"in review", "done", "done", "done", "blocked", "canceled"}
+	commitKinds = []string{"feat", "fix", "refactor", "test", "docs", "chore"}
+)
+
+// generator carries the RNG and accumulates the graph
+type generator struct {
+	opts  Options
+	rng   *rand.Rand
+	nodes []graph.Node
+	edges []graph.Edge
+}
+
+// Generate builds a graph: services own projects; projects own issues and
+// files; issues block earlier issues and are assigned to people; PRs
+// implement issues and modify files, with review state; commits implement
+// PRs, mention their issue and modify the PR's files; people and teams are
+// derived from assignees, authors and requested reviewers.
+func Generate(opts Options) ([]graph.Node, []graph.Edge) {
+	opts = opts.withDefaults()
+	g := &generator{opts: opts, rng: rand.New(rand.NewSource(opts.Seed))}
+	people := g.people()
+
+	g.node("service:github", graph.NodeTypeService, graph.RoleExec, 90*24*time.Hour, 0, map[string]interface{}{
+		"name": "GitHub", "type": "vcs", "repo": "github.com/example/maat",
+	})
+	g.node("service:linear", graph.NodeTypeService, graph.RoleExec, 60*24*time.Hour, 0, map[string]interface{}{
+		"name": "Linear", "type": "api", "repo": "linear.app/example",
+	})
+
+	prNumber := 100
+	for p := 0; p < opts.Projects; p++ {
+		name := projectNames[p%len(projectNames)]
+		key := projectKey(name)
+		if p >= len(projectNames) {
+			name = fmt.Sprintf("%s %d", name, p/len(projectNames)+1)
+			key += fmt.Sprint(p/len(projectNames) + 1)
+		}
+		dir := strings.ReplaceAll(strings.ToLower(name), " ", "")
+		projectID := "project:" + dir
+		access := graph.RoleLead
+		if p == 0 {
+			access = graph.RoleExec
+		}
+		g.node(projectID, graph.NodeTypeProject, access, g.days(60, 120), g.days(0, 7), map[string]interface{}{
+			"name":        name,
+			"description": fmt.Sprintf("%s work across services and clients", name),
+			"status":      "active",
+		})
+		g.edge([]string{"service:github", "service:linear"}[p%2], projectID, graph.EdgeOwns, opts.Now)
+
+		// Files
+		fileIDs := make([]string, opts.FilesPerProject)
+		for f := range fileIDs {
+			path := fmt.Sprintf("internal/%s/%s", dir, fileNames[f%len(fileNames)])
+			if f >= len(fileNames) {
+				path = fmt.Sprintf("internal/%s/%s%d/%s", dir, dir, f/len(fileNames), fileNames[f%len(fileNames)])
+			}
+			fileIDs[f] = fmt.Sprintf("file:%s", path)
+			g.node(fileIDs[f], graph.NodeTypeFile, graph.RoleIC, g.days(30, 120), g.days(0, 30), map[string]interface{}{
+				"path":     path,
+				"language": "Go",
+				"lines":    50 + g.rng.Intn(750),
+				"owner":    g.pick(people),
+			})
+			g.edge(projectID, fileIDs[f], graph.EdgeOwns, opts.Now)
+		}
+
+		// Issues, each blocking at most one earlier issue so dependencies stay acyclic
+		issueIDs := make([]string, opts.IssuesPerProject)
+		issueDone := make([]bool, opts.IssuesPerProject)
+		for i := range issueIDs {
+			identifier := fmt.Sprintf("%s-%d", key, i+1)
+			issueIDs[i] = "issue:" + identifier
+			status := g.pick(issueStatuses)
+			issueDone[i] = status == "done"
+			created := g.days(5, 45)
+			access, updatedAgo := g.access(), g.since(created)
+			data := map[string]interface{}{
+				"title":       g.title(),
+				"identifier":  identifier,
+				"description": fmt.Sprintf("Tracked under %s. %s", name, g.title()+" as a follow-up."),
+				"status":      status,
+				"priority":    g.rng.Intn(5),
+				"labels":      g.sample(labels, 1+g.rng.Intn(2)),
+				"assignee":    g.pick(people),
+				"url":         "https://linear.app/example/issue/" + identifier,
+			}
+			g.plan(data, created, updatedAgo, issueDone[i], i)
+			g.node(issueIDs[i], graph.NodeTypeIssue, access, created, updatedAgo, data)
+			g.edge(projectID, issueIDs[i], graph.EdgeOwns, opts.Now)
+			if i > 0 && g.rng.Float64() < opts.BlockRate {
+				g.edge(issueIDs[i], issueIDs[g.rng.Intn(i)], graph.EdgeBlocks, opts.Now)
+			}
+			if i > 1 && g.rng.Float64() < opts.BlockRate/3 {
+				g.edge(issueIDs[i], issueIDs[g.rng.Intn(i)], graph.EdgeRelated, opts.Now)
+			}
+		}
+
+		// PRs with their commit histories
+		for r := 0; r < opts.PRsPerProject; r++ {
+			prNumber++
+			issue := g.rng.Intn(len(issueIDs))
+			prID := fmt.Sprintf("pr:%d", prNumber)
+			author := g.pick(people)
+			opened := g.days(1, 20)
+			data := map[string]interface{}{
+				"title":       g.title(),
+				"description": fmt.Sprintf("Implements %s", strings.TrimPrefix(issueIDs[issue], "issue:")),
+				"number":      prNumber,
+				"author":      author,
+				"url":         fmt.Sprintf("https://github.com/example/maat/pull/%d", prNumber),
+			}
+			g.review(data, issueDone[issue], people, author)
+			data["ci_status"] = ciStatus(data["status"], prNumber)
+			g.node(prID, graph.NodeTypePR, g.access(), opened, g.since(opened), data)
+			g.edge(prID, issueIDs[issue], graph.EdgeImplements, opts.Now)
+
+			touched := g.sample(fileIDs, min(1+g.rng.Intn(3), len(fileIDs)))
+			for _, fileID := range touched {
+				g.edge(prID, fileID, graph.EdgeModifies, opts.Now)
+			}
+
+			// Oldest commit first, spread over the time the PR was open
+			for c := 0; c < opts.CommitsPerPR; c++ {
+				hash := g.hash()
+				commitID := "commit:" + hash[:12]
+				age := time.Duration(int64(opened) * int64(opts.CommitsPerPR-c) / int64(opts.CommitsPerPR+1))
+				commitAuthor := author
+				if g.rng.Intn(5) == 0 {
+					commitAuthor = g.pick(people)
+				}
+				message := fmt.Sprintf("%s: %s (%s)", g.pick(commitKinds), strings.ToLower(g.title()), strings.TrimPrefix(issueIDs[issue], "issue:"))
+				g.node(commitID, graph.NodeTypeCommit, graph.RoleIC, age, age, map[string]interface{}{
+					"title":   message,
+					"message": message,
+					"author":  commitAuthor,
+					"hash":    hash,
+					"date":    opts.Now.Add(-age).Format("2006-01-02"),
+				})
+				g.edge(commitID, prID, graph.EdgeImplements, opts.Now.Add(-age))
+				g.edge(commitID, issueIDs[issue], graph.EdgeMentions, opts.Now.Add(-age))
+				g.edge(commitID, touched[g.rng.Intn(len(touched))], graph.EdgeModifies, opts.Now.Add(-age))
+			}
+		}
+	}
+
+	derived, derivedEdges := derivePeople(g.nodes, opts.Now)
+	return append(g.nodes, derived...), append(g.edges, derivedEdges...)
+}
+
+// people returns the logins work is assigned to and authored by
+func (g *generator) people() []string {
+	people := make([]string, g.opts.People)
+	for i := range people {
+		people[i] = personNames[i%len(personNames)]
+		if i >= len(personNames) {
+			people[i] += fmt.Sprint(i / len(personNames))
+		}
+	}
+	return people
+}
+
+// review sets PR status and review fields: PRs for finished issues are
+// merged, the rest are open or draft at some point of review.
+func (g *generator) review(data map[string]interface{}, merged bool, people []string, author string) {
+	if merged {
+		data["status"] = "merged"
+		data["review_state"] = "approved"
+		data["approvals"] = 1 + g.rng.Intn(2)
+		data["mergeable"] = "mergeable"
+		return
+	}
+	if g.rng.Intn(6) == 0 {
+		data["status"] = "draft"
+		return
+	}
+	data["status"] = "open"
+	data["mergeable"] = []string{"mergeable", "mergeable", "conflicting"}[g.rng.Intn(3)]
+
+	var reviewers []string
+	for _, reviewer := range g.sample(people, min(2, len(people))) {
+		if reviewer != author {
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+	switch g.rng.Intn(4) {
+	case 0:
+		data["review_state"] = "approved"
+		data["approvals"] = 1 + g.rng.Intn(2)
+	case 1:
+		data["review_state"] = "changes_requested"
+		data["approvals"] = g.rng.Intn(2)
+		data["changes_requested"] = 1
+		data["requested_reviewers"] = append(reviewers, "maat/core")
+	default:
+		data["review_state"] = "review_required"
+		data["requested_reviewers"] = reviewers
+	}
+}
+
+// ciStatus picks a build result for a PR without touching the random
+// sequence, so seeds keep generating the same graph: merged PRs passed,
+// drafts have no builds, open ones mostly pass
+func ciStatus(status interface{}, prNumber int) string {
+	switch status {
+	case "merged":
+		return "success"
+	case "draft":
+		return ""
+	}
+	return []string{"success", "success", "pending", "failure"}[prNumber%4]
+}
+
+// cycleLength is how long a sprint runs
+const cycleLength = 14 * 24 * time.Hour
+
+// currentCycle numbers the sprint running at Now, a week either side of it
+const currentCycle = 12
+
+// plan puts an issue created age ago into a cycle and estimates it, without
+// touching the random sequence so seeds keep generating the same graph:
+// issues go into the cycle after the one they were filed in, except every
+// third, which was added to the running cycle mid-way. Done issues were
+// mostly finished within their cycle, the rest by their last update.
+func (g *generator) plan(data map[string]interface{}, age, updatedAgo time.Duration, done bool, i int) {
+	currentStart := g.opts.Now.Add(-cycleLength / 2)
+	filed := g.opts.Now.Add(-age)
+	number := currentCycle + int(math.Floor(float64(filed.Sub(currentStart))/float64(cycleLength)))
+	if i%3 != 0 {
+		number++
+	}
+	number = min(number, currentCycle)
+	start := currentStart.Add(time.Duration(number-currentCycle) * cycleLength)
+
+	data["cycle"] = fmt.Sprintf("Cycle %d", number)
+	data["cycle_starts_at"] = start.Format(time.RFC3339)
+	data["cycle_ends_at"] = start.Add(cycleLength).Format(time.RFC3339)
+	data["estimate"] = []int{1, 2, 3, 5, 8}[i%5]
+	if done {
+		completed := g.opts.Now.Add(-updatedAgo)
+		if within := start.Add(time.Duration(3+i%10) * 24 * time.Hour); i%4 != 0 && within.Before(completed) && within.After(filed) {
+			completed = within
+		}
+		data["completed_at"] = completed.Format(time.RFC3339)
+	}
+}
+
+// node adds a node created age ago and last updated updatedAgo ago
+func (g *generator) node(id string, nodeType graph.NodeType, access graph.Role, age, updatedAgo time.Duration, data map[string]interface{}) {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		panic(fmt.Sprintf("failed to marshal JSON: %v", err)) // Only plain values go in
+	}
+	updated := g.opts.Now.Add(-updatedAgo)
+	g.nodes = append(g.nodes, graph.Node{
+		ID:     id,
+		Type:   nodeType,
+		Source: "mock",
+		Data:   dataJSON,
+		Metadata: graph.NodeMetadata{
+			CreatedAt:   g.opts.Now.Add(-age),
+			UpdatedAt:   updated,
+			CreatedBy:   "mock",
+			AccessLevel: access,
+			SyncedAt:    updated,
+		},
+	})
+}
+
+// edge adds a relation between two generated nodes
+func (g *generator) edge(from, to string, relation graph.EdgeType, at time.Time) {
+	g.edges = append(g.edges, graph.Edge{
+		ID:       fmt.Sprintf("edge:%s:%s:%s", relation, from, to),
+		FromID:   from,
+		ToID:     to,
+		Relation: relation,
+		Metadata: graph.EdgeMetadata{CreatedAt: at},
+	})
+}
+
+// days returns a random duration between lo and hi days
+func (g *generator) days(lo, hi int) time.Duration {
+	return time.Duration(lo)*24*time.Hour + time.Duration(g.rng.Int63n(int64(hi-lo)*int64(24*time.Hour)+1))
+}
+
+// since returns a random duration shorter than age (time since the last update)
+func (g *generator) since(age time.Duration) time.Duration {
+	return time.Duration(g.rng.Int63n(int64(age) + 1))
+}
+
+// access picks an access level, mostly IC-level detail
+func (g *generator) access() graph.Role {
+	if g.rng.Intn(4) == 0 {
+		return graph.RoleLead
+	}
+	return graph.RoleIC
+}
+
+// title makes a work item title such as "Optimize search index"
+func (g *generator) title() string {
+	return g.pick(verbs) + " " + g.pick(subjects)
+}
+
+// hash makes a 40-character commit SHA
+func (g *generator) hash() string {
+	return fmt.Sprintf("%016x%016x%08x", g.rng.Uint64(), g.rng.Uint64(), g.rng.Uint32())
+}
+
+func (g *generator) pick(values []string) string {
+	return values[g.rng.Intn(len(values))]
+}
+
+// sample picks n distinct values in random order
+func (g *generator) sample(values []string, n int) []string {
+	picked := make([]string, 0, n)
+	for _, i := range g.rng.Perm(len(values))[:min(n, len(values))] {
+		picked = append(picked, values[i])
+	}
+	return picked
+}
+
+// projectKey derives a Linear-style team key from a project name ("Design System" → "DS")
+func projectKey(name string) string {
+	words := strings.Fields(name)
+	if len(words) == 1 {
+		return strings.ToUpper(name[:min(4, len(name))])
+	}
+	key := ""
+	for _, word := range words {
+		key += strings.ToUpper(word[:1])
+	}
+	return key
+}
+
+// derivePeople derives Person/Team nodes from generated work items: issue
+// assignees, commit and PR authors, and requested PR reviewers (GitHub).
+// They are stamped with now so the graph stays reproducible.
+func derivePeople(nodes []graph.Node, now time.Time) ([]graph.Node, []graph.Edge) {
+	var people []graph.Node
+	var edges []graph.Edge
+	seen := make(map[string]bool)
+	add := func(node graph.Node) string {
+		if !seen[node.ID] {
+			seen[node.ID] = true
+			node.Metadata.CreatedAt, node.Metadata.UpdatedAt, node.Metadata.SyncedAt = now, now, now
+			people = append(people, node)
+		}
+		return node.ID
+	}
+	link := func(from, to string, relation graph.EdgeType, at time.Time) {
+		edges = append(edges, graph.Edge{
+			ID:       fmt.Sprintf("edge:%s:%s:%s", relation, from, to),
+			FromID:   from,
+			ToID:     to,
+			Relation: relation,
+			Metadata: graph.EdgeMetadata{CreatedAt: at},
+		})
+	}
+
+	for _, node := range nodes {
+		var data struct {
+			Assignee           string   `json:"assignee"`
+			Author             string   `json:"author"`
+			RequestedReviewers []string `json:"requested_reviewers"`
+		}
+		if err := json.Unmarshal(node.Data, &data); err != nil {
+			continue
+		}
+		at := node.Metadata.UpdatedAt
+
+		if data.Assignee != "" && node.Type == graph.NodeTypeIssue {
+			link(node.ID, add(graph.NewPersonNode(data.Assignee, "mock")), graph.EdgeAssignedTo, at)
+		}
+		if data.Author != "" && (node.Type == graph.NodeTypeCommit || node.Type == graph.NodeTypePR) {
+			link(add(graph.NewPersonNode(data.Author, "mock")), node.ID, graph.EdgeAuthored, at)
+		}
+		for _, reviewer := range data.RequestedReviewers {
+			if graph.IsTeamRef(reviewer) {
+				link(node.ID, add(graph.NewTeamNode(reviewer, "mock")), graph.EdgeAssignedTo, at)
+			} else {
+				link(node.ID, add(graph.NewPersonNode(reviewer, "mock")), graph.EdgeAssignedTo, at)
+			}
+		}
+	}
+	return people, edges
+}
@@ -0,0 +1,53 @@
+package mockgraph
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestGenerateDeterministic checks that snapshots can rely on a fixed seed and time.
+func TestGenerateDeterministic(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Now = time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	nodes1, edges1 := Generate(opts)
+	nodes2, edges2 := Generate(opts)
+	if !reflect.DeepEqual(nodes1, nodes2) || !reflect.DeepEqual(edges1, edges2) {
+		t.Fatal("same options produced different graphs")
+	}
+
+	opts.Seed++
+	nodes3, _ := Generate(opts)
+	if reflect.DeepEqual(nodes1, nodes3) {
+		t.Error("a different seed produced the same graph")
+	}
+}
+
+// TestGenerateSized checks scale and that every edge joins generated nodes.
+func TestGenerateSized(t *testing.T) {
+	for _, size := range []int{1, 1000, 10000} {
+		nodes, edges := Generate(Sized(size))
+		if size > 1 && (len(nodes) < size*9/10 || len(nodes) > size*11/10) {
+			t.Errorf("Sized(%d) generated %d nodes", size, len(nodes))
+		}
+
+		ids := make(map[string]bool, len(nodes))
+		for _, node := range nodes {
+			if ids[node.ID] {
+				t.Fatalf("Sized(%d): duplicate node %s", size, node.ID)
+			}
+			ids[node.ID] = true
+		}
+		edgeIDs := make(map[string]bool, len(edges))
+		for _, edge := range edges {
+			if !ids[edge.FromID] || !ids[edge.ToID] {
+				t.Fatalf("Sized(%d): edge %s has a missing endpoint", size, edge.ID)
+			}
+			if edgeIDs[edge.ID] {
+				t.Fatalf("Sized(%d): duplicate edge %s", size, edge.ID)
+			}
+			edgeIDs[edge.ID] = true
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Package paths places MAAT's files where each platform expects them:
+// configuration under XDG_CONFIG_HOME, the graph database and saved views
+// under XDG_DATA_HOME, caches under XDG_CACHE_HOME, and session state, sync
+// state and crash reports under XDG_STATE_HOME. macOS and Windows use their
+// native locations unless the XDG variables are set explicitly.
+//
+// An existing ~/.maat directory (the layout before these locations) keeps
+// holding everything an XDG variable doesn't place elsewhere; MigrateLegacy
+// moves what one does, so upgrading never strands a database or config.
+package paths
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// appDir is the directory name used under each base directory
+const appDir = "maat"
+
+// Config returns the directory for config.yaml and the Starlark rules.
+func Config() string {
+	return current().config()
+}
+
+// Data returns the directory for the graph database and saved views.
+func Data() string {
+	return current().data()
+}
+
+// Cache returns the directory for data that can be rebuilt at any time.
+func Cache() string {
+	return current().cache()
+}
+
+// State returns the directory for session state, sync state and crash reports.
+func State() string {
+	return current().state()
+}
+
+// ProjectKey names the files kept for one project: its directory's name and
+// a short hash of its absolute path, so two checkouts named alike stay apart.
+func ProjectKey(projectPath string) string {
+	sum := sha1.Sum([]byte(filepath.Clean(projectPath)))
+	return filepath.Base(projectPath) + "-" + hex.EncodeToString(sum[:4])
+}
+
+// MigrateLegacy moves the files an explicitly set XDG variable places
+// outside ~/.maat to their new location, returning where each went. A file
+// already at the new location is left alone in both places.
+func MigrateLegacy() ([]string, error) {
+	return current().migrateLegacy()
+}
+
+// env is what the locations are derived from, injectable for tests
+type env struct {
+	goos      string
+	home      string
+	getenv    func(string) string
+	hasLegacy bool
+}
+
+// current reads the running process's environment
+func current() env {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "" // Relative locations below; better than failing to start
+	}
+	e := env{goos: runtime.GOOS, home: home, getenv: os.Getenv}
+	if info, err := os.Stat(e.legacy()); err == nil && info.IsDir() {
+		e.hasLegacy = true
+	}
+	return e
+}
+
+func (e env) legacy() string {
+	return filepath.Join(e.home, ".maat")
+}
+
+func (e env) config() string {
+	return e.resolve("XDG_CONFIG_HOME", ".config", "Library/Application Support", "APPDATA")
+}
+
+func (e env) data() string {
+	return e.resolve("XDG_DATA_HOME", ".local/share", "Library/Application Support", "LOCALAPPDATA")
+}
+
+func (e env) cache() string {
+	return e.resolve("XDG_CACHE_HOME", ".cache", "Library/Caches", "LOCALAPPDATA")
+}
+
+func (e env) state() string {
+	return e.resolve("XDG_STATE_HOME", ".local/state", "Library/Application Support", "LOCALAPPDATA")
+}
+
+// resolve picks a base directory: an explicit absolute XDG variable, the
+// legacy ~/.maat (caches in its cache subdirectory), then the platform
+// default - unixDefault and macDefault relative to home, windowsVar an
+// environment variable. Windows keeps state and caches apart from data in
+// subdirectories.
+func (e env) resolve(xdgVar, unixDefault, macDefault, windowsVar string) string {
+	if dir := e.getenv(xdgVar); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Join(dir, appDir)
+	}
+	if e.hasLegacy && xdgVar == "XDG_CACHE_HOME" {
+		return filepath.Join(e.legacy(), "cache")
+	}
+	if e.hasLegacy {
+		return e.legacy()
+	}
+	switch e.goos {
+	case "darwin":
+		return filepath.Join(e.home, macDefault, appDir)
+	case "windows":
+		base := e.getenv(windowsVar)
+		if base == "" && windowsVar == "APPDATA" {
+			base = filepath.Join(e.home, "AppData", "Roaming")
+		} else if base == "" {
+			base = filepath.Join(e.home, "AppData", "Local")
+		}
+		switch xdgVar {
+		case "XDG_CACHE_HOME":
+			return filepath.Join(base, appDir, "cache")
+		case "XDG_STATE_HOME":
+			return filepath.Join(base, appDir, "state")
+		}
+		return filepath.Join(base, appDir)
+	default:
+		return filepath.Join(e.home, unixDefault, appDir)
+	}
+}
+
+// migrateLegacy moves each file ~/.maat holds for a location now placed
+// elsewhere. SQLite's journal files go with their database.
+func (e env) migrateLegacy() ([]string, error) {
+	if !e.hasLegacy {
+		return nil, nil
+	}
+	locations := []struct {
+		dir   string
+		names []string
+	}{
+		{e.config(), []string{"config.yaml", "maat.star"}},
+		{e.data(), []string{"graphs", "graph.db", "graph.db-wal", "graph.db-shm", "queries.yaml", "reviewed.yaml"}},
+		{e.cache(), []string{"cache"}},
+		{e.state(), []string{"session.yaml", "sync-state", "crashes"}},
+	}
+	var moved []string
+	for _, location := range locations {
+		if location.dir == e.legacy() {
+			continue
+		}
+		for _, name := range location.names {
+			from, to := filepath.Join(e.legacy(), name), filepath.Join(location.dir, name)
+			if name == "cache" {
+				// The legacy cache directory becomes the cache location itself
+				to = location.dir
+			}
+			if from == to {
+				continue
+			}
+			if _, err := os.Stat(from); err != nil {
+				continue
+			}
+			if _, err := os.Stat(to); err == nil {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(to), 0o700); err != nil {
+				return moved, fmt.Errorf("moving %s: %w", from, err)
+			}
+			if err := os.Rename(from, to); err != nil {
+				return moved, fmt.Errorf("moving %s to %s: %w", from, to, err)
+			}
+			moved = append(moved, to)
+		}
+	}
+	return moved, nil
+}
@@ -0,0 +1,109 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLocations checks each platform's defaults, XDG overrides and the legacy directory.
+func TestLocations(t *testing.T) {
+	vars := func(values map[string]string) func(string) string {
+		return func(name string) string { return values[name] }
+	}
+	home := filepath.FromSlash("/home/ana")
+	tests := []struct {
+		name                       string
+		env                        env
+		config, data, cache, state string
+	}{
+		{
+			name:   "linux defaults",
+			env:    env{goos: "linux", home: home, getenv: vars(nil)},
+			config: "/home/ana/.config/maat", data: "/home/ana/.local/share/maat",
+			cache: "/home/ana/.cache/maat", state: "/home/ana/.local/state/maat",
+		},
+		{
+			name: "xdg overrides, relative ones ignored",
+			env: env{goos: "linux", home: home, getenv: vars(map[string]string{
+				"XDG_DATA_HOME": "/data", "XDG_CACHE_HOME": "/cache", "XDG_STATE_HOME": "relative/state",
+			})},
+			config: "/home/ana/.config/maat", data: "/data/maat",
+			cache: "/cache/maat", state: "/home/ana/.local/state/maat",
+		},
+		{
+			name:   "macOS",
+			env:    env{goos: "darwin", home: home, getenv: vars(nil)},
+			config: "/home/ana/Library/Application Support/maat", data: "/home/ana/Library/Application Support/maat",
+			cache: "/home/ana/Library/Caches/maat", state: "/home/ana/Library/Application Support/maat",
+		},
+		{
+			name:   "windows",
+			env:    env{goos: "windows", home: home, getenv: vars(map[string]string{"LOCALAPPDATA": "/local"})},
+			config: "/home/ana/AppData/Roaming/maat", data: "/local/maat",
+			cache: "/local/maat/cache", state: "/local/maat/state",
+		},
+		{
+			name:   "legacy directory over defaults, not explicit variables",
+			env:    env{goos: "linux", home: home, getenv: vars(map[string]string{"XDG_DATA_HOME": "/data"}), hasLegacy: true},
+			config: "/home/ana/.maat", data: "/data/maat",
+			cache: "/home/ana/.maat/cache", state: "/home/ana/.maat",
+		},
+	}
+	for _, tt := range tests {
+		got := []string{tt.env.config(), tt.env.data(), tt.env.cache(), tt.env.state()}
+		want := []string{tt.config, tt.data, tt.cache, tt.state}
+		for i := range got {
+			if got[i] != filepath.FromSlash(want[i]) {
+				t.Errorf("%s: got %q, want %q", tt.name, got[i], want[i])
+			}
+		}
+	}
+}
+
+// TestMigrateLegacy checks setting XDG_DATA_HOME moves the legacy database
+// and saved views there, leaves what other locations hold, and never
+// overwrites a file already moved.
+func TestMigrateLegacy(t *testing.T) {
+	home, data := t.TempDir(), t.TempDir()
+	legacy := filepath.Join(home, ".maat")
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(legacy, "graphs", "app-1234.db"), "graph")
+	write(filepath.Join(legacy, "queries.yaml"), "old views")
+	write(filepath.Join(legacy, "config.yaml"), "config")
+	write(filepath.Join(data, "maat", "queries.yaml"), "new views")
+
+	e := env{goos: "linux", home: home, getenv: func(name string) string {
+		if name == "XDG_DATA_HOME" {
+			return data
+		}
+		return ""
+	}, hasLegacy: true}
+	moved, err := e.migrateLegacy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(moved) != 1 || moved[0] != filepath.Join(data, "maat", "graphs") {
+		t.Errorf("moved %v, want only the graphs", moved)
+	}
+	if content, err := os.ReadFile(filepath.Join(e.data(), "graphs", "app-1234.db")); err != nil || string(content) != "graph" {
+		t.Errorf("migrated database = %q, %v", content, err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(e.data(), "queries.yaml")); string(content) != "new views" {
+		t.Errorf("saved views overwritten with %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(legacy, "config.yaml")); err != nil {
+		t.Errorf("config left ~/.maat though no XDG variable moved it: %v", err)
+	}
+	if moved, _ := e.migrateLegacy(); len(moved) != 0 {
+		t.Errorf("second migration moved %v", moved)
+	}
+}
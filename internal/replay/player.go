@@ -0,0 +1,132 @@
+package replay
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stepMsg fires when the next scripted key is due
+type stepMsg struct {
+	index int
+}
+
+// doneMsg fires once the last key's effects have had time to settle
+type doneMsg struct{}
+
+// Player wraps a model and feeds it the scenario's keys on schedule.
+// Every other message passes straight through, so the wrapped model runs
+// exactly as it would live. It is itself a tea.Model with value semantics.
+type Player struct {
+	model  tea.Model
+	steps  []step
+	next   int
+	speed  float64       // Delay divisor (2 = twice as fast)
+	settle time.Duration // Wait after the last key before quitting (0 = keep running)
+	start  tea.Cmd       // Command from applying a fixed screen size
+	frames []string      // View after each key, when capturing
+	record bool
+}
+
+// NewPlayer prepares a replay of the scenario against model.
+func NewPlayer(model tea.Model, scenario Scenario) (Player, error) {
+	steps, err := scenario.steps()
+	if err != nil {
+		return Player{}, err
+	}
+	return Player{model: model, steps: steps, speed: 1}, nil
+}
+
+// WithSpeed returns a Player that waits delay/speed between keys.
+// Zero or negative speeds replay without delays.
+func (p Player) WithSpeed(speed float64) Player {
+	p.speed = speed
+	return p
+}
+
+// WithQuitAfter returns a Player that quits settle after the last key.
+func (p Player) WithQuitAfter(settle time.Duration) Player {
+	p.settle = settle
+	return p
+}
+
+// WithScreenSize returns a Player whose model starts at a fixed screen size,
+// for headless runs where no terminal reports one. The size is applied
+// before the first key so nothing races it.
+func (p Player) WithScreenSize(width, height int) Player {
+	p.model, p.start = p.model.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return p
+}
+
+// WithFrames returns a Player that captures the view after every key.
+func (p Player) WithFrames(capture bool) Player {
+	p.record = capture
+	return p
+}
+
+// Model returns the wrapped model in its current state.
+func (p Player) Model() tea.Model {
+	return p.model
+}
+
+// Frames returns the views captured after each key (see WithFrames).
+func (p Player) Frames() []string {
+	return p.frames
+}
+
+// Init starts the wrapped model and schedules the first key once its
+// startup commands have delivered their messages.
+func (p Player) Init() tea.Cmd {
+	return tea.Sequence(tea.Batch(p.model.Init(), p.start), p.schedule())
+}
+
+// Update presses due keys and forwards everything else to the wrapped model.
+func (p Player) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case stepMsg:
+		if msg.index != p.next || p.next >= len(p.steps) {
+			return p, nil
+		}
+		var cmd tea.Cmd
+		p.model, cmd = p.model.Update(p.steps[p.next].key)
+		p.next++
+		if p.record {
+			p.frames = append(p.frames, p.model.View())
+		}
+		// The key's own effects land before the next key is pressed
+		return p, tea.Sequence(cmd, p.schedule())
+
+	case doneMsg:
+		return p, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	p.model, cmd = p.model.Update(msg)
+	return p, cmd
+}
+
+// View renders the wrapped model.
+func (p Player) View() string {
+	return p.model.View()
+}
+
+// schedule returns the timer for the next key, or for quitting once done
+func (p Player) schedule() tea.Cmd {
+	if p.next >= len(p.steps) {
+		if p.settle <= 0 {
+			return nil
+		}
+		return tea.Tick(p.settle, func(time.Time) tea.Msg { return doneMsg{} })
+	}
+	index := p.next
+	delay := p.steps[index].after
+	if p.speed <= 0 {
+		delay = 0
+	} else {
+		delay = time.Duration(float64(delay) / p.speed)
+	}
+	if delay <= 0 {
+		return func() tea.Msg { return stepMsg{index: index} }
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg { return stepMsg{index: index} })
+}
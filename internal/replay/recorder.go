@@ -0,0 +1,42 @@
+package replay
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Recorder captures key presses with their timing from a live session.
+// Install Filter with tea.WithFilter; it observes messages without changing them.
+type Recorder struct {
+	scenario Scenario
+	last     time.Time
+}
+
+// NewRecorder starts a recording of a session over the given mock graph
+// (seed and size as passed to the mock source; zero for the demo graph).
+func NewRecorder(seed int64, size int) *Recorder {
+	return &Recorder{scenario: Scenario{Seed: seed, Size: size}, last: time.Now()}
+}
+
+// Filter records key presses and the terminal size, passing every message on.
+func (r *Recorder) Filter(_ tea.Model, msg tea.Msg) tea.Msg {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		now := time.Now()
+		event := Event{Key: msg.String(), After: now.Sub(r.last).Round(10 * time.Millisecond)}
+		if msg.Paste {
+			event.Key, event.Type = "", string(msg.Runes)
+		}
+		r.scenario.Events = append(r.scenario.Events, event)
+		r.last = now
+	case tea.WindowSizeMsg:
+		r.scenario.Width, r.scenario.Height = msg.Width, msg.Height
+	}
+	return msg
+}
+
+// Scenario returns what has been recorded so far.
+func (r *Recorder) Scenario() Scenario {
+	return r.scenario
+}
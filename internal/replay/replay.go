@@ -0,0 +1,145 @@
+// Package replay records and replays key events against the TUI, for
+// deterministic demo screencasts and end-to-end tests of the Update loop.
+//
+// A scenario names the generated graph to load and the keys to press, each
+// after a delay. Replay drives the real Bubble Tea runtime (a Player wraps
+// the model), so commands and their messages run exactly as they do live.
+package replay
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario is a scripted session: the graph to load and the keys to press.
+type Scenario struct {
+	Seed   int64   `yaml:"seed,omitempty"`   // Mock graph seed (default 1)
+	Size   int     `yaml:"size,omitempty"`   // Mock graph size in nodes (0 = demo graph)
+	Width  int     `yaml:"width,omitempty"`  // Headless screen size (default 120x36)
+	Height int     `yaml:"height,omitempty"` // A live terminal keeps its own size
+	Events []Event `yaml:"events"`
+}
+
+// Event is one step: a key press or typed text, after a delay.
+type Event struct {
+	Key   string        `yaml:"key,omitempty"`   // Key as Bubble Tea names it: "j", "enter", "ctrl+a", "alt+x"
+	Type  string        `yaml:"type,omitempty"`  // Text typed one character at a time
+	After time.Duration `yaml:"after,omitempty"` // Delay before the event (between characters for Type)
+}
+
+// Load reads a scenario file.
+func Load(path string) (Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("reading scenario: %w", err)
+	}
+	var scenario Scenario
+	if err := yaml.Unmarshal(data, &scenario); err != nil {
+		return Scenario{}, fmt.Errorf("parsing scenario %s: %w", path, err)
+	}
+	if _, err := scenario.steps(); err != nil {
+		return Scenario{}, fmt.Errorf("scenario %s: %w", path, err)
+	}
+	return scenario, nil
+}
+
+// Write saves the scenario as YAML.
+func (s Scenario) Write(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("encoding scenario: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing scenario: %w", err)
+	}
+	return nil
+}
+
+// ScreenSize returns the headless screen size, defaulting to 120x36.
+func (s Scenario) ScreenSize() (int, int) {
+	width, height := s.Width, s.Height
+	if width <= 0 {
+		width = 120
+	}
+	if height <= 0 {
+		height = 36
+	}
+	return width, height
+}
+
+// step is one key press due after a delay
+type step struct {
+	key   tea.KeyMsg
+	after time.Duration
+}
+
+// steps expands events into individual key presses
+func (s Scenario) steps() ([]step, error) {
+	var steps []step
+	for i, event := range s.Events {
+		switch {
+		case event.Key != "" && event.Type != "":
+			return nil, fmt.Errorf("event %d: set key or type, not both", i+1)
+		case event.Key != "":
+			key, err := ParseKey(event.Key)
+			if err != nil {
+				return nil, fmt.Errorf("event %d: %w", i+1, err)
+			}
+			steps = append(steps, step{key: key, after: event.After})
+		case event.Type != "":
+			for _, r := range event.Type {
+				steps = append(steps, step{key: runeKey(r), after: event.After})
+			}
+		default:
+			return nil, fmt.Errorf("event %d: no key or type", i+1)
+		}
+	}
+	return steps, nil
+}
+
+// keyTypes maps Bubble Tea key names ("enter", "ctrl+a", "f1") to key types
+var keyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	for t := tea.KeyType(-128); t < 128; t++ {
+		if name := t.String(); name != "" && t != tea.KeyRunes {
+			if _, taken := types[name]; !taken {
+				types[name] = t
+			}
+		}
+	}
+	return types
+}()
+
+// ParseKey turns a key name, as tea.KeyMsg.String() prints it, back into a key.
+func ParseKey(name string) (tea.KeyMsg, error) {
+	alt := false
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		alt, name = true, rest
+	}
+	if t, ok := keyTypes[name]; ok {
+		key := tea.Key{Type: t, Alt: alt}
+		if t == tea.KeySpace {
+			key.Runes = []rune{' '}
+		}
+		return tea.KeyMsg(key), nil
+	}
+	if runes := []rune(name); len(runes) == 1 {
+		key := runeKey(runes[0])
+		key.Alt = alt
+		return key, nil
+	}
+	return tea.KeyMsg{}, fmt.Errorf("unknown key %q", name)
+}
+
+// runeKey is a single typed character
+func runeKey(r rune) tea.KeyMsg {
+	if r == ' ' {
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
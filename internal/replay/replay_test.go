@@ -0,0 +1,134 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestParseKeyRoundTrip checks that recorded key names replay as the same keys.
+func TestParseKeyRoundTrip(t *testing.T) {
+	for _, name := range []string{"j", "J", "/", " ", "enter", "esc", "tab", "shift+tab", "ctrl+a", "ctrl+c", "up", "pgdown", "f5", "alt+x", "alt+enter", "é"} {
+		key, err := ParseKey(name)
+		if err != nil {
+			t.Errorf("ParseKey(%q): %v", name, err)
+			continue
+		}
+		if got := key.String(); got != name {
+			t.Errorf("ParseKey(%q).String() = %q", name, got)
+		}
+	}
+	if _, err := ParseKey("hyper+q"); err == nil {
+		t.Error("ParseKey accepted an unknown key name")
+	}
+}
+
+// TestScenarioWriteLoad checks that recordings survive the YAML round trip.
+func TestScenarioWriteLoad(t *testing.T) {
+	scenario := Scenario{Seed: 7, Width: 100, Height: 30, Events: []Event{
+		{Key: "j", After: 250 * time.Millisecond},
+		{Type: "/auth", After: 80 * time.Millisecond},
+		{Key: "enter", After: time.Second},
+	}}
+	path := filepath.Join(t.TempDir(), "demo.yaml")
+	if err := scenario.Write(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, scenario) {
+		t.Errorf("loaded %+v, want %+v", loaded, scenario)
+	}
+}
+
+// keyLog is a model that remembers the keys it was sent
+type keyLog struct {
+	keys []string
+}
+
+func (k keyLog) Init() tea.Cmd { return nil }
+
+func (k keyLog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		k.keys = append(k.keys, key.String())
+	}
+	return k, nil
+}
+
+func (k keyLog) View() string { return "" }
+
+func (k keyLog) StateHash() string { return "keys:" + strings.Join(k.keys, ",") }
+
+// TestPlayerPressesKeysInOrder drives a Player by hand, as the runtime would.
+func TestPlayerPressesKeysInOrder(t *testing.T) {
+	player, err := NewPlayer(keyLog{}, Scenario{Events: []Event{{Key: "g"}, {Type: "ab"}, {Key: "enter"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var model tea.Model = player.WithSpeed(0)
+	for i := 0; i < 4; i++ {
+		model, _ = model.Update(stepMsg{index: i})
+	}
+	model, _ = model.Update(stepMsg{index: 1}) // Stale timers are ignored
+
+	want := []string{"g", "a", "b", "enter"}
+	if got := model.(Player).Model().(keyLog).keys; !reflect.DeepEqual(got, want) {
+		t.Errorf("pressed %v, want %v", got, want)
+	}
+}
+
+// TestUpdateLogReplay checks that a logged session replays to the same state
+// and that a different starting state is reported as a divergence.
+func TestUpdateLogReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "updates.jsonl")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec := NewCodec(tea.KeyMsg{})
+	logger, err := NewLogger(keyLog{}, codec, f, map[string]int{"seed": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var model tea.Model = logger
+	for _, msg := range []tea.Msg{runeKey('j'), struct{ Done func() }{}, tea.KeyMsg{Type: tea.KeyEnter}} {
+		model, _ = model.Update(msg)
+	}
+	if err := model.(Logger).Err(); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	if !IsUpdateLog(path) {
+		t.Fatal("IsUpdateLog = false for a logger's output")
+	}
+	log, err := ReadUpdateLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := log.Replay(keyLog{}, codec, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Applied != 2 || len(result.Skipped) != 1 || result.Divergence != nil {
+		t.Errorf("applied %d, skipped %d, divergence %+v; want 2, 1, none", result.Applied, len(result.Skipped), result.Divergence)
+	}
+	if got := result.Model.(keyLog).keys; !reflect.DeepEqual(got, []string{"j", "enter"}) {
+		t.Errorf("replayed keys %v", got)
+	}
+
+	result, err = log.Replay(keyLog{keys: []string{"x"}}, codec, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Applied != 1 || result.Divergence == nil || result.Divergence.Seq != 0 {
+		t.Errorf("applied %d, divergence %+v; want 1 and the initial state", result.Applied, result.Divergence)
+	}
+}
@@ -0,0 +1,262 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// updateLogFormat marks the first line of an update log
+const updateLogFormat = "maat-update-log"
+
+// Hasher is implemented by models that can fingerprint their state.
+type Hasher interface {
+	StateHash() string
+}
+
+// Codec encodes messages by their Go type name ("tea.KeyMsg", "tui.StatusMsg")
+// and decodes the registered ones back into values of the same type.
+type Codec struct {
+	types map[string]reflect.Type
+}
+
+// NewCodec registers the message types whose JSON form can be replayed.
+func NewCodec(samples ...tea.Msg) *Codec {
+	c := &Codec{types: make(map[string]reflect.Type, len(samples))}
+	for _, sample := range samples {
+		c.types[fmt.Sprintf("%T", sample)] = reflect.TypeOf(sample)
+	}
+	return c
+}
+
+// encode returns the message's type name and, when it is replayable, its JSON
+func (c *Codec) encode(msg tea.Msg) (string, json.RawMessage, bool) {
+	name := fmt.Sprintf("%T", msg)
+	if _, ok := c.types[name]; !ok {
+		return name, nil, false
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return name, nil, false
+	}
+	return name, data, true
+}
+
+// decode rebuilds a logged message
+func (c *Codec) decode(name string, data json.RawMessage) (tea.Msg, error) {
+	t, ok := c.types[name]
+	if !ok {
+		return nil, fmt.Errorf("message type %s is not replayable", name)
+	}
+	value := reflect.New(t)
+	if err := json.Unmarshal(data, value.Interface()); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", name, err)
+	}
+	return value.Elem().Interface(), nil
+}
+
+// LogHeader is the first line of an update log: what the session started
+// from. Session is opaque here; the caller records whatever it needs to
+// rebuild the initial model (graph data, flags, config).
+type LogHeader struct {
+	Format  string          `json:"format"`
+	Version int             `json:"version"`
+	Started time.Time       `json:"started"`
+	Session json.RawMessage `json:"session"`
+	Hash    string          `json:"hash"` // Initial model state
+}
+
+// LogEntry is one message delivered to Update and the state it produced.
+type LogEntry struct {
+	Seq     int             `json:"seq"`
+	Elapsed time.Duration   `json:"elapsed"`
+	Type    string          `json:"type"`
+	Msg     json.RawMessage `json:"msg,omitempty"`  // Absent for messages that cannot be replayed
+	Text    string          `json:"text,omitempty"` // Printed form of unreplayable messages
+	Hash    string          `json:"hash"`
+}
+
+// logWriter is shared by every copy of a Logger
+type logWriter struct {
+	enc   *json.Encoder
+	start time.Time
+	seq   int
+	err   error
+}
+
+// Logger wraps a model and logs every message it receives, with the hash of
+// the resulting state, for bug reports. It is a tea.Model with value semantics.
+type Logger struct {
+	model tea.Model
+	codec *Codec
+	out   *logWriter
+}
+
+// NewLogger writes the header for a session and returns the wrapped model.
+// session is stored verbatim for the replayer to rebuild the model from.
+func NewLogger(model tea.Model, codec *Codec, w io.Writer, session any) (Logger, error) {
+	sessionJSON, err := json.Marshal(session)
+	if err != nil {
+		return Logger{}, fmt.Errorf("encoding session: %w", err)
+	}
+	out := &logWriter{enc: json.NewEncoder(w), start: time.Now()}
+	header := LogHeader{Format: updateLogFormat, Version: 1, Started: out.start, Session: sessionJSON, Hash: hashOf(model)}
+	if err := out.enc.Encode(header); err != nil {
+		return Logger{}, fmt.Errorf("writing update log: %w", err)
+	}
+	return Logger{model: model, codec: codec, out: out}, nil
+}
+
+// Err returns the first error writing the log, if any.
+func (l Logger) Err() error {
+	return l.out.err
+}
+
+// Model returns the wrapped model in its current state.
+func (l Logger) Model() tea.Model {
+	return l.model
+}
+
+// Init starts the wrapped model.
+func (l Logger) Init() tea.Cmd {
+	return l.model.Init()
+}
+
+// Update forwards the message and logs it with the resulting state hash.
+func (l Logger) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	l.model, cmd = l.model.Update(msg)
+
+	if l.out.err == nil {
+		l.out.seq++
+		entry := LogEntry{Seq: l.out.seq, Elapsed: time.Since(l.out.start), Hash: hashOf(l.model)}
+		var ok bool
+		entry.Type, entry.Msg, ok = l.codec.encode(msg)
+		if !ok {
+			entry.Text = fmt.Sprintf("%+v", msg)
+		}
+		l.out.err = l.out.enc.Encode(entry)
+	}
+	return l, cmd
+}
+
+// View renders the wrapped model.
+func (l Logger) View() string {
+	return l.model.View()
+}
+
+// hashOf fingerprints a model, or returns "" when it cannot
+func hashOf(model tea.Model) string {
+	if h, ok := model.(Hasher); ok {
+		return h.StateHash()
+	}
+	return ""
+}
+
+// UpdateLog is a recorded session read back from disk.
+type UpdateLog struct {
+	Header  LogHeader
+	Entries []LogEntry
+}
+
+// IsUpdateLog reports whether the file starts with an update log header
+// (as opposed to a key scenario).
+func IsUpdateLog(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil && len(line) == 0 {
+		return false
+	}
+	var header struct {
+		Format string `json:"format"`
+	}
+	return json.Unmarshal(line, &header) == nil && header.Format == updateLogFormat
+}
+
+// ReadUpdateLog loads an update log.
+func ReadUpdateLog(path string) (UpdateLog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return UpdateLog{}, fmt.Errorf("reading update log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	dec := json.NewDecoder(bufio.NewReader(f))
+	var log UpdateLog
+	if err := dec.Decode(&log.Header); err != nil || log.Header.Format != updateLogFormat {
+		return UpdateLog{}, fmt.Errorf("%s is not a MAAT update log", path)
+	}
+	if log.Header.Version != 1 {
+		return UpdateLog{}, fmt.Errorf("update log version %d is not supported", log.Header.Version)
+	}
+	for {
+		var entry LogEntry
+		if err := dec.Decode(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			// A crash can cut the last line short; keep what was complete
+			if len(log.Entries) > 0 {
+				break
+			}
+			return UpdateLog{}, fmt.Errorf("parsing update log: %w", err)
+		}
+		log.Entries = append(log.Entries, entry)
+	}
+	return log, nil
+}
+
+// Divergence is the first step where the replayed state differs from the recording.
+type Divergence struct {
+	Seq      int
+	Type     string
+	Recorded string
+	Replayed string
+}
+
+// ReplayResult summarizes a re-driven update log.
+type ReplayResult struct {
+	Model      tea.Model
+	Applied    int
+	Skipped    []LogEntry  // Unreplayable messages, in order
+	Divergence *Divergence // nil when every hash matched
+}
+
+// Replay re-drives model's Update with the logged messages up to and
+// including seq until (0 = all). Commands returned by Update are dropped:
+// their resulting messages are in the log already, and running them again
+// would repeat side effects such as external writes.
+func (log UpdateLog) Replay(model tea.Model, codec *Codec, until int) (ReplayResult, error) {
+	result := ReplayResult{Model: model}
+	if log.Header.Hash != "" && hashOf(model) != log.Header.Hash {
+		result.Divergence = &Divergence{Type: "initial state", Recorded: log.Header.Hash, Replayed: hashOf(model)}
+	}
+	for _, entry := range log.Entries {
+		if until > 0 && entry.Seq > until {
+			break
+		}
+		if entry.Msg == nil {
+			result.Skipped = append(result.Skipped, entry)
+			continue
+		}
+		msg, err := codec.decode(entry.Type, entry.Msg)
+		if err != nil {
+			return result, fmt.Errorf("message %d: %w", entry.Seq, err)
+		}
+		result.Model, _ = result.Model.Update(msg)
+		result.Applied++
+		if replayed := hashOf(result.Model); result.Divergence == nil && entry.Hash != "" && replayed != entry.Hash {
+			result.Divergence = &Divergence{Seq: entry.Seq, Type: entry.Type, Recorded: entry.Hash, Replayed: replayed}
+		}
+	}
+	return result, nil
+}
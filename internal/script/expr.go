@@ -0,0 +1,106 @@
+package script
+
+import (
+	"fmt"
+	"math"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// Expr is a Starlark expression over a node's data fields, such as
+// "estimate * priority" or "lines // 100", computing a custom metric.
+type Expr struct {
+	src    string
+	fields []string // Names the expression reads that are not builtins
+}
+
+// CompileExpr parses a metric expression
+func CompileExpr(src string) (*Expr, error) {
+	parsed, err := syntax.ParseExpr("metric", src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %w", src, err)
+	}
+	expr := &Expr{src: src}
+	seen := map[string]bool{}
+	syntax.Walk(parsed, func(n syntax.Node) bool {
+		ident, ok := n.(*syntax.Ident)
+		if ok && !seen[ident.Name] && starlark.Universe[ident.Name] == nil {
+			seen[ident.Name] = true
+			expr.fields = append(expr.fields, ident.Name)
+		}
+		return true
+	})
+	return expr, nil
+}
+
+// Eval computes the expression over fields (decoded JSON values). It
+// returns a float64, a string, or nil when the result is None or False, or
+// when a field it reads is missing: the metric does not apply to the node.
+func (e *Expr) Eval(fields map[string]interface{}) (interface{}, error) {
+	env := make(starlark.StringDict, len(e.fields))
+	for _, name := range e.fields {
+		value := toStarlark(fields[name])
+		if value == starlark.None {
+			return nil, nil
+		}
+		env[name] = value
+	}
+
+	thread := &starlark.Thread{Name: "metric"}
+	thread.SetMaxExecutionSteps(maxSteps)
+	out, err := starlark.EvalOptions(&syntax.FileOptions{}, thread, "metric", e.src, env)
+	if err != nil {
+		return nil, describe(err)
+	}
+	switch v := out.(type) {
+	case starlark.Int:
+		f, _ := starlark.AsFloat(v)
+		return f, nil
+	case starlark.Float:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return nil, nil
+		}
+		return float64(v), nil
+	case starlark.String:
+		return string(v), nil
+	case starlark.Bool:
+		if v {
+			return "✓", nil
+		}
+		return nil, nil
+	case starlark.NoneType:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("want a number or str, got %s", out.Type())
+}
+
+// toStarlark converts a decoded JSON value (None for null or missing)
+func toStarlark(value interface{}) starlark.Value {
+	switch v := value.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return starlark.MakeInt64(int64(v))
+		}
+		return starlark.Float(v)
+	case int:
+		return starlark.MakeInt(v)
+	case string:
+		return starlark.String(v)
+	case bool:
+		return starlark.Bool(v)
+	case []interface{}:
+		items := make([]starlark.Value, len(v))
+		for i, item := range v {
+			items[i] = toStarlark(item)
+		}
+		return starlark.NewList(items)
+	case map[string]interface{}:
+		dict := starlark.NewDict(len(v))
+		for key, item := range v {
+			_ = dict.SetKey(starlark.String(key), toStarlark(item))
+		}
+		return dict
+	}
+	return starlark.None
+}
@@ -0,0 +1,220 @@
+// Package script runs user Starlark rules that customize the graph view:
+// which nodes to hide, a decoration shown before a node's title, and
+// computed badges shown after it. Starlark is hermetic - scripts cannot read
+// files, run commands or reach the network - so a rules file is safe to share.
+package script
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// maxSteps bounds each rule call so a runaway loop cannot hang the TUI
+const maxSteps = 100_000
+
+// Rule functions a script may define; all are optional
+const (
+	filterFunc   = "filter"   // filter(node) -> bool: False hides the node
+	decorateFunc = "decorate" // decorate(node) -> str: shown before the title
+	badgesFunc   = "badges"   // badges(node) -> str | list[str]: shown after the title
+)
+
+// Node is the view of a graph node handed to rules
+type Node struct {
+	ID          string
+	Type        string
+	Title       string
+	Identifier  string
+	Status      string
+	Description string
+	Priority    int
+	Labels      []string
+	Owner       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Edges       int // Edges touching the node
+}
+
+// Result is what the rules decided for one node
+type Result struct {
+	Hidden     bool
+	Decoration string
+	Badges     []string
+}
+
+// Engine holds a loaded rules file
+type Engine struct {
+	path     string
+	filter   starlark.Callable
+	decorate starlark.Callable
+	badges   starlark.Callable
+}
+
+// Load executes a rules file and collects the rule functions it defines
+func Load(path string) (*Engine, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading script: %w", err)
+	}
+
+	thread := &starlark.Thread{Name: "load " + path}
+	thread.SetMaxExecutionSteps(maxSteps)
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, path, src, nil)
+	if err != nil {
+		return nil, fmt.Errorf("loading script %s: %w", path, describe(err))
+	}
+
+	engine := &Engine{path: path}
+	for name, target := range map[string]*starlark.Callable{
+		filterFunc:   &engine.filter,
+		decorateFunc: &engine.decorate,
+		badgesFunc:   &engine.badges,
+	} {
+		value, ok := globals[name]
+		if !ok {
+			continue
+		}
+		fn, ok := value.(starlark.Callable)
+		if !ok {
+			return nil, fmt.Errorf("script %s: %s must be a function, got %s", path, name, value.Type())
+		}
+		*target = fn
+	}
+	return engine, nil
+}
+
+// Path returns the rules file the engine was loaded from
+func (e *Engine) Path() string {
+	return e.path
+}
+
+// Eval runs the rules over every node. Nodes whose rules fail keep the
+// default presentation; the first failure is returned alongside the results.
+func (e *Engine) Eval(nodes []Node, now time.Time) (map[string]Result, error) {
+	results := make(map[string]Result, len(nodes))
+	var firstErr error
+	fail := func(node Node, rule string, err error) {
+		if firstErr == nil {
+			firstErr = fmt.Errorf("%s(%s): %w", rule, node.ID, describe(err))
+		}
+	}
+
+	for _, node := range nodes {
+		value := nodeValue(node, now)
+		var result Result
+
+		if e.filter != nil {
+			if out, err := e.call(e.filter, value); err != nil {
+				fail(node, filterFunc, err)
+			} else {
+				result.Hidden = !bool(out.Truth())
+			}
+		}
+		if e.decorate != nil {
+			if out, err := e.call(e.decorate, value); err != nil {
+				fail(node, decorateFunc, err)
+			} else if s, ok := starlark.AsString(out); ok {
+				result.Decoration = s
+			} else if out != starlark.None {
+				fail(node, decorateFunc, fmt.Errorf("want str, got %s", out.Type()))
+			}
+		}
+		if e.badges != nil {
+			if out, err := e.call(e.badges, value); err != nil {
+				fail(node, badgesFunc, err)
+			} else if badges, err := stringList(out); err != nil {
+				fail(node, badgesFunc, err)
+			} else {
+				result.Badges = badges
+			}
+		}
+
+		if result.Hidden || result.Decoration != "" || len(result.Badges) > 0 {
+			results[node.ID] = result
+		}
+	}
+	return results, firstErr
+}
+
+// call runs one rule on a fresh, step-limited thread
+func (e *Engine) call(fn starlark.Callable, node starlark.Value) (starlark.Value, error) {
+	thread := &starlark.Thread{Name: fn.Name()}
+	thread.SetMaxExecutionSteps(maxSteps)
+	return starlark.Call(thread, fn, starlark.Tuple{node}, nil)
+}
+
+// nodeValue exposes a node to Starlark as a read-only struct
+func nodeValue(node Node, now time.Time) starlark.Value {
+	labels := make([]starlark.Value, len(node.Labels))
+	for i, label := range node.Labels {
+		labels[i] = starlark.String(label)
+	}
+	return starlarkstruct.FromStringDict(starlark.String("node"), starlark.StringDict{
+		"id":           starlark.String(node.ID),
+		"type":         starlark.String(node.Type),
+		"title":        starlark.String(node.Title),
+		"identifier":   starlark.String(node.Identifier),
+		"status":       starlark.String(node.Status),
+		"description":  starlark.String(node.Description),
+		"priority":     starlark.MakeInt(node.Priority),
+		"labels":       starlark.NewList(labels),
+		"owner":        starlark.String(node.Owner),
+		"age_days":     starlark.MakeInt(daysSince(node.CreatedAt, now)),
+		"updated_days": starlark.MakeInt(daysSince(node.UpdatedAt, now)),
+		"edges":        starlark.MakeInt(node.Edges),
+	})
+}
+
+// daysSince returns whole days from t to now (-1 when t is unknown)
+func daysSince(t, now time.Time) int {
+	if t.IsZero() {
+		return -1
+	}
+	return int(now.Sub(t).Hours() / 24)
+}
+
+// stringList accepts a str, a list/tuple of str, or None
+func stringList(value starlark.Value) ([]string, error) {
+	if value == starlark.None {
+		return nil, nil
+	}
+	if s, ok := starlark.AsString(value); ok {
+		if s == "" {
+			return nil, nil
+		}
+		return []string{s}, nil
+	}
+	iterable, ok := value.(starlark.Iterable)
+	if !ok {
+		return nil, fmt.Errorf("want str or list of str, got %s", value.Type())
+	}
+	var out []string
+	iter := iterable.Iterate()
+	defer iter.Done()
+	var item starlark.Value
+	for iter.Next(&item) {
+		s, ok := starlark.AsString(item)
+		if !ok {
+			return nil, fmt.Errorf("want str badges, got %s", item.Type())
+		}
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// describe adds the Starlark backtrace to evaluation errors
+func describe(err error) error {
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return errors.New(evalErr.Backtrace())
+	}
+	return err
+}
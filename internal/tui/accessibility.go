@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// selectedMarker replaces background-color highlighting in accessible mode.
+const selectedMarker = "[SELECTED]"
+
+// WithAccessible returns a new Model with screen-reader friendly output toggled.
+// Accessible mode drops box-drawing characters, emoji and background-color
+// selection in favor of plain text markers.
+func (m Model) WithAccessible(enabled bool) Model {
+	m.accessible = enabled
+	return m
+}
+
+// IsAccessible reports whether accessible mode is active.
+func (m Model) IsAccessible() bool {
+	return m.accessible
+}
+
+// treeConnector returns the branch drawn before a tree row.
+// Accessible mode announces the level instead of drawing a branch.
+func (m Model) treeConnector(isLast bool, depth int) string {
+	if m.accessible {
+		return fmt.Sprintf("Level %d: ", depth)
+	}
+	if isLast {
+		return "└── "
+	}
+	return "├── "
+}
+
+// treeIndent returns the prefix added for each level beneath a row.
+func (m Model) treeIndent(isLast bool) string {
+	if m.accessible {
+		return "  "
+	}
+	if isLast {
+		return "    "
+	}
+	return "│   "
+}
+
+// button renders a dialog button. Accessible mode drops its colors, leaving
+// the bracketed key and label.
+func (m Model) button(style lipgloss.Style, label string) string {
+	if m.accessible {
+		return label
+	}
+	return style.Render(label)
+}
+
+// typeLabel returns a plain-text name for a node type (accessible mode icon).
+func (m Model) typeLabel(t graph.NodeType) string {
+	return m.nodeTypes.Get(string(t)).Label
+}
+
+// plainText strips decorative glyphs a screen reader would read out literally
+// or skip confusingly: box drawing (borders, tree lines), block elements
+// (progress bars, cursors), geometric status shapes, braille spinner frames
+// and emoji. Box and block characters become spaces to keep alignment.
+func plainText(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 0x2500 && r <= 0x259F: // Box drawing, block elements
+			return ' '
+		case r >= 0x25A0 && r <= 0x25FF, // Geometric shapes (◐ ○ ▸ ▾ ▶)
+			r >= 0x2600 && r <= 0x27BF,   // Misc symbols, dingbats (✓ ✗ ⚙)
+			r >= 0x2800 && r <= 0x28FF,   // Braille (spinner frames)
+			r >= 0x1F000 && r <= 0x1FAFF, // Emoji
+			r == 0xFE0F, r == 0x200D:     // Variation selector, zero-width joiner
+			return -1
+		default:
+			return r
+		}
+	}, s)
+}
@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// QueuedAction is an external write deferred to the action queue.
+type QueuedAction struct {
+	Request  ConfirmationRequest
+	QueuedAt time.Time
+	LastErr  string // Why the last flush did not run it ("" = never flushed)
+}
+
+// ActionQueue returns the deferred write actions, oldest first.
+func (m Model) ActionQueue() []QueuedAction {
+	return m.actionQueue
+}
+
+// WithSelectedActionIdx returns a new Model with the Action queue selection
+// clamped to the queue.
+func (m Model) WithSelectedActionIdx(idx int) Model {
+	m.selectedActionIdx = max(min(idx, len(m.actionQueue)-1), 0)
+	return m
+}
+
+// enqueueAction defers a confirmation request to the action queue.
+func (m Model) enqueueAction(req ConfirmationRequest) Model {
+	req.Conflicts, req.Warning = nil, ""
+	queue := make([]QueuedAction, len(m.actionQueue), len(m.actionQueue)+1)
+	copy(queue, m.actionQueue)
+	m.actionQueue = append(queue, QueuedAction{Request: req, QueuedAt: time.Now()})
+	return m.WithStatus(fmt.Sprintf("Queued: %s (%d pending, A to review)", req.Action, len(m.actionQueue)), false)
+}
+
+// withoutAction returns a new Model with the queued action at idx removed.
+func (m Model) withoutAction(idx int) Model {
+	if idx < 0 || idx >= len(m.actionQueue) {
+		return m
+	}
+	queue := make([]QueuedAction, 0, len(m.actionQueue)-1)
+	queue = append(queue, m.actionQueue[:idx]...)
+	m.actionQueue = append(queue, m.actionQueue[idx+1:]...)
+	return m.WithSelectedActionIdx(m.selectedActionIdx)
+}
+
+// flushConfirmation asks once for every queued action.
+func (m Model) flushConfirmation() *ConfirmationRequest {
+	destructive := false
+	for _, action := range m.actionQueue {
+		destructive = destructive || action.Request.Destructive
+	}
+	return &ConfirmationRequest{
+		Action:      fmt.Sprintf("Run %d queued actions?", len(m.actionQueue)),
+		Batch:       m.actionQueue,
+		Category:    CategoryQueue,
+		Destructive: destructive,
+		Queued:      true,
+	}
+}
+
+// withRequeued puts an action run alone back at the front of the queue.
+func (m Model) withRequeued(action QueuedAction) Model {
+	m.actionQueue = append([]QueuedAction{action}, m.actionQueue...)
+	return m.WithSelectedActionIdx(0)
+}
+
+// withQueueFlushing empties the queue while a confirmed batch runs, keeping
+// the actions toggled off in the dialog; actions queued meanwhile are kept,
+// and failures are put back by withQueueFlushed.
+func (m Model) withQueueFlushing(batch, skipped []QueuedAction) Model {
+	rest := m.actionQueue[min(len(batch), len(m.actionQueue)):]
+	m.actionQueue = append(append([]QueuedAction{}, skipped...), rest...)
+	return m.WithSelectedActionIdx(0)
+}
+
+// withQueueFlushed puts the failed actions of a flush back at the front of the queue.
+func (m Model) withQueueFlushed(msg QueueFlushedMsg) Model {
+	m.actionQueue = append(append([]QueuedAction{}, msg.Failed...), m.actionQueue...)
+	m = m.WithSelectedActionIdx(m.selectedActionIdx)
+	if len(msg.Failed) > 0 {
+		return m.WithStatus(fmt.Sprintf("%d of %d actions failed and are queued (A to retry)", len(msg.Failed), len(msg.Failed)+msg.Done), true)
+	}
+	return m.WithStatus(fmt.Sprintf("Ran %d actions", msg.Done), false)
+}
+
+// executeQueuedActions runs a confirmed batch in order. Edits whose remote
+// copy changed the same fields since the last sync are skipped, not
+// overwritten: they stay queued to be confirmed one by one.
+func executeQueuedActions(dispatcher *Dispatcher, batch []QueuedAction) tea.Cmd {
+	return func() tea.Msg {
+		var result QueueFlushedMsg
+		for _, action := range batch {
+			// Each action waits its turn with the provider it calls
+			release, _ := dispatcher.acquire(context.Background(), action.Request.providers()...)
+			err := runQueuedAction(action.Request)
+			release()
+			if err != nil {
+				action.LastErr = err.Error()
+				result.Failed = append(result.Failed, action)
+				continue
+			}
+			result.Done++
+			if action.Request.Done != nil {
+				result.Applied = append(result.Applied, action.Request.Done)
+			}
+		}
+		return result
+	}
+}
+
+// executeRequeued runs a queued action confirmed on its own. It reports like
+// a flush of one, so a failure puts it back in the queue. Remote conflicts
+// were already reviewed in its dialog and are not checked again.
+func executeRequeued(action QueuedAction, req ConfirmationRequest) tea.Cmd {
+	return func() tea.Msg {
+		if req.Execute == nil {
+			action.LastErr = "nothing to execute"
+			return QueueFlushedMsg{Failed: []QueuedAction{action}}
+		}
+		if err := req.Execute(); err != nil {
+			action.LastErr = err.Error()
+			return QueueFlushedMsg{Failed: []QueuedAction{action}}
+		}
+		result := QueueFlushedMsg{Done: 1}
+		if req.Done != nil {
+			result.Applied = []tea.Msg{req.Done}
+		}
+		return result
+	}
+}
+
+// runQueuedAction checks a queued edit for remote conflicts, then executes it.
+func runQueuedAction(req ConfirmationRequest) error {
+	if edit := req.Edit; edit != nil && edit.FetchRemote != nil {
+		remote, err := edit.FetchRemote()
+		if err != nil {
+			return fmt.Errorf("checking remote: %w", err)
+		}
+		if remote.UpdatedAt.After(edit.SyncedAt) {
+			if conflicts := DetectConflicts(edit.Base, edit.Local, remote.Fields); len(conflicts) > 0 {
+				fields := make([]string, len(conflicts))
+				for i, c := range conflicts {
+					fields[i] = c.Field
+				}
+				return fmt.Errorf("remote changed %s since last sync - run it alone (r) to review", strings.Join(fields, ", "))
+			}
+		}
+	}
+	if req.Execute == nil {
+		return fmt.Errorf("nothing to execute")
+	}
+	return req.Execute()
+}
+
+// handleActionQueueKeys processes keys in the Action queue view.
+func (m Model) handleActionQueueKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	hasSelection := m.selectedActionIdx < len(m.actionQueue)
+
+	switch msg.String() {
+	case "j", "down":
+		return m.WithSelectedActionIdx(m.selectedActionIdx + 1), nil
+	case "k", "up":
+		return m.WithSelectedActionIdx(m.selectedActionIdx - 1), nil
+	case "x", "d":
+		// Drop the selected action without running it
+		return m.withoutAction(m.selectedActionIdx), nil
+	case "r":
+		// Run the selected action alone, through the regular confirmation
+		if !hasSelection {
+			return m, nil
+		}
+		// It leaves the queue now so a flush can't run it twice, and comes
+		// back if the dialog is rejected or the write fails
+		action := m.actionQueue[m.selectedActionIdx]
+		req := action.Request
+		m = m.withoutAction(m.selectedActionIdx)
+		return m.Update(ConfirmationRequested{Action: req.Action, Execute: req.Execute, Edit: req.Edit, Done: req.Done, Category: req.Category, Destructive: req.Destructive, Optimistic: req.Optimistic, Target: req.Target, Requeue: &action})
+	case "enter":
+		// Flush the whole queue with one confirmation
+		if len(m.actionQueue) == 0 {
+			return m, nil
+		}
+		return m.confirmOrRun(*m.flushConfirmation())
+	case "esc", "A":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
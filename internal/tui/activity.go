@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// Kinds of session activity
+const (
+	ActivitySync   = "sync"   // Sources refreshed
+	ActivityJump   = "jump"   // Focus moved along a relation or link
+	ActivityWrite  = "write"  // External write run after confirmation
+	ActivityExport = "export" // Something copied or opened outside maat
+)
+
+// ActivityEntry is one action taken during the session.
+type ActivityEntry struct {
+	At   time.Time
+	Kind string // One of the Activity* kinds
+	Text string
+}
+
+// Activity returns what was done this session, oldest first.
+func (m Model) Activity() []ActivityEntry {
+	return m.activity
+}
+
+// logActivity returns a new Model with an action added to the session log.
+func (m Model) logActivity(kind, text string) Model {
+	activity := make([]ActivityEntry, len(m.activity), len(m.activity)+1)
+	copy(activity, m.activity)
+	m.activity = append(activity, ActivityEntry{At: time.Now(), Kind: kind, Text: text})
+	return m
+}
+
+// WithActivityExport returns a new Model appending the session's activity
+// log to path on quit ("" = not exported).
+func (m Model) WithActivityExport(path string) Model {
+	m.activityPath = path
+	return m
+}
+
+// ActivityMarkdown renders the activity log as a Markdown list under a
+// heading naming when the session started.
+func ActivityMarkdown(entries []ActivityEntry) string {
+	if len(entries) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Session %s\n\n", entries[0].At.Format("2006-01-02 15:04"))
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "- %s %s: %s\n", entry.At.Format("15:04:05"), entry.Kind, entry.Text)
+	}
+	return b.String()
+}
+
+// exportActivity appends the activity log to path (local file, not an
+// external write)
+func exportActivity(path string, entries []ActivityEntry) tea.Cmd {
+	return func() tea.Msg {
+		if path == "" || len(entries) == 0 {
+			return nil
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return StatusMsg{Message: "Failed to export activity: " + err.Error(), IsError: true}
+		}
+		defer func() { _ = f.Close() }()
+		if _, err := f.WriteString(ActivityMarkdown(entries) + "\n"); err != nil {
+			return StatusMsg{Message: "Failed to export activity: " + err.Error(), IsError: true}
+		}
+		return nil
+	}
+}
+
+// WithActivityScroll returns a new Model with the Activity view scrolled to
+// offset, clamped to the log.
+func (m Model) WithActivityScroll(offset int) Model {
+	m.activityScroll = max(min(offset, len(m.activity)-1), 0)
+	return m
+}
+
+// handleActivityKeys processes keys in the Activity view.
+func (m Model) handleActivityKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		return m.WithActivityScroll(m.activityScroll + 1), nil
+	case "k", "up":
+		return m.WithActivityScroll(m.activityScroll - 1), nil
+	case "y":
+		// Copy the log as Markdown, for standup notes or an audit trail
+		return m, copyToClipboard(ActivityMarkdown(m.activity), "activity")
+	case "esc", "L":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// renderActivityView renders the session's actions, newest first.
+func (m Model) renderActivityView(width, height int) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	kindStyle := lipgloss.NewStyle().Foreground(styles.Secondary).Width(7)
+
+	lines := []string{headerStyle.Render(fmt.Sprintf("📜 Session activity (%d)", len(m.activity))), ""}
+	if len(m.activity) == 0 {
+		lines = append(lines, mutedStyle.Italic(true).Render("Nothing yet. Syncs, jumps, confirmed writes and exports are logged here."))
+		return strings.Join(lines, "\n")
+	}
+
+	rows := clampMin(height-len(lines), 1)
+	for i := len(m.activity) - 1 - m.activityScroll; i >= 0 && rows > 0; i-- {
+		entry := m.activity[i]
+		prefix := mutedStyle.Render(entry.At.Format("15:04:05")) + " " + kindStyle.Render(entry.Kind) + " "
+		lines = append(lines, prefix+truncate(entry.Text, clampMin(width-lipgloss.Width(prefix), 10)))
+		rows--
+	}
+	return strings.Join(lines, "\n")
+}
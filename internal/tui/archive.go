@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// archiveLimit caps one archive listing; narrow it down with a search
+const archiveLimit = 200
+
+// WithArchived returns a new Model listing the archived nodes matching search.
+func (m Model) WithArchived(search string, nodes []DisplayNode) Model {
+	m.archiveSearch = search
+	m.archived = nodes
+	return m.WithSelectedArchiveIdx(m.selectedArchiveIdx)
+}
+
+// WithSelectedArchiveIdx returns a new Model with the archive selection moved (wrapping).
+func (m Model) WithSelectedArchiveIdx(idx int) Model {
+	count := len(m.archived)
+	if count == 0 {
+		m.selectedArchiveIdx = 0
+		return m
+	}
+	m.selectedArchiveIdx = (idx%count + count) % count
+	return m
+}
+
+// WithArchiveSearchMode returns a new Model with the archive search prompt enabled/disabled.
+func (m Model) WithArchiveSearchMode(enabled bool) Model {
+	m.archiveSearchMode = enabled
+	return m
+}
+
+// withRestored brings restored nodes back into the graph and drops them from
+// the archive listing. Restored edges are kept where both ends are loaded.
+func (m Model) withRestored(nodes []DisplayNode, edges []DisplayEdge) Model {
+	restored := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		restored[node.ID] = true
+	}
+	var archived []DisplayNode
+	for _, node := range m.archived {
+		if !restored[node.ID] {
+			archived = append(archived, node)
+		}
+	}
+	m.archived = archived
+	return m.WithSelectedArchiveIdx(m.selectedArchiveIdx).withMerged(nodes, edges)
+}
+
+// listArchived searches the store's archive of nodes expired by their TTL
+func listArchived(ctx context.Context, store graph.GraphStore, search string) tea.Cmd {
+	return func() tea.Msg {
+		archiver, ok := store.(graph.Archiver)
+		if !ok {
+			return StatusMsg{Message: "This graph store keeps no archive", IsError: true}
+		}
+		nodes, err := archiver.ListArchived(ctx, search, archiveLimit)
+		if err != nil {
+			return StatusMsg{Message: "Archive error: " + err.Error(), IsError: true}
+		}
+		display := make([]DisplayNode, len(nodes))
+		for i, node := range nodes {
+			display[i] = displayNodeFromGraph(node)
+		}
+		return ArchiveListedMsg{Search: search, Nodes: display}
+	}
+}
+
+// restoreArchived moves archived nodes back into the store's working graph
+func restoreArchived(ctx context.Context, store graph.GraphStore, ids []string) tea.Cmd {
+	return func() tea.Msg {
+		archiver, ok := store.(graph.Archiver)
+		if !ok {
+			return StatusMsg{Message: "This graph store keeps no archive", IsError: true}
+		}
+		nodes, edges, err := archiver.Restore(ctx, ids)
+		if err != nil {
+			return StatusMsg{Message: "Restore failed: " + err.Error(), IsError: true}
+		}
+		display := make([]DisplayNode, len(nodes))
+		for i, node := range nodes {
+			display[i] = displayNodeFromGraph(node)
+		}
+		return ArchiveRestoredMsg{Nodes: display, Edges: EdgesToDisplayEdges(edges)}
+	}
+}
+
+// handleArchiveKeys processes keys in the Archive view.
+func (m Model) handleArchiveKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var selected *DisplayNode
+	if m.selectedArchiveIdx < len(m.archived) {
+		selected = &m.archived[m.selectedArchiveIdx]
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		return m.WithSelectedArchiveIdx(m.selectedArchiveIdx + 1), nil
+	case "k", "up":
+		return m.WithSelectedArchiveIdx(m.selectedArchiveIdx - 1), nil
+	case "/":
+		return m.WithArchiveSearchMode(true), nil
+	case "enter", "r":
+		// Restore into the active graph. A write, so quitting waits for it
+		// rather than cancelling it like the read-only listing
+		if selected == nil {
+			return m, nil
+		}
+		return m, m.writes.track(restoreArchived(context.Background(), m.store, []string{selected.ID}))
+	case "esc", "Z":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// handleArchiveSearchInput processes input while typing an archive search
+func (m Model) handleArchiveSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		// Clear the search and list the whole archive again
+		m = m.WithArchiveSearchMode(false)
+		m.archiveSearch = ""
+		return m, listArchived(m.ctx, m.store, "")
+
+	case tea.KeyEnter:
+		m.archiveSearchMode = false
+		m.selectedArchiveIdx = 0
+		return m, listArchived(m.ctx, m.store, m.archiveSearch)
+
+	case tea.KeyBackspace:
+		m.archiveSearch = dropLastRune(m.archiveSearch)
+		return m, nil
+
+	case tea.KeySpace:
+		m.archiveSearch += " "
+		return m, nil
+
+	case tea.KeyRunes:
+		m.archiveSearch += string(msg.Runes)
+		return m, nil
+
+	case tea.KeyCtrlC:
+		return m.quit()
+	}
+	return m, nil
+}
+
+// archiveCount describes the archive listing for its header
+func (m Model) archiveCount() string {
+	count := fmt.Sprintf("%d archived", len(m.archived))
+	if len(m.archived) >= archiveLimit {
+		count = fmt.Sprintf("first %d archived", archiveLimit)
+	}
+	if m.archiveSearch != "" {
+		count += fmt.Sprintf(" matching %q", m.archiveSearch)
+	}
+	return count
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// batchConfirmation builds one confirmation for several writes, all of them
+// selected to run.
+func batchConfirmation(msg BatchConfirmationRequested) *ConfirmationRequest {
+	batch := make([]QueuedAction, len(msg.Actions))
+	for i, action := range msg.Actions {
+		batch[i] = QueuedAction{Request: action}
+	}
+	return &ConfirmationRequest{
+		Action:      msg.Action,
+		Batch:       batch,
+		Category:    msg.Category,
+		Destructive: msg.Destructive,
+	}
+}
+
+// splitBatch separates the batch items selected to run from the ones
+// toggled off in the dialog.
+func (r ConfirmationRequest) splitBatch() (run, skipped []QueuedAction) {
+	for i, action := range r.Batch {
+		if i < len(r.Skip) && r.Skip[i] {
+			skipped = append(skipped, action)
+		} else {
+			run = append(run, action)
+		}
+	}
+	return run, skipped
+}
+
+// handleBatchKeys moves the selection in a batch confirmation and toggles
+// items on and off. It reports false for keys it leaves to the dialog.
+func (m Model) handleBatchKeys(msg tea.KeyMsg) (Model, bool) {
+	req := *m.confirmation
+	switch msg.String() {
+	case "j", "down":
+		req.Cursor = min(req.Cursor+1, len(req.Batch)-1)
+	case "k", "up":
+		req.Cursor = max(req.Cursor-1, 0)
+	case " ", "x":
+		skip := make([]bool, len(req.Batch))
+		copy(skip, req.Skip)
+		skip[req.Cursor] = !skip[req.Cursor]
+		req.Skip = skip
+	default:
+		return m, false
+	}
+	return m.WithConfirmation(&req), true
+}
+
+// renderBatchItems renders a batch confirmation's writes with their toggles,
+// windowed around the selection.
+func renderBatchItems(req ConfirmationRequest, width, rows int) string {
+	cursorStyle := lipgloss.NewStyle().Foreground(styles.Accent).Bold(true)
+	skippedStyle := lipgloss.NewStyle().Foreground(styles.Muted).Strikethrough(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	start := max(min(req.Cursor-rows/2, len(req.Batch)-rows), 0)
+	end := min(start+rows, len(req.Batch))
+
+	run, _ := req.splitBatch()
+	lines := []string{mutedStyle.Render(fmt.Sprintf("%d of %d selected · jk:move · space:toggle", len(run), len(req.Batch)))}
+	for i := start; i < end; i++ {
+		box := "[x] "
+		style := lipgloss.NewStyle()
+		if i < len(req.Skip) && req.Skip[i] {
+			box = "[ ] "
+			style = skippedStyle
+		}
+		line := style.Render(truncate(req.Batch[i].Request.Action, width-6))
+		if i == req.Cursor {
+			lines = append(lines, cursorStyle.Render("▸ "+box)+line)
+		} else {
+			lines = append(lines, "  "+box+line)
+		}
+	}
+	if end < len(req.Batch) || start > 0 {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("%d-%d of %d", start+1, end, len(req.Batch))))
+	}
+	return lipgloss.NewStyle().Align(lipgloss.Left).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
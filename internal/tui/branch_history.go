@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// historyPageSize is how many more commits expanding a branch loads
+const historyPageSize = 50
+
+// branchPrefix starts the ID of every git branch node
+const branchPrefix = "service:branch:"
+
+// BranchHistory loads a branch's older commits on demand (the data source
+// loader). The initial load stops at the commit cap; the rest of a branch
+// arrives a page at a time as it is expanded.
+type BranchHistory interface {
+	LoadBranchHistory(ctx context.Context, branchID string, skip, limit int) ([]graph.Node, []graph.Edge, error)
+}
+
+// WithBranchHistory returns a new Model that loads more commits when a branch
+// is expanded.
+func (m Model) WithBranchHistory(history BranchHistory) Model {
+	m.history = history
+	return m
+}
+
+// expandsHistory reports whether expanding nodeID should load more of its
+// history: a branch that is collapsed or has nothing loaded yet, and whose
+// history has not run out
+func (m Model) expandsHistory(nodeID string) bool {
+	if m.history == nil || !strings.HasPrefix(nodeID, branchPrefix) || m.historyDone[nodeID] {
+		return false
+	}
+	return m.IsCollapsed(nodeID) || !m.HasChildren(nodeID)
+}
+
+// expandBranch expands a branch and loads its next page of commits.
+func (m Model) expandBranch(branchID string) (tea.Model, tea.Cmd) {
+	if m.IsCollapsed(branchID) {
+		m = m.ToggleCollapse(branchID)
+	}
+	loaded := 0
+	for _, edge := range m.edges {
+		if edge.FromID == branchID && edge.Relation == graph.EdgeOwns {
+			loaded++
+		}
+	}
+	name := branchID
+	if node, ok := m.GetNodeByID(branchID); ok && node.Title != "" {
+		name = node.Title
+	}
+	m = m.WithStatus(fmt.Sprintf("Loading history of %s…", name), false)
+	return m, loadBranchHistory(m.ctx, m.history, branchID, loaded)
+}
+
+// loadBranchHistory fetches the page of a branch's commits after the first skip
+func loadBranchHistory(ctx context.Context, history BranchHistory, branchID string, skip int) tea.Cmd {
+	return func() tea.Msg {
+		nodes, edges, err := history.LoadBranchHistory(ctx, branchID, skip, historyPageSize)
+		if err != nil {
+			return StatusMsg{Message: "History error: " + err.Error(), IsError: true}
+		}
+		commits := 0
+		display := make([]DisplayNode, len(nodes))
+		for i, node := range nodes {
+			display[i] = displayNodeFromGraph(node)
+			if node.Type == graph.NodeTypeCommit {
+				commits++
+			}
+		}
+		return BranchHistoryLoadedMsg{
+			BranchID: branchID,
+			Nodes:    display,
+			Edges:    EdgesToDisplayEdges(edges),
+			Commits:  commits,
+			Complete: commits < historyPageSize,
+		}
+	}
+}
+
+// withBranchHistory adds a loaded page of commits under its branch.
+func (m Model) withBranchHistory(msg BranchHistoryLoadedMsg) Model {
+	m = m.withMerged(msg.Nodes, msg.Edges)
+	if msg.Complete {
+		// Create a new map to maintain immutability
+		done := make(map[string]bool, len(m.historyDone)+1)
+		for k, v := range m.historyDone {
+			done[k] = v
+		}
+		done[msg.BranchID] = true
+		m.historyDone = done
+	}
+
+	name := msg.BranchID
+	if node, ok := m.GetNodeByID(msg.BranchID); ok && node.Title != "" {
+		name = node.Title
+	}
+	switch {
+	case msg.Commits == 0:
+		return m.WithStatus(fmt.Sprintf("No more history on %s", name), false)
+	case msg.Complete:
+		return m.WithStatus(fmt.Sprintf("Loaded the last %d commits on %s", msg.Commits, name), false)
+	default:
+		return m.WithStatus(fmt.Sprintf("Loaded %d more commits on %s (collapse and expand for more)", msg.Commits, name), false)
+	}
+}
@@ -0,0 +1,377 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// CheckState is how one pre-merge check came out.
+type CheckState int
+
+const (
+	CheckPass    CheckState = iota // Nothing to do
+	CheckWarn                      // Worth a look before merging
+	CheckFail                      // Should hold the merge
+	CheckUnknown                   // The graph has no signal for it
+)
+
+// Marker returns the check's glyph, or a bracketed word in accessible mode
+func (s CheckState) Marker(accessible bool) string {
+	glyphs := [...]string{"✓", "!", "✗", "?"}
+	words := [...]string{"[ok]", "[warn]", "[fail]", "[?]"}
+	if accessible {
+		return words[s]
+	}
+	return glyphs[s]
+}
+
+// CheckItem is one line of a PR's merge checklist.
+type CheckItem struct {
+	Name    string
+	State   CheckState
+	Detail  string
+	NodeIDs []string // Nodes the check is about (linked issues, blockers); Enter jumps to the first
+}
+
+// MergeChecklist gathers what the graph knows about a PR's readiness to
+// merge: its linked issues, CI, open blockers, review, conflicts and files
+// owned by teams. Pure function over the model's unfiltered graph.
+func (m Model) MergeChecklist(prID string) []CheckItem {
+	pr, ok := m.GetNodeByID(prID)
+	if !ok || pr.Type != graph.NodeTypePR {
+		return nil
+	}
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+
+	var issues, files []DisplayNode
+	for _, edge := range m.edges {
+		if edge.FromID != prID {
+			continue
+		}
+		target, ok := nodeByID[edge.ToID]
+		switch {
+		case !ok:
+		case edge.Relation == graph.EdgeImplements && target.Type == graph.NodeTypeIssue:
+			issues = append(issues, target)
+		case edge.Relation == graph.EdgeModifies && target.Type == graph.NodeTypeFile:
+			files = append(files, target)
+		}
+	}
+
+	return []CheckItem{
+		linkedIssuesCheck(issues),
+		ciCheck(pr),
+		m.blockersCheck(pr, issues, nodeByID),
+		reviewCheck(pr),
+		mergeableCheck(pr),
+		m.codeOwnersCheck(pr, files, nodeByID),
+	}
+}
+
+// linkedIssuesCheck wants the PR to implement an issue that is still wanted
+func linkedIssuesCheck(issues []DisplayNode) CheckItem {
+	item := CheckItem{Name: "Linked issue"}
+	if len(issues) == 0 {
+		item.State, item.Detail = CheckWarn, "No issue is linked; the change is untracked"
+		return item
+	}
+	var states []string
+	for _, issue := range issues {
+		item.NodeIDs = append(item.NodeIDs, issue.ID)
+		states = append(states, fmt.Sprintf("%s %s", issueLabel(issue), orUnknown(issue.Status)))
+		if styles.CategoryOf(issue.Status) == styles.CategoryCanceled {
+			item.State = CheckFail
+		}
+	}
+	item.Detail = strings.Join(states, ", ")
+	if item.State == CheckFail {
+		item.Detail += " (canceled work)"
+	}
+	return item
+}
+
+// ciCheck reads the CI status a source recorded on the PR
+func ciCheck(pr DisplayNode) CheckItem {
+	var data PRData
+	_ = json.Unmarshal(pr.Data, &data)
+	item := CheckItem{Name: "CI", Detail: data.CIStatus}
+	switch strings.ToLower(data.CIStatus) {
+	case "success", "passed", "passing":
+		item.State = CheckPass
+	case "failure", "failed", "failing", "error":
+		item.State = CheckFail
+	case "pending", "running", "queued":
+		item.State = CheckWarn
+		item.Detail += ": builds still running"
+	case "":
+		item.State, item.Detail = CheckUnknown, "No build status in the graph"
+	default:
+		item.State = CheckWarn
+	}
+	return item
+}
+
+// blockersCheck looks for unfinished work blocking the PR or its issues
+func (m Model) blockersCheck(pr DisplayNode, issues []DisplayNode, nodeByID map[string]DisplayNode) CheckItem {
+	blocked := map[string]bool{pr.ID: true}
+	for _, issue := range issues {
+		blocked[issue.ID] = true
+	}
+	var open []DisplayNode
+	seen := make(map[string]bool)
+	for _, edge := range m.edges {
+		if edge.Relation != graph.EdgeBlocks || !blocked[edge.ToID] || seen[edge.FromID] {
+			continue
+		}
+		blocker, ok := nodeByID[edge.FromID]
+		if !ok {
+			continue
+		}
+		category := styles.CategoryOf(blocker.Status)
+		if category.IsDone() || category == styles.CategoryCanceled {
+			continue
+		}
+		seen[blocker.ID] = true
+		open = append(open, blocker)
+	}
+
+	item := CheckItem{Name: "Blockers"}
+	if len(open) == 0 {
+		item.Detail = "Nothing unfinished blocks it"
+		return item
+	}
+	sort.Slice(open, func(i, j int) bool { return open[i].ID < open[j].ID })
+	names := make([]string, len(open))
+	for i, blocker := range open {
+		names[i] = issueLabel(blocker)
+		item.NodeIDs = append(item.NodeIDs, blocker.ID)
+	}
+	item.State = CheckFail
+	item.Detail = fmt.Sprintf("%d open: %s", len(open), strings.Join(names, ", "))
+	return item
+}
+
+// reviewCheck wants an approval and no outstanding change requests
+func reviewCheck(pr DisplayNode) CheckItem {
+	review := pr.Review
+	item := CheckItem{Name: "Review"}
+	switch {
+	case review.ChangesRequested > 0 || review.State == "changes_requested":
+		item.State, item.Detail = CheckFail, "Changes requested"
+	case review.State == "approved" || review.Approvals > 0:
+		item.Detail = fmt.Sprintf("%d approval(s)", max(review.Approvals, 1))
+	case len(review.RequestedReviewers) > 0:
+		item.State, item.Detail = CheckWarn, "Waiting on "+strings.Join(review.RequestedReviewers, ", ")
+	default:
+		item.State, item.Detail = CheckWarn, "No review yet"
+	}
+	return item
+}
+
+// mergeableCheck reports merge conflicts with the base branch
+func mergeableCheck(pr DisplayNode) CheckItem {
+	item := CheckItem{Name: "Conflicts"}
+	switch pr.Review.Mergeable {
+	case "mergeable":
+		item.Detail = "Merges cleanly"
+	case "conflicting":
+		item.State, item.Detail = CheckFail, "Conflicts with the base branch"
+	default:
+		item.State, item.Detail = CheckUnknown, "Mergeability not known"
+	}
+	return item
+}
+
+// codeOwnersCheck finds teams owning the files the PR modifies that have
+// not been asked to review it
+func (m Model) codeOwnersCheck(pr DisplayNode, files []DisplayNode, nodeByID map[string]DisplayNode) CheckItem {
+	modified := make(map[string]bool, len(files))
+	for _, file := range files {
+		modified[file.ID] = true
+	}
+	owned := make(map[string]int) // Team node ID → files of the PR it owns
+	for _, edge := range m.edges {
+		if edge.Relation != graph.EdgeOwns || !modified[edge.ToID] {
+			continue
+		}
+		if owner, ok := nodeByID[edge.FromID]; ok && owner.Type == graph.NodeTypeTeam {
+			owned[owner.ID]++
+		}
+	}
+
+	item := CheckItem{Name: "Code owners"}
+	if len(owned) == 0 {
+		item.Detail = "No modified file is owned by a team"
+		return item
+	}
+	asked := make(map[string]bool)
+	for _, reviewer := range pr.Review.RequestedReviewers {
+		asked[graph.TeamID(reviewer)] = true
+	}
+	teams := make([]string, 0, len(owned))
+	for id := range owned {
+		teams = append(teams, id)
+	}
+	sort.Strings(teams)
+	var unasked []string
+	for _, id := range teams {
+		item.NodeIDs = append(item.NodeIDs, id)
+		if !asked[id] {
+			unasked = append(unasked, fmt.Sprintf("%s (%d files)", nodeByID[id].Title, owned[id]))
+		}
+	}
+	if len(unasked) == 0 {
+		item.Detail = "Owning teams are asked to review"
+		return item
+	}
+	item.State = CheckWarn
+	item.Detail = "Not asked to review: " + strings.Join(unasked, ", ")
+	return item
+}
+
+// issueLabel names a node briefly: its identifier, or its title
+func issueLabel(node DisplayNode) string {
+	if node.Identifier != "" {
+		return node.Identifier
+	}
+	return truncate(node.Title, 30)
+}
+
+// orUnknown returns s, or "status unknown" when empty
+func orUnknown(s string) string {
+	if s == "" {
+		return "status unknown"
+	}
+	return s
+}
+
+// checklistVerdict sums up a checklist in a few words
+func checklistVerdict(items []CheckItem) (string, CheckState) {
+	verdict, state := "Ready to merge", CheckPass
+	for _, item := range items {
+		switch {
+		case item.State == CheckFail:
+			return "Not ready", CheckFail
+		case item.State == CheckWarn:
+			verdict, state = "Needs a look", CheckWarn
+		}
+	}
+	return verdict, state
+}
+
+// ChecklistMarkdown renders a checklist as a Markdown task list, e.g. for
+// the PR description
+func ChecklistMarkdown(pr DisplayNode, items []CheckItem) string {
+	verdict, _ := checklistVerdict(items)
+	var b strings.Builder
+	fmt.Fprintf(&b, "### Merge checklist: %s (%s)\n\n", pr.Title, verdict)
+	for _, item := range items {
+		box := " "
+		if item.State == CheckPass {
+			box = "x"
+		}
+		fmt.Fprintf(&b, "- [%s] **%s**: %s\n", box, item.Name, item.Detail)
+	}
+	return b.String()
+}
+
+// openChecklist shows the focused PR's merge checklist.
+func (m Model) openChecklist() Model {
+	node, ok := m.GetFocusedNode()
+	if !ok || node.Type != graph.NodeTypePR {
+		return m.WithStatus("The merge checklist is for PRs; focus one first", true)
+	}
+	m.checklistPR = node.ID
+	m.selectedCheckIdx = 0
+	return m.PushView(ViewChecklist)
+}
+
+// handleChecklistKeys processes keys in the Checklist view.
+func (m Model) handleChecklistKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	items := m.MergeChecklist(m.checklistPR)
+	switch msg.String() {
+	case "j", "down":
+		if len(items) > 0 {
+			m.selectedCheckIdx = (m.selectedCheckIdx + 1) % len(items)
+		}
+		return m, nil
+	case "k", "up":
+		if len(items) > 0 {
+			m.selectedCheckIdx = (m.selectedCheckIdx - 1 + len(items)) % len(items)
+		}
+		return m, nil
+	case "enter":
+		// Show what the check is about in the graph
+		if m.selectedCheckIdx >= len(items) || len(items[m.selectedCheckIdx].NodeIDs) == 0 {
+			return m, nil
+		}
+		return m.PopView().WithView(ViewGraph).WithFocusedNode(items[m.selectedCheckIdx].NodeIDs[0]), nil
+	case "y":
+		pr, _ := m.GetNodeByID(m.checklistPR)
+		return m, copyToClipboard(ChecklistMarkdown(pr, items), "checklist")
+	case "esc", "C":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// renderChecklistView renders the merge readiness of the PR it was opened on.
+func (m Model) renderChecklistView(width, height int) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	stateStyles := [...]lipgloss.Style{
+		lipgloss.NewStyle().Foreground(styles.StatusDone),
+		lipgloss.NewStyle().Foreground(styles.StatusInProgress),
+		lipgloss.NewStyle().Foreground(styles.StatusCanceled),
+		mutedStyle,
+	}
+
+	pr, ok := m.GetNodeByID(m.checklistPR)
+	if !ok {
+		return mutedStyle.Italic(true).Render("The PR is no longer in the graph.")
+	}
+	items := m.MergeChecklist(pr.ID)
+	verdict, state := checklistVerdict(items)
+	if styles.CategoryOf(pr.Status).IsDone() {
+		verdict = "Already " + pr.Status
+	}
+
+	lines := []string{
+		headerStyle.Render("☑ Merge checklist: " + truncate(pr.Title, clampMin(width-22, 10))),
+		stateStyles[state].Bold(true).Render(state.Marker(m.accessible) + " " + verdict),
+		"",
+	}
+	const nameWidth = 13
+	for i, item := range items {
+		marker := stateStyles[item.State].Render(item.State.Marker(m.accessible))
+		row := marker + " " + lipgloss.NewStyle().Bold(true).Width(nameWidth).Render(item.Name) + " "
+		detail := truncate(item.Detail, clampMin(width-lipgloss.Width(row)-2, 10))
+		if i == m.selectedCheckIdx {
+			if m.accessible {
+				lines = append(lines, row+detail+" "+selectedMarker)
+				continue
+			}
+			detail = lipgloss.NewStyle().Foreground(styles.Accent).Render(detail)
+			row = lipgloss.NewStyle().Foreground(styles.Accent).Render("›") + row
+		} else {
+			row = " " + row
+		}
+		lines = append(lines, row+detail)
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,10 +1,16 @@
 package tui
 
 import (
+	"context"
+	"fmt"
 	"os/exec"
 	"runtime"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/mockgraph"
 )
 
 // Commands describe effects, runtime executes (Commandment #8: Async Purity)
@@ -15,30 +21,39 @@ func doNothing() tea.Msg {
 	return nil
 }
 
-// fetchData loads mock graph data
+// fetchData loads the generated demo graph
 // In Phase 2+, this will call Linear/GitHub APIs
 func fetchData() tea.Cmd {
 	return func() tea.Msg {
 		// Load mock graph for testing
-		nodes, edges := GetMockGraph()
+		nodes, edges := mockgraph.Generate(mockgraph.DefaultOptions())
 
 		// Convert to display format
 		displayNodes := make([]DisplayNode, len(nodes))
 		for i, node := range nodes {
 			displayNodes[i] = DisplayNode{
-				ID:     node.ID,
-				Type:   node.Type,
-				Title:  node.Title(),
-				Status: node.Status(),
+				ID:          node.ID,
+				Type:        node.Type,
+				Title:       node.Title(),
+				Identifier:  node.Identifier(),
+				Status:      node.Status(),
+				AccessLevel: node.Metadata.AccessLevel,
+				Review:      reviewFromNode(node),
+				Owner:       node.Owner(),
+				Handles:     node.Handles(),
+				Data:        node.Data,
+				CreatedAt:   node.Metadata.CreatedAt,
+				UpdatedAt:   node.Metadata.UpdatedAt,
 			}
 		}
 
 		displayEdges := make([]DisplayEdge, len(edges))
 		for i, edge := range edges {
 			displayEdges[i] = DisplayEdge{
-				FromID:   edge.FromID,
-				ToID:     edge.ToID,
-				Relation: edge.Relation,
+				FromID:    edge.FromID,
+				ToID:      edge.ToID,
+				Relation:  edge.Relation,
+				CreatedAt: edge.Metadata.CreatedAt,
 			}
 		}
 
@@ -50,11 +65,14 @@ func fetchData() tea.Cmd {
 }
 
 // executeConfirmedAction runs a user-confirmed external write
-func executeConfirmedAction(action func() error) tea.Cmd {
+func executeConfirmedAction(action func() error, done tea.Msg) tea.Cmd {
 	return func() tea.Msg {
 		if err := action(); err != nil {
 			return ErrorOccurred{Err: err}
 		}
+		if done != nil {
+			return done
+		}
 		return DataLoadedMsg{Data: "Action completed successfully"}
 	}
 }
@@ -94,11 +112,12 @@ func openInBrowser(url string) tea.Cmd {
 	}
 }
 
-// copyToClipboard copies text to the system clipboard (read-only action)
-func copyToClipboard(text string) tea.Cmd {
+// copyToClipboard copies text to the system clipboard (read-only action).
+// what names the copied content in the status message (e.g. "URL").
+func copyToClipboard(text, what string) tea.Cmd {
 	return func() tea.Msg {
 		if text == "" {
-			return StatusMsg{Message: "No URL to copy", IsError: true}
+			return StatusMsg{Message: "No " + what + " to copy", IsError: true}
 		}
 
 		var cmd *exec.Cmd
@@ -129,6 +148,66 @@ func copyToClipboard(text string) tea.Cmd {
 			return StatusMsg{Message: "Clipboard error: " + err.Error(), IsError: true}
 		}
 
-		return StatusMsg{Message: "URL copied to clipboard", IsError: false}
+		return StatusMsg{Message: what + " copied to clipboard", IsError: false}
+	}
+}
+
+// runSQLQuery executes a read-only ad-hoc query against the store
+func runSQLQuery(ctx context.Context, store graph.GraphStore, query string) tea.Cmd {
+	return func() tea.Msg {
+		if store == nil {
+			return StatusMsg{Message: "No graph store available for SQL queries", IsError: true}
+		}
+		result, err := store.QueryContext(ctx, query)
+		if err != nil {
+			return StatusMsg{Message: "SQL error: " + err.Error(), IsError: true}
+		}
+		return SQLResultMsg{Query: query, Result: result}
+	}
+}
+
+// runSavedQuerySQL resolves a SQL-backed saved query to node IDs (first column)
+func runSavedQuerySQL(ctx context.Context, store graph.GraphStore, name, query string) tea.Cmd {
+	return func() tea.Msg {
+		if store == nil {
+			return StatusMsg{Message: "No graph store available for SQL-backed views", IsError: true}
+		}
+		result, err := store.QueryContext(ctx, query)
+		if err != nil {
+			return StatusMsg{Message: fmt.Sprintf("View %q: %v", name, err), IsError: true}
+		}
+		ids := make([]string, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			if len(row) > 0 {
+				ids = append(ids, row[0])
+			}
+		}
+		return SavedQueryResultMsg{Name: name, IDs: ids}
+	}
+}
+
+// writeSavedQueries persists interactively saved queries (local file, not an external write)
+func writeSavedQueries(path string, queries []config.SavedQuery) tea.Cmd {
+	return func() tea.Msg {
+		if path == "" {
+			return StatusMsg{Message: "View saved for this session only", IsError: false}
+		}
+		if err := config.WriteSavedQueries(path, queries); err != nil {
+			return StatusMsg{Message: "Failed to save view: " + err.Error(), IsError: true}
+		}
+		return StatusMsg{Message: "View saved to " + path, IsError: false}
+	}
+}
+
+// writeReviewedPRs persists the review queue's viewed marks (local file, not an external write)
+func writeReviewedPRs(path string, viewed map[string]time.Time) tea.Cmd {
+	return func() tea.Msg {
+		if path == "" {
+			return nil
+		}
+		if err := config.WriteReviewedPRs(path, viewed); err != nil {
+			return StatusMsg{Message: "Failed to save viewed PRs: " + err.Error(), IsError: true}
+		}
+		return nil
 	}
 }
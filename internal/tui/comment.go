@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// Commenter posts comments on nodes through the source they came from (the
+// data source loader, calling Linear's commentCreate).
+type Commenter interface {
+	PostComment(ctx context.Context, node graph.Node, body string) error
+}
+
+// commentComposer drafts a comment on the node shown in Details
+type commentComposer struct {
+	nodeID string
+	body   textarea.Model
+}
+
+// WithComments returns a new Model that can post comments on Linear issues
+// from Details (c key).
+func (m Model) WithComments(commenter Commenter) Model {
+	m.commenter = commenter
+	return m
+}
+
+// takesComments reports whether comments can be posted on a node: a
+// Linear issue, with a source to post through
+func (m Model) takesComments(node DisplayNode) bool {
+	return m.commenter != nil && node.Type == graph.NodeTypeIssue && providerOf(node.ID) == ProviderLinear
+}
+
+// startComment opens the comment composer on the focused node.
+func (m Model) startComment() (tea.Model, tea.Cmd) {
+	node, ok := m.GetFocusedNode()
+	if !ok {
+		return m, nil
+	}
+	if !m.takesComments(node) {
+		if m.commenter == nil {
+			return m.WithStatus("Comments need a Linear source (LINEAR_API_KEY and LINEAR_TEAM_ID) and no --offline", true), nil
+		}
+		return m.WithStatus("Only Linear issues take comments", true), nil
+	}
+
+	// Same width as the details box it replaces, with a steady cursor
+	body := textarea.New()
+	body.ShowLineNumbers = false
+	body.Placeholder = "Write a comment (Markdown)"
+	body.SetWidth(clampMin(min(m.width-4, 80)-4, 10))
+	body.SetHeight(8)
+	body.Cursor.SetMode(cursor.CursorStatic)
+	body.Focus()
+
+	m.composer = &commentComposer{nodeID: node.ID, body: body}
+	return m, nil
+}
+
+// handleCommentInput processes keys while the comment composer is open
+func (m Model) handleCommentInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	composer := *m.composer
+	switch msg.String() {
+	case "esc":
+		m.composer = nil
+		return m.WithStatus("Comment discarded", false), nil
+	case "ctrl+s":
+		return m.requestComment()
+	case "ctrl+c":
+		return m.quit()
+	}
+
+	var cmd tea.Cmd
+	composer.body, cmd = composer.body.Update(msg)
+	m.composer = &composer
+	return m, cmd
+}
+
+// requestComment asks to post the composed comment
+func (m Model) requestComment() (tea.Model, tea.Cmd) {
+	body := strings.TrimSpace(m.composer.body.Value())
+	if body == "" {
+		return m.WithStatus("A comment needs some text", true), nil
+	}
+	node, ok := m.GetNodeByID(m.composer.nodeID)
+	if !ok {
+		m.composer = nil
+		return m.WithStatus("The issue is no longer loaded; comment discarded", true), nil
+	}
+	// The draft travels with the request until the comment is posted, and
+	// comes back if the dialog is rejected or the post fails
+	draft := m.composer
+	m.composer = nil
+
+	return m.Update(ConfirmationRequested{
+		Action:   fmt.Sprintf("Post a comment on %s", issueName(node)),
+		Execute:  postComment(m.commenter, graph.Node{ID: node.ID, Type: node.Type, Data: node.Data}, body),
+		Done:     CommentPostedMsg{NodeID: node.ID},
+		Category: CategoryWriteBack,
+		Target:   node.ID,
+		Draft:    draft,
+	})
+}
+
+// executeComment posts a confirmed comment, handing its draft back if the
+// post fails
+func executeComment(draft *commentComposer, req ConfirmationRequest) tea.Cmd {
+	return func() tea.Msg {
+		if err := req.Execute(); err != nil {
+			return CommentFailedMsg{Draft: draft, Err: fmt.Errorf("posting comment: %w", err)}
+		}
+		return req.Done
+	}
+}
+
+// withDraft reopens a comment draft that was not posted in Details on its
+// issue, unless another comment is being written by now or the issue is
+// gone. An open dialog stays on top.
+func (m Model) withDraft(draft *commentComposer) Model {
+	if m.composer != nil || draft == nil {
+		return m
+	}
+	if _, ok := m.GetNodeByID(draft.nodeID); !ok {
+		return m
+	}
+	m.composer = draft
+	if m.focusedNode != draft.nodeID {
+		m = m.WithFocusedNode(draft.nodeID)
+	}
+	if m.currentView != ViewDetails && m.currentView != ViewConfirm {
+		m = m.PushView(ViewDetails)
+	}
+	return m
+}
+
+// postComment posts a comment. Writes are waited for on quit, so it does
+// not give up on the model's context.
+func postComment(commenter Commenter, node graph.Node, body string) func() error {
+	return func() error {
+		return commenter.PostComment(context.Background(), node, body)
+	}
+}
+
+// withCommentPosted reports a posted comment.
+func (m Model) withCommentPosted(msg CommentPostedMsg) Model {
+	name := msg.NodeID
+	if node, ok := m.GetNodeByID(msg.NodeID); ok {
+		name = issueName(node)
+	}
+	return m.WithStatus("Comment posted on "+name, false)
+}
+
+// issueName names an issue by its identifier, or its title without one
+func issueName(node DisplayNode) string {
+	if node.Identifier != "" {
+		return node.Identifier
+	}
+	return fmt.Sprintf("%q", node.Title)
+}
+
+// renderComposer renders the comment composer in place of the node's details
+func (m Model) renderComposer(node DisplayNode, width int) string {
+	headerStyle := lipgloss.NewStyle().Foreground(styles.Accent).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	lines := []string{
+		headerStyle.Render(truncate("💬 Comment on "+issueName(node)+": "+node.Title, clampMin(width-4, 10))),
+		"",
+		m.composer.body.View(),
+		"",
+		mutedStyle.Render("Posted to Linear after confirmation"),
+	}
+	return styles.PaneContentStyle.Width(width).Render(strings.Join(lines, "\n"))
+}
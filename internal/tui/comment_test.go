@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// failingCommenter rejects every comment
+type failingCommenter struct{ posts int }
+
+func (c *failingCommenter) PostComment(ctx context.Context, node graph.Node, body string) error {
+	c.posts++
+	return errors.New("Linear is down")
+}
+
+// TestCommentDraftSurvivesRejectionAndFailure checks the composed comment
+// comes back when the dialog is rejected and when the post fails.
+func TestCommentDraftSurvivesRejectionAndFailure(t *testing.T) {
+	commenter := &failingCommenter{}
+	issue := DisplayNode{ID: "linear:A-1", Type: graph.NodeTypeIssue, Identifier: "A-1", Title: "Flaky login"}
+	var model tea.Model = NewModel().WithNodes([]DisplayNode{issue}).WithFocusedNode(issue.ID).WithComments(commenter).PushView(ViewDetails)
+
+	update := func(msg tea.Msg) tea.Cmd {
+		t.Helper()
+		var cmd tea.Cmd
+		model, cmd = model.Update(msg)
+		return cmd
+	}
+	draft := func() string {
+		t.Helper()
+		composer := model.(Model).composer
+		if composer == nil {
+			t.Fatal("the comment draft is gone")
+		}
+		return composer.body.Value()
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Still flaky")})
+	update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if view := model.(Model).currentView; view != ViewConfirm {
+		t.Fatalf("view after ctrl+s = %v, want the confirmation", view)
+	}
+
+	update(ConfirmationRejected{})
+	if got := draft(); got != "Still flaky" {
+		t.Errorf("draft after rejecting = %q", got)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	cmd := update(ConfirmationAccepted{})
+	if model.(Model).composer != nil {
+		t.Error("the composer stayed open while posting")
+	}
+	if cmd == nil {
+		t.Fatal("accepting did not post the comment")
+	}
+	update(cmd())
+	if commenter.posts != 1 {
+		t.Errorf("posts = %d, want 1", commenter.posts)
+	}
+	if got := draft(); got != "Still flaky" {
+		t.Errorf("draft after a failed post = %q", got)
+	}
+	if model.(Model).err == nil {
+		t.Error("the failed post was not reported")
+	}
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// renderCommitDetails renders the lines shown in the Details view for a
+// commit: its tags, diff stats, trailers and message body.
+func renderCommitDetails(node DisplayNode, maxWidth int) []string {
+	var data CommitData
+	if err := json.Unmarshal(node.Data, &data); err != nil {
+		return nil
+	}
+	var lines []string
+	labelStyle := lipgloss.NewStyle().Foreground(styles.Muted).Bold(true)
+
+	if len(data.Tags) > 0 {
+		tagStyle := lipgloss.NewStyle().
+			Background(styles.Secondary).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Padding(0, 1)
+		tags := make([]string, len(data.Tags))
+		for i, tag := range data.Tags {
+			tags[i] = tagStyle.Render(tag)
+		}
+		lines = append(lines, labelStyle.Render("🔖 Tags: ")+strings.Join(tags, " "))
+	}
+
+	if data.FilesChanged > 0 {
+		files := "files"
+		if data.FilesChanged == 1 {
+			files = "file"
+		}
+		lines = append(lines, labelStyle.Render("Changes: ")+
+			lipgloss.NewStyle().Foreground(styles.StatusDone).Render(fmt.Sprintf("+%d", data.Insertions))+" "+
+			lipgloss.NewStyle().Foreground(styles.StatusCanceled).Render(fmt.Sprintf("-%d", data.Deletions))+
+			fmt.Sprintf(" in %d %s", data.FilesChanged, files))
+	}
+
+	if data.Hash != "" {
+		lines = append(lines, labelStyle.Render("Commit: ")+data.Hash)
+	}
+	if len(data.CoAuthors) > 0 {
+		lines = append(lines, labelStyle.Render("Co-authors: ")+strings.Join(data.CoAuthors, ", "))
+	}
+	if len(data.SignedOffBy) > 0 {
+		lines = append(lines, labelStyle.Render("Signed off by: ")+strings.Join(data.SignedOffBy, ", "))
+	}
+
+	if data.Body != "" {
+		bodyStyle := lipgloss.NewStyle().Foreground(styles.Foreground).Width(maxWidth)
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		// Wrapped line by line: commit bodies keep their own line breaks
+		for _, line := range strings.Split(data.Body, "\n") {
+			lines = append(lines, bodyStyle.Render(wrapText(line, maxWidth-4)))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Action categories a confirmation policy is set for (config "confirm")
+const (
+	CategoryEdit      = "edit"       // Saving inline edits to hand-made nodes
+	CategoryCreate    = "create"     // Creating hand-made issues
+	CategoryDelete    = "delete"     // Deleting hand-made relations
+	CategoryWriteBack = "write_back" // Writing an edit or a comment back to the source a node was synced from
+	CategoryQueue     = "queue"      // Flushing the action queue
+)
+
+// ConfirmPolicy is when actions of a category ask before they run.
+type ConfirmPolicy int
+
+const (
+	ConfirmAlways      ConfirmPolicy = iota // Every action asks (the default)
+	ConfirmDestructive                      // Only actions that delete or overwrite ask
+	ConfirmNever                            // Actions run without asking for the whole session
+)
+
+// String returns the config name of the policy
+func (p ConfirmPolicy) String() string {
+	switch p {
+	case ConfirmDestructive:
+		return "destructive"
+	case ConfirmNever:
+		return "never"
+	default:
+		return "always"
+	}
+}
+
+// ParseConfirmPolicies validates configured confirmation policies, keyed by
+// action category or "default" for every category not listed.
+func ParseConfirmPolicies(policies map[string]string) (map[string]ConfirmPolicy, error) {
+	known := map[string]bool{"default": true, CategoryEdit: true, CategoryCreate: true, CategoryDelete: true, CategoryWriteBack: true, CategoryQueue: true}
+	levels := map[string]ConfirmPolicy{"always": ConfirmAlways, "destructive": ConfirmDestructive, "never": ConfirmNever}
+
+	categories := make([]string, 0, len(policies))
+	for category := range policies {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	parsed := make(map[string]ConfirmPolicy, len(policies))
+	for _, category := range categories {
+		name := strings.ToLower(strings.TrimSpace(category))
+		if !known[name] {
+			return nil, fmt.Errorf("unknown confirmation category %q (want default, edit, create, delete, write_back or queue)", category)
+		}
+		level, ok := levels[strings.ToLower(strings.TrimSpace(policies[category]))]
+		if !ok {
+			return nil, fmt.Errorf("unknown confirmation policy %q for %s (want always, destructive or never)", policies[category], name)
+		}
+		parsed[name] = level
+	}
+	return parsed, nil
+}
+
+// WithConfirmPolicies returns a new Model asking for confirmation as the
+// policies say; categories without one always ask.
+func (m Model) WithConfirmPolicies(policies map[string]ConfirmPolicy) Model {
+	m.confirmPolicies = policies
+	return m
+}
+
+// confirmPolicy returns the policy for a category
+func (m Model) confirmPolicy(category string) ConfirmPolicy {
+	if policy, ok := m.confirmPolicies[category]; ok {
+		return policy
+	}
+	return m.confirmPolicies["default"]
+}
+
+// needsConfirmation reports whether a request has to ask before it runs.
+// Remote conflicts and failed remote checks always ask, whatever the policy.
+func (m Model) needsConfirmation(req ConfirmationRequest) bool {
+	if len(req.Conflicts) > 0 || req.Warning != "" {
+		return true
+	}
+	if m.confirmRemembered[req.Category] {
+		return false
+	}
+	switch m.confirmPolicy(req.Category) {
+	case ConfirmNever:
+		return false
+	case ConfirmDestructive:
+		return req.Destructive
+	default:
+		return true
+	}
+}
+
+// confirmOrRun opens the confirmation for a request, or runs it straight
+// away when its category's policy does not ask.
+func (m Model) confirmOrRun(req ConfirmationRequest) (Model, tea.Cmd) {
+	if m.needsConfirmation(req) {
+		return m.WithConfirmation(&req), nil
+	}
+	return m.runConfirmed(req)
+}
+
+// runConfirmed runs a confirmed request: the action, or a flushed batch.
+func (m Model) runConfirmed(req ConfirmationRequest) (Model, tea.Cmd) {
+	m = m.WithConfirmation(nil)
+	if len(req.Batch) > 0 {
+		run, skipped := req.splitBatch()
+		for _, action := range run {
+			m = m.logActivity(ActivityWrite, action.Request.Action)
+		}
+		if req.Queued {
+			m = m.withQueueFlushing(req.Batch, skipped)
+		}
+		if len(run) == 0 {
+			return m.WithStatus("Nothing selected to run", false), nil
+		}
+		return m.WithStatus(fmt.Sprintf("Running %d actions...", len(run)), false), m.writes.track(executeQueuedActions(m.dispatcher, run))
+	}
+	m = m.logActivity(ActivityWrite, req.Action)
+	if req.Requeue != nil {
+		return m, m.writes.track(m.dispatcher.Dispatch(context.Background(), executeRequeued(*req.Requeue, req), req.providers()...))
+	}
+	if req.Draft != nil {
+		return m, m.writes.track(m.dispatcher.Dispatch(context.Background(), executeComment(req.Draft, req), req.providers()...))
+	}
+	if req.Optimistic != nil {
+		return m.runOptimistic(req)
+	}
+	// Writes are waited for on quit, so they do not give up on the context
+	return m, m.writes.track(m.dispatcher.Dispatch(context.Background(), executeConfirmedAction(req.Execute, req.Done), req.providers()...))
+}
+
+// rememberConfirmation stops asking for a category's actions until maat exits.
+func (m Model) rememberConfirmation(category string) Model {
+	// Create a new map to maintain immutability
+	remembered := make(map[string]bool, len(m.confirmRemembered)+1)
+	for k, v := range m.confirmRemembered {
+		remembered[k] = v
+	}
+	remembered[category] = true
+	m.confirmRemembered = remembered
+	return m
+}
@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CrashSummary describes the UI state for a crash report: enough to tell
+// which screen and data a panic came from, without dumping the whole graph.
+func (m Model) CrashSummary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "view:     %s (stack %v)\n", m.currentView, m.navStack.stack)
+	fmt.Fprintf(&b, "screen:   %dx%d ready=%t loading=%t\n", m.width, m.height, m.ready, m.loading)
+	fmt.Fprintf(&b, "graph:    %d nodes, %d edges, %d visible\n", len(m.nodes), len(m.edges), len(m.GetFilteredNodes()))
+	if node, ok := m.GetFocusedNode(); ok {
+		fmt.Fprintf(&b, "focused:  %s %q\n", node.ID, node.Title)
+	} else {
+		fmt.Fprintf(&b, "focused:  %q\n", m.focusedNode)
+	}
+	fmt.Fprintf(&b, "filters:  type=%s status=%s owner=%q query=%q depth=%d\n", m.filterMode, m.statusFilter, m.ownerFilter, m.activeQuery, m.maxDepth)
+	fmt.Fprintf(&b, "modes:    role=%s exec=%t myWork=%t review=%t search=%t sql=%t accessible=%t\n",
+		m.role, m.execMode, m.myWork, m.needsReview, m.searchMode, m.sqlMode, m.accessible)
+	fmt.Fprintf(&b, "scroll:   graph=%d relations=%d/%d sql=%d card=%d\n", m.graphScroll, m.selectedRelIdx, m.relationsScroll, m.sqlScroll, m.selectedCard)
+	fmt.Fprintf(&b, "queued:   %d actions, confirmation=%t\n", len(m.actionQueue), m.confirmation != nil)
+	if m.statusMsg != "" {
+		fmt.Fprintf(&b, "status:   %q (error=%t)\n", m.statusMsg, m.statusIsError)
+	}
+	return b.String()
+}
@@ -0,0 +1,203 @@
+package tui
+
+import (
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// maxTopBlockers limits how many blockers a project card lists
+const maxTopBlockers = 3
+
+// ProjectSummary rolls up the work beneath a project for the dashboard.
+type ProjectSummary struct {
+	Project     DisplayNode
+	Total       int // Issues and PRs beneath the project
+	Done        int
+	Active      int
+	Todo        int
+	Blocked     int
+	TopBlockers []BlockerItem
+	LastCommit  *DisplayNode
+}
+
+// BlockerItem is an open node that blocks other open work.
+type BlockerItem struct {
+	Node   DisplayNode
+	Blocks int // Number of open nodes it blocks
+}
+
+// Progress returns the fraction of work done (0 when there is no work).
+func (p ProjectSummary) Progress() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Done) / float64(p.Total)
+}
+
+// ProjectSummaries computes a roll-up for every project node, sorted by title.
+// Pure function over the model's unfiltered graph.
+func (m Model) ProjectSummaries() []ProjectSummary {
+	nodeByID, children, blocks := m.rollupIndex()
+
+	var summaries []ProjectSummary
+	for _, node := range m.nodes {
+		if node.Type != graph.NodeTypeProject {
+			continue
+		}
+		summaries = append(summaries, summarizeProject(node, nodeByID, children, blocks))
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Project.Title < summaries[j].Project.Title
+	})
+	return summaries
+}
+
+// Rollups returns roll-up metrics keyed by node ID for every project and
+// service node (the aggregation levels shown in exec mode).
+func (m Model) Rollups() map[string]ProjectSummary {
+	nodeByID, children, blocks := m.rollupIndex()
+
+	rollups := make(map[string]ProjectSummary)
+	for _, node := range m.nodes {
+		if node.Type == graph.NodeTypeProject || node.Type == graph.NodeTypeService {
+			rollups[node.ID] = summarizeProject(node, nodeByID, children, blocks)
+		}
+	}
+	return rollups
+}
+
+// rollupIndex builds the lookup tables shared by the roll-up computations.
+func (m Model) rollupIndex() (map[string]DisplayNode, map[string][]string, map[string][]string) {
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+
+	children := make(map[string][]string)
+	blocks := make(map[string][]string)
+	for _, edge := range m.edges {
+		if isHierarchicalEdge(edge.Relation) {
+			children[edge.FromID] = append(children[edge.FromID], edge.ToID)
+		}
+		if edge.Relation == graph.EdgeBlocks {
+			blocks[edge.FromID] = append(blocks[edge.FromID], edge.ToID)
+		}
+	}
+	return nodeByID, children, blocks
+}
+
+// summarizeProject walks the subtree under a project (or service) and
+// aggregates status counts, blockers and the most recent commit.
+func summarizeProject(project DisplayNode, nodeByID map[string]DisplayNode, children, blocks map[string][]string) ProjectSummary {
+	summary := ProjectSummary{Project: project}
+
+	visited := map[string]bool{project.ID: true}
+	queue := append([]string(nil), children[project.ID]...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		queue = append(queue, children[id]...)
+
+		node, ok := nodeByID[id]
+		if !ok {
+			continue
+		}
+
+		switch node.Type {
+		case graph.NodeTypeIssue, graph.NodeTypePR:
+			summary.Total++
+			switch styles.CategoryOf(node.Status) {
+			case styles.CategoryStarted:
+				summary.Active++
+			case styles.CategoryCompleted:
+				summary.Done++
+			case styles.CategoryCanceled, styles.CategoryBlocked:
+				summary.Blocked++
+			default:
+				summary.Todo++
+			}
+
+			if !styles.CategoryOf(node.Status).IsDone() {
+				openBlocked := 0
+				for _, targetID := range blocks[id] {
+					if target, ok := nodeByID[targetID]; ok && !styles.CategoryOf(target.Status).IsDone() {
+						openBlocked++
+					}
+				}
+				if openBlocked > 0 {
+					summary.TopBlockers = append(summary.TopBlockers, BlockerItem{Node: node, Blocks: openBlocked})
+				}
+			}
+
+		case graph.NodeTypeCommit:
+			if summary.LastCommit == nil || node.UpdatedAt.After(summary.LastCommit.UpdatedAt) {
+				commit := node
+				summary.LastCommit = &commit
+			}
+		}
+	}
+
+	sort.Slice(summary.TopBlockers, func(i, j int) bool {
+		if summary.TopBlockers[i].Blocks != summary.TopBlockers[j].Blocks {
+			return summary.TopBlockers[i].Blocks > summary.TopBlockers[j].Blocks
+		}
+		return summary.TopBlockers[i].Node.Title < summary.TopBlockers[j].Node.Title
+	})
+	if len(summary.TopBlockers) > maxTopBlockers {
+		summary.TopBlockers = summary.TopBlockers[:maxTopBlockers]
+	}
+
+	return summary
+}
+
+// WithSelectedCard returns a new Model with the dashboard card selection clamped.
+func (m Model) WithSelectedCard(idx int) Model {
+	count := len(m.ProjectSummaries())
+	if idx >= count {
+		idx = count - 1
+	}
+	if idx < 0 {
+		idx = 0
+	}
+	m.selectedCard = idx
+	return m
+}
+
+// handleDashboardKeys processes keys in the Dashboard view.
+// h/l move between cards in a row, j/k move between rows.
+func (m Model) handleDashboardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	columns := dashboardColumns(m.width)
+
+	switch msg.String() {
+	case "l", "right":
+		return m.WithSelectedCard(m.selectedCard + 1), nil
+	case "h", "left":
+		return m.WithSelectedCard(m.selectedCard - 1), nil
+	case "j", "down":
+		return m.WithSelectedCard(m.selectedCard + columns), nil
+	case "k", "up":
+		return m.WithSelectedCard(m.selectedCard - columns), nil
+	case "enter":
+		// Drill into the selected project in the Graph view
+		summaries := m.ProjectSummaries()
+		if m.selectedCard < len(summaries) {
+			m = m.WithFocusedNode(summaries[m.selectedCard].Project.ID)
+			m = m.WithFilterMode(FilterProjects).refocusFiltered()
+			return m.PushView(ViewGraph), nil
+		}
+		return m, nil
+	case "esc", "D":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"reflect"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// edgeKey identifies an edge: an edge whose ends or relation change is a
+// different edge, so edges are only ever added or removed
+type edgeKey struct{ from, to, relation string }
+
+func keyOf(edge DisplayEdge) edgeKey {
+	return edgeKey{edge.FromID, edge.ToID, string(edge.Relation)}
+}
+
+// graphDelta works out what changes the loaded graph (nodes, edges) into
+// what a sync produced (fresh nodes, the edges touching them, and the IDs
+// the sync no longer produces). Nodes and edges the sync did not touch
+// (other sources', hand-made ones) are left out of the delta.
+func graphDelta(nodes []DisplayNode, edges []DisplayEdge, fresh []DisplayNode, freshEdges []DisplayEdge, removed []string) GraphDeltaMsg {
+	var delta GraphDeltaMsg
+	loaded := make(map[string]DisplayNode, len(nodes))
+	for _, node := range nodes {
+		loaded[node.ID] = node
+	}
+	scope := make(map[string]bool, len(fresh)+len(removed))
+	for _, node := range fresh {
+		scope[node.ID] = true
+		old, ok := loaded[node.ID]
+		switch {
+		case !ok:
+			delta.AddedNodes = append(delta.AddedNodes, node)
+		case !reflect.DeepEqual(old, node):
+			delta.UpdatedNodes = append(delta.UpdatedNodes, node)
+		}
+	}
+	for _, id := range removed {
+		if _, ok := loaded[id]; ok && !scope[id] {
+			scope[id] = true
+			delta.RemovedNodes = append(delta.RemovedNodes, id)
+		}
+	}
+
+	current := make(map[edgeKey]bool, len(freshEdges))
+	for _, edge := range freshEdges {
+		current[keyOf(edge)] = true
+	}
+	existing := make(map[edgeKey]bool, len(edges))
+	for _, edge := range edges {
+		key := keyOf(edge)
+		existing[key] = true
+		if !edge.HandMade() && (scope[edge.FromID] || scope[edge.ToID]) && !current[key] {
+			delta.RemovedEdges = append(delta.RemovedEdges, edge)
+		}
+	}
+	for _, edge := range freshEdges {
+		if key := keyOf(edge); !existing[key] {
+			existing[key] = true
+			delta.AddedEdges = append(delta.AddedEdges, edge)
+		}
+	}
+	return delta
+}
+
+// fullDelta works out the delta from the loaded graph to a complete reload.
+// Nodes created locally or by hand are not any source's, so a reload that
+// lacks them does not remove them.
+func fullDelta(nodes []DisplayNode, edges []DisplayEdge, fresh []DisplayNode, freshEdges []DisplayEdge) GraphDeltaMsg {
+	present := make(map[string]bool, len(fresh))
+	for _, node := range fresh {
+		present[node.ID] = true
+	}
+	var removed []string
+	for _, node := range nodes {
+		if !present[node.ID] && node.CreatedBy == "" && !graph.IsLocalID(node.ID) {
+			removed = append(removed, node.ID)
+		}
+	}
+	return graphDelta(nodes, edges, fresh, freshEdges, removed)
+}
+
+// Empty reports whether the delta changes nothing
+func (d GraphDeltaMsg) Empty() bool {
+	return len(d.AddedNodes)+len(d.UpdatedNodes)+len(d.RemovedNodes)+len(d.AddedEdges)+len(d.RemovedEdges) == 0
+}
+
+// withDelta merges a delta into the graph in place. Updated nodes keep
+// their place in the tree, so focus, scroll, collapsed branches and the
+// Relations selection all survive the update. A focused node that is
+// removed hands focus to the node above it in the tree.
+func (m Model) withDelta(delta GraphDeltaMsg) Model {
+	removed := make(map[string]bool, len(delta.RemovedNodes))
+	for _, id := range delta.RemovedNodes {
+		removed[id] = true
+	}
+	updated := make(map[string]DisplayNode, len(delta.UpdatedNodes))
+	for _, node := range delta.UpdatedNodes {
+		updated[node.ID] = node
+	}
+
+	// Remember where the focus sat, in case its node goes
+	var before []string
+	if removed[m.focusedNode] {
+		filteredNodes, filteredEdges := m.filteredGraph()
+		before = flattenTreeWithCollapse(m.buildTree(filteredNodes, filteredEdges), m)
+	}
+
+	present := make(map[string]bool, len(m.nodes)+len(delta.AddedNodes))
+	nodes := make([]DisplayNode, 0, len(m.nodes)+len(delta.AddedNodes))
+	for _, node := range m.nodes {
+		if removed[node.ID] {
+			continue
+		}
+		if update, ok := updated[node.ID]; ok {
+			node = update
+		}
+		present[node.ID] = true
+		nodes = append(nodes, node)
+	}
+	for _, node := range delta.AddedNodes {
+		if !present[node.ID] {
+			present[node.ID] = true
+			nodes = append(nodes, node)
+		}
+	}
+
+	gone := make(map[edgeKey]bool, len(delta.RemovedEdges))
+	for _, edge := range delta.RemovedEdges {
+		gone[keyOf(edge)] = true
+	}
+	seen := make(map[edgeKey]bool, len(m.edges))
+	edges := make([]DisplayEdge, 0, len(m.edges)+len(delta.AddedEdges))
+	for _, edge := range m.edges {
+		key := keyOf(edge)
+		if gone[key] || !present[edge.FromID] || !present[edge.ToID] {
+			continue
+		}
+		seen[key] = true
+		edges = append(edges, edge)
+	}
+	for _, edge := range delta.AddedEdges {
+		key := keyOf(edge)
+		if present[edge.FromID] && present[edge.ToID] && !seen[key] {
+			seen[key] = true
+			edges = append(edges, edge)
+		}
+	}
+
+	selected := m.selectedRelIdx
+	focus := m.focusedNode
+	if removed[focus] {
+		focus = ""
+		for i := indexOf(before, m.focusedNode) - 1; i >= 0 && focus == ""; i-- {
+			if present[before[i]] {
+				focus = before[i]
+			}
+		}
+		if focus == "" && present[delta.Focus] {
+			focus = delta.Focus
+		}
+		selected = 0
+	}
+	m.focusedNode = ""
+	m = m.WithNodes(nodes).WithEdges(edges)
+	if focus != "" {
+		m.focusedNode = focus
+	}
+
+	// Keep the Relations selection on its row, within the shorter list
+	if n := len(m.GetRelationsList()); selected >= n {
+		selected = n - 1
+	}
+	if selected < 0 {
+		selected = 0
+	}
+	m.selectedRelIdx = selected
+
+	filteredNodes, filteredEdges := m.filteredGraph()
+	after := flattenTreeWithCollapse(m.buildTree(filteredNodes, filteredEdges), m)
+	if i := indexOf(after, m.focusedNode); i >= 0 {
+		m = m.ensureFocusVisible(i, len(after))
+	}
+	return m
+}
+
+// indexOf returns where id sits in ids, or -1
+func indexOf(ids []string, id string) int {
+	for i, candidate := range ids {
+		if candidate == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// detailSectionNames are the Details view sections a template can place.
+// Each is a template function rendering to "" when it does not apply.
+var detailSectionNames = []string{
+	"title", "type", "status", "priority", "review", "owner", "metadata",
+	"languages", "description", "commit", "links", "labels", "trace", "related", "link", "id", "hint",
+}
+
+// defaultTemplateKey configures the layout for types without their own template
+const defaultTemplateKey = "default"
+
+// DetailTemplates are per-node-type Details layouts (config "details"),
+// keyed by node type or "default".
+type DetailTemplates map[string]*template.Template
+
+// detailTemplateData is what a Details template sees as "."
+type detailTemplateData struct {
+	DisplayNode
+	Data map[string]interface{} // Every field the source loaded, e.g. {{.Data.estimate}}
+}
+
+// ParseDetailTemplates parses Details layouts keyed by node type
+// ("Issue", "PR", ...) or "default". Sections are template functions:
+//
+//	{{title}}
+//	{{status}} · estimate {{field "estimate"}}
+//	{{description}}
+//	{{trace}}
+func ParseDetailTemplates(layouts map[string]string) (DetailTemplates, error) {
+	if len(layouts) == 0 {
+		return nil, nil
+	}
+
+	// Sections are bound per render; parsing only needs the names
+	funcs := template.FuncMap{"field": func(string) string { return "" }}
+	for _, name := range detailSectionNames {
+		funcs[name] = func() string { return "" }
+	}
+
+	templates := make(DetailTemplates, len(layouts))
+	for key, layout := range layouts {
+		if key != defaultTemplateKey && !graph.ValidateNodeType(key) {
+			return nil, fmt.Errorf("details template %q: unknown node type", key)
+		}
+		tmpl, err := template.New(key).Funcs(funcs).Parse(layout)
+		if err != nil {
+			return nil, fmt.Errorf("details template %q: %w", key, err)
+		}
+		templates[key] = tmpl
+	}
+	return templates, nil
+}
+
+// forType returns the template for a node type, falling back to "default"
+func (t DetailTemplates) forType(nodeType graph.NodeType) *template.Template {
+	if tmpl, ok := t[string(nodeType)]; ok {
+		return tmpl
+	}
+	return t[defaultTemplateKey]
+}
+
+// WithDetailTemplates returns a new Model whose Details view uses the given layouts.
+func (m Model) WithDetailTemplates(templates DetailTemplates) Model {
+	m.detailTemplates = templates
+	return m
+}
+
+// extraBlankLines matches runs of blank lines left by sections that rendered nothing
+var extraBlankLines = regexp.MustCompile(`\n{3,}`)
+
+// renderDetailTemplate renders a node through a Details template. A failing
+// template shows its error above the default layout instead of an empty view.
+func (m Model) renderDetailTemplate(tmpl *template.Template, node DisplayNode, maxWidth int) string {
+	data := detailTemplateData{DisplayNode: node}
+	_ = json.Unmarshal(node.Data, &data.Data)
+
+	funcs := template.FuncMap{
+		"field": func(name string) string {
+			if value, ok := data.Data[name]; ok && value != nil {
+				return fmt.Sprint(value)
+			}
+			return ""
+		},
+	}
+	for _, name := range detailSectionNames {
+		funcs[name] = func() string { return strings.Join(m.detailSection(name, node, maxWidth), "\n") }
+	}
+
+	var out bytes.Buffer
+	clone, err := tmpl.Clone()
+	if err == nil {
+		err = clone.Funcs(funcs).Execute(&out, data)
+	}
+	if err != nil {
+		fallback := m.WithDetailTemplates(nil).renderNodeDetailsExpanded(node, maxWidth)
+		errStyle := lipgloss.NewStyle().Foreground(styles.StatusCanceled).Width(maxWidth)
+		return errStyle.Render("⚠ Details template: "+err.Error()) + "\n\n" + fallback
+	}
+	return strings.TrimSpace(extraBlankLines.ReplaceAllString(out.String(), "\n\n"))
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	chromastyles "github.com/alecthomas/chroma/v2/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// maxDiffLines caps a diff kept for viewing; huge generated diffs are cut
+const maxDiffLines = 5000
+
+// Differ fetches the changes a node made as a unified diff (the data source
+// loader, reading git show).
+type Differ interface {
+	Diff(ctx context.Context, node graph.Node) (string, error)
+}
+
+// WithDiffs returns a new Model that can show commit and PR diffs (d key).
+func (m Model) WithDiffs(differ Differ) Model {
+	m.differ = differ
+	return m
+}
+
+// openDiff fetches the focused commit's diff, or the diffs of the loaded
+// commits implementing the focused PR, oldest first.
+func (m Model) openDiff() (tea.Model, tea.Cmd) {
+	node, ok := m.GetFocusedNode()
+	if !ok {
+		return m, nil
+	}
+	if m.differ == nil {
+		return m.WithStatus("Diffs need a git repository (--git)", true), nil
+	}
+
+	var commits []DisplayNode
+	switch node.Type {
+	case graph.NodeTypeCommit:
+		commits = []DisplayNode{node}
+	case graph.NodeTypePR:
+		for _, edge := range m.edges {
+			if edge.ToID == node.ID && edge.Relation == graph.EdgeImplements {
+				if commit, ok := m.GetNodeByID(edge.FromID); ok && commit.Type == graph.NodeTypeCommit {
+					commits = append(commits, commit)
+				}
+			}
+		}
+		if len(commits) == 0 {
+			return m.WithStatus("No loaded commits implement this PR", true), nil
+		}
+		sort.SliceStable(commits, func(i, j int) bool { return commits[i].UpdatedAt.Before(commits[j].UpdatedAt) })
+	default:
+		return m.WithStatus("Diffs are shown for commits and PRs", true), nil
+	}
+
+	m = m.WithStatus(fmt.Sprintf("Loading diff of %s…", node.Title), false)
+	return m, loadDiff(m.ctx, m.differ, node.ID, commits)
+}
+
+// loadDiff fetches and highlights the diffs of commits, shown for nodeID
+func loadDiff(ctx context.Context, differ Differ, nodeID string, commits []DisplayNode) tea.Cmd {
+	return func() tea.Msg {
+		var diffs []string
+		for _, commit := range commits {
+			diff, err := differ.Diff(ctx, graph.Node{ID: commit.ID, Type: commit.Type, Data: commit.Data})
+			if err != nil {
+				return StatusMsg{Message: "Diff error: " + err.Error(), IsError: true}
+			}
+			diffs = append(diffs, strings.TrimRight(diff, "\n"))
+		}
+		return DiffLoadedMsg{NodeID: nodeID, Lines: highlightDiff(strings.Join(diffs, "\n\n"))}
+	}
+}
+
+// highlightDiff colors a unified diff for the terminal and splits it into
+// lines. The diff lexer is line based, so every line carries its own colors.
+func highlightDiff(diff string) []string {
+	lines := strings.Split(diff, "\n")
+	truncated := len(lines) > maxDiffLines
+	if truncated {
+		lines = lines[:maxDiffLines]
+		diff = strings.Join(lines, "\n")
+	}
+
+	var out strings.Builder
+	iterator, err := lexers.Get("diff").Tokenise(nil, diff)
+	if err == nil {
+		err = formatters.Get("terminal256").Format(&out, chromastyles.Get("monokai"), iterator)
+	}
+	if err == nil {
+		lines = strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	}
+	if truncated {
+		lines = append(lines, fmt.Sprintf("… diff cut at %d lines", maxDiffLines))
+	}
+	return lines
+}
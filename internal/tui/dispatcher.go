@@ -0,0 +1,300 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/config"
+)
+
+// Providers whose APIs the dispatcher rations
+const (
+	ProviderLinear = "linear"
+	ProviderGitHub = "github"
+)
+
+// RateLimit caps how hard commands may call one provider's API. Where the
+// provider's HTTP client is rationed (Transport), PerMinute counts requests
+// rather than commands, after a burst of a minute's worth.
+type RateLimit struct {
+	Concurrency int // Calls running at once
+	PerMinute   int // Calls started per minute, after a burst of Concurrency
+}
+
+// DefaultRateLimits returns limits that stay under each provider's
+// documented limits (Linear allows 1500 requests an hour per key, GitHub
+// 5000), in a map of the caller's own.
+func DefaultRateLimits() map[string]RateLimit {
+	return map[string]RateLimit{
+		ProviderLinear: {Concurrency: 2, PerMinute: 25},
+		ProviderGitHub: {Concurrency: 4, PerMinute: 80},
+	}
+}
+
+// ParseRateLimits validates configured rate limits (config "rate_limits")
+// and fills fields left out from the defaults.
+func ParseRateLimits(limits map[string]config.RateLimit) (map[string]RateLimit, error) {
+	parsed := DefaultRateLimits()
+	providers := make([]string, 0, len(limits))
+	for provider := range limits {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	for _, provider := range providers {
+		name := strings.ToLower(strings.TrimSpace(provider))
+		limit, ok := parsed[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rate limit provider %q (want linear or github)", provider)
+		}
+		configured := limits[provider]
+		if configured.Concurrency < 0 || configured.PerMinute < 0 {
+			return nil, fmt.Errorf("rate limit for %s must not be negative", name)
+		}
+		if configured.Concurrency > 0 {
+			limit.Concurrency = configured.Concurrency
+		}
+		if configured.PerMinute > 0 {
+			limit.PerMinute = configured.PerMinute
+		}
+		parsed[name] = limit
+	}
+	return parsed, nil
+}
+
+// Dispatcher runs API-calling commands within per-provider limits, so rapid
+// refreshes or bulk actions queue up instead of drawing 429s. It is shared
+// by every copy of a Model, like the store. A nil Dispatcher limits nothing.
+type Dispatcher struct {
+	providers map[string]*providerBudget
+}
+
+// providerBudget is one provider's concurrency slots and token bucket
+type providerBudget struct {
+	slots      chan struct{}
+	mu         sync.Mutex
+	tokens     float64
+	burst      float64
+	rate       float64 // Tokens per second
+	filled     time.Time
+	perRequest bool // Tokens are spent by HTTP requests (Transport), not commands
+}
+
+// NewDispatcher returns a dispatcher enforcing limits per provider;
+// providers without a limit run freely.
+func NewDispatcher(limits map[string]RateLimit) *Dispatcher {
+	d := &Dispatcher{providers: make(map[string]*providerBudget, len(limits))}
+	for provider, limit := range limits {
+		concurrency := clampMin(limit.Concurrency, 1)
+		d.providers[provider] = &providerBudget{
+			slots:  make(chan struct{}, concurrency),
+			tokens: float64(concurrency),
+			burst:  float64(concurrency),
+			rate:   float64(limit.PerMinute) / 60,
+			filled: time.Now(),
+		}
+	}
+	return d
+}
+
+// WithRateLimits returns a new Model whose API calls keep to limits.
+func (m Model) WithRateLimits(limits map[string]RateLimit) Model {
+	m.dispatcher = NewDispatcher(limits)
+	return m
+}
+
+// WithDispatcher returns a new Model rationing its API calls with d, e.g.
+// one whose Transport a source's HTTP client already goes through.
+func (m Model) WithDispatcher(d *Dispatcher) Model {
+	m.dispatcher = d
+	return m
+}
+
+// Dispatch returns cmd, made to wait for a slot and budget with each of
+// the providers it calls before it runs. Cancelling ctx (quitting) abandons
+// the wait and the command.
+func (d *Dispatcher) Dispatch(ctx context.Context, cmd tea.Cmd, providers ...string) tea.Cmd {
+	if d == nil || len(providers) == 0 {
+		return cmd
+	}
+	return func() tea.Msg {
+		release, err := d.acquire(ctx, providers...)
+		if err != nil {
+			return nil
+		}
+		defer release()
+		return cmd()
+	}
+}
+
+// acquire waits for each provider's slot and a token from its budget,
+// returning a func that gives the slots back. Providers are taken in name
+// order so two commands never hold one each while waiting for the other.
+func (d *Dispatcher) acquire(ctx context.Context, providers ...string) (func(), error) {
+	if d == nil {
+		return func() {}, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var budgets []*providerBudget
+	names := append([]string(nil), providers...)
+	sort.Strings(names)
+	for i, name := range names {
+		if i > 0 && names[i-1] == name {
+			continue
+		}
+		if budget, ok := d.providers[name]; ok {
+			budgets = append(budgets, budget)
+		}
+	}
+
+	release := func(held []*providerBudget) {
+		for _, budget := range held {
+			<-budget.slots
+		}
+	}
+	for i, budget := range budgets {
+		select {
+		case budget.slots <- struct{}{}:
+		case <-ctx.Done():
+			release(budgets[:i])
+			return nil, ctx.Err()
+		}
+		if budget.spentByRequests() {
+			continue
+		}
+		if err := budget.take(ctx); err != nil {
+			release(budgets[:i+1])
+			return nil, err
+		}
+	}
+	return func() { release(budgets) }, nil
+}
+
+// Transport returns next (nil = http.DefaultTransport) spending a token
+// from provider's budget on each request, so a sync that pages through an
+// API pays per page rather than once. Commands dispatched for the provider
+// then only wait for a slot, and a minute's worth of requests may go out at
+// once. Without a limit for provider, next is returned as is.
+func (d *Dispatcher) Transport(provider string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if d == nil {
+		return next
+	}
+	budget, ok := d.providers[provider]
+	if !ok {
+		return next
+	}
+	budget.mu.Lock()
+	budget.perRequest = true
+	budget.burst = max(budget.burst, budget.rate*60)
+	budget.tokens = budget.burst
+	budget.mu.Unlock()
+	return rationedTransport{budget: budget, next: next}
+}
+
+// rationedTransport waits for a token before each request
+type rationedTransport struct {
+	budget *providerBudget
+	next   http.RoundTripper
+}
+
+// RoundTrip spends a token, waiting for one as long as the request's
+// context allows, and sends the request on
+func (t rationedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.budget.take(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// spentByRequests reports whether HTTP requests spend the budget's tokens
+func (b *providerBudget) spentByRequests() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.perRequest
+}
+
+// take waits until the budget has a token and spends it
+func (b *providerBudget) take(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.filled).Seconds()*b.rate)
+		b.filled = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Minute
+		if b.rate > 0 {
+			wait = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		}
+		b.mu.Unlock()
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// providerOf returns the provider whose API a node's writes call, from its
+// ID prefix ("linear:CET-352"), or "" for nodes no API backs
+func providerOf(nodeID string) string {
+	switch prefix, _, _ := strings.Cut(nodeID, ":"); prefix {
+	case ProviderLinear, ProviderGitHub:
+		return prefix
+	}
+	return ""
+}
+
+// providers returns the providers a write calls: the edited node's, for a
+// write-back, or the target's
+func (r ConfirmationRequest) providers() []string {
+	var nodeID string
+	switch {
+	case r.Edit != nil:
+		nodeID = r.Edit.NodeID
+	case r.Optimistic != nil:
+		nodeID = r.Optimistic.ID
+	case r.Target != "":
+		nodeID = r.Target
+	}
+	if provider := providerOf(nodeID); provider != "" {
+		return []string{provider}
+	}
+	return nil
+}
+
+// syncProviders returns the providers d rations among synced sources (nil
+// sources = every source, so every provider it rations)
+func (d *Dispatcher) syncProviders(sources []string) []string {
+	if d == nil {
+		return nil
+	}
+	var providers []string
+	if sources == nil {
+		for provider := range d.providers {
+			providers = append(providers, provider)
+		}
+		sort.Strings(providers)
+		return providers
+	}
+	for _, source := range sources {
+		if _, ok := d.providers[source]; ok {
+			providers = append(providers, source)
+		}
+	}
+	return providers
+}
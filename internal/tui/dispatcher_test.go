@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// countingTransport answers every request and counts them
+type countingTransport struct{ requests int }
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+// TestTransportSpendsTheBudgetPerRequest checks a rationed client pays for
+// each request, and that commands for the provider then only take a slot.
+func TestTransportSpendsTheBudgetPerRequest(t *testing.T) {
+	d := NewDispatcher(map[string]RateLimit{ProviderLinear: {Concurrency: 1, PerMinute: 2}})
+	next := &countingTransport{}
+	client := &http.Client{Transport: d.Transport(ProviderLinear, next)}
+
+	get := func(timeout time.Duration) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://linear.invalid/graphql", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+	// A minute's worth goes out at once
+	for i := 0; i < 2; i++ {
+		if err := get(time.Second); err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+	}
+	if err := get(20 * time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("request past the budget: error %v, want it to wait past its deadline", err)
+	}
+	if next.requests != 2 {
+		t.Errorf("%d requests sent, want 2", next.requests)
+	}
+
+	// The spent budget does not hold up a command; its requests pay
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	ran := d.Dispatch(ctx, func() tea.Msg { return StatusMsg{Message: "ran"} }, ProviderLinear)()
+	if msg, ok := ran.(StatusMsg); !ok || msg.Message != "ran" {
+		t.Errorf("dispatched command returned %v, want it run at once", ran)
+	}
+}
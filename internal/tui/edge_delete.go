@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// requestEdgeDeletion asks to delete the selected relation from the store.
+// Only hand-made edges qualify: one derived from a source would be back on
+// the next load.
+func (m Model) requestEdgeDeletion() (tea.Model, tea.Cmd) {
+	relations := m.GetRelationsList()
+	if m.selectedRelIdx >= len(relations) {
+		return m, nil
+	}
+	rel := relations[m.selectedRelIdx]
+	if !rel.Edge.HandMade() {
+		return m.WithStatus(fmt.Sprintf("%q is derived from a source and would return on reload; only hand-made relations can be deleted", rel.Relation), true), nil
+	}
+	if m.store == nil {
+		return m.WithStatus("No graph store available to delete relations from", true), nil
+	}
+
+	req := m.edgeDeletion(rel)
+	return m.Update(ConfirmationRequested{
+		Action:      req.Action,
+		Execute:     req.Execute,
+		Done:        req.Done,
+		Category:    req.Category,
+		Destructive: req.Destructive,
+	})
+}
+
+// requestBulkEdgeDeletion asks once to delete every hand-made relation in
+// the (filtered) Relations list; each can be toggled off in the dialog.
+func (m Model) requestBulkEdgeDeletion() (tea.Model, tea.Cmd) {
+	if m.store == nil {
+		return m.WithStatus("No graph store available to delete relations from", true), nil
+	}
+	var actions []ConfirmationRequest
+	for _, rel := range m.GetRelationsList() {
+		if rel.Edge.HandMade() {
+			actions = append(actions, m.edgeDeletion(rel))
+		}
+	}
+	if len(actions) == 0 {
+		return m.WithStatus("No hand-made relations in the list to delete", true), nil
+	}
+	return m.Update(BatchConfirmationRequested{
+		Action:      fmt.Sprintf("Delete %d relations?", len(actions)),
+		Actions:     actions,
+		Category:    CategoryDelete,
+		Destructive: true,
+	})
+}
+
+// edgeDeletion describes deleting a relation's edge from the store
+func (m Model) edgeDeletion(rel RelationItem) ConfirmationRequest {
+	from, to := rel.Edge.FromID, rel.Edge.ToID
+	if fromNode, ok := m.GetNodeByID(from); ok && fromNode.Title != "" {
+		from = fromNode.Title
+	}
+	if toNode, ok := m.GetNodeByID(to); ok && toNode.Title != "" {
+		to = toNode.Title
+	}
+	return ConfirmationRequest{
+		Action:      fmt.Sprintf("Delete relation: %s —%s→ %s", from, rel.Relation, to),
+		Execute:     deleteStoredEdge(m.store, rel.Edge),
+		Done:        EdgeDeletedMsg{Edge: rel.Edge},
+		Category:    CategoryDelete,
+		Destructive: true,
+	}
+}
+
+// deleteStoredEdge removes the stored edge matching a display edge. Stored
+// IDs can differ from a freshly derived one, so it is looked up by its
+// endpoints and relation. An edge to a node only its source knows was never
+// stored (see saveNewIssue); there is nothing to remove but the display.
+func deleteStoredEdge(store graph.GraphStore, edge DisplayEdge) func() error {
+	return func() error {
+		stored, err := store.GetEdges(edge.FromID)
+		if err != nil {
+			return err
+		}
+		for _, candidate := range stored {
+			if candidate.FromID == edge.FromID && candidate.ToID == edge.ToID && candidate.Relation == edge.Relation {
+				if err := store.DeleteEdge(candidate.ID); err != nil {
+					return err
+				}
+				return graph.Flush(store)
+			}
+		}
+		if !storedNode(store, edge.FromID) || !storedNode(store, edge.ToID) {
+			return nil
+		}
+		return fmt.Errorf("relation not found in the store: %s %s %s", edge.FromID, edge.Relation, edge.ToID)
+	}
+}
+
+// storedNode reports whether the store holds the node id
+func storedNode(store graph.GraphStore, id string) bool {
+	_, err := store.GetNode(id)
+	return err == nil
+}
+
+// withoutEdge returns a new Model with edge removed, keeping the Relations
+// selection within the shorter list.
+func (m Model) withoutEdge(edge DisplayEdge) Model {
+	edges := make([]DisplayEdge, 0, len(m.edges))
+	for _, e := range m.edges {
+		if e.FromID != edge.FromID || e.ToID != edge.ToID || e.Relation != edge.Relation {
+			edges = append(edges, e)
+		}
+	}
+	m = m.WithEdges(edges)
+	if count := len(m.GetRelationsList()); m.selectedRelIdx >= count {
+		m = m.WithSelectedRelIdx(max(count-1, 0))
+	}
+	return m
+}
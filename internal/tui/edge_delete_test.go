@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// TestDeleteStoredEdgeRoundTrip checks a hand-made edge read back from the
+// store can be deleted there, and that one to a node the store never held
+// is dropped from the display only.
+func TestDeleteStoredEdgeRoundTrip(t *testing.T) {
+	store, err := graph.NewStore(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	issue, edges, err := newIssue("Mine", "", issueDraft{projectID: "project:p"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	project := graph.Node{ID: "project:p", Type: graph.NodeTypeProject, Source: "linear", Data: []byte(`{"name":"P"}`)}
+	other := graph.Node{ID: "issue:A-1", Type: graph.NodeTypeIssue, Source: "linear", Data: []byte(`{"title":"Theirs"}`)}
+	derived := graph.Edge{FromID: "project:p", ToID: "issue:A-1", Relation: graph.EdgeOwns}
+	for _, node := range []graph.Node{project, other} {
+		if err := store.UpsertNode(node); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.UpsertEdge(derived); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveNewIssue(store, issue, edges)(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, stored, err := graph.ReadStored(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var handMade []DisplayEdge
+	for _, edge := range EdgesToDisplayEdges(stored) {
+		if edge.HandMade() {
+			handMade = append(handMade, edge)
+		}
+	}
+	if len(handMade) != 1 || handMade[0].ToID != issue.ID {
+		t.Fatalf("hand-made edges read back = %+v, want the one to the new issue", handMade)
+	}
+	if err := deleteStoredEdge(store, handMade[0])(); err != nil {
+		t.Fatalf("deleting the hand-made edge: %v", err)
+	}
+	if left, _ := store.ListEdges(); len(left) != 1 || left[0].ToID != "issue:A-1" {
+		t.Errorf("edges left in the store = %+v, want only the derived one", left)
+	}
+
+	unstored := DisplayEdge{FromID: "project:q", ToID: issue.ID, Relation: graph.EdgeOwns, CreatedBy: "user"}
+	if err := deleteStoredEdge(store, unstored)(); err != nil {
+		t.Errorf("deleting an edge the store never held: %v", err)
+	}
+}
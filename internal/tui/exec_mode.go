@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// isRollupType reports whether a node type is an aggregation level shown in exec mode.
+func isRollupType(t graph.NodeType) bool {
+	return t == graph.NodeTypeProject || t == graph.NodeTypeService
+}
+
+// WithRole returns a new Model viewing the graph as the given role.
+// Nodes whose AccessLevel is above the role are hidden.
+func (m Model) WithRole(role graph.Role) Model {
+	m.role = role
+	return m.refocusFiltered()
+}
+
+// GetRole returns the viewer role.
+func (m Model) GetRole() graph.Role {
+	return m.role
+}
+
+// WithExecMode returns a new Model with exec mode toggled.
+// Exec mode shows only projects and services, each with rolled-up metrics.
+func (m Model) WithExecMode(enabled bool) Model {
+	m.execMode = enabled
+	m.graphScroll = 0
+	return m.refocusFiltered()
+}
+
+// IsExecMode reports whether exec mode is active.
+func (m Model) IsExecMode() bool {
+	return m.execMode
+}
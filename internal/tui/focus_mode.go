@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"strings"
+)
+
+// WithFocusRoot returns a new Model with the graph isolated to the subtree
+// (plus direct neighbors) of nodeID. Focusing again from inside a focused
+// view narrows further; each Esc widens one level.
+func (m Model) WithFocusRoot(nodeID string) Model {
+	if nodeID == "" || m.FocusRoot() == nodeID {
+		return m
+	}
+	m.focusStack = append(append([]string(nil), m.focusStack...), nodeID)
+	m.graphScroll = 0
+	m = m.withFocusSet()
+	return m.WithFocusedNode(nodeID)
+}
+
+// WidenFocus returns a new Model with the innermost focus level removed.
+// The previous focus root (if any) keeps the cursor.
+func (m Model) WidenFocus() Model {
+	if len(m.focusStack) == 0 {
+		return m
+	}
+	widened := m.focusStack[len(m.focusStack)-1]
+	m.focusStack = m.focusStack[:len(m.focusStack)-1]
+	m.graphScroll = 0
+	m = m.withFocusSet()
+	return m.WithFocusedNode(widened)
+}
+
+// FocusRoot returns the innermost focus root ("" when the full graph is shown).
+func (m Model) FocusRoot() string {
+	if len(m.focusStack) == 0 {
+		return ""
+	}
+	return m.focusStack[len(m.focusStack)-1]
+}
+
+// withFocusSet recomputes the node IDs visible under the current focus root.
+// Called whenever the focus stack or the underlying graph changes.
+func (m Model) withFocusSet() Model {
+	root := m.FocusRoot()
+	if root == "" {
+		m.focusSet = nil
+		return m
+	}
+
+	children := make(map[string][]string)
+	set := map[string]bool{root: true}
+	for _, edge := range m.edges {
+		if isHierarchicalEdge(edge.Relation) {
+			children[edge.FromID] = append(children[edge.FromID], edge.ToID)
+		}
+		// Direct neighbors of the root (blockers, related work) stay visible
+		if edge.FromID == root {
+			set[edge.ToID] = true
+		}
+		if edge.ToID == root {
+			set[edge.FromID] = true
+		}
+	}
+
+	visited := map[string]bool{root: true}
+	queue := append([]string(nil), children[root]...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		set[id] = true
+		queue = append(queue, children[id]...)
+	}
+
+	m.focusSet = set
+	return m
+}
+
+// renderFocusBreadcrumb renders the "Focused on X" line shown above the graph.
+func (m Model) renderFocusBreadcrumb() string {
+	titles := make([]string, 0, len(m.focusStack))
+	for _, id := range m.focusStack {
+		title := id
+		for _, node := range m.nodes {
+			if node.ID == id {
+				title = node.Title
+				break
+			}
+		}
+		titles = append(titles, title)
+	}
+	return "Focused on " + strings.Join(titles, " › ") + " — press Esc to widen"
+}
@@ -0,0 +1,319 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// issueDraft is what a template settled before the new-issue form opened
+type issueDraft struct {
+	template     string // Template name ("" = blank issue)
+	labels       []string
+	projectID    string // "" = no project
+	projectTitle string
+}
+
+// ParseIssueTemplates validates configured issue templates (config
+// "issue_templates"): each needs a name, and names must be unique.
+func ParseIssueTemplates(templates []config.IssueTemplate) ([]config.IssueTemplate, error) {
+	parsed := make([]config.IssueTemplate, 0, len(templates))
+	seen := make(map[string]bool, len(templates))
+	for i, template := range templates {
+		template.Name = strings.TrimSpace(template.Name)
+		if template.Name == "" {
+			return nil, fmt.Errorf("issue template %d has no name", i+1)
+		}
+		key := strings.ToLower(template.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("issue template %q is defined twice", template.Name)
+		}
+		seen[key] = true
+		parsed = append(parsed, template)
+	}
+	return parsed, nil
+}
+
+// WithIssueTemplates returns a new Model offering templates when an issue is created.
+func (m Model) WithIssueTemplates(templates []config.IssueTemplate) Model {
+	m.issueTemplates = templates
+	return m.WithSelectedTemplateIdx(m.selectedTemplateIdx)
+}
+
+// WithSelectedTemplateIdx returns a new Model with the template menu
+// selection moved (wrapping). The last entry is a blank issue.
+func (m Model) WithSelectedTemplateIdx(idx int) Model {
+	count := len(m.issueTemplates) + 1
+	m.selectedTemplateIdx = (idx%count + count) % count
+	return m
+}
+
+// handleNewIssueKeys processes keys in the template menu that opens before
+// the new-issue form.
+func (m Model) handleNewIssueKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		return m.WithSelectedTemplateIdx(m.selectedTemplateIdx + 1), nil
+	case "k", "up":
+		return m.WithSelectedTemplateIdx(m.selectedTemplateIdx - 1), nil
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		idx := int(msg.String()[0] - '1')
+		if idx > len(m.issueTemplates) {
+			return m, nil
+		}
+		return m.WithSelectedTemplateIdx(idx).startIssueDraft()
+	case "enter":
+		return m.startIssueDraft()
+	case "esc", "n":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// startIssueDraft opens the new-issue form prefilled from the selected template.
+func (m Model) startIssueDraft() (tea.Model, tea.Cmd) {
+	var template config.IssueTemplate
+	if m.selectedTemplateIdx < len(m.issueTemplates) {
+		template = m.issueTemplates[m.selectedTemplateIdx]
+	}
+	draft := &issueDraft{template: template.Name, labels: template.Labels}
+	if project, ok := m.draftProject(template.Project); ok {
+		draft.projectID, draft.projectTitle = project.ID, project.Title
+	} else if template.Project != "" {
+		m = m.WithStatus(fmt.Sprintf("Project %q is not loaded; the issue will have none", template.Project), true)
+	}
+
+	width := clampMin(min(m.width-4, 80)-4, 10)
+	title := textinput.New()
+	title.Prompt = ""
+	title.CharLimit = 200
+	title.Width = width
+	title.Cursor.SetMode(cursor.CursorStatic)
+	title.SetValue(template.TitlePrefix)
+	title.CursorEnd()
+	title.Focus()
+
+	description := textarea.New()
+	description.ShowLineNumbers = false
+	description.SetWidth(width)
+	description.SetHeight(8)
+	description.Cursor.SetMode(cursor.CursorStatic)
+	description.SetValue(template.Description)
+	description.Blur()
+
+	m.editor = &nodeEditor{title: title, description: description, draft: draft}
+	return m, nil
+}
+
+// draftProject finds the project a new issue goes under: the template's,
+// by name or ID, or else the focused node's (the node itself, or the
+// project that owns it)
+func (m Model) draftProject(name string) (DisplayNode, bool) {
+	if name != "" {
+		for _, node := range m.nodes {
+			if node.Type == graph.NodeTypeProject && (node.ID == name || strings.EqualFold(node.Title, name)) {
+				return node, true
+			}
+		}
+		return DisplayNode{}, false
+	}
+	focused, ok := m.GetFocusedNode()
+	if !ok {
+		return DisplayNode{}, false
+	}
+	if focused.Type == graph.NodeTypeProject {
+		return focused, true
+	}
+	for _, edge := range m.edges {
+		if edge.ToID == focused.ID && edge.Relation == graph.EdgeOwns {
+			if owner, ok := m.GetNodeByID(edge.FromID); ok && owner.Type == graph.NodeTypeProject {
+				return owner, true
+			}
+		}
+	}
+	return DisplayNode{}, false
+}
+
+// requestIssueCreate asks to add the drafted issue to the store
+func (m Model) requestIssueCreate() (tea.Model, tea.Cmd) {
+	title := strings.TrimSpace(m.editor.title.Value())
+	if title == "" {
+		return m.WithStatus("A title is required", true), nil
+	}
+	if m.store == nil {
+		return m.WithStatus("No graph store available to create issues in", true), nil
+	}
+	draft := *m.editor.draft
+	node, edges, err := newIssue(title, m.editor.description.Value(), draft, time.Now())
+	if err != nil {
+		return m.WithStatus("Creating issue: "+err.Error(), true), nil
+	}
+	m.editor = nil
+
+	return m.Update(ConfirmationRequested{
+		Action:   fmt.Sprintf("Create issue %q", title),
+		Execute:  saveNewIssue(m.store, node, edges),
+		Done:     IssueCreatedMsg{Node: displayNodeFromGraph(node), Edges: EdgesToDisplayEdges(edges)},
+		Category: CategoryCreate,
+	})
+}
+
+// newIssue builds a hand-made issue and the edge putting it under its project
+func newIssue(title, description string, draft issueDraft, now time.Time) (graph.Node, []graph.Edge, error) {
+	data, err := json.Marshal(IssueData{
+		Title:       title,
+		Description: description,
+		Status:      "todo",
+		Labels:      draft.labels,
+		Project:     draft.projectTitle,
+	})
+	if err != nil {
+		return graph.Node{}, nil, err
+	}
+	node := graph.Node{
+		ID:     graph.NewLocalID(),
+		Type:   graph.NodeTypeIssue,
+		Source: "local",
+		Data:   data,
+		Metadata: graph.NodeMetadata{
+			CreatedAt: now,
+			UpdatedAt: now,
+			CreatedBy: "user",
+		},
+	}
+	var edges []graph.Edge
+	if draft.projectID != "" {
+		edges = append(edges, graph.Edge{
+			ID:       graph.NewLocalID(),
+			FromID:   draft.projectID,
+			ToID:     node.ID,
+			Relation: graph.EdgeOwns,
+			Metadata: graph.EdgeMetadata{CreatedAt: now, CreatedBy: "user"},
+		})
+	}
+	return node, edges, nil
+}
+
+// saveNewIssue writes the issue to the store. The edge to its project is
+// stored where the project is; a project only its source knows keeps the
+// edge in memory and its name in the issue
+func saveNewIssue(store graph.GraphStore, node graph.Node, edges []graph.Edge) func() error {
+	return func() error {
+		if err := store.UpsertNode(node); err != nil {
+			return err
+		}
+		for _, edge := range edges {
+			if _, err := store.GetNode(edge.FromID); err != nil {
+				continue
+			}
+			if err := store.UpsertEdge(edge); err != nil {
+				return err
+			}
+		}
+		return graph.Flush(store)
+	}
+}
+
+// withIssueCreated adds a created issue to the graph and focuses it.
+func (m Model) withIssueCreated(msg IssueCreatedMsg) Model {
+	if m.currentView == ViewNewIssue {
+		m = m.PopView()
+	}
+	m = m.withMerged([]DisplayNode{msg.Node}, msg.Edges)
+	return m.WithFocusedNode(msg.Node.ID).WithStatus("Created "+msg.Node.Title, false)
+}
+
+// renderNewIssueView renders the template menu, or the form once a
+// template is picked
+func (m Model) renderNewIssueView(width, height int) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	contentWidth := 80
+	if width < 80 {
+		contentWidth = clampMin(width-4, 1)
+	}
+
+	if m.editor != nil && m.editor.draft != nil {
+		draft := m.editor.draft
+		header := "📝 New issue"
+		if draft.template != "" {
+			header += " (" + draft.template + ")"
+		}
+		lines := []string{headerStyle.Render(header)}
+		var facts []string
+		if draft.projectTitle != "" {
+			facts = append(facts, "Project: "+draft.projectTitle)
+		}
+		if len(draft.labels) > 0 {
+			facts = append(facts, "Labels: "+strings.Join(draft.labels, ", "))
+		}
+		if len(facts) > 0 {
+			lines = append(lines, mutedStyle.Render(truncate(strings.Join(facts, " · "), contentWidth)))
+		}
+		lines = append(lines, "", m.renderEditor(contentWidth))
+		return lipgloss.NewStyle().Width(width).Align(lipgloss.Center).Render(strings.Join(lines, "\n"))
+	}
+
+	lines := []string{headerStyle.Render("📝 New issue: pick a template"), ""}
+	for i := 0; i <= len(m.issueTemplates); i++ {
+		name, summary := "blank", "no prefix, labels or project"
+		if i < len(m.issueTemplates) {
+			template := m.issueTemplates[i]
+			name = template.Name
+			summary = templateSummary(template)
+		}
+		row := fmt.Sprintf("%d  %-12s %s", i+1, truncate(name, 12), summary)
+		if i >= 9 {
+			row = fmt.Sprintf("   %-12s %s", truncate(name, 12), summary)
+		}
+		row = truncate(row, clampMin(width-2, 10))
+		switch {
+		case i == m.selectedTemplateIdx && m.accessible:
+			lines = append(lines, row+" "+selectedMarker)
+		case i == m.selectedTemplateIdx:
+			lines = append(lines, lipgloss.NewStyle().
+				Background(styles.Primary).
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Bold(true).
+				Width(width).
+				Render(row))
+		default:
+			lines = append(lines, lipgloss.NewStyle().Foreground(styles.Foreground).Render(row))
+		}
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// templateSummary describes what a template prefills, for the menu
+func templateSummary(template config.IssueTemplate) string {
+	var parts []string
+	if template.TitlePrefix != "" {
+		parts = append(parts, fmt.Sprintf("%q", template.TitlePrefix))
+	}
+	if len(template.Labels) > 0 {
+		parts = append(parts, "labels "+strings.Join(template.Labels, ", "))
+	}
+	if template.Project != "" {
+		parts = append(parts, "in "+template.Project)
+	}
+	if len(parts) == 0 {
+		return "no prefix or labels"
+	}
+	return strings.Join(parts, " · ")
+}
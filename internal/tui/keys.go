@@ -5,6 +5,7 @@ import "github.com/charmbracelet/bubbles/key"
 // KeyMap defines all keybindings
 type KeyMap struct {
 	Quit        key.Binding
+	Suspend     key.Binding
 	Enter       key.Binding
 	Back        key.Binding
 	Up          key.Binding
@@ -25,6 +26,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("ctrl+c", "q"),
 			key.WithHelp("ctrl+c/q", "quit"),
 		),
+		Suspend: key.NewBinding(
+			key.WithKeys("ctrl+z"),
+			key.WithHelp("ctrl+z", "suspend to shell"),
+		),
 		Enter: key.NewBinding(
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "drill down"),
@@ -82,6 +87,6 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
 		{k.Enter, k.Back, k.Refresh, k.AI},
-		{k.OpenBrowser, k.CopyURL, k.Help, k.Quit},
+		{k.OpenBrowser, k.CopyURL, k.Help, k.Suspend, k.Quit},
 	}
 }
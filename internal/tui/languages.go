@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// languageLegendMax is how many languages the legend names before folding
+// the rest into "Other"
+const languageLegendMax = 6
+
+// LanguageShare is one language's part of a project's code.
+type LanguageShare struct {
+	Language string
+	Files    int
+	Lines    int
+	Share    float64 // Fraction of the project's lines, 0-1
+}
+
+// LanguageBreakdown aggregates the language of the files in a project into
+// shares of its lines, largest first (files of unknown language left out).
+func (m Model) LanguageBreakdown(projectID string) []LanguageShare {
+	index := projectIndex(m.nodes, m.edges)
+	project, ok := index[projectID]
+	if !ok {
+		return nil
+	}
+
+	byLanguage := map[string]*LanguageShare{}
+	total := 0
+	for _, node := range m.nodes {
+		if node.Type != graph.NodeTypeFile || index[node.ID] != project {
+			continue
+		}
+		var data struct {
+			Language string `json:"language"`
+			Lines    int    `json:"lines"`
+		}
+		if json.Unmarshal(node.Data, &data) != nil || data.Language == "" || data.Language == "Unknown" {
+			continue
+		}
+		share, ok := byLanguage[data.Language]
+		if !ok {
+			share = &LanguageShare{Language: data.Language}
+			byLanguage[data.Language] = share
+		}
+		share.Files++
+		share.Lines += max(data.Lines, 1)
+		total += max(data.Lines, 1)
+	}
+
+	breakdown := make([]LanguageShare, 0, len(byLanguage))
+	for _, share := range byLanguage {
+		share.Share = float64(share.Lines) / float64(total)
+		breakdown = append(breakdown, *share)
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		if breakdown[i].Lines != breakdown[j].Lines {
+			return breakdown[i].Lines > breakdown[j].Lines
+		}
+		return breakdown[i].Language < breakdown[j].Language
+	})
+	return breakdown
+}
+
+// renderLanguageDetails renders a project's language breakdown: a bar split
+// in proportion to each language's lines (left out in accessible mode,
+// where color carries no meaning) over a legend of percentages
+func (m Model) renderLanguageDetails(node DisplayNode, maxWidth int) []string {
+	if node.Type != graph.NodeTypeProject {
+		return nil
+	}
+	breakdown := m.LanguageBreakdown(node.ID)
+	if len(breakdown) == 0 {
+		return nil
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	lines := []string{headerStyle.Render("🗂  Languages:")}
+	if !m.accessible {
+		lines = append(lines, "  "+languageBar(breakdown, clampMin(maxWidth-4, 10)))
+	}
+
+	var legend []string
+	other := 0.0
+	for i, share := range breakdown {
+		if i >= languageLegendMax {
+			other += share.Share
+			continue
+		}
+		dot := lipgloss.NewStyle().Foreground(styles.LanguageColor(share.Language)).Render("●")
+		legend = append(legend, fmt.Sprintf("%s %s %s", dot, share.Language, formatShare(share.Share)))
+	}
+	if other > 0 {
+		dot := lipgloss.NewStyle().Foreground(styles.Muted).Render("●")
+		legend = append(legend, fmt.Sprintf("%s Other %s", dot, formatShare(other)))
+	}
+	// Wrap the legend to the pane
+	row := "  "
+	for _, entry := range legend {
+		if lipgloss.Width(row) > 2 && lipgloss.Width(row)+lipgloss.Width(entry)+2 > maxWidth {
+			lines = append(lines, row)
+			row = "  "
+		}
+		if lipgloss.Width(row) > 2 {
+			row += "  "
+		}
+		row += entry
+	}
+	return append(lines, row)
+}
+
+// languageBar draws the breakdown as width cells, each language's run of
+// cells in proportion to its share (largest remainders get the odd cells)
+func languageBar(breakdown []LanguageShare, width int) string {
+	cells := make([]int, len(breakdown))
+	used := 0
+	for i, share := range breakdown {
+		cells[i] = int(share.Share * float64(width))
+		used += cells[i]
+	}
+	order := make([]int, len(breakdown))
+	for i := range order {
+		order[i] = i
+	}
+	remainder := func(i int) float64 {
+		return breakdown[i].Share*float64(width) - float64(cells[i])
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainder(order[a]) > remainder(order[b]) })
+	for _, i := range order[:min(width-used, len(order))] {
+		cells[i]++
+	}
+
+	var bar strings.Builder
+	for i, share := range breakdown {
+		if cells[i] > 0 {
+			bar.WriteString(lipgloss.NewStyle().Foreground(styles.LanguageColor(share.Language)).
+				Render(strings.Repeat("█", cells[i])))
+		}
+	}
+	return bar.String()
+}
+
+// formatShare renders a share as a percentage, with a decimal under 10%
+func formatShare(share float64) string {
+	if share < 0.1 {
+		return fmt.Sprintf("%.1f%%", share*100)
+	}
+	return fmt.Sprintf("%.0f%%", share*100)
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// largeGraphRows is how many tree rows a load may show before maat suggests
+// narrowing it; past this the tree is too long to scan
+const largeGraphRows = 2000
+
+// filterSuggestion is a narrower view offered once when a large graph loads.
+type filterSuggestion struct {
+	status StatusFilter
+	depth  int // 0 = leave the depth limit alone
+	rows   int // Tree rows before the suggestion
+	after  int // Tree rows with it applied
+}
+
+// describe names the suggested change, e.g. "hide done items, depth 2"
+func (s filterSuggestion) describe() string {
+	var parts []string
+	if s.status != StatusAll {
+		parts = append(parts, fmt.Sprintf("status %s", s.status))
+	}
+	if s.depth > 0 {
+		parts = append(parts, fmt.Sprintf("depth %d", s.depth))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// apply narrows the model to the suggestion
+func (s filterSuggestion) apply(m Model) Model {
+	if s.status != StatusAll {
+		m = m.WithStatusFilter(s.status)
+	}
+	if s.depth > 0 {
+		m = m.WithMaxDepth(s.depth)
+	}
+	return m
+}
+
+// withFilterSuggestion checks a freshly loaded graph once and, when its tree
+// would run past largeGraphRows, offers the first narrower view that fits:
+// hiding done items, then a depth limit, then both. Later loads never ask
+// again, whether or not the suggestion was taken.
+func (m Model) withFilterSuggestion() Model {
+	if m.suggestedFilter || !m.ready || len(m.nodes) <= largeGraphRows {
+		return m
+	}
+	rows := len(m.layoutGraph().rows)
+	if rows <= largeGraphRows {
+		return m
+	}
+	m.suggestedFilter = true
+
+	var candidates []filterSuggestion
+	if m.statusFilter == StatusAll {
+		candidates = append(candidates, filterSuggestion{status: StatusNotDone})
+	}
+	for _, depth := range []int{3, 2, 1} {
+		if m.maxDepth == 0 || depth < m.maxDepth {
+			candidates = append(candidates, filterSuggestion{depth: depth})
+			if m.statusFilter == StatusAll {
+				candidates = append(candidates, filterSuggestion{status: StatusNotDone, depth: depth})
+			}
+		}
+	}
+
+	var best *filterSuggestion
+	for _, candidate := range candidates {
+		candidate.rows = rows
+		candidate.after = len(candidate.apply(m).layoutGraph().rows)
+		if best == nil || candidate.after < best.after {
+			c := candidate
+			best = &c
+		}
+		if candidate.after <= largeGraphRows {
+			break
+		}
+	}
+	if best == nil || best.after >= rows {
+		return m
+	}
+	m.suggestion = best
+	return m
+}
+
+// handleSuggestionKeys answers the large graph prompt: y narrows the view,
+// n or Esc keeps it. Any other key dismisses the prompt and acts as usual.
+func (m Model) handleSuggestionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	suggestion := *m.suggestion
+	m.suggestion = nil
+	switch msg.String() {
+	case "y":
+		m = suggestion.apply(m)
+		return m.WithStatus(fmt.Sprintf("Showing %s (%d of %d rows) · s and 0 widen it again", suggestion.describe(), suggestion.after, suggestion.rows), false), nil
+	case "n", "esc":
+		return m, nil
+	}
+	return m.handleKeyPress(msg)
+}
+
+// renderSuggestionBar renders the large graph prompt in place of the status bar.
+func (m Model) renderSuggestionBar() string {
+	promptStyle := lipgloss.NewStyle().
+		Foreground(styles.Accent).
+		Bold(true)
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(styles.Foreground)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.Muted).
+		Faint(true)
+
+	s := m.suggestion
+	content := fmt.Sprintf("%s %s  %s",
+		promptStyle.Render(fmt.Sprintf("Large graph: %d rows.", s.rows)),
+		textStyle.Render(fmt.Sprintf("Show %s (%d rows)?", s.describe(), s.after)),
+		hintStyle.Render("y:apply | n:keep all"),
+	)
+
+	return styles.RenderStatusBar(lipgloss.NewStyle().MaxWidth(clampMin(m.width-2, 1)).Render(content), m.width)
+}
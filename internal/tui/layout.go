@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+const (
+	// compactWidth is the width below which the condensed layout kicks in:
+	// single column, no status suffixes, short key hints.
+	compactWidth = 60
+
+	// Below these dimensions nothing useful fits - show a "too small" screen
+	minTerminalWidth  = 30
+	minTerminalHeight = 8
+)
+
+// isCompact reports whether the terminal is narrow enough for the condensed layout.
+func (m Model) isCompact() bool {
+	return m.width < compactWidth
+}
+
+// isTooSmall reports whether the terminal is below the minimum usable size.
+func (m Model) isTooSmall() bool {
+	return m.width < minTerminalWidth || m.height < minTerminalHeight
+}
+
+// clampMin returns v, or min if v is smaller. Layout math subtracts padding
+// and chrome from the terminal size, which goes negative on tiny terminals.
+func clampMin(v, min int) int {
+	if v < min {
+		return min
+	}
+	return v
+}
+
+// renderTooSmall renders the placeholder shown when the terminal is too small.
+func (m Model) renderTooSmall() string {
+	msg := lipgloss.NewStyle().
+		Foreground(styles.Muted).
+		Italic(true).
+		Render(fmt.Sprintf("Terminal too small\n%dx%d (need %dx%d)", m.width, m.height, minTerminalWidth, minTerminalHeight))
+
+	return lipgloss.Place(
+		clampMin(m.width, 1),
+		clampMin(m.height, 1),
+		lipgloss.Center,
+		lipgloss.Center,
+		msg,
+		lipgloss.WithWhitespaceChars(" "),
+	)
+}
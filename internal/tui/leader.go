@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// leaderChord is a key pressed after the leader (space). Chords group
+// actions under one prefix so they need no single letter of their own.
+type leaderChord struct {
+	key   string
+	help  string
+	views []ViewMode // Views the chord works in
+}
+
+// leaderChords lists the chords in the order the hint panel shows them.
+var leaderChords = []leaderChord{
+	{"f", "filter by type", []ViewMode{ViewGraph}},
+	{"e", "export graph as text", []ViewMode{ViewGraph, ViewDetails, ViewRelations}},
+	{"s", "sync sources", []ViewMode{ViewGraph, ViewDetails, ViewRelations}},
+	{"r", "resync focused node's sources", []ViewMode{ViewGraph, ViewDetails, ViewRelations}},
+	{"p", "preview file", []ViewMode{ViewGraph, ViewDetails}},
+}
+
+// WithLeaderPending returns a new Model waiting (or no longer waiting) for
+// a chord after the leader key.
+func (m Model) WithLeaderPending(pending bool) Model {
+	m.leaderPending = pending
+	return m
+}
+
+// availableChords returns the chords that work in the current view
+func (m Model) availableChords() []leaderChord {
+	var chords []leaderChord
+	for _, chord := range leaderChords {
+		for _, view := range chord.views {
+			if view == m.currentView {
+				chords = append(chords, chord)
+				break
+			}
+		}
+	}
+	return chords
+}
+
+// startLeader shows the chord hints, if the current view has any chords.
+func (m Model) startLeader() (tea.Model, tea.Cmd) {
+	if len(m.availableChords()) == 0 {
+		return m, nil
+	}
+	return m.WithLeaderPending(true), nil
+}
+
+// handleLeaderKey runs the chord for the key pressed after the leader. Any
+// other key (Esc, or space again) dismisses the hints.
+func (m Model) handleLeaderKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m = m.WithLeaderPending(false)
+	if msg.Type == tea.KeyCtrlC {
+		return m.quit()
+	}
+	for _, chord := range m.availableChords() {
+		if msg.String() == chord.key {
+			return m.runChord(chord.key)
+		}
+	}
+	if msg.Type != tea.KeyEsc && msg.Type != tea.KeySpace {
+		return m.WithStatus("No chord on space "+msg.String(), true), nil
+	}
+	return m, nil
+}
+
+// runChord performs the action a chord names
+func (m Model) runChord(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "f":
+		return m.cycleTypeFilter(), nil
+	case "e":
+		return m.logActivity(ActivityExport, "Copied the graph as text"), copyToClipboard(RenderPlain(m), "Graph")
+	case "s":
+		return m.Update(RefreshRequested{})
+	case "r":
+		return m.refreshFocused()
+	case "p":
+		return m.openPreview()
+	}
+	return m, nil
+}
+
+// renderLeaderHints renders the which-key panel listing the chords that
+// follow the leader in the current view
+func (m Model) renderLeaderHints() string {
+	keyStyle := lipgloss.NewStyle().Foreground(styles.Accent).Bold(true)
+	helpStyle := lipgloss.NewStyle().Foreground(styles.Foreground)
+
+	var lines []string
+	for _, chord := range m.availableChords() {
+		lines = append(lines, keyStyle.Render(chord.key)+"  "+helpStyle.Render(chord.help))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Accent).
+		Padding(0, 1).
+		Render(keyStyle.Render("Space …") + "\n" + strings.Join(lines, "\n"))
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// WithLegend returns a new Model showing (or hiding) the legend overlay.
+func (m Model) WithLegend(shown bool) Model {
+	m.legend = shown
+	return m
+}
+
+// handleLegendKeys closes the legend; every other key waits until it is gone.
+func (m Model) handleLegendKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "?", "esc":
+		return m.WithLegend(false), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// renderLegend renders what the glyphs, icons and colors in the tree mean.
+// Every entry comes from the status and node type registries and the
+// renderers' own helpers, so the legend shows exactly what the tree draws.
+func (m Model) renderLegend() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	width := clampMin(min(m.width-8, 76), 40)
+
+	status := []string{headerStyle.Render("Status")}
+	for _, category := range styles.StatusCategories() {
+		names := strings.Join(styles.StatusNames(category), ", ")
+		if category == styles.CategoryUnknown {
+			names = "anything else"
+		}
+		marker := lipgloss.NewStyle().Foreground(category.Color()).Render(category.Indicator() + " " + category.Icon())
+		if m.accessible {
+			marker = "-" // The tree spells status out instead
+		}
+		status = append(status, marker+" "+lipgloss.NewStyle().Width(10).Render(category.String())+mutedStyle.Render(truncate(names, clampMin(width/2-18, 8))))
+	}
+
+	types := []string{headerStyle.Render("Types")}
+	for _, name := range m.nodeTypes.Names() {
+		style := m.nodeTypes.Get(name)
+		types = append(types, style.Icon+" "+lipgloss.NewStyle().Foreground(style.Color).Render(style.Label))
+	}
+
+	// Accessible mode has no glyphs or colors to explain, only its text markers
+	marks := []string{"", headerStyle.Render("Marks")}
+	if m.accessible {
+		marks = append(marks,
+			"(collapsed)  children hidden (Enter)",
+			selectedMarker+"  the focused row")
+	} else {
+		marks = append(marks,
+			"▾ ▸  expanded / collapsed (Enter)",
+			m.projectMarker("Project")+"    project accent, one color per project")
+	}
+	marks = append(marks,
+		lipgloss.NewStyle().Foreground(styles.StatusDone).Faint(true).Render("[done]")+" status text, in its status color",
+		reviewBadge(PRReview{Approvals: 1, ChangesRequested: 1, Mergeable: MergeableConflicting}, m.accessible)+"  PR approvals, change requests, conflicts",
+		treeMarkerStyle.Render("… (+3 more)")+"  below the depth limit (0 shows all)")
+
+	columns := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(width/2+4).Render(strings.Join(status, "\n")),
+		strings.Join(types, "\n"))
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.NewStyle().Bold(true).Foreground(styles.Accent).Render("Legend")+mutedStyle.Render("  (? or Esc closes)"),
+		"",
+		columns,
+		strings.Join(marks, "\n"))
+
+	dialog := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Accent).
+		Padding(1, 2).
+		Render(body)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, dialog)
+}
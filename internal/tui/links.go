@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// maxDetailLinks is how many links Details numbers; 1-9 follow them
+const maxDetailLinks = 9
+
+// identifierPattern matches issue identifiers such as CET-352 in free text
+var identifierPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]{1,9}-\d+\b`)
+
+// markdownLinkPattern matches inline links, [text](url), and bare URLs
+var markdownLinkPattern = regexp.MustCompile(`\[[^\]]*\]\((https?://[^)\s]+)[^)]*\)|(https?://[^\s<>()\[\]]+)`)
+
+// DetailLink is a link found in a node's description or commit message:
+// a loaded node to jump to, or a web page to open.
+type DetailLink struct {
+	Text   string // Identifier or URL as written
+	NodeID string // Loaded node it names ("" = open URL)
+	URL    string
+}
+
+// DetailLinks extracts the issue identifiers and URLs in a node's free text,
+// in the order they appear. Identifiers naming no loaded node are dropped,
+// and URLs of loaded nodes jump to them instead of opening.
+func (m Model) DetailLinks(node DisplayNode) []DetailLink {
+	text := linkText(node)
+	if text == "" {
+		return nil
+	}
+	byIdentifier := map[string]string{}
+	byURL := map[string]string{}
+	for _, other := range m.nodes {
+		if other.Identifier != "" {
+			byIdentifier[strings.ToUpper(other.Identifier)] = other.ID
+		}
+		if other.URL != "" {
+			byURL[other.URL] = other.ID
+		}
+	}
+
+	type found struct {
+		at   int
+		link DetailLink
+	}
+	var all []found
+	var urlSpans [][]int
+	for _, match := range markdownLinkPattern.FindAllStringSubmatchIndex(text, -1) {
+		urlSpans = append(urlSpans, match[:2])
+		url := ""
+		if match[2] >= 0 {
+			url = text[match[2]:match[3]]
+		} else {
+			url = strings.TrimRight(text[match[4]:match[5]], ".,;:!?'\"")
+		}
+		all = append(all, found{match[0], DetailLink{Text: url, NodeID: byURL[url], URL: url}})
+	}
+	for _, match := range identifierPattern.FindAllStringIndex(text, -1) {
+		inURL := false
+		for _, span := range urlSpans {
+			inURL = inURL || (match[0] >= span[0] && match[1] <= span[1])
+		}
+		key := text[match[0]:match[1]]
+		if target, ok := byIdentifier[key]; ok && !inURL {
+			all = append(all, found{match[0], DetailLink{Text: key, NodeID: target}})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].at < all[j].at })
+
+	var links []DetailLink
+	seen := map[string]bool{node.ID: true}
+	for _, f := range all {
+		key := f.link.NodeID
+		if key == "" {
+			key = f.link.URL
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		links = append(links, f.link)
+		if len(links) == maxDetailLinks {
+			break
+		}
+	}
+	return links
+}
+
+// linkText is the free text of a node links are read from: a description,
+// or a commit's message and body
+func linkText(node DisplayNode) string {
+	if node.Type == graph.NodeTypeCommit {
+		var data CommitData
+		_ = json.Unmarshal(node.Data, &data)
+		return node.Title + "\n" + data.Body
+	}
+	if describesAsMarkdown(node.Type) {
+		return node.Description
+	}
+	return ""
+}
+
+// followLink jumps to the nth (1-based) link of the focused node, or opens it
+// in the browser when it names no loaded node.
+func (m Model) followLink(n int) (tea.Model, tea.Cmd) {
+	node, ok := m.GetFocusedNode()
+	if !ok {
+		return m, nil
+	}
+	links := m.DetailLinks(node)
+	if n < 1 || n > len(links) {
+		return m, nil
+	}
+	link := links[n-1]
+	if link.NodeID == "" {
+		return m.logActivity(ActivityExport, "Opened "+link.URL), openInBrowser(link.URL)
+	}
+	m = m.logActivity(ActivityJump, fmt.Sprintf("%s → %s (link)", node.Title, link.Text))
+	return m.WithFocusedNode(link.NodeID).WithStatus("Followed "+link.Text, false), nil
+}
+
+// renderLinksSection renders a node's numbered links for the Details view
+func (m Model) renderLinksSection(node DisplayNode, maxWidth int) []string {
+	links := m.DetailLinks(node)
+	if len(links) == 0 {
+		return nil
+	}
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Muted)
+	numberStyle := lipgloss.NewStyle().Foreground(styles.Accent).Bold(true)
+	urlStyle := lipgloss.NewStyle().Foreground(styles.Primary).Underline(true)
+
+	lines := []string{headerStyle.Render("🔗 Links") + lipgloss.NewStyle().Foreground(styles.Muted).Render("  (1-9 to follow)")}
+	for i, link := range links {
+		number := numberStyle.Render(fmt.Sprintf("  [%d] ", i+1))
+		if target, ok := m.GetNodeByID(link.NodeID); ok {
+			label := target.Title
+			if target.Identifier != "" {
+				label = target.Identifier + " " + label
+			}
+			lines = append(lines, number+m.typeIcon(target.Type)+" "+truncate(label, clampMin(maxWidth-10, 10)))
+			continue
+		}
+		lines = append(lines, number+urlStyle.Render(truncate(link.URL, clampMin(maxWidth-8, 10))))
+	}
+	return lines
+}
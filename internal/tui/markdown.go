@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/glamour"
+	glamourstyles "github.com/charmbracelet/glamour/styles"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// markdownCacheSize bounds the rendered Markdown kept between frames
+const markdownCacheSize = 64
+
+// markdownCache holds rendered Markdown by style, width and source. Details
+// renders on every frame and glamour is slow enough to notice, so a
+// description is rendered once per width. It is shared by every copy of a
+// Model, like the in-flight writes.
+type markdownCache struct {
+	mu       sync.Mutex
+	rendered map[markdownKey][]string
+}
+
+type markdownKey struct {
+	style  string
+	width  int
+	source string
+}
+
+// WithLightBackground returns a new Model that renders Markdown for a light
+// (or dark) terminal background. main asks the terminal before the TUI
+// starts; asking while it runs would race its input reader.
+func (m Model) WithLightBackground(light bool) Model {
+	m.lightBackground = light
+	return m
+}
+
+// markdownStyle picks the glamour style for the model: plain ASCII in
+// accessible mode, otherwise the one matching the terminal background
+func (m Model) markdownStyle() string {
+	switch {
+	case m.accessible:
+		return glamourstyles.AsciiStyle
+	case m.lightBackground:
+		return glamourstyles.LightStyle
+	}
+	return glamourstyles.DarkStyle
+}
+
+// renderMarkdown renders Markdown for the terminal, word wrapped to width, and
+// splits it into lines. Markdown glamour cannot render comes back as wrapped
+// plain text.
+func (m Model) renderMarkdown(source string, width int) []string {
+	return m.markdown.render(source, width, m.markdownStyle())
+}
+
+// render renders source in a glamour style, or returns the cached lines
+func (c *markdownCache) render(source string, width int, style string) []string {
+	key := markdownKey{style, width, source}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if lines, ok := c.rendered[key]; ok {
+		return lines
+	}
+
+	var lines []string
+	renderer, err := glamour.NewTermRenderer(glamour.WithStandardStyle(style), glamour.WithWordWrap(width))
+	if err == nil {
+		var out string
+		if out, err = renderer.Render(source); err == nil {
+			lines = strings.Split(strings.Trim(out, "\n"), "\n")
+		}
+	}
+	if err != nil {
+		lines = strings.Split(wrapText(source, width), "\n")
+	}
+
+	if c.rendered == nil || len(c.rendered) >= markdownCacheSize {
+		c.rendered = map[markdownKey][]string{}
+	}
+	c.rendered[key] = lines
+	return lines
+}
+
+// describesAsMarkdown reports whether a node type's description is Markdown
+// (issue and PR bodies) rather than a summary field (a commit's author, a
+// file's language).
+func describesAsMarkdown(nodeType graph.NodeType) bool {
+	return nodeType != graph.NodeTypeCommit && nodeType != graph.NodeTypeFile
+}
@@ -1,5 +1,12 @@
 package tui
 
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
 // Message types define the TUI API (Commandment #3: Text Interface)
 // All async operations communicate via these message types
 
@@ -19,27 +26,202 @@ type DataLoadedMsg struct {
 	Data interface{}
 }
 
+// StatusMsg is sent to show a transient message in the status bar
+type StatusMsg struct {
+	Message string
+	IsError bool
+}
+
+// SQLResultMsg is sent when an ad-hoc SQL query against the store completes
+type SQLResultMsg struct {
+	Query  string
+	Result *graph.QueryResult
+}
+
+// SavedQueryResultMsg is sent when a SQL-backed saved query resolves its node IDs
+type SavedQueryResultMsg struct {
+	Name string
+	IDs  []string
+}
+
+// ArchiveListedMsg is sent when an archive search completes
+type ArchiveListedMsg struct {
+	Search string
+	Nodes  []DisplayNode
+}
+
+// ArchiveRestoredMsg is sent when archived nodes are back in the store,
+// with the archived edges that came back with them
+type ArchiveRestoredMsg struct {
+	Nodes []DisplayNode
+	Edges []DisplayEdge
+}
+
+// SyncRunsListedMsg is sent when the sync history has been read for the
+// sync stats view
+type SyncRunsListedMsg struct {
+	Runs []graph.SyncRun
+}
+
+// DiffLoadedMsg is sent when a commit's or PR's diff has been fetched and
+// highlighted
+type DiffLoadedMsg struct {
+	NodeID string
+	Lines  []string
+}
+
+// FilePreviewMsg is sent when a file's contents have been read and highlighted
+// (or rendered, for Markdown)
+type FilePreviewMsg struct {
+	NodeID   string
+	Lines    []string
+	Numbered bool // Source code, shown with line numbers
+}
+
+// BranchHistoryLoadedMsg is sent when another page of a branch's commits
+// has loaded
+type BranchHistoryLoadedMsg struct {
+	BranchID string
+	Nodes    []DisplayNode // Commits and their authors
+	Edges    []DisplayEdge
+	Commits  int  // Commits in the page
+	Complete bool // The branch has no older commits
+}
+
 // GraphDataLoadedMsg is sent when graph data is loaded
 type GraphDataLoadedMsg struct {
 	Nodes []DisplayNode
 	Edges []DisplayEdge
 }
 
+// LoadProgressMsg is sent as each data source finishes during a streaming load
+type LoadProgressMsg struct {
+	Source string // Source that just finished (e.g. "git", "linear")
+	Done   int    // Sources finished so far
+	Total  int    // Total sources being loaded
+}
+
 // RefreshRequested is sent when user presses 'r'
 type RefreshRequested struct{}
 
+// GraphDeltaMsg is sent when a sync finishes, carrying only what changed so
+// it can be merged into the loaded graph instead of replacing it
+type GraphDeltaMsg struct {
+	Sources      []string // Sources synced (nil = all of them)
+	Focus        string   // Node to focus if the focused one is removed
+	AddedNodes   []DisplayNode
+	UpdatedNodes []DisplayNode
+	RemovedNodes []string
+	AddedEdges   []DisplayEdge
+	RemovedEdges []DisplayEdge
+	Diagnostics  []string // What the sync reported as it ran, for the activity log
+	Warnings     []string // The diagnostics about something that went wrong
+}
+
+// SyncFailedMsg is sent when a sync started with r, space s or R fails
+type SyncFailedMsg struct {
+	Err         error
+	Diagnostics []string // What the sync reported before it failed
+}
+
 // AIInvoked is sent when user presses Ctrl+A (Commandment #6: Human Contact)
 type AIInvoked struct{}
 
 // ConfirmationRequested is sent when an external write is attempted (Commandment #10: Sovereignty)
 type ConfirmationRequested struct {
-	Action  string
-	Execute func() error
+	Action      string
+	Execute     func() error
+	Edit        *PendingEdit     // Set for write-back of a synced node: checked for remote conflicts first
+	Done        tea.Msg          // Sent once Execute succeeds, to bring the model in line (nil = generic status)
+	Category    string           // Action category (Category*) whose confirmation policy applies
+	Destructive bool             // Deletes or overwrites data
+	Optimistic  *DisplayNode     // The node as the write leaves it, shown at once and rolled back on failure
+	Target      string           // Node whose source's API the write calls, when neither Edit nor Optimistic names it
+	Requeue     *QueuedAction    // Queued action run alone (r): back in the queue if rejected or failed
+	Draft       *commentComposer // Comment being posted: reopened if rejected or failed
+}
+
+// QueueActionRequested defers an external write to the action queue (A key)
+// instead of confirming it now; the queue is flushed with one confirmation
+type QueueActionRequested struct {
+	Action      string
+	Execute     func() error
+	Edit        *PendingEdit
+	Category    string
+	Destructive bool
+	Optimistic  *DisplayNode
+	Target      string
+}
+
+// BatchConfirmationRequested asks once for several external writes from a
+// bulk action; each can be toggled off in the dialog
+type BatchConfirmationRequested struct {
+	Action      string // Headline, e.g. "Delete 3 relations?"
+	Actions     []ConfirmationRequest
+	Category    string
+	Destructive bool
+}
+
+// QueueFlushedMsg is sent when a flushed batch of queued actions has run
+type QueueFlushedMsg struct {
+	Failed  []QueuedAction // Actions that errored or hit a remote conflict, with LastErr set
+	Done    int
+	Applied []tea.Msg // Done messages of the actions that ran
+}
+
+// RemoteCheckedMsg is sent when a pending edit's remote copy has been re-read
+type RemoteCheckedMsg struct {
+	Request ConfirmationRequest
+	Remote  RemoteSnapshot
+	Err     error
+}
+
+// NodeEditedMsg is sent when an inline edit of a hand-made node is saved
+type NodeEditedMsg struct {
+	NodeID      string
+	Title       string
+	Description string
+}
+
+// IssueCreatedMsg is sent when an issue created with n is in the store,
+// with the edge putting it under its project
+type IssueCreatedMsg struct {
+	Node  DisplayNode
+	Edges []DisplayEdge
+}
+
+// CommentPostedMsg is sent when a comment composed in Details is posted to
+// the node's source
+type CommentPostedMsg struct {
+	NodeID string
+}
+
+// CommentFailedMsg is sent when posting a comment fails, with the draft to
+// reopen
+type CommentFailedMsg struct {
+	Draft *commentComposer
+	Err   error
+}
+
+// EdgeDeletedMsg is sent when a relation deleted from the Relations view is
+// gone from the store
+type EdgeDeletedMsg struct {
+	Edge DisplayEdge
+}
+
+// WritesPolledMsg is sent while quitting to check whether pending writes
+// finished; quitting gives up on them at Deadline
+type WritesPolledMsg struct {
+	Deadline time.Time
 }
 
 // ConfirmationAccepted is sent when user confirms an action
 type ConfirmationAccepted struct{}
 
+// ConfirmationRemembered is sent when user confirms an action and stops
+// confirming its category for the rest of the session
+type ConfirmationRemembered struct{}
+
 // ConfirmationRejected is sent when user rejects an action
 type ConfirmationRejected struct{}
 
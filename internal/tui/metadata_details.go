@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"encoding/json"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// renderMetadataDetails lists the license, Go module and npm package a node
+// declares, one per line
+func renderMetadataDetails(node DisplayNode) []string {
+	var data struct {
+		License        string `json:"license"`
+		LicenseFile    string `json:"license_file"`
+		Module         string `json:"module"`
+		Package        string `json:"package"`
+		PackageVersion string `json:"package_version"`
+	}
+	if json.Unmarshal(node.Data, &data) != nil {
+		return nil
+	}
+	labelStyle := lipgloss.NewStyle().Foreground(styles.Secondary)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	var lines []string
+	if data.License != "" {
+		line := labelStyle.Render("⚖️  License: " + data.License)
+		if data.LicenseFile != "" {
+			line += mutedStyle.Render("  (" + data.LicenseFile + ")")
+		}
+		lines = append(lines, line)
+	}
+	if data.Module != "" {
+		lines = append(lines, labelStyle.Render("📦 Module: "+data.Module))
+	}
+	if data.Package != "" {
+		pkg := data.Package
+		if data.PackageVersion != "" {
+			pkg += "@" + data.PackageVersion
+		}
+		lines = append(lines, labelStyle.Render("📦 Package: "+pkg))
+	}
+	return lines
+}
@@ -0,0 +1,264 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/script"
+)
+
+// Metric is a user-defined computed field (config "metrics"), shown as a
+// badge on tree rows and as a sortable column in the Table view.
+type Metric struct {
+	Name  string
+	types map[graph.NodeType]bool // nil = every type
+	expr  *script.Expr            // Set for expression metrics
+	count *metricCount            // Set for count metrics
+}
+
+// metricCount selects the related nodes a count metric counts
+type metricCount struct {
+	relation  graph.EdgeType // "" = any relation
+	nodeType  graph.NodeType // "" = any type
+	status    StatusFilter
+	direction string // "out", "in" or "both"
+}
+
+// MetricValue is one node's value for a metric: a number, or text for
+// expressions that compute a string.
+type MetricValue struct {
+	Number float64
+	Text   string
+	IsText bool
+}
+
+// String formats the value for badges and cells: whole numbers without a
+// decimal point, others to two places.
+func (v MetricValue) String() string {
+	if v.IsText {
+		return v.Text
+	}
+	if v.Number == math.Trunc(v.Number) {
+		return strconv.FormatFloat(v.Number, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(v.Number, 'f', 2, 64)
+}
+
+// ParseMetrics validates the configured metrics, compiling their expressions.
+func ParseMetrics(rules []config.MetricRule) ([]Metric, error) {
+	metrics := make([]Metric, 0, len(rules))
+	seen := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		name := strings.TrimSpace(rule.Name)
+		if name == "" {
+			return nil, fmt.Errorf("metric without a name")
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("metric %q defined twice", name)
+		}
+		seen[name] = true
+
+		metric := Metric{Name: name}
+		for _, t := range rule.Types {
+			if !graph.ValidateNodeType(t) {
+				return nil, fmt.Errorf("metric %q: unknown node type %q", name, t)
+			}
+			if metric.types == nil {
+				metric.types = make(map[graph.NodeType]bool)
+			}
+			metric.types[graph.NodeType(t)] = true
+		}
+
+		switch {
+		case rule.Expr != "" && rule.Count != nil:
+			return nil, fmt.Errorf("metric %q: set expr or count, not both", name)
+		case rule.Expr != "":
+			expr, err := script.CompileExpr(rule.Expr)
+			if err != nil {
+				return nil, fmt.Errorf("metric %q: %w", name, err)
+			}
+			metric.expr = expr
+		case rule.Count != nil:
+			count, err := parseMetricCount(*rule.Count)
+			if err != nil {
+				return nil, fmt.Errorf("metric %q: %w", name, err)
+			}
+			metric.count = &count
+		default:
+			return nil, fmt.Errorf("metric %q: set expr or count", name)
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, nil
+}
+
+// parseMetricCount validates what a count metric counts
+func parseMetricCount(rule config.MetricCount) (metricCount, error) {
+	count := metricCount{
+		relation:  graph.EdgeType(rule.Relation),
+		nodeType:  graph.NodeType(rule.Type),
+		direction: strings.ToLower(rule.Direction),
+	}
+	if rule.Relation != "" && !graph.ValidateEdgeType(rule.Relation) {
+		return count, fmt.Errorf("unknown relation %q", rule.Relation)
+	}
+	if rule.Type != "" && !graph.ValidateNodeType(rule.Type) {
+		return count, fmt.Errorf("unknown node type %q", rule.Type)
+	}
+	if rule.Status != "" {
+		status, ok := ParseStatusFilter(rule.Status)
+		if !ok {
+			return count, fmt.Errorf("unknown status %q (want all, active, not_done or done)", rule.Status)
+		}
+		count.status = status
+	}
+	switch count.direction {
+	case "":
+		count.direction = "both"
+	case "out", "in", "both":
+	default:
+		return count, fmt.Errorf("unknown direction %q (want out, in or both)", rule.Direction)
+	}
+	return count, nil
+}
+
+// appliesTo reports whether a node of type t gets the metric
+func (metric Metric) appliesTo(t graph.NodeType) bool {
+	return metric.types == nil || metric.types[t]
+}
+
+// WithMetrics returns a new Model computing the given metrics for its graph.
+func (m Model) WithMetrics(metrics []Metric) Model {
+	m.metrics = metrics
+	return m.withMetricValues()
+}
+
+// withMetricValues recomputes every metric over the current graph. Like
+// script results, values are computed once per load, not per frame.
+func (m Model) withMetricValues() Model {
+	if len(m.metrics) == 0 {
+		m.metricValues = nil
+		return m
+	}
+
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+	var firstErr error
+	m.metricValues = make(map[string]map[string]MetricValue)
+	set := func(nodeID, name string, value MetricValue) {
+		if m.metricValues[nodeID] == nil {
+			m.metricValues[nodeID] = make(map[string]MetricValue)
+		}
+		m.metricValues[nodeID][name] = value
+	}
+
+	for _, metric := range m.metrics {
+		if metric.count != nil {
+			for id, n := range metric.count.counts(m.edges, nodeByID) {
+				if node := nodeByID[id]; metric.appliesTo(node.Type) {
+					set(id, metric.Name, MetricValue{Number: float64(n)})
+				}
+			}
+			continue
+		}
+		for _, node := range m.nodes {
+			if !metric.appliesTo(node.Type) {
+				continue
+			}
+			out, err := metric.expr.Eval(metricFields(node))
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s(%s): %w", metric.Name, node.ID, err)
+				}
+				continue
+			}
+			switch v := out.(type) {
+			case float64:
+				set(node.ID, metric.Name, MetricValue{Number: v})
+			case string:
+				set(node.ID, metric.Name, MetricValue{Text: v, IsText: true})
+			}
+		}
+	}
+	if firstErr != nil {
+		lines := strings.Split(strings.TrimSpace(firstErr.Error()), "\n")
+		m = m.WithStatus("Metric: "+strings.TrimSpace(lines[len(lines)-1]), true)
+	}
+	return m
+}
+
+// counts counts, per node, the related nodes the metric selects
+func (count metricCount) counts(edges []DisplayEdge, nodeByID map[string]DisplayNode) map[string]int {
+	counts := make(map[string]int)
+	matches := func(id string) bool {
+		node, ok := nodeByID[id]
+		if !ok || (count.nodeType != "" && node.Type != count.nodeType) {
+			return false
+		}
+		return count.status == StatusAll || (node.Status != "" && count.status.MatchesStatus(node.Status))
+	}
+	for _, edge := range edges {
+		if count.relation != "" && edge.Relation != count.relation {
+			continue
+		}
+		if count.direction != "in" && matches(edge.ToID) {
+			counts[edge.FromID]++
+		}
+		if count.direction != "out" && matches(edge.FromID) {
+			counts[edge.ToID]++
+		}
+	}
+	return counts
+}
+
+// metricFields are the names a metric expression can read: every data
+// field the source loaded, plus the node's own fields where the data
+// lacks them
+func metricFields(node DisplayNode) map[string]interface{} {
+	fields := map[string]interface{}{}
+	_ = json.Unmarshal(node.Data, &fields)
+	for name, value := range map[string]interface{}{
+		"id":       node.ID,
+		"type":     string(node.Type),
+		"title":    node.Title,
+		"status":   node.Status,
+		"priority": float64(node.Priority),
+		"owner":    node.Owner,
+	} {
+		if _, ok := fields[name]; !ok && value != "" {
+			fields[name] = value
+		}
+	}
+	if !node.CreatedAt.IsZero() {
+		fields["age_days"] = float64(int(time.Since(node.CreatedAt).Hours() / 24))
+	}
+	if !node.UpdatedAt.IsZero() {
+		fields["updated_days"] = float64(int(time.Since(node.UpdatedAt).Hours() / 24))
+	}
+	return fields
+}
+
+// metricValue returns a node's value for the named metric
+func (m Model) metricValue(nodeID, name string) (MetricValue, bool) {
+	value, ok := m.metricValues[nodeID][name]
+	return value, ok
+}
+
+// metricBadges renders a node's metrics for its tree row, in config order
+func (m Model) metricBadges(nodeID string) string {
+	var badges string
+	for _, metric := range m.metrics {
+		if value, ok := m.metricValue(nodeID, metric.Name); ok {
+			badges += " ‹" + metric.Name + " " + value.String() + "›"
+		}
+	}
+	return badges
+}
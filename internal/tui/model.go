@@ -1,11 +1,19 @@
 package tui
 
 import (
+	"context"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/config"
 	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/script"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
 )
 
 // NOTE: Pane concept removed in favor of single-pane design with ViewMode cycling.
@@ -20,36 +28,214 @@ type Model struct {
 	edges       []DisplayEdge
 
 	// UI State
-	currentView     ViewMode        // Graph, Details, or Relations (full-screen views)
-	filterMode      FilterMode      // Controls which node types are shown (default: FilterProjects)
-	statusFilter    StatusFilter    // Controls which statuses are shown (default: StatusAll)
-	collapsed       map[string]bool // Tracks which projects/nodes are collapsed
-	navStack        NavigationStack
-	ready           bool
-	width           int
-	height          int
-	selectedRelIdx  int    // Index of selected relation in Relations view (for drill-down)
-	relationsScroll int    // Scroll offset for relations list
-	graphScroll     int    // Scroll offset for graph view (line-based)
-	searchMode      bool   // True when in search/filter mode (/ key)
-	searchQuery     string // Current search query for filtering
+	currentView         ViewMode        // Graph, Details, or Relations (full-screen views)
+	filterMode          FilterMode      // Controls which node types are shown (default: FilterProjects)
+	statusFilter        StatusFilter    // Controls which statuses are shown (default: StatusAll)
+	collapsed           map[string]bool // Tracks which projects/nodes are collapsed
+	navStack            NavigationStack
+	ready               bool
+	width               int
+	height              int
+	selectedRelIdx      int          // Index of selected relation in Relations view (for drill-down)
+	relationsScroll     int          // Scroll offset for relations list
+	relationsFilter     string       // Narrows the Relations list by title or relation (/ in Relations)
+	relationsFilterMode bool         // True when typing a Relations filter
+	relationsSort       RelationSort // Order of the Relations list (s in Relations)
+	graphScroll         int          // Scroll offset for graph view (line-based)
+	searchMode          bool         // True when in search/filter mode (/ key)
+	searchQuery         string       // Current search query for filtering
+	sqlMode             bool         // True when typing an ad-hoc SQL query (: key)
+	sqlQuery            string       // Current SQL query text
+	sqlResult           *graph.QueryResult
+	sqlScroll           int    // Scroll offset for SQL results table
+	statusMsg           string // Transient status bar message
+	statusIsError       bool
+
+	// Saved queries / smart views
+	configQueries    []config.SavedQuery // Defined in config.yaml (read-only)
+	userQueries      []config.SavedQuery // Saved interactively (persisted to queriesPath)
+	queriesPath      string
+	selectedQueryIdx int             // Selection in the Views sidebar
+	activeQuery      string          // Name of the applied saved query ("" if none)
+	activeSQL        string          // SQL of the applied saved query ("" if none)
+	idFilter         map[string]bool // Node IDs allowed by a SQL-backed query (nil = unrestricted)
+	queryNameMode    bool            // True when typing a name for a new saved query
+	queryName        string
+	selectedCard     int // Selected project card in the Dashboard view
+
+	// Role-based visibility
+	role     graph.Role // Viewer role (exec | lead | ic); nodes above it are hidden
+	execMode bool       // Show only project/service roll-ups, hiding commits and files
+
+	// Focus mode (subtree isolation)
+	focusStack []string        // Nested focus roots, innermost last
+	focusSet   map[string]bool // Node IDs visible under the focus root (nil = whole graph)
+	maxDepth   int             // Tree levels rendered before "… (+k more)" markers (0 = unlimited)
+
+	// Pull request review ("needs my review" filter)
+	viewer      string // Viewer's GitHub login
+	needsReview bool   // Show only open PRs requesting the viewer's review
+
+	// Review queue (W key)
+	reviewedPRs       map[string]time.Time // PR ID -> when it was marked viewed locally
+	reviewedPath      string               // Where viewed marks are persisted ("" = session only)
+	selectedReviewIdx int                  // Selected row in the Review queue view
+
+	// Action queue (A key): deferred external writes, flushed with one confirmation
+	actionQueue       []QueuedAction
+	selectedActionIdx int
+
+	// Inline editing of hand-made nodes and Linear issues (e key in Details)
+	editor    *nodeEditor      // nil when not editing
+	composer  *commentComposer // nil when no comment is being written
+	writeBack WriteBack        // Pushes edits of Linear issues (nil = hand-made nodes only)
+
+	// Archive (Z key): nodes expired by their TTL, searched and restored on demand
+	archived           []DisplayNode
+	archiveSearch      string // Search applied to the archive listing
+	archiveSearchMode  bool   // True when typing an archive search
+	selectedArchiveIdx int
+
+	// Merge checklist (C key) of the PR it was opened on
+	checklistPR      string
+	selectedCheckIdx int
+
+	// Retro (E key): the cycle shown, newest first
+	retroCycleIdx int
+
+	// Hotspots (H key): the selected file, riskiest first
+	selectedRiskIdx int
+
+	// New issue (n key): templates offered before the form opens
+	issueTemplates      []config.IssueTemplate
+	selectedTemplateIdx int
+
+	// Sync stats (T key): what each source's recent syncs cost, from sync_runs
+	syncRuns        []graph.SyncRun
+	syncStatsScroll int
+
+	// Session activity log (L key), appended to activityPath on quit
+	activity       []ActivityEntry
+	activityPath   string
+	activityScroll int
+
+	// Legend overlay (? key): what glyphs, icons and colors mean
+	legend bool
+
+	// Large graph prompt, offered once per session
+	suggestion      *filterSuggestion
+	suggestedFilter bool
+
+	// Presentation mode (P key): no status bar, high contrast, large focus card
+	presentation bool
+
+	// Leader key (space): the next key is a chord, with its hints shown
+	leaderPending bool
+
+	// Full-screen views beyond the core four, by mode (see ViewModule)
+	views map[ViewMode]ViewModule
+
+	// Diff (d key) and file Preview (space p) views
+	differ    Differ    // nil = diffs unavailable
+	previewer Previewer // nil = previews unavailable
+	commenter Commenter // nil = comments cannot be posted
+	pager     pager     // Lines the Diff or Preview view shows
+
+	// Branch history: commits past the initial cap, loaded as branches expand
+	history     BranchHistory   // nil = no lazy loading
+	historyDone map[string]bool // Branches whose whole history is loaded
+
+	// Scoped refresh (R in Graph or Details, space r): reload only the focused node's sources
+	refresher SourceRefresher // nil = scoped refresh unavailable
+	syncing   bool            // A sync is running: another is refused until it reports back
+
+	// User Starlark rules (--script): filters, decorations and badges per node
+	script        *script.Engine
+	scriptResults map[string]script.Result
+
+	// User-defined metrics (config "metrics"): badges in the tree, columns
+	// in the Table view (V key), sorted by the selected column
+	metrics         []Metric
+	metricValues    map[string]map[string]MetricValue // Node ID -> metric name -> value
+	tableSortColumn int                               // Index into tableColumns
+	tableSortDesc   bool
+	selectedRowIdx  int
+
+	// My-work mode (the viewer's assigned issues, PRs and recent commits)
+	myWork    bool
+	myWorkSet map[string]bool // Node IDs my-work shows (nil = viewer unresolved)
+
+	statusBar  []string  // Status bar segments in order (nil = default layout)
+	loadedAt   time.Time // When the graph data was loaded (status bar sync age)
+	loadErrors []error   // Sources that failed during the last load
+	truncated  []string  // Sources the last load capped, e.g. "git: truncated at 50 commits"
+
+	traceExpanded   bool                  // Details view expands issue traceability / file impact
+	detailTemplates DetailTemplates       // Per-type Details layouts from config (nil = built-in layout)
+	nodeTypes       styles.NodeTypeStyles // Node type styles from config over the built-in ones (nil = built-in)
+	projectColors   styles.ProjectColors  // Project accents from config (nil = picked by name)
+
+	ownerFilter string // Show only files/directories with this inferred owner ("" = all)
+
+	siblingLimit map[string]int // Per-parent count of children shown (default siblingPageSize)
+	accessible   bool           // Screen-reader friendly output: text markers, no glyphs
+	altScreen    bool           // Running on the alternate screen (false = inline, I toggles)
 
 	// Components
 	viewport viewport.Model
 	help     help.Model
 	keys     KeyMap
+	spinner  spinner.Model // Animated loading indicator, ticks only while loading
 
 	// Application State
 	data         interface{}
 	err          error
 	loading      bool
+	loadProgress LoadProgressMsg // Latest per-source progress while loading
 	confirmation *ConfirmationRequest
+
+	// Confirmation policy per action category, and categories the user
+	// stopped confirming for this session ([s] in the dialog)
+	confirmPolicies   map[string]ConfirmPolicy
+	confirmRemembered map[string]bool
+	store             graph.GraphStore // Optional: enables the read-only SQL prompt
+
+	// API calls wait their turn per provider (rate_limits)
+	dispatcher *Dispatcher
+
+	// Optimistic writes: nodes shown as a running write leaves them
+	pending map[string]pendingWrite
+
+	// Rendered Markdown (descriptions, previews), in the style for the terminal
+	markdown        *markdownCache
+	lightBackground bool
+
+	// Shutdown: reads are cancelled on quit, writes are waited for
+	ctx         context.Context
+	cancel      context.CancelFunc
+	writes      *inFlight
+	quitting    bool
+	sessionPath string // Where the session state is saved on quit ("" = not saved)
 }
 
 // ConfirmationRequest represents a pending external write (Commandment #10: Sovereignty)
 type ConfirmationRequest struct {
-	Action  string
-	Execute func() error
+	Action      string
+	Execute     func() error
+	Edit        *PendingEdit
+	Done        tea.Msg          // Sent once Execute succeeds (nil = generic status)
+	Category    string           // Action category its confirmation policy is looked up by
+	Destructive bool             // Deletes or overwrites data (asks under the "destructive" policy)
+	Optimistic  *DisplayNode     // The node as the write leaves it, shown (pending) while it runs (nil = wait for Done)
+	Target      string           // Node whose source's API the write calls, when neither Edit nor Optimistic names it
+	Conflicts   []FieldConflict  // Fields changed both locally and remotely since the last sync
+	Warning     string           // Shown when the remote copy could not be checked
+	Batch       []QueuedAction   // Several writes confirmed at once: run these instead of Execute
+	Skip        []bool           // Batch items toggled off in the dialog
+	Cursor      int              // Selected batch item
+	Queued      bool             // Batch is the head of the action queue
+	Requeue     *QueuedAction    // Queued action run alone: put back if rejected or failed
+	Draft       *commentComposer // Comment being posted: reopened if rejected or failed
 }
 
 // NewModel creates the initial model state
@@ -61,25 +247,35 @@ func NewModel() Model {
 		edges:       make([]DisplayEdge, 0),
 
 		// UI State
-		currentView: ViewGraph,              // Start in Graph view (full screen)
-		filterMode:  FilterProjects,         // Start with filtered view (much more usable!)
-		collapsed:   make(map[string]bool),  // All projects start expanded
+		currentView: ViewGraph,             // Start in Graph view (full screen)
+		filterMode:  FilterProjects,        // Start with filtered view (much more usable!)
+		collapsed:   make(map[string]bool), // All projects start expanded
 		navStack:    NewNavigationStack(),
+		views:       builtinViews(),
 		ready:       false,
 		width:       80,
 		height:      24,
+		role:        graph.RoleIC, // Full detail unless a narrower role is chosen
+		altScreen:   true,         // maat runs full screen unless --inline
 
 		// Components
 		viewport: viewport.New(80, 24),
 		help:     help.New(),
 		keys:     DefaultKeyMap(),
+		spinner: spinner.New(
+			spinner.WithSpinner(spinner.Dot),
+			spinner.WithStyle(styles.StatusBarLoadingStyle),
+		),
 
 		// Application State
-		data:         nil,
-		err:          nil,
-		loading:      true,
-		confirmation: nil,
-	}
+		data:           nil,
+		err:            nil,
+		loading:        true,
+		confirmation:   nil,
+		dispatcher:     NewDispatcher(DefaultRateLimits()),
+		issueTemplates: config.DefaultIssueTemplates,
+		markdown:       &markdownCache{},
+	}.WithContext(context.Background())
 }
 
 // NewModelWithData creates a model with pre-loaded data from data sources
@@ -89,30 +285,19 @@ func NewModelWithData(nodes []graph.Node, edges []graph.Edge, projectPath string
 	// Convert graph nodes to display nodes
 	displayNodes := make([]DisplayNode, len(nodes))
 	for i, node := range nodes {
-		displayNodes[i] = DisplayNode{
-			ID:          node.ID,
-			Type:        node.Type,
-			Title:       node.Title(),
-			Status:      node.Status(),
-			Description: node.Description(),
-			Priority:    node.Priority(),
-			Labels:      node.Labels(),
-		}
+		displayNodes[i] = displayNodeFromGraph(node)
 	}
 
 	// Convert graph edges to display edges
 	displayEdges := make([]DisplayEdge, len(edges))
 	for i, edge := range edges {
-		displayEdges[i] = DisplayEdge{
-			FromID:   edge.FromID,
-			ToID:     edge.ToID,
-			Relation: edge.Relation,
-		}
+		displayEdges[i] = EdgeToDisplayEdge(edge)
 	}
 
 	m.nodes = displayNodes
 	m.edges = displayEdges
 	m.loading = false
+	m.loadedAt = time.Now()
 
 	// Set focus to first node if available
 	if len(displayNodes) > 0 {
@@ -122,6 +307,38 @@ func NewModelWithData(nodes []graph.Node, edges []graph.Edge, projectPath string
 	return m
 }
 
+// displayNodeFromGraph converts a loaded graph node for display
+func displayNodeFromGraph(node graph.Node) DisplayNode {
+	return DisplayNode{
+		ID:          node.ID,
+		Type:        node.Type,
+		Title:       node.Title(),
+		Identifier:  node.Identifier(),
+		URL:         node.URL(),
+		Status:      node.Status(),
+		Description: node.Description(),
+		Priority:    node.Priority(),
+		Labels:      node.Labels(),
+		AccessLevel: node.Metadata.AccessLevel,
+		Review:      reviewFromNode(node),
+		Owner:       node.Owner(),
+		Handles:     node.Handles(),
+		Data:        node.Data,
+		CreatedBy:   handMadeBy(node),
+		CreatedAt:   node.Metadata.CreatedAt,
+		UpdatedAt:   node.Metadata.UpdatedAt,
+	}
+}
+
+// displayNodesFromGraph converts loaded graph nodes for display
+func displayNodesFromGraph(nodes []graph.Node) []DisplayNode {
+	display := make([]DisplayNode, len(nodes))
+	for i, node := range nodes {
+		display[i] = displayNodeFromGraph(node)
+	}
+	return display
+}
+
 // WithSize returns a new Model with updated dimensions
 func (m Model) WithSize(width, height int) Model {
 	m.width = width
@@ -149,14 +366,25 @@ func (m Model) WithError(err error) Model {
 // WithLoading returns a new Model in loading state
 func (m Model) WithLoading(loading bool) Model {
 	m.loading = loading
+	m.loadProgress = LoadProgressMsg{}
+	return m
+}
+
+// WithLoadProgress returns a new Model with the latest per-source load progress.
+func (m Model) WithLoadProgress(progress LoadProgressMsg) Model {
+	m.loadProgress = progress
 	return m
 }
 
-// WithConfirmation returns a new Model with a pending confirmation
+// WithConfirmation returns a new Model with a pending confirmation.
+// The dialog is pushed over the current view and popped once resolved (nil).
 func (m Model) WithConfirmation(req *ConfirmationRequest) Model {
 	m.confirmation = req
-	if req != nil {
-		m.currentView = ViewConfirm
+	switch {
+	case req != nil && m.currentView != ViewConfirm:
+		m = m.PushView(ViewConfirm)
+	case req == nil && m.currentView == ViewConfirm:
+		m = m.PopView()
 	}
 	return m
 }
@@ -204,13 +432,49 @@ func (m Model) WithNodes(nodes []DisplayNode) Model {
 // WithEdges returns a new Model with display edges set.
 func (m Model) WithEdges(edges []DisplayEdge) Model {
 	m.edges = edges
-	return m
+	return m.withFocusSet().withMyWorkSet().withScriptResults().withMetricValues()
+}
+
+// withMerged returns a new Model with nodes and edges added to the graph.
+// Nodes already loaded keep their loaded copy; edges are added once, and only
+// where both ends are loaded.
+func (m Model) withMerged(nodes []DisplayNode, edges []DisplayEdge) Model {
+	present := make(map[string]bool, len(m.nodes)+len(nodes))
+	merged := make([]DisplayNode, 0, len(m.nodes)+len(nodes))
+	for _, node := range m.nodes {
+		present[node.ID] = true
+		merged = append(merged, node)
+	}
+	for _, node := range nodes {
+		if !present[node.ID] {
+			present[node.ID] = true
+			merged = append(merged, node)
+		}
+	}
+
+	type edgeKey struct{ from, to, relation string }
+	seen := make(map[edgeKey]bool, len(m.edges))
+	mergedEdges := make([]DisplayEdge, 0, len(m.edges)+len(edges))
+	for _, edge := range m.edges {
+		seen[edgeKey{edge.FromID, edge.ToID, string(edge.Relation)}] = true
+		mergedEdges = append(mergedEdges, edge)
+	}
+	for _, edge := range edges {
+		key := edgeKey{edge.FromID, edge.ToID, string(edge.Relation)}
+		if present[edge.FromID] && present[edge.ToID] && !seen[key] {
+			seen[key] = true
+			mergedEdges = append(mergedEdges, edge)
+		}
+	}
+	return m.WithNodes(merged).WithEdges(mergedEdges)
 }
 
 // WithFocusedNode returns a new Model with the focused node set.
 func (m Model) WithFocusedNode(nodeID string) Model {
 	m.focusedNode = nodeID
 	m.selectedRelIdx = 0 // Reset relation selection when focus changes
+	m.relationsScroll = 0
+	m.relationsFilter = ""
 	return m
 }
 
@@ -273,6 +537,9 @@ func (m Model) GetStatusFilter() StatusFilter {
 // GetFilteredNodes returns nodes filtered by the current filter mode, status filter, and search query.
 func (m Model) GetFilteredNodes() []DisplayNode {
 	allowedTypes := m.filterMode.Types()
+	if m.execMode || m.needsReview || m.myWork || m.focusesPeople() {
+		allowedTypes = nil // Exec mode, the review/my-work filters and people focus fix the node types themselves
+	}
 
 	// Build type filter set
 	var typeSet map[string]bool
@@ -286,16 +553,54 @@ func (m Model) GetFilteredNodes() []DisplayNode {
 	// Normalize search query for case-insensitive matching
 	searchLower := strings.ToLower(m.searchQuery)
 
-	filtered := make([]DisplayNode, 0)
+	filtered := make([]DisplayNode, 0, len(m.nodes))
 	for _, node := range m.nodes {
 		// Apply type filter
 		if typeSet != nil && !typeSet[string(node.Type)] {
 			continue
 		}
 
-		// Apply status filter (for nodes that have status - issues, PRs)
+		// Apply role visibility and exec-mode aggregation level
+		if !m.role.CanSee(node.AccessLevel) {
+			continue
+		}
+		if m.execMode && !isRollupType(node.Type) {
+			continue
+		}
+
+		// Apply "needs my review" filter
+		if m.needsReview && !needsReviewFrom(node, m.viewer) {
+			continue
+		}
+
+		// Apply my-work filter
+		if m.myWork && !m.myWorkSet[node.ID] {
+			continue
+		}
+
+		// Apply owner filter (nodes without an inferred owner are unaffected)
+		if m.ownerFilter != "" && node.Owner != "" && node.Owner != m.ownerFilter {
+			continue
+		}
+
+		// Apply focus mode subtree isolation
+		if m.focusSet != nil && !m.focusSet[node.ID] {
+			continue
+		}
+
+		// Apply the user's script filter
+		if m.scriptHidden(node.ID) {
+			continue
+		}
+
+		// Apply saved query ID restriction (SQL-backed smart views)
+		if m.idFilter != nil && !m.idFilter[node.ID] {
+			continue
+		}
+
+		// Apply status filter (for nodes that have status - issues, PRs, tasks)
 		// Projects are always shown as parents, even if their children are filtered
-		if node.Type == graph.NodeTypeIssue || node.Type == graph.NodeTypePR {
+		if node.Type == graph.NodeTypeIssue || node.Type == graph.NodeTypePR || node.Type == graph.NodeTypeTask {
 			if !m.statusFilter.MatchesStatus(node.Status) {
 				continue
 			}
@@ -316,19 +621,36 @@ func (m Model) GetFilteredNodes() []DisplayNode {
 
 // GetFilteredEdges returns edges that connect filtered nodes.
 func (m Model) GetFilteredEdges() []DisplayEdge {
+	_, edges := m.filteredGraph()
+	return edges
+}
+
+// filteredGraph returns the filtered nodes and the edges between them in a
+// single filtering pass (render and navigation need both on every keystroke).
+func (m Model) filteredGraph() ([]DisplayNode, []DisplayEdge) {
 	filteredNodes := m.GetFilteredNodes()
-	nodeSet := make(map[string]bool)
+	nodeSet := make(map[string]bool, len(filteredNodes))
 	for _, node := range filteredNodes {
 		nodeSet[node.ID] = true
 	}
 
-	filtered := make([]DisplayEdge, 0)
+	filtered := make([]DisplayEdge, 0, len(m.edges))
 	for _, edge := range m.edges {
 		if nodeSet[edge.FromID] && nodeSet[edge.ToID] {
 			filtered = append(filtered, edge)
 		}
 	}
-	return filtered
+	return filteredNodes, filtered
+}
+
+// filteredNodeSet returns the IDs of the filtered nodes for membership checks.
+func (m Model) filteredNodeSet() map[string]bool {
+	filteredNodes := m.GetFilteredNodes()
+	nodeSet := make(map[string]bool, len(filteredNodes))
+	for _, node := range filteredNodes {
+		nodeSet[node.ID] = true
+	}
+	return nodeSet
 }
 
 // GetFilterMode returns the current filter mode.
@@ -336,21 +658,85 @@ func (m Model) GetFilterMode() FilterMode {
 	return m.filterMode
 }
 
-// WithSelectedRelIdx returns a new Model with updated relation selection index.
+// WithSelectedRelIdx returns a new Model with updated relation selection
+// index, scrolling the Relations list to keep the selection in view.
 func (m Model) WithSelectedRelIdx(idx int) Model {
 	m.selectedRelIdx = idx
+
+	line := m.relationLine(idx)
+	rows := m.relationsListRows()
+	if line < m.relationsScroll {
+		m.relationsScroll = line
+	} else if line >= m.relationsScroll+rows {
+		m.relationsScroll = line - rows + 1
+	}
+	if idx == 0 {
+		m.relationsScroll = 0 // Show the group heading above the first relation
+	}
 	return m
 }
 
-// GetRelationsList returns the list of relations for the focused node.
-// Returns a slice of (targetNodeID, relationName, isOutgoing) tuples.
+// relationsListRows is how many lines of the Relations list fit on screen
+// below its heading and above the statistics footer.
+func (m Model) relationsListRows() int {
+	return clampMin(m.height-14, 3)
+}
+
+// relationLine returns the line of the Relations list holding the relation
+// at idx, counting the group headings: outgoing relations come first under
+// a heading and a blank line, then a blank line and the incoming group.
+func (m Model) relationLine(idx int) int {
+	outgoing := 0
+	for _, rel := range m.GetRelationsList() {
+		if rel.IsOutgoing {
+			outgoing++
+		}
+	}
+	if idx < outgoing {
+		return 2 + idx
+	}
+	line := 2 + idx - outgoing
+	if outgoing > 0 {
+		line += outgoing + 3
+	}
+	return line
+}
+
+// pageRelations moves the Relations selection by a screenful (negative: up),
+// stopping at either end.
+func (m Model) pageRelations(direction int) Model {
+	count := len(m.GetRelationsList())
+	if count == 0 {
+		return m
+	}
+	idx := m.selectedRelIdx + direction*clampMin(m.relationsListRows()-2, 1)
+	return m.WithSelectedRelIdx(max(min(idx, count-1), 0))
+}
+
+// GetRelationsList returns the relations of the focused node that pass the
+// Relations filter.
 func (m Model) GetRelationsList() []RelationItem {
+	if m.relationsFilter == "" {
+		return m.allRelations()
+	}
+	var relations []RelationItem
+	for _, rel := range m.allRelations() {
+		if m.matchesRelationsFilter(rel) {
+			relations = append(relations, rel)
+		}
+	}
+	return relations
+}
+
+// allRelations returns every relation of the focused node, outgoing first.
+func (m Model) allRelations() []RelationItem {
 	node, ok := m.GetFocusedNode()
 	if !ok {
 		return nil
 	}
 
 	var relations []RelationItem
+	projects := projectIndex(m.nodes, m.edges)
 
 	// Outgoing edges first
 	for _, edge := range m.edges {
@@ -360,8 +746,11 @@ func (m Model) GetRelationsList() []RelationItem {
 					NodeID:     edge.ToID,
 					NodeTitle:  targetNode.Title,
 					NodeType:   targetNode.Type,
+					NodeStatus: targetNode.Status,
+					Project:    projects[edge.ToID],
 					Relation:   string(edge.Relation),
 					IsOutgoing: true,
+					Edge:       edge,
 				})
 			}
 		}
@@ -375,23 +764,70 @@ func (m Model) GetRelationsList() []RelationItem {
 					NodeID:     edge.FromID,
 					NodeTitle:  sourceNode.Title,
 					NodeType:   sourceNode.Type,
+					NodeStatus: sourceNode.Status,
+					Project:    projects[edge.FromID],
 					Relation:   string(edge.Relation),
 					IsOutgoing: false,
+					Edge:       edge,
 				})
 			}
 		}
 	}
 
+	// Sort each direction on its own; the list shows outgoing first
+	outgoing := 0
+	for outgoing < len(relations) && relations[outgoing].IsOutgoing {
+		outgoing++
+	}
+	m.sortRelations(relations[:outgoing])
+	m.sortRelations(relations[outgoing:])
 	return relations
 }
 
+// sortRelations orders relations by the Relations sort key, then by target
+// title, so the order no longer depends on which source loaded an edge first.
+func (m Model) sortRelations(relations []RelationItem) {
+	sort.SliceStable(relations, func(i, j int) bool {
+		a, b := relations[i], relations[j]
+		switch m.relationsSort {
+		case RelationSortRelation:
+			if a.Relation != b.Relation {
+				return a.Relation < b.Relation
+			}
+		case RelationSortType:
+			if a.NodeType != b.NodeType {
+				return a.NodeType < b.NodeType
+			}
+		case RelationSortStatus:
+			if ra, rb := styles.CategoryOf(a.NodeStatus).Rank(), styles.CategoryOf(b.NodeStatus).Rank(); ra != rb {
+				return ra < rb
+			}
+		case RelationSortRecent:
+			if !a.Edge.CreatedAt.Equal(b.Edge.CreatedAt) {
+				return a.Edge.CreatedAt.After(b.Edge.CreatedAt)
+			}
+		}
+		return strings.ToLower(a.NodeTitle) < strings.ToLower(b.NodeTitle)
+	})
+}
+
+// WithRelationsSort returns a new Model with the Relations list sorted by
+// order, selection back at the top.
+func (m Model) WithRelationsSort(order RelationSort) Model {
+	m.relationsSort = order
+	return m.WithSelectedRelIdx(0)
+}
+
 // RelationItem represents a single relation in the relations list.
 type RelationItem struct {
 	NodeID     string
 	NodeTitle  string
 	NodeType   graph.NodeType
+	NodeStatus string
+	Project    string // Project the target belongs to ("" = none)
 	Relation   string
 	IsOutgoing bool
+	Edge       DisplayEdge // The edge behind the relation
 }
 
 // moveRelationUp moves the selection up in the Relations view.
@@ -463,6 +899,43 @@ func (m Model) GetGraphScroll() int {
 	return m.graphScroll
 }
 
+// WithMaxDepth returns a new Model rendering at most depth tree levels (0 = unlimited).
+// If the focused node ends up hidden, focus moves to its nearest visible ancestor.
+func (m Model) WithMaxDepth(depth int) Model {
+	if depth < 0 {
+		depth = 0
+	}
+	m.maxDepth = depth
+	m.graphScroll = 0
+
+	tree := m.buildTree(m.filteredGraph())
+	visible := make(map[string]bool)
+	for _, id := range flattenTreeWithCollapse(tree, m) {
+		visible[id] = true
+	}
+
+	nodeID := m.focusedNode
+	for nodeID != "" && !visible[nodeID] {
+		parent := ""
+		for _, edge := range m.edges {
+			if edge.ToID == nodeID && isHierarchicalEdge(edge.Relation) {
+				parent = edge.FromID
+				break
+			}
+		}
+		nodeID = parent
+	}
+	if nodeID != "" && nodeID != m.focusedNode {
+		m = m.WithFocusedNode(nodeID)
+	}
+	return m
+}
+
+// GetMaxDepth returns the current tree depth limit (0 = unlimited).
+func (m Model) GetMaxDepth() int {
+	return m.maxDepth
+}
+
 // WithSearchMode returns a new Model with search mode enabled/disabled.
 func (m Model) WithSearchMode(enabled bool) Model {
 	m.searchMode = enabled
@@ -488,6 +961,56 @@ func (m Model) GetSearchQuery() string {
 	return m.searchQuery
 }
 
+// WithStore returns a new Model backed by the given graph store.
+func (m Model) WithStore(store graph.GraphStore) Model {
+	m.store = store
+	return m
+}
+
+// WithStatus returns a new Model with a transient status bar message.
+func (m Model) WithStatus(message string, isError bool) Model {
+	m.statusMsg = message
+	m.statusIsError = isError
+	return m
+}
+
+// WithSQLMode returns a new Model with the SQL prompt enabled/disabled.
+func (m Model) WithSQLMode(enabled bool) Model {
+	m.sqlMode = enabled
+	if !enabled {
+		m.sqlQuery = ""
+	}
+	return m
+}
+
+// WithSQLQuery returns a new Model with updated SQL query text.
+func (m Model) WithSQLQuery(query string) Model {
+	m.sqlQuery = query
+	return m
+}
+
+// WithSQLResult returns a new Model showing the given SQL result.
+func (m Model) WithSQLResult(query string, result *graph.QueryResult) Model {
+	m.sqlQuery = query
+	m.sqlResult = result
+	m.sqlScroll = 0
+	return m
+}
+
+// WithSQLScroll returns a new Model with updated SQL results scroll offset.
+func (m Model) WithSQLScroll(offset int) Model {
+	if m.sqlResult == nil || offset < 0 {
+		offset = 0
+	} else if offset > len(m.sqlResult.Rows)-1 {
+		offset = len(m.sqlResult.Rows) - 1
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	m.sqlScroll = offset
+	return m
+}
+
 // ToggleCollapse toggles the collapsed state of a node
 func (m Model) ToggleCollapse(nodeID string) Model {
 	// Create a new map to maintain immutability
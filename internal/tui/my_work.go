@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// myWorkCommitWindow is how far back "my recent commits" reaches
+const myWorkCommitWindow = 14 * 24 * time.Hour
+
+// ViewerPerson resolves the configured viewer identity (--me) to a Person
+// node by ID, name, alias, GitHub login, Linear name or email.
+func (m Model) ViewerPerson() (DisplayNode, bool) {
+	viewer := strings.ToLower(m.viewer)
+	if viewer == "" {
+		return DisplayNode{}, false
+	}
+	for _, node := range m.nodes {
+		if node.Type != graph.NodeTypePerson {
+			continue
+		}
+		if node.ID == graph.PersonID(viewer) {
+			return node, true
+		}
+		for _, handle := range node.Handles {
+			if handle == viewer {
+				return node, true
+			}
+		}
+	}
+	return DisplayNode{}, false
+}
+
+// WithMyWork returns a new Model showing only the viewer's work: issues
+// assigned to them, PRs they authored or must review, and their recent commits.
+func (m Model) WithMyWork(enabled bool) Model {
+	if enabled {
+		if _, ok := m.ViewerPerson(); !ok {
+			return m.WithStatus("Set --me (or \"me\" in config) to a name, login or email seen in the graph", true)
+		}
+	}
+	m.myWork = enabled
+	m.graphScroll = 0
+	return m.withMyWorkSet().refocusFiltered()
+}
+
+// IsMyWork reports whether the my-work filter is on.
+func (m Model) IsMyWork() bool {
+	return m.myWork
+}
+
+// withMyWorkSet recomputes the node IDs the my-work filter shows.
+// Called whenever the filter is toggled or the underlying graph changes.
+func (m Model) withMyWorkSet() Model {
+	me, ok := m.ViewerPerson()
+	if !m.myWork || !ok {
+		m.myWorkSet = nil
+		return m
+	}
+
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+	since := time.Now().Add(-myWorkCommitWindow)
+
+	set := make(map[string]bool)
+	for _, edge := range m.edges {
+		switch {
+		case edge.Relation == graph.EdgeAssignedTo && edge.ToID == me.ID:
+			// Assigned issues, and PRs awaiting my review
+			work := nodeByID[edge.FromID]
+			if work.Type == graph.NodeTypeIssue || (work.Type == graph.NodeTypePR && StatusNotDone.MatchesStatus(work.Status)) {
+				set[work.ID] = true
+			}
+		case edge.Relation == graph.EdgeAuthored && edge.FromID == me.ID:
+			work := nodeByID[edge.ToID]
+			if work.Type == graph.NodeTypePR || (work.Type == graph.NodeTypeCommit && work.UpdatedAt.After(since)) {
+				set[work.ID] = true
+			}
+		}
+	}
+	m.myWorkSet = set
+	return m
+}
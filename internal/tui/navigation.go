@@ -27,8 +27,9 @@ func (m Model) moveLeft() Model {
 	parents := getParentNodes(m.focusedNode, m.GetFilteredEdges())
 	if len(parents) > 0 {
 		// Check if parent is in filtered set
+		inFilter := m.filteredNodeSet()
 		for _, parentID := range parents {
-			if m.isNodeInFilter(parentID) {
+			if inFilter[parentID] {
 				return m.WithFocusedNode(parentID)
 			}
 		}
@@ -48,8 +49,9 @@ func (m Model) moveRight() Model {
 	children := getChildNodes(m.focusedNode, m.GetFilteredEdges())
 	if len(children) > 0 {
 		// Check if child is in filtered set
+		inFilter := m.filteredNodeSet()
 		for _, childID := range children {
-			if m.isNodeInFilter(childID) {
+			if inFilter[childID] {
 				return m.WithFocusedNode(childID)
 			}
 		}
@@ -61,13 +63,13 @@ func (m Model) moveRight() Model {
 
 // moveUp implements k key - navigate to previous node in tree order.
 func (m Model) moveUp() Model {
-	filteredNodes := m.GetFilteredNodes()
+	filteredNodes, filteredEdges := m.filteredGraph()
 	if len(filteredNodes) == 0 || m.focusedNode == "" {
 		return m
 	}
 
 	// Build tree and get flattened list
-	tree := buildTree(filteredNodes, m.GetFilteredEdges())
+	tree := m.buildTree(filteredNodes, filteredEdges)
 	flatList := flattenTreeWithCollapse(tree, m)
 
 	// Find current index and move up
@@ -97,13 +99,13 @@ func (m Model) moveUp() Model {
 
 // moveDown implements j key - navigate to next node in tree order.
 func (m Model) moveDown() Model {
-	filteredNodes := m.GetFilteredNodes()
+	filteredNodes, filteredEdges := m.filteredGraph()
 	if len(filteredNodes) == 0 || m.focusedNode == "" {
 		return m
 	}
 
 	// Build tree and get flattened list
-	tree := buildTree(filteredNodes, m.GetFilteredEdges())
+	tree := m.buildTree(filteredNodes, filteredEdges)
 	flatList := flattenTreeWithCollapse(tree, m)
 
 	// Find current index and move down
@@ -164,16 +166,6 @@ func flattenTree(tree TreeStructure) []string {
 	return result
 }
 
-// isNodeInFilter checks if a node ID is in the current filtered set
-func (m Model) isNodeInFilter(nodeID string) bool {
-	for _, node := range m.GetFilteredNodes() {
-		if node.ID == nodeID {
-			return true
-		}
-	}
-	return false
-}
-
 // getParentNodes returns all parent nodes (nodes with edges pointing TO this node).
 // Used for h key (move left) to follow parent relationships.
 func getParentNodes(nodeID string, edges []DisplayEdge) []string {
@@ -206,13 +198,25 @@ func getChildNodes(nodeID string, edges []DisplayEdge) []string {
 	return children
 }
 
-// flattenTreeWithCollapse returns visible node IDs respecting collapsed state
+// flattenTreeWithCollapse returns visible node IDs respecting collapsed state,
+// the max depth setting and sibling pagination ("show more" pseudo-rows included)
 func flattenTreeWithCollapse(tree TreeStructure, m Model) []string {
 	result := make([]string, 0, len(tree.Nodes))
 	visited := make(map[string]bool)
 
-	var visit func(nodeID string)
-	visit = func(nodeID string) {
+	// hide marks a subtree below the depth limit so it isn't listed as an orphan
+	var hide func(nodeID string)
+	hide = func(nodeID string) {
+		for _, childID := range tree.Children[nodeID] {
+			if !visited[childID] {
+				visited[childID] = true
+				hide(childID)
+			}
+		}
+	}
+
+	var visit func(nodeID string, depth int)
+	visit = func(nodeID string, depth int) {
 		if visited[nodeID] {
 			return
 		}
@@ -221,15 +225,31 @@ func flattenTreeWithCollapse(tree TreeStructure, m Model) []string {
 
 		// Only visit children if not collapsed
 		if !m.IsCollapsed(nodeID) {
-			for _, childID := range tree.Children[nodeID] {
-				visit(childID)
+			if m.maxDepth > 0 && depth >= m.maxDepth {
+				hide(nodeID)
+				return
+			}
+			children := tree.Children[nodeID]
+			shown := m.visibleChildCount(nodeID, len(children))
+			for _, childID := range children[:shown] {
+				visit(childID, depth+1)
+			}
+			if shown < len(children) {
+				// Later siblings stay hidden behind the "show more" pseudo-row
+				result = append(result, moreRowID(nodeID))
+				for _, childID := range children[shown:] {
+					if !visited[childID] {
+						visited[childID] = true
+						hide(childID)
+					}
+				}
 			}
 		}
 	}
 
 	// Visit all roots
 	for _, rootID := range tree.Roots {
-		visit(rootID)
+		visit(rootID, 1)
 	}
 
 	// Add any unvisited nodes (orphans not in tree)
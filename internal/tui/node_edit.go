@@ -0,0 +1,231 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// nodeEditor edits a node's title and description in Details, or drafts a
+// new issue. Hand-made nodes are saved to the store; Linear issues are
+// written back to Linear (write_back.go). Other nodes loaded from a source
+// are not editable: the next load would undo it.
+type nodeEditor struct {
+	nodeID      string
+	title       textinput.Model
+	description textarea.Model
+	onDesc      bool        // Description has focus (Tab switches)
+	draft       *issueDraft // Set when creating an issue (nodeID is then "")
+}
+
+// editable reports whether a node was created by hand and so may be edited here
+func editable(node DisplayNode) bool {
+	return node.CreatedBy != ""
+}
+
+// startEditing opens the editor on the focused node.
+func (m Model) startEditing() (tea.Model, tea.Cmd) {
+	node, ok := m.GetFocusedNode()
+	if !ok {
+		return m, nil
+	}
+	if !editable(node) && !m.writesBack(node) {
+		return m.WithStatus("Only hand-made notes and tasks, and Linear issues, can be edited; this node comes from a source", true), nil
+	}
+
+	// Same width as the details box it replaces; a steady cursor needs no
+	// blink messages routed through Update
+	width := clampMin(min(m.width-4, 80)-4, 10)
+	title := textinput.New()
+	title.Prompt = ""
+	title.CharLimit = 200
+	title.Width = width
+	title.Cursor.SetMode(cursor.CursorStatic)
+	title.SetValue(node.Title)
+	title.Focus()
+
+	description := textarea.New()
+	description.ShowLineNumbers = false
+	description.SetWidth(width)
+	description.SetHeight(8)
+	description.Cursor.SetMode(cursor.CursorStatic)
+	description.SetValue(node.Description)
+	description.Blur()
+
+	m.editor = &nodeEditor{nodeID: node.ID, title: title, description: description}
+	return m, nil
+}
+
+// handleEditorInput processes keys while the inline editor is open
+func (m Model) handleEditorInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	editor := *m.editor
+	switch msg.String() {
+	case "esc":
+		// A new issue goes back to the template menu
+		cancelled := "Edit cancelled"
+		if editor.draft != nil {
+			cancelled = "New issue discarded"
+		}
+		m.editor = nil
+		return m.WithStatus(cancelled, false), nil
+	case "ctrl+s":
+		return m.requestEditSave()
+	case "ctrl+c":
+		return m.quit()
+	case "tab", "shift+tab":
+		editor.onDesc = !editor.onDesc
+		if editor.onDesc {
+			editor.title.Blur()
+			editor.description.Focus()
+		} else {
+			editor.description.Blur()
+			editor.title.Focus()
+		}
+		m.editor = &editor
+		return m, nil
+	case "enter":
+		if !editor.onDesc {
+			// A title is one line: Enter moves on to the description
+			editor.onDesc = true
+			editor.title.Blur()
+			editor.description.Focus()
+			m.editor = &editor
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	if editor.onDesc {
+		editor.description, cmd = editor.description.Update(msg)
+	} else {
+		editor.title, cmd = editor.title.Update(msg)
+	}
+	m.editor = &editor
+	return m, cmd
+}
+
+// requestEditSave asks to write the edited fields to the store, or back to
+// Linear for an issue synced from there
+func (m Model) requestEditSave() (tea.Model, tea.Cmd) {
+	if m.editor.draft != nil {
+		return m.requestIssueCreate()
+	}
+	title := strings.TrimSpace(m.editor.title.Value())
+	if title == "" {
+		return m.WithStatus("A title is required", true), nil
+	}
+	if node, ok := m.GetNodeByID(m.editor.nodeID); ok && !editable(node) {
+		description := m.editor.description.Value()
+		m.editor = nil
+		return m.requestWriteBack(node, title, description)
+	}
+	if m.store == nil {
+		return m.WithStatus("No graph store available to save edits to", true), nil
+	}
+	description := m.editor.description.Value()
+	nodeID := m.editor.nodeID
+	m.editor = nil
+
+	// Shown as saved while the store write runs
+	var edited *DisplayNode
+	if node, ok := m.GetNodeByID(nodeID); ok {
+		node.Title, node.Description = title, description
+		edited = &node
+	}
+	return m.Update(ConfirmationRequested{
+		Action:     fmt.Sprintf("Save edits to %q", title),
+		Execute:    saveNodeEdit(m.store, nodeID, title, description),
+		Done:       NodeEditedMsg{NodeID: nodeID, Title: title, Description: description},
+		Category:   CategoryEdit,
+		Optimistic: edited,
+	})
+}
+
+// saveNodeEdit writes a new title and description into the stored node
+func saveNodeEdit(store graph.GraphStore, nodeID, title, description string) func() error {
+	return func() error {
+		node, err := store.GetNode(nodeID)
+		if err != nil {
+			return err
+		}
+		if node.Data, err = patchNodeData(node.Data, title, description); err != nil {
+			return fmt.Errorf("editing %s: %w", nodeID, err)
+		}
+		if err := store.UpsertNode(*node); err != nil {
+			return err
+		}
+		return graph.Flush(store)
+	}
+}
+
+// patchNodeData sets the title and description in a node's data, keeping
+// every other field as it was
+func patchNodeData(data json.RawMessage, title, description string) (json.RawMessage, error) {
+	fields := make(map[string]interface{})
+	if len(data) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&fields); err != nil {
+			return nil, err
+		}
+	}
+	fields[titleKey(fields)] = title
+	fields["description"] = description
+	return json.Marshal(fields)
+}
+
+// titleKey is the data field Node.Title reads the title from: "name" for
+// nodes titled by name, "title" otherwise
+func titleKey(fields map[string]interface{}) string {
+	if _, ok := fields["title"]; !ok {
+		if _, ok := fields["name"]; ok {
+			return "name"
+		}
+	}
+	return "title"
+}
+
+// withNodeEdited applies a saved edit to the displayed node.
+func (m Model) withNodeEdited(msg NodeEditedMsg) Model {
+	nodes := make([]DisplayNode, len(m.nodes))
+	copy(nodes, m.nodes)
+	for i := range nodes {
+		if nodes[i].ID == msg.NodeID {
+			nodes[i].Title = msg.Title
+			nodes[i].Description = msg.Description
+			if data, err := patchNodeData(nodes[i].Data, msg.Title, msg.Description); err == nil {
+				nodes[i].Data = data
+			}
+		}
+	}
+	return m.WithNodes(nodes)
+}
+
+// renderEditor renders the inline editor in place of the node's details
+func (m Model) renderEditor(width int) string {
+	editor := m.editor
+	labelStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	activeStyle := lipgloss.NewStyle().Foreground(styles.Accent).Bold(true)
+	titleLabel, descLabel := activeStyle.Render("Title"), labelStyle.Render("Description")
+	if editor.onDesc {
+		titleLabel, descLabel = labelStyle.Render("Title"), activeStyle.Render("Description")
+	}
+
+	lines := []string{
+		titleLabel,
+		editor.title.View(),
+		"",
+		descLabel,
+		editor.description.View(),
+	}
+	return styles.PaneContentStyle.Width(width).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pendingMarker follows a node whose write is still running
+const pendingMarker = "⟳ pending"
+
+// pendingWrite is a node shown as a running write will leave it, with the
+// copy to restore if the write fails
+type pendingWrite struct {
+	before DisplayNode
+	writes int // Writes to the node still running
+}
+
+// WriteSettledMsg is sent when a write shown optimistically finishes
+type WriteSettledMsg struct {
+	NodeID string
+	Err    error   // nil = the write went through
+	Done   tea.Msg // The write's own completion message, applied on success
+}
+
+// IsPending reports whether a node shows a write that has not finished
+func (m Model) IsPending(nodeID string) bool {
+	_, ok := m.pending[nodeID]
+	return ok
+}
+
+// runOptimistic shows a confirmed write's result at once, marked pending,
+// and runs the write; WriteSettledMsg keeps or rolls back what it showed.
+func (m Model) runOptimistic(req ConfirmationRequest) (Model, tea.Cmd) {
+	after := *req.Optimistic
+	pending := make(map[string]pendingWrite, len(m.pending)+1)
+	for k, v := range m.pending {
+		pending[k] = v
+	}
+	write, ok := pending[after.ID]
+	if !ok {
+		before, found := m.GetNodeByID(after.ID)
+		if !found {
+			// Nothing to show it on; run it the ordinary way
+			return m, m.writes.track(m.dispatcher.Dispatch(context.Background(), executeConfirmedAction(req.Execute, req.Done), req.providers()...))
+		}
+		write.before = before
+	}
+	write.writes++
+	pending[after.ID] = write
+	m.pending = pending
+
+	m = m.withNodeReplaced(after)
+	return m, m.writes.track(m.dispatcher.Dispatch(context.Background(), executeOptimistic(after.ID, req.Execute, req.Done), req.providers()...))
+}
+
+// executeOptimistic runs a write already shown in the model
+func executeOptimistic(nodeID string, action func() error, done tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return WriteSettledMsg{NodeID: nodeID, Err: action(), Done: done}
+	}
+}
+
+// withWriteSettled reconciles a finished optimistic write: on success the
+// pending mark goes and the write's own message applies; on failure the
+// node goes back to how it was before its first pending write.
+func (m Model) withWriteSettled(msg WriteSettledMsg) (tea.Model, tea.Cmd) {
+	write, ok := m.pending[msg.NodeID]
+	pending := make(map[string]pendingWrite, len(m.pending))
+	for k, v := range m.pending {
+		pending[k] = v
+	}
+	if write.writes--; write.writes > 0 && msg.Err == nil {
+		pending[msg.NodeID] = write
+	} else {
+		delete(pending, msg.NodeID)
+	}
+	m.pending = pending
+
+	if msg.Err != nil {
+		name := msg.NodeID
+		if ok {
+			m = m.withNodeReplaced(write.before)
+			name = write.before.Title
+		}
+		return m.WithStatus(fmt.Sprintf("Rolled back %s: %v", name, msg.Err), true), nil
+	}
+	if msg.Done != nil {
+		return m.Update(msg.Done)
+	}
+	return m.WithStatus("Saved", false), nil
+}
+
+// withNodeReplaced returns a new Model with one node swapped for a new copy
+func (m Model) withNodeReplaced(node DisplayNode) Model {
+	nodes := make([]DisplayNode, len(m.nodes))
+	copy(nodes, m.nodes)
+	for i := range nodes {
+		if nodes[i].ID == node.ID {
+			nodes[i] = node
+		}
+	}
+	return m.WithNodes(nodes)
+}
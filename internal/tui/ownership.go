@@ -0,0 +1,59 @@
+package tui
+
+import "sort"
+
+// Owners returns the distinct inferred owners of files and directories,
+// most files owned first.
+func (m Model) Owners() []string {
+	counts := make(map[string]int)
+	for _, node := range m.nodes {
+		if node.Owner != "" {
+			counts[node.Owner]++
+		}
+	}
+	owners := make([]string, 0, len(counts))
+	for owner := range counts {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if counts[owners[i]] != counts[owners[j]] {
+			return counts[owners[i]] > counts[owners[j]]
+		}
+		return owners[i] < owners[j]
+	})
+	return owners
+}
+
+// nextOwner returns the owner after the current filter in Owners() order,
+// wrapping back to "" (all owners) after the last one.
+func (m Model) nextOwner() string {
+	owners := m.Owners()
+	if m.ownerFilter == "" {
+		if len(owners) == 0 {
+			return ""
+		}
+		return owners[0]
+	}
+	for i, owner := range owners {
+		if owner == m.ownerFilter && i+1 < len(owners) {
+			return owners[i+1]
+		}
+	}
+	return ""
+}
+
+// WithOwnerFilter returns a new Model showing only files and directories
+// owned by owner ("" shows all). Nodes without an owner are unaffected.
+func (m Model) WithOwnerFilter(owner string) Model {
+	if owner == "" && m.ownerFilter == "" {
+		return m.WithStatus("No inferred owners (needs git history)", true)
+	}
+	m.ownerFilter = owner
+	m.graphScroll = 0
+	return m.refocusFiltered()
+}
+
+// GetOwnerFilter returns the current owner filter ("" = all).
+func (m Model) GetOwnerFilter() string {
+	return m.ownerFilter
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// pager is a scrollable block of pre-highlighted lines about one node: the
+// Diff and Preview views.
+type pager struct {
+	nodeID   string
+	lines    []string
+	scroll   int
+	numbered bool // Show a line number gutter (file previews)
+}
+
+// WithPager returns a new Model paging through lines about a node, from the top.
+func (m Model) WithPager(nodeID string, lines []string, numbered bool) Model {
+	m.pager = pager{nodeID: nodeID, lines: lines, numbered: numbered}
+	return m
+}
+
+// WithPagerScroll returns a new Model with the pager scrolled, clamped to its length.
+func (m Model) WithPagerScroll(offset int) Model {
+	m.pager.scroll = max(min(offset, len(m.pager.lines)-1), 0)
+	return m
+}
+
+// handlePagerKeys processes keys in the Diff and Preview views. closeKey
+// (the key that opened the view) closes it like Esc.
+func (m Model) handlePagerKeys(msg tea.KeyMsg, closeKey string) (tea.Model, tea.Cmd) {
+	page := clampMin(m.height-6, 1)
+	switch key := msg.String(); key {
+	case "j", "down":
+		return m.WithPagerScroll(m.pager.scroll + 1), nil
+	case "k", "up":
+		return m.WithPagerScroll(m.pager.scroll - 1), nil
+	case "ctrl+d", "pgdown":
+		return m.WithPagerScroll(m.pager.scroll + page), nil
+	case "ctrl+u", "pgup":
+		return m.WithPagerScroll(m.pager.scroll - page), nil
+	case "g", "home":
+		return m.WithPagerScroll(0), nil
+	case "G", "end":
+		return m.WithPagerScroll(len(m.pager.lines) - page), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	default:
+		if key == "esc" || key == closeKey {
+			return m.PopView(), nil
+		}
+	}
+	return m, nil
+}
+
+// renderPagerView renders the pager under a heading, scrolled to its offset.
+func (m Model) renderPagerView(heading string, width, height int) string {
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	title := m.pager.nodeID
+	if node, ok := m.GetNodeByID(m.pager.nodeID); ok && node.Title != "" {
+		title = node.Title
+	}
+	lines := []string{headerStyle.Render(heading + ": " + truncate(title, clampMin(width-lipgloss.Width(heading)-4, 10)))}
+
+	if len(m.pager.lines) == 0 {
+		lines = append(lines, "", mutedStyle.Italic(true).Render("Loading…"))
+		return strings.Join(lines, "\n")
+	}
+
+	visible := clampMin(height-2, 1)
+	end := min(m.pager.scroll+visible, len(m.pager.lines))
+	lines = append(lines, mutedStyle.Render(fmt.Sprintf("lines %d-%d of %d", m.pager.scroll+1, end, len(m.pager.lines))))
+
+	// Long lines are clipped rather than wrapped so scrolling stays line by line
+	clip := lipgloss.NewStyle().MaxWidth(width)
+	gutter := len(fmt.Sprint(len(m.pager.lines)))
+	for i, line := range m.pager.lines[m.pager.scroll:end] {
+		line = strings.ReplaceAll(line, "\t", "    ")
+		if m.pager.numbered {
+			line = mutedStyle.Render(fmt.Sprintf("%*d │ ", gutter, m.pager.scroll+i+1)) + line
+		}
+		lines = append(lines, clip.Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
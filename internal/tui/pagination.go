@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"strings"
+)
+
+// siblingPageSize is how many children of one parent are shown per page.
+// Further children sit behind a "show more" pseudo-row so a single huge
+// parent (hundreds of commits under a project) can't swamp the view.
+const siblingPageSize = 50
+
+// moreRowPrefix marks the synthetic ID of a "show more" pseudo-row.
+const moreRowPrefix = "more:"
+
+// moreRowID returns the pseudo-row ID for a parent's hidden children.
+func moreRowID(parentID string) string {
+	return moreRowPrefix + parentID
+}
+
+// parseMoreRowID returns the parent ID if id is a "show more" pseudo-row.
+func parseMoreRowID(id string) (string, bool) {
+	if !strings.HasPrefix(id, moreRowPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(id, moreRowPrefix), true
+}
+
+// visibleChildCount returns how many of a parent's total children are shown.
+func (m Model) visibleChildCount(parentID string, total int) int {
+	limit := siblingPageSize
+	if extra, ok := m.siblingLimit[parentID]; ok {
+		limit = extra
+	}
+	if total < limit {
+		return total
+	}
+	return limit
+}
+
+// WithMoreSiblings returns a new Model showing another page of a parent's
+// children. Focus moves to the first newly revealed child.
+func (m Model) WithMoreSiblings(parentID string) Model {
+	tree := m.buildTree(m.filteredGraph())
+	children := tree.Children[parentID]
+	shown := m.visibleChildCount(parentID, len(children))
+
+	// Create a new map to maintain immutability
+	newLimits := make(map[string]int, len(m.siblingLimit)+1)
+	for k, v := range m.siblingLimit {
+		newLimits[k] = v
+	}
+	newLimits[parentID] = shown + siblingPageSize
+	m.siblingLimit = newLimits
+
+	if shown < len(children) {
+		m = m.WithFocusedNode(children[shown])
+	}
+	return m
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// Involvement is everything a person or team is attached to:
+// work assigned to them, work they wrote, and files they own.
+type Involvement struct {
+	Person   DisplayNode
+	Assigned []DisplayNode // Issues and PRs (review requests), open first
+	Authored []DisplayNode // Commits and PRs, newest first
+	Owned    []DisplayNode // Files and directories (CODEOWNERS)
+}
+
+// IsEmpty reports whether nothing in the graph involves the person.
+func (i Involvement) IsEmpty() bool {
+	return len(i.Assigned) == 0 && len(i.Authored) == 0 && len(i.Owned) == 0
+}
+
+// isPeopleType reports whether a node type is a Person or Team.
+func isPeopleType(t graph.NodeType) bool {
+	return t == graph.NodeTypePerson || t == graph.NodeTypeTeam
+}
+
+// Involvement computes what involves a person or team.
+// Pure function over the model's unfiltered graph.
+func (m Model) Involvement(personID string) Involvement {
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+	inv := Involvement{Person: nodeByID[personID]}
+
+	assigned := map[string]bool{}
+	authored := map[string]bool{}
+	owned := map[string]bool{}
+	for _, edge := range m.edges {
+		switch {
+		case edge.Relation == graph.EdgeAssignedTo && edge.ToID == personID:
+			assigned[edge.FromID] = true
+		case edge.Relation == graph.EdgeAuthored && edge.FromID == personID:
+			authored[edge.ToID] = true
+		case edge.Relation == graph.EdgeOwns && edge.FromID == personID:
+			owned[edge.ToID] = true
+		}
+	}
+
+	inv.Assigned = collectNodes(assigned, nodeByID)
+	inv.Authored = collectNodes(authored, nodeByID)
+	inv.Owned = collectNodes(owned, nodeByID)
+	sortByStatus(inv.Assigned)
+	sortByRecency(inv.Authored)
+	return inv
+}
+
+// focusesPeople reports whether the focus root is a person or team. Their
+// focus view shows every neighbor whatever the type filter says.
+func (m Model) focusesPeople() bool {
+	root := m.FocusRoot()
+	if root == "" {
+		return false
+	}
+	node, ok := m.GetNodeByID(root)
+	return ok && isPeopleType(node.Type)
+}
+
+// renderInvolvementSection renders the involvement section of the Details
+// view for a person or team. Collapsed it shows counts only.
+func (m Model) renderInvolvementSection(personID string, maxWidth int) []string {
+	inv := m.Involvement(personID)
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	summary := fmt.Sprintf("%d assigned · %d authored · %d owned", len(inv.Assigned), len(inv.Authored), len(inv.Owned))
+	if !m.traceExpanded {
+		return []string{
+			headerStyle.Render("👥 Involvement ▸ ") + mutedStyle.Render(summary+"  (t to expand, F in graph to focus)"),
+		}
+	}
+
+	lines := []string{headerStyle.Render("👥 Involvement ▾ ") + mutedStyle.Render(summary+"  (t to collapse)")}
+	if inv.IsEmpty() {
+		return append(lines, mutedStyle.Italic(true).Render("  Nothing in the graph involves them yet."))
+	}
+
+	return append(lines, m.renderTraceGroups([]traceGroup{
+		{"Assigned", inv.Assigned},
+		{"Authored", inv.Authored},
+		{"Owns", inv.Owned},
+	}, maxWidth)...)
+}
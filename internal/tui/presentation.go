@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Presentation mode colors: plain white on the terminal background and a
+// reversed focus row, readable through screen-sharing compression
+var (
+	presentRowStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	presentFocusStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("0")).Background(lipgloss.Color("15"))
+	presentCardStyle  = lipgloss.NewStyle().Border(lipgloss.DoubleBorder()).BorderForeground(lipgloss.Color("15")).Padding(1, 3)
+)
+
+// presentCardMinWidth is the narrowest terminal that puts the card beside
+// the graph rather than above it
+const presentCardMinWidth = 100
+
+// WithPresentation returns a new Model in (or out of) presentation mode: no
+// status bar or hints, high-contrast rows and a large card for the focused
+// node, for sharing the graph in meetings.
+func (m Model) WithPresentation(enabled bool) Model {
+	m.presentation = enabled
+	return m
+}
+
+// renderPresentation renders the graph with the focused node's card beside
+// it, or above it on narrow terminals.
+func (m Model) renderPresentation() string {
+	if m.width >= presentCardMinWidth {
+		cardWidth := m.width * 2 / 5
+		graphView := m.renderGraphView(m.width-cardWidth, m.height)
+		card := m.renderPresentationCard(cardWidth-2, m.height-2)
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(m.width-cardWidth).MaxHeight(m.height).Render(graphView),
+			card)
+	}
+
+	card := m.renderPresentationCard(m.width-2, m.height/3)
+	graphHeight := clampMin(m.height-lipgloss.Height(card), 1)
+	graphView := lipgloss.NewStyle().MaxWidth(m.width).MaxHeight(graphHeight).Render(m.renderGraphView(m.width, graphHeight))
+	return lipgloss.JoinVertical(lipgloss.Left, card, graphView)
+}
+
+// renderPresentationCard renders the focused node large: type, title,
+// status, owner and description inside a bold border.
+func (m Model) renderPresentationCard(width, height int) string {
+	node, ok := m.GetFocusedNode()
+	if !ok {
+		return ""
+	}
+	inner := clampMin(width-presentCardStyle.GetHorizontalFrameSize(), 10)
+
+	title := node.Title
+	if node.Identifier != "" && node.Identifier != node.Title {
+		title = node.Identifier + "  " + title
+	}
+	lines := []string{
+		presentRowStyle.Render(m.typeIcon(node.Type) + "  " + strings.ToUpper(string(node.Type))),
+		"",
+		presentRowStyle.Bold(true).Width(inner).Render(title),
+		"",
+	}
+	for _, name := range []string{"status", "priority", "owner"} {
+		lines = append(lines, m.detailSection(name, node, inner)...)
+	}
+	if description := m.detailSection("description", node, inner); len(description) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, description...)
+	}
+
+	body := strings.Join(lines, "\n")
+	maxBody := clampMin(height-presentCardStyle.GetVerticalFrameSize(), 3)
+	return presentCardStyle.Width(width).Render(lipgloss.NewStyle().MaxHeight(maxBody).Render(body))
+}
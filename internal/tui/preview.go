@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	chromastyles "github.com/alecthomas/chroma/v2/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// maxPreviewLines caps a file kept for previewing
+const maxPreviewLines = 5000
+
+// Previewer reads a node's contents (the data source loader, reading the
+// scanned file or vault note from disk).
+type Previewer interface {
+	Content(ctx context.Context, node graph.Node) ([]byte, error)
+}
+
+// WithPreviews returns a new Model that can preview files (space p, or Enter on
+// a file with nothing under it).
+func (m Model) WithPreviews(previewer Previewer) Model {
+	m.previewer = previewer
+	return m
+}
+
+// previewsFile reports whether Enter on nodeID should preview it rather than
+// open Details: a file with no TODOs or tasks to expand.
+func (m Model) previewsFile(nodeID string) bool {
+	node, ok := m.GetNodeByID(nodeID)
+	return ok && node.Type == graph.NodeTypeFile && m.previewer != nil && !m.HasChildren(nodeID)
+}
+
+// openPreview reads the focused file or document, highlighting code and
+// rendering Markdown.
+func (m Model) openPreview() (tea.Model, tea.Cmd) {
+	node, ok := m.GetFocusedNode()
+	if !ok {
+		return m, nil
+	}
+	if node.Type != graph.NodeTypeFile && node.Type != graph.NodeTypeDocument {
+		return m.WithStatus("Previews are shown for files and documents", true), nil
+	}
+	if m.previewer == nil {
+		return m.WithStatus("Previews need a scanned project directory", true), nil
+	}
+	m = m.WithStatus(fmt.Sprintf("Loading %s…", node.Title), false)
+	return m, loadPreview(m.ctx, m.previewer, node, clampMin(m.width-2, 20), m.renderMarkdown)
+}
+
+// loadPreview reads a file's contents and highlights them, or renders them
+// to width when they are Markdown
+func loadPreview(ctx context.Context, previewer Previewer, node DisplayNode, width int, markdown func(string, int) []string) tea.Cmd {
+	return func() tea.Msg {
+		content, err := previewer.Content(ctx, graph.Node{ID: node.ID, Type: node.Type, Data: node.Data})
+		if err != nil {
+			return StatusMsg{Message: "Preview error: " + err.Error(), IsError: true}
+		}
+		var data struct {
+			Path string `json:"path"`
+		}
+		_ = json.Unmarshal(node.Data, &data)
+		if ext := strings.ToLower(filepath.Ext(data.Path)); ext == ".md" || ext == ".markdown" {
+			return FilePreviewMsg{NodeID: node.ID, Lines: markdown(string(content), width)}
+		}
+		return FilePreviewMsg{NodeID: node.ID, Lines: highlightSource(data.Path, string(content)), Numbered: true}
+	}
+}
+
+// highlightSource colors a file for the terminal, picking the lexer by file
+// name, then by content, then plain text, and splits it into lines.
+func highlightSource(path, source string) []string {
+	source = strings.TrimRight(source, "\n")
+	lines := strings.Split(source, "\n")
+	truncated := len(lines) > maxPreviewLines
+	if truncated {
+		lines = lines[:maxPreviewLines]
+		source = strings.Join(lines, "\n")
+	}
+
+	lexer := lexers.Match(filepath.Base(path))
+	if lexer == nil {
+		lexer = lexers.Analyse(source)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	var out strings.Builder
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, source)
+	if err == nil {
+		err = formatters.Get("terminal256").Format(&out, chromastyles.Get("monokai"), splitTokenLines(iterator))
+	}
+	if err == nil {
+		lines = strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	}
+	if truncated {
+		lines = append(lines, fmt.Sprintf("… file cut at %d lines", maxPreviewLines))
+	}
+	return lines
+}
+
+// splitTokenLines splits multi-line tokens (block comments, raw strings) at
+// newlines so the formatter colors every line, not just the one a token
+// starts on.
+func splitTokenLines(iterator chroma.Iterator) chroma.Iterator {
+	var tokens []chroma.Token
+	for _, line := range chroma.SplitTokensIntoLines(iterator.Tokens()) {
+		tokens = append(tokens, line...)
+	}
+	return chroma.Literator(tokens...)
+}
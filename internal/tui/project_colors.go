@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// projectMarkerGlyph is the colored bar in front of rows that belong to a project
+const projectMarkerGlyph = "▍"
+
+// projectIndex maps nodes to the name of the project they belong to: the
+// project itself, the project named in a node's data, or else the nearest
+// project above it in the tree. Projects are walked by title, so a node
+// under two projects always lands in the same one.
+func projectIndex(nodes []DisplayNode, edges []DisplayEdge) map[string]string {
+	children := make(map[string][]string)
+	for _, edge := range edges {
+		if isHierarchicalEdge(edge.Relation) {
+			children[edge.FromID] = append(children[edge.FromID], edge.ToID)
+		}
+	}
+
+	index := make(map[string]string)
+	var projects []DisplayNode
+	for _, node := range nodes {
+		switch {
+		case node.Type == graph.NodeTypeProject:
+			projects = append(projects, node)
+		case node.Project != "":
+			index[node.ID] = node.Project
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Title < projects[j].Title })
+
+	for _, project := range projects {
+		index[project.ID] = project.Title
+		queue := append([]string(nil), children[project.ID]...)
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			if _, done := index[id]; done {
+				continue
+			}
+			index[id] = project.Title
+			queue = append(queue, children[id]...)
+		}
+	}
+	return index
+}
+
+// WithProjectColors returns a new Model drawing projects in colors
+// (nil = palette colors picked by name).
+func (m Model) WithProjectColors(colors styles.ProjectColors) Model {
+	m.projectColors = colors
+	return m
+}
+
+// projectOf returns the name of the project nodeID belongs to ("" = none)
+func (m Model) projectOf(nodeID string) string {
+	return projectIndex(m.nodes, m.edges)[nodeID]
+}
+
+// projectMarker renders the colored bar for a project, a space outside
+// projects to keep rows aligned, and nothing in accessible mode, where
+// color carries no meaning
+func (m Model) projectMarker(project string) string {
+	if m.accessible {
+		return ""
+	}
+	if project == "" {
+		return " "
+	}
+	return lipgloss.NewStyle().Foreground(m.projectColors.Get(project)).Render(projectMarkerGlyph)
+}
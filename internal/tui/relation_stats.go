@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// RelationStats puts a node's relations in the context of the whole graph.
+type RelationStats struct {
+	Degree       int             // Relations of the node
+	MedianDegree int             // Median relations per loaded node
+	ByRelation   []relationCount // Relation types, most frequent first
+	Hidden       int             // Related nodes the Graph filters hide
+	Unloaded     int             // Edges to nodes that are not loaded
+}
+
+// relationCount is how often one relation type occurs
+type relationCount struct {
+	Relation string
+	Count    int
+}
+
+// RelationStats computes relation statistics for a node over the loaded graph.
+// Pure function over the model's edges and filters.
+func (m Model) RelationStats(nodeID string, relations []RelationItem) RelationStats {
+	stats := RelationStats{Degree: len(relations)}
+
+	// Degree of every loaded node, counting only edges shown as relations
+	degrees := make(map[string]int, len(m.nodes))
+	for _, node := range m.nodes {
+		degrees[node.ID] = 0
+	}
+	for _, edge := range m.edges {
+		_, fromOK := degrees[edge.FromID]
+		_, toOK := degrees[edge.ToID]
+		switch {
+		case fromOK && toOK:
+			degrees[edge.FromID]++
+			degrees[edge.ToID]++
+		case edge.FromID == nodeID || edge.ToID == nodeID:
+			stats.Unloaded++
+		}
+	}
+	if len(degrees) > 0 {
+		all := make([]int, 0, len(degrees))
+		for _, degree := range degrees {
+			all = append(all, degree)
+		}
+		sort.Ints(all)
+		stats.MedianDegree = all[len(all)/2]
+	}
+
+	counts := map[string]int{}
+	visible := m.filteredNodeSet()
+	for _, rel := range relations {
+		counts[rel.Relation]++
+		if !visible[rel.NodeID] {
+			stats.Hidden++
+		}
+	}
+	for relation, count := range counts {
+		stats.ByRelation = append(stats.ByRelation, relationCount{relation, count})
+	}
+	sort.Slice(stats.ByRelation, func(i, j int) bool {
+		if stats.ByRelation[i].Count != stats.ByRelation[j].Count {
+			return stats.ByRelation[i].Count > stats.ByRelation[j].Count
+		}
+		return stats.ByRelation[i].Relation < stats.ByRelation[j].Relation
+	})
+	return stats
+}
+
+// renderRelationStats renders the Relations view footer: totals, how the
+// node's degree compares to the graph, the dominant relation types, and
+// what the filters hide.
+func (m Model) renderRelationStats(nodeID string, relations []RelationItem, maxWidth int) []string {
+	stats := m.RelationStats(nodeID, relations)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	warnStyle := lipgloss.NewStyle().Foreground(styles.StatusInProgress)
+
+	outgoing := 0
+	for _, rel := range relations {
+		if rel.IsOutgoing {
+			outgoing++
+		}
+	}
+	lines := []string{mutedStyle.Render(fmt.Sprintf("Total: %d outgoing, %d incoming | j/k: navigate | Enter: jump to selected",
+		outgoing, len(relations)-outgoing))}
+
+	// Degree against the median: hubs stand out, loners too
+	var comparison string
+	switch {
+	case stats.MedianDegree == 0:
+		comparison = fmt.Sprintf("Degree %d; most nodes have no relations", stats.Degree)
+	case stats.Degree > stats.MedianDegree:
+		comparison = fmt.Sprintf("Degree %d, %.1f× the median of %d", stats.Degree, float64(stats.Degree)/float64(stats.MedianDegree), stats.MedianDegree)
+	case stats.Degree < stats.MedianDegree:
+		comparison = fmt.Sprintf("Degree %d, below the median of %d", stats.Degree, stats.MedianDegree)
+	default:
+		comparison = fmt.Sprintf("Degree %d, the median", stats.Degree)
+	}
+	if len(stats.ByRelation) > 0 {
+		var top []string
+		for _, rc := range stats.ByRelation[:min(len(stats.ByRelation), 3)] {
+			top = append(top, fmt.Sprintf("%s %d", rc.Relation, rc.Count))
+		}
+		comparison += " · mostly " + strings.Join(top, ", ")
+	}
+	lines = append(lines, mutedStyle.Render(truncate(comparison, maxWidth)))
+
+	if stats.Hidden > 0 {
+		lines = append(lines, warnStyle.Render(truncate(fmt.Sprintf(
+			"⚠ %d related nodes are hidden by the Graph filters (type, status, search, focus…)",
+			stats.Hidden), maxWidth)))
+	}
+	if stats.Unloaded > 0 {
+		lines = append(lines, warnStyle.Render(truncate(fmt.Sprintf(
+			"⚠ %d edges point at nodes that are not loaded (archived, or from a source that failed)",
+			stats.Unloaded), maxWidth)))
+	}
+	return lines
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// WithRelationsFilter returns a new Model narrowing the Relations list to
+// relations whose target title or relation type contains filter, with the
+// selection back at the top.
+func (m Model) WithRelationsFilter(filter string) Model {
+	m.relationsFilter = filter
+	return m.WithSelectedRelIdx(0)
+}
+
+// WithRelationsFilterMode returns a new Model with the Relations filter prompt enabled/disabled.
+func (m Model) WithRelationsFilterMode(enabled bool) Model {
+	m.relationsFilterMode = enabled
+	return m
+}
+
+// matchesRelationsFilter reports whether a relation passes the Relations
+// filter (case-insensitive, on the target's title or the relation type)
+func (m Model) matchesRelationsFilter(rel RelationItem) bool {
+	if m.relationsFilter == "" {
+		return true
+	}
+	filter := strings.ToLower(m.relationsFilter)
+	return strings.Contains(strings.ToLower(rel.NodeTitle), filter) ||
+		strings.Contains(strings.ToLower(rel.Relation), filter)
+}
+
+// handleRelationsFilterInput processes input while typing a Relations
+// filter; the list narrows with every key
+func (m Model) handleRelationsFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		// Clear the filter and show every relation again
+		return m.WithRelationsFilterMode(false).WithRelationsFilter(""), nil
+
+	case tea.KeyEnter:
+		// Keep the filter and go back to selecting
+		return m.WithRelationsFilterMode(false), nil
+
+	case tea.KeyBackspace:
+		if len(m.relationsFilter) > 0 {
+			runes := []rune(m.relationsFilter)
+			return m.WithRelationsFilter(string(runes[:len(runes)-1])), nil
+		}
+		return m, nil
+
+	case tea.KeySpace:
+		return m.WithRelationsFilter(m.relationsFilter + " "), nil
+
+	case tea.KeyRunes:
+		return m.WithRelationsFilter(m.relationsFilter + string(msg.Runes)), nil
+
+	case tea.KeyCtrlC:
+		return m.quit()
+	}
+	return m, nil
+}
+
+// renderRelationsFilterBar renders the status bar while typing a Relations filter
+func (m Model) renderRelationsFilterBar() string {
+	promptStyle := lipgloss.NewStyle().
+		Foreground(styles.Accent).
+		Bold(true)
+
+	inputStyle := lipgloss.NewStyle().
+		Foreground(styles.Foreground)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.Muted).
+		Faint(true)
+
+	content := fmt.Sprintf("%s %s%s  %s",
+		promptStyle.Render("Relations /"),
+		inputStyle.Render(m.relationsFilter),
+		inputStyle.Render("█"), // Cursor
+		hintStyle.Render(fmt.Sprintf("title or relation · %d shown | Enter:keep | Esc:clear", len(m.GetRelationsList()))),
+	)
+
+	return styles.RenderStatusBar(content, m.width)
+}
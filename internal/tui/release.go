@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// Release is what shipped in a release: the commits from its tagged commit
+// back to the previous release, and the issues those commits reference.
+type Release struct {
+	Release DisplayNode
+	Commits []DisplayNode // Newest first
+	Issues  []DisplayNode // Open issues first
+}
+
+// IsEmpty reports whether no loaded commit belongs to the release.
+func (r Release) IsEmpty() bool {
+	return len(r.Commits) == 0
+}
+
+// Shipped computes what shipped in a release by walking the commit history
+// from the tagged commit until it reaches a commit another release tagged.
+// Only loaded history is walked; expanding a branch loads more.
+// Pure function over the model's unfiltered graph.
+func (m Model) Shipped(releaseID string) Release {
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+	release := Release{Release: nodeByID[releaseID]}
+
+	isType := func(id string, t graph.NodeType) bool {
+		node, ok := nodeByID[id]
+		return ok && node.Type == t
+	}
+
+	// Tagged commits, and the history walked from each commit
+	var start string
+	taggedByOther := map[string]bool{}
+	older := map[string][]string{}
+	for _, edge := range m.edges {
+		switch edge.Relation {
+		case graph.EdgeTagged:
+			if edge.FromID == releaseID {
+				start = edge.ToID
+			} else {
+				taggedByOther[edge.ToID] = true
+			}
+		case graph.EdgeParentOf:
+			if isType(edge.FromID, graph.NodeTypeCommit) {
+				older[edge.FromID] = append(older[edge.FromID], edge.ToID)
+			}
+		}
+	}
+
+	commits := map[string]bool{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if commits[id] || !isType(id, graph.NodeTypeCommit) || (id != start && taggedByOther[id]) {
+			continue
+		}
+		commits[id] = true
+		queue = append(queue, older[id]...)
+	}
+
+	// Issues those commits reference
+	issues := map[string]bool{}
+	for _, edge := range m.edges {
+		if (edge.Relation == graph.EdgeMentions || edge.Relation == graph.EdgeImplements) && commits[edge.FromID] && isType(edge.ToID, graph.NodeTypeIssue) {
+			issues[edge.ToID] = true
+		}
+	}
+
+	release.Commits = collectNodes(commits, nodeByID)
+	release.Issues = collectNodes(issues, nodeByID)
+	sortByRecency(release.Commits)
+	sortByStatus(release.Issues)
+	return release
+}
+
+// renderShippedSection renders the "what shipped" section of the Details view
+// for a release. Collapsed it shows counts only.
+func (m Model) renderShippedSection(releaseID string, maxWidth int) []string {
+	release := m.Shipped(releaseID)
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	summary := fmt.Sprintf("%d commits · %d issues", len(release.Commits), len(release.Issues))
+	if !m.traceExpanded {
+		return []string{
+			headerStyle.Render("🚀 Shipped ▸ ") + mutedStyle.Render(summary+"  (t to expand)"),
+		}
+	}
+
+	lines := []string{headerStyle.Render("🚀 Shipped ▾ ") + mutedStyle.Render(summary+"  (t to collapse)")}
+	if release.IsEmpty() {
+		return append(lines, mutedStyle.Italic(true).Render("  The tagged commit is not loaded; expand its branch for older history."))
+	}
+
+	return append(lines, m.renderTraceGroups([]traceGroup{
+		{"Issues", release.Issues},
+		{"Commits", release.Commits},
+	}, maxWidth)...)
+}
@@ -0,0 +1,288 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// Release notes group the issues a range of commits delivered
+const (
+	GroupByProject = "project"
+	GroupByLabel   = "label"
+)
+
+// ReleaseNotes is what changed between two points in history: the commits
+// after From up to and including To, the PRs and issues they trace to, and
+// the work no issue explains.
+type ReleaseNotes struct {
+	From     string // Refs as given (v1.0, HEAD)
+	To       string
+	Commits  []DisplayNode            // Newest first
+	Groups   []ReleaseGroup           // Issues by project or label, largest group first
+	PRs      map[string][]DisplayNode // PRs delivering each issue, by issue ID
+	Unlinked []DisplayNode            // PRs and commits referencing no issue, newest first
+}
+
+// ReleaseGroup is the issues sharing a project or label.
+type ReleaseGroup struct {
+	Name   string
+	Issues []DisplayNode
+}
+
+// IsEmpty reports whether no loaded commit falls in the range.
+func (r ReleaseNotes) IsEmpty() bool {
+	return len(r.Commits) == 0
+}
+
+// ResolveCommitRef finds the commit a ref points at: HEAD (the newest commit
+// on the checked-out branch), a release tag, a branch name, a commit node
+// or a commit hash prefix.
+func (m Model) ResolveCommitRef(ref string) (DisplayNode, bool) {
+	if strings.EqualFold(ref, "HEAD") {
+		for _, node := range m.nodes {
+			var data struct {
+				Type    string `json:"type"`
+				Current bool   `json:"current"`
+			}
+			if node.Type == graph.NodeTypeService && json.Unmarshal(node.Data, &data) == nil && data.Type == "branch" && data.Current {
+				return m.branchHead(node.ID)
+			}
+		}
+		return m.newestCommit(func(DisplayNode) bool { return true })
+	}
+
+	if node, ok := m.ResolveNodeRef(ref); ok {
+		switch node.Type {
+		case graph.NodeTypeCommit:
+			return node, true
+		case graph.NodeTypeRelease:
+			for _, edge := range m.edges {
+				if edge.FromID == node.ID && edge.Relation == graph.EdgeTagged {
+					return m.GetNodeByID(edge.ToID)
+				}
+			}
+			return DisplayNode{}, false
+		}
+	}
+	for _, node := range m.nodes {
+		if node.Type == graph.NodeTypeService && node.Title == ref {
+			if head, ok := m.branchHead(node.ID); ok {
+				return head, true
+			}
+		}
+	}
+	if len(ref) < 4 {
+		return DisplayNode{}, false
+	}
+	return m.newestCommit(func(node DisplayNode) bool {
+		var data struct {
+			Hash string `json:"hash"`
+		}
+		return json.Unmarshal(node.Data, &data) == nil && strings.HasPrefix(data.Hash, strings.ToLower(ref))
+	})
+}
+
+// branchHead returns the newest loaded commit a branch owns
+func (m Model) branchHead(branchID string) (DisplayNode, bool) {
+	owned := map[string]bool{}
+	for _, edge := range m.edges {
+		if edge.FromID == branchID && edge.Relation == graph.EdgeOwns {
+			owned[edge.ToID] = true
+		}
+	}
+	return m.newestCommit(func(node DisplayNode) bool { return owned[node.ID] })
+}
+
+// newestCommit returns the most recent commit matching keep
+func (m Model) newestCommit(keep func(DisplayNode) bool) (DisplayNode, bool) {
+	var newest DisplayNode
+	found := false
+	for _, node := range m.nodes {
+		if node.Type != graph.NodeTypeCommit || !keep(node) {
+			continue
+		}
+		if !found || node.UpdatedAt.After(newest.UpdatedAt) {
+			newest, found = node, true
+		}
+	}
+	return newest, found
+}
+
+// ReleaseNotes computes the notes for the commits reachable from the to ref
+// but not from the from ref (see ResolveCommitRef), with issues grouped by
+// groupBy (GroupByProject or GroupByLabel). Only loaded history is walked.
+// Pure function over the model's unfiltered graph.
+func (m Model) ReleaseNotes(from, to, groupBy string) (ReleaseNotes, error) {
+	fromCommit, ok := m.ResolveCommitRef(from)
+	if !ok {
+		return ReleaseNotes{}, fmt.Errorf("no loaded commit matches %q", from)
+	}
+	toCommit, ok := m.ResolveCommitRef(to)
+	if !ok {
+		return ReleaseNotes{}, fmt.Errorf("no loaded commit matches %q", to)
+	}
+	fromID, toID := fromCommit.ID, toCommit.ID
+
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+	notes := ReleaseNotes{From: from, To: to, PRs: map[string][]DisplayNode{}}
+
+	isType := func(id string, t graph.NodeType) bool {
+		node, ok := nodeByID[id]
+		return ok && node.Type == t
+	}
+
+	older := map[string][]string{}
+	for _, edge := range m.edges {
+		if edge.Relation == graph.EdgeParentOf && isType(edge.FromID, graph.NodeTypeCommit) {
+			older[edge.FromID] = append(older[edge.FromID], edge.ToID)
+		}
+	}
+	walk := func(start string, skip map[string]bool) map[string]bool {
+		seen := map[string]bool{}
+		queue := []string{start}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			if seen[id] || skip[id] || !isType(id, graph.NodeTypeCommit) {
+				continue
+			}
+			seen[id] = true
+			queue = append(queue, older[id]...)
+		}
+		return seen
+	}
+	commits := walk(toID, walk(fromID, nil))
+
+	// PRs the commits reference, then issues the commits or PRs reference
+	prs := map[string]bool{}
+	for _, edge := range m.edges {
+		if (edge.Relation == graph.EdgeMentions || edge.Relation == graph.EdgeImplements) && commits[edge.FromID] && isType(edge.ToID, graph.NodeTypePR) {
+			prs[edge.ToID] = true
+		}
+	}
+	issues := map[string]bool{}
+	explained := map[string]bool{}
+	prsFor := map[string]map[string]bool{}
+	for _, edge := range m.edges {
+		if edge.Relation != graph.EdgeMentions && edge.Relation != graph.EdgeImplements {
+			continue
+		}
+		if !(commits[edge.FromID] || prs[edge.FromID]) || !isType(edge.ToID, graph.NodeTypeIssue) {
+			continue
+		}
+		issues[edge.ToID] = true
+		explained[edge.FromID] = true
+		if prs[edge.FromID] {
+			if prsFor[edge.ToID] == nil {
+				prsFor[edge.ToID] = map[string]bool{}
+			}
+			prsFor[edge.ToID][edge.FromID] = true
+		}
+	}
+	// A commit landing an explained PR is explained too
+	for _, edge := range m.edges {
+		if commits[edge.FromID] && explained[edge.ToID] && (edge.Relation == graph.EdgeMentions || edge.Relation == graph.EdgeImplements) {
+			explained[edge.FromID] = true
+		}
+	}
+
+	grouped := map[string]map[string]bool{}
+	for id := range issues {
+		name := releaseGroupName(nodeByID[id], groupBy)
+		if grouped[name] == nil {
+			grouped[name] = map[string]bool{}
+		}
+		grouped[name][id] = true
+		notes.PRs[id] = collectNodes(prsFor[id], nodeByID)
+	}
+	for name, ids := range grouped {
+		notes.Groups = append(notes.Groups, ReleaseGroup{Name: name, Issues: collectNodes(ids, nodeByID)})
+	}
+	sort.Slice(notes.Groups, func(i, j int) bool {
+		a, b := notes.Groups[i], notes.Groups[j]
+		if len(a.Issues) != len(b.Issues) {
+			return len(a.Issues) > len(b.Issues)
+		}
+		return a.Name < b.Name
+	})
+
+	unlinked := map[string]bool{}
+	for id := range prs {
+		if !explained[id] {
+			unlinked[id] = true
+		}
+	}
+	for id := range commits {
+		if !explained[id] {
+			unlinked[id] = true
+		}
+	}
+	notes.Commits = collectNodes(commits, nodeByID)
+	notes.Unlinked = collectNodes(unlinked, nodeByID)
+	sortByRecency(notes.Commits)
+	sortByRecency(notes.Unlinked)
+	return notes, nil
+}
+
+// releaseGroupName names the group an issue goes in: its project, or its
+// first label
+func releaseGroupName(issue DisplayNode, groupBy string) string {
+	if groupBy == GroupByLabel {
+		if len(issue.Labels) > 0 {
+			return issue.Labels[0]
+		}
+		return "Unlabelled"
+	}
+	if issue.Project != "" {
+		return issue.Project
+	}
+	return "No project"
+}
+
+// Markdown renders the notes as a changelog section.
+func (r ReleaseNotes) Markdown() string {
+	var b strings.Builder
+	issues := 0
+	for _, group := range r.Groups {
+		issues += len(group.Issues)
+	}
+	fmt.Fprintf(&b, "## Release notes: %s..%s\n\n", r.From, r.To)
+	fmt.Fprintf(&b, "%d commits, %d issues\n", len(r.Commits), issues)
+	for _, group := range r.Groups {
+		fmt.Fprintf(&b, "\n### %s\n\n", group.Name)
+		for _, issue := range group.Issues {
+			line := "- " + standupLabel(issue) + issue.Title
+			var refs []string
+			for _, pr := range r.PRs[issue.ID] {
+				refs = append(refs, prRef(pr))
+			}
+			if len(refs) > 0 {
+				line += " (" + strings.Join(refs, ", ") + ")"
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	if len(r.Unlinked) > 0 {
+		b.WriteString("\n### Other changes\n\n")
+		for _, node := range r.Unlinked {
+			fmt.Fprintf(&b, "- %s%s\n", standupLabel(node), node.Title)
+		}
+	}
+	return b.String()
+}
+
+// prRef is how a PR is cited next to an issue ("#42", or its title)
+func prRef(pr DisplayNode) string {
+	var data PRData
+	if json.Unmarshal(pr.Data, &data) == nil && data.Number > 0 {
+		return fmt.Sprintf("#%d", data.Number)
+	}
+	return pr.Title
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// renderActionQueueView renders the deferred write actions, oldest first.
+func (m Model) renderActionQueueView(width, height int) string {
+	var lines []string
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	errorStyle := lipgloss.NewStyle().Foreground(styles.StatusCanceled)
+
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("⏳ Action Queue (%d pending)", len(m.actionQueue))))
+	lines = append(lines, "")
+
+	if len(m.actionQueue) == 0 {
+		lines = append(lines, mutedStyle.Italic(true).Render("No queued actions. Press a in a confirmation dialog to defer it here."))
+		return strings.Join(lines, "\n")
+	}
+
+	selectedLine := 0
+	for i, action := range m.actionQueue {
+		age := relativeTime(action.QueuedAt)
+		row := fmt.Sprintf("%d. %s", i+1, truncate(action.Request.Action, clampMin(width-len(age)-8, 10)))
+		if action.Request.Edit != nil {
+			row += mutedStyle.Render("  (edit)")
+		}
+		padding := clampMin(width-lipgloss.Width(row)-lipgloss.Width(age), 1)
+
+		if i == m.selectedActionIdx {
+			selectedLine = len(lines)
+			if m.accessible {
+				lines = append(lines, lipgloss.NewStyle().Foreground(styles.Accent).Bold(true).Render(row+" "+selectedMarker)+strings.Repeat(" ", clampMin(padding-len(selectedMarker)-1, 1))+age)
+			} else {
+				lines = append(lines, lipgloss.NewStyle().
+					Background(styles.Primary).
+					Foreground(lipgloss.Color("#FFFFFF")).
+					Bold(true).
+					Width(width).
+					Render(row+strings.Repeat(" ", padding)+age))
+			}
+		} else {
+			lines = append(lines, row+strings.Repeat(" ", padding)+mutedStyle.Render(age))
+		}
+		if action.LastErr != "" {
+			lines = append(lines, errorStyle.Render("   ✗ "+truncate(action.LastErr, clampMin(width-5, 10))))
+		}
+	}
+
+	// Keep the selection visible on short terminals
+	if len(lines) > height {
+		start := clampMin(selectedLine+1-height, 0)
+		lines = lines[start:min(start+height, len(lines))]
+	}
+	return strings.Join(lines, "\n")
+}
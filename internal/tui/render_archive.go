@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// renderArchiveView renders nodes archived past their TTL, most recently archived first.
+func (m Model) renderArchiveView(width, height int) string {
+	var lines []string
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("🗄 Archive (%s)", m.archiveCount())))
+	lines = append(lines, "")
+
+	if len(m.archived) == 0 {
+		empty := "Nothing archived. Nodes idle past their ttl rule are archived at startup."
+		if m.archiveSearch != "" {
+			empty = "No archived nodes match. / searches again, Esc in the prompt clears it."
+		}
+		lines = append(lines, mutedStyle.Italic(true).Render(empty))
+		return strings.Join(lines, "\n")
+	}
+
+	const ageWidth = 10
+	for i, node := range m.archived {
+		marker := m.typeIcon(node.Type) + " "
+		if m.accessible {
+			marker = "[" + string(node.Type) + "] "
+		}
+
+		age := relativeTime(node.UpdatedAt)
+		title := node.Title
+		if title == "" {
+			title = node.ID
+		}
+		if node.Identifier != "" {
+			title = node.Identifier + " " + title
+		}
+		title = truncate(title, clampMin(width-lipgloss.Width(marker)-ageWidth-2, 10))
+		row := marker + title
+		padding := clampMin(width-lipgloss.Width(row)-lipgloss.Width(age), 1)
+
+		switch {
+		case i == m.selectedArchiveIdx && m.accessible:
+			lines = append(lines, lipgloss.NewStyle().Foreground(styles.Accent).Bold(true).Render(row+" "+selectedMarker)+strings.Repeat(" ", clampMin(padding-len(selectedMarker)-1, 1))+age)
+		case i == m.selectedArchiveIdx:
+			lines = append(lines, lipgloss.NewStyle().
+				Background(styles.Primary).
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Bold(true).
+				Width(width).
+				Render(row+strings.Repeat(" ", padding)+age))
+		default:
+			lines = append(lines, lipgloss.NewStyle().Foreground(styles.Foreground).Render(row)+strings.Repeat(" ", padding)+mutedStyle.Render(age))
+		}
+	}
+
+	// Keep the selection visible on short terminals
+	if len(lines) > height {
+		start := clampMin(m.selectedArchiveIdx+2-height+1, 0)
+		lines = append(lines[:2], lines[2+start:]...)
+		if len(lines) > height {
+			lines = lines[:height]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderArchiveSearchBar renders the archive search prompt in the status bar
+func (m Model) renderArchiveSearchBar() string {
+	promptStyle := lipgloss.NewStyle().
+		Foreground(styles.Accent).
+		Bold(true)
+
+	inputStyle := lipgloss.NewStyle().
+		Foreground(styles.Foreground)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.Muted).
+		Faint(true)
+
+	content := fmt.Sprintf("%s %s%s  %s",
+		promptStyle.Render("Archive /"),
+		inputStyle.Render(m.archiveSearch),
+		inputStyle.Render("█"), // Cursor
+		hintStyle.Render("ID or content | Enter:search | Esc:clear"),
+	)
+
+	return styles.RenderStatusBar(content, m.width)
+}
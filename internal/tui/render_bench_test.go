@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// benchModel builds a ready Model over a synthetic graph of roughly n nodes:
+// projects own issues, issues are implemented by commits (1:5:20 ratio).
+func benchModel(n int) Model {
+	projects := n / 26
+	if projects < 1 {
+		projects = 1
+	}
+
+	var nodes []DisplayNode
+	var edges []DisplayEdge
+	statuses := []string{"todo", "in_progress", "done", "blocked"}
+	for p := 0; p < projects; p++ {
+		projectID := fmt.Sprintf("project:%d", p)
+		nodes = append(nodes, DisplayNode{ID: projectID, Type: graph.NodeTypeProject, Title: fmt.Sprintf("Project %d", p), Status: "active"})
+		for i := 0; i < 5; i++ {
+			issueID := fmt.Sprintf("issue:%d-%d", p, i)
+			nodes = append(nodes, DisplayNode{ID: issueID, Type: graph.NodeTypeIssue, Title: fmt.Sprintf("Issue %d of project %d", i, p), Status: statuses[i%len(statuses)]})
+			edges = append(edges, DisplayEdge{FromID: projectID, ToID: issueID, Relation: graph.EdgeOwns})
+			for c := 0; c < 4; c++ {
+				commitID := fmt.Sprintf("commit:%d-%d-%d", p, i, c)
+				nodes = append(nodes, DisplayNode{ID: commitID, Type: graph.NodeTypeCommit, Title: fmt.Sprintf("Commit %d for issue %d", c, i)})
+				edges = append(edges, DisplayEdge{FromID: issueID, ToID: commitID, Relation: graph.EdgeImplements})
+			}
+		}
+	}
+
+	m := NewModel().
+		WithNodes(nodes).
+		WithEdges(edges).
+		WithLoading(false).
+		WithSize(160, 50).
+		WithReady(true).
+		WithFilterMode(FilterAll)
+	return m.WithFocusedNode(nodes[len(nodes)/2].ID)
+}
+
+func BenchmarkGetFilteredNodes10k(b *testing.B) {
+	m := benchModel(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m.GetFilteredNodes()
+	}
+}
+
+func BenchmarkRenderGraph10k(b *testing.B) {
+	m := benchModel(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = RenderGraph(m, 156)
+	}
+}
+
+func BenchmarkMoveDown10k(b *testing.B) {
+	m := benchModel(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m.HandleNavigation("j")
+	}
+}
+
+func BenchmarkView10k(b *testing.B) {
+	m := benchModel(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m.View()
+	}
+}
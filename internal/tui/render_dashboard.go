@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+const (
+	minCardWidth = 34 // Narrowest card that still fits the status counts line
+	cardHeight   = 10 // Fixed card height (including border) for a regular grid
+)
+
+// dashboardColumns returns how many cards fit side by side.
+func dashboardColumns(width int) int {
+	columns := width / (minCardWidth + 1)
+	if columns < 1 {
+		columns = 1
+	}
+	return columns
+}
+
+// renderDashboardView renders every project as a card in a terminal-sized grid.
+func (m Model) renderDashboardView(width, height int) string {
+	var builder strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Accent).
+		Width(width).
+		Align(lipgloss.Center).
+		MarginBottom(1)
+
+	builder.WriteString(titleStyle.Render("🗂  Project Dashboard"))
+	builder.WriteString("\n")
+
+	summaries := m.ProjectSummaries()
+	if len(summaries) == 0 {
+		builder.WriteString(lipgloss.NewStyle().
+			Width(width).
+			Height(height-3).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render(styles.LoadingStyle.Render("No projects loaded.")))
+		return builder.String()
+	}
+
+	columns := dashboardColumns(width)
+	cardWidth := width/columns - 1
+
+	// Scroll by whole card rows so the selected card stays visible
+	visibleRows := (height - 3) / cardHeight
+	if visibleRows < 1 {
+		visibleRows = 1
+	}
+	selectedRow := m.selectedCard / columns
+	firstRow := 0
+	if selectedRow >= visibleRows {
+		firstRow = selectedRow - visibleRows + 1
+	}
+
+	totalRows := (len(summaries) + columns - 1) / columns
+	var rows []string
+	for row := firstRow; row < totalRows && row < firstRow+visibleRows; row++ {
+		var cards []string
+		for col := 0; col < columns; col++ {
+			idx := row*columns + col
+			if idx >= len(summaries) {
+				break
+			}
+			cards = append(cards, m.renderProjectCard(summaries[idx], cardWidth, idx == m.selectedCard))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cards...))
+	}
+	builder.WriteString(lipgloss.JoinVertical(lipgloss.Left, rows...))
+
+	if totalRows > visibleRows {
+		builder.WriteString(lipgloss.NewStyle().
+			Foreground(styles.Muted).
+			Faint(true).
+			Render(fmt.Sprintf("\n[%d projects, rows %d-%d of %d]", len(summaries), firstRow+1, firstRow+len(rows), totalRows)))
+	}
+
+	return builder.String()
+}
+
+// renderProjectCard renders a single project's roll-up card.
+func (m Model) renderProjectCard(summary ProjectSummary, width int, selected bool) string {
+	innerWidth := width - 4 // border + padding
+
+	borderColor := lipgloss.TerminalColor(styles.Border)
+	if selected {
+		borderColor = styles.Accent
+	}
+	cardStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Padding(0, 1).
+		Width(width - 2).
+		Height(cardHeight - 2)
+
+	var lines []string
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.typeColor(summary.Project.Type))
+	title := m.typeIcon(summary.Project.Type) + " " + summary.Project.Title
+	if selected && m.accessible {
+		title = selectedMarker + " " + summary.Project.Title
+	}
+	lines = append(lines, titleStyle.Render(truncate(title, innerWidth)))
+
+	// Progress bar with percentage
+	bar := progress.New(
+		progress.WithSolidFill(string(styles.StatusDone)),
+		progress.WithWidth(innerWidth-5),
+		progress.WithoutPercentage(),
+	)
+	lines = append(lines, fmt.Sprintf("%s %3.0f%%", bar.ViewAs(summary.Progress()), summary.Progress()*100))
+
+	// Counts by status category
+	counts := fmt.Sprintf("%s %d  %s %d  %s %d  %s %d",
+		lipgloss.NewStyle().Foreground(styles.CategoryCompleted.Color()).Render("✓"), summary.Done,
+		lipgloss.NewStyle().Foreground(styles.CategoryStarted.Color()).Render("◐"), summary.Active,
+		lipgloss.NewStyle().Foreground(styles.CategoryUnstarted.Color()).Render("○"), summary.Todo,
+		lipgloss.NewStyle().Foreground(styles.CategoryBlocked.Color()).Render("✗"), summary.Blocked,
+	)
+	if m.accessible {
+		counts = fmt.Sprintf("done %d, active %d, todo %d, blocked %d", summary.Done, summary.Active, summary.Todo, summary.Blocked)
+	}
+	lines = append(lines, counts)
+
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	// Top blockers
+	if len(summary.TopBlockers) > 0 {
+		lines = append(lines, mutedStyle.Render("Top blockers:"))
+		for _, blocker := range summary.TopBlockers {
+			suffix := fmt.Sprintf(" (blocks %d)", blocker.Blocks)
+			lines = append(lines, " "+truncate(blocker.Node.Title, innerWidth-len(suffix)-1)+mutedStyle.Render(suffix))
+		}
+	} else {
+		lines = append(lines, mutedStyle.Render("No blockers"))
+	}
+
+	// Last commit
+	if summary.LastCommit != nil {
+		age := relativeTime(summary.LastCommit.UpdatedAt)
+		lines = append(lines, mutedStyle.Render(truncate("💾 "+summary.LastCommit.Title, innerWidth-len(age)-3)+" · "+age))
+	}
+
+	return cardStyle.Render(strings.Join(lines, "\n"))
+}
+
+// relativeTime formats a timestamp as a compact age ("5m ago", "3d ago").
+func relativeTime(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
@@ -14,21 +14,62 @@ import (
 // This replaces the broken canvas-based approach with a much more usable design.
 // Pure function following Commandment #1 (Immutable Truth).
 func RenderGraph(m Model, maxWidth int) string {
-	// Get filtered nodes and edges
-	nodes := m.GetFilteredNodes()
-	edges := m.GetFilteredEdges()
+	layout := m.layoutGraph()
+
+	var result strings.Builder
+	for i := 0; i < layout.lineCount(); i++ {
+		if i > 0 {
+			result.WriteByte('\n')
+		}
+		result.WriteString(layout.line(m, i, maxWidth))
+	}
+	return result.String()
+}
+
+// graphLayout is the unstyled line layout of the filtered graph. Rows are
+// styled on demand so the Graph view only pays for the lines on screen.
+type graphLayout struct {
+	header []string // Pre-rendered header lines
+	tree   TreeStructure
+	rows   []treeRow
+}
+
+// treeRowKind distinguishes real nodes from the synthetic tree rows
+type treeRowKind int
+
+const (
+	rowNode        treeRowKind = iota
+	rowDepthMarker             // "… (+k more)" below the max depth
+	rowMore                    // "show more" sibling pagination pseudo-row
+)
+
+// treeRow is one laid-out line of the tree, before styling
+type treeRow struct {
+	kind   treeRowKind
+	nodeID string // Node (rowNode) or parent (rowDepthMarker, rowMore)
+	prefix string // Tree lines plus connector
+	hidden int    // Hidden node count for marker rows
+}
+
+// layoutGraph filters the graph, builds the tree and lays out its rows.
+func (m Model) layoutGraph() graphLayout {
+	nodes, edges := m.filteredGraph()
 
 	if len(nodes) == 0 {
-		return lipgloss.NewStyle().
+		return graphLayout{header: []string{lipgloss.NewStyle().
 			Foreground(lipgloss.Color("240")).
-			Render("No nodes match current filter. Press 'f' to change filter.")
+			Render("No nodes match current filter. Press 'f' to change filter.")}}
 	}
 
 	// Build the tree structure
-	tree := buildTree(nodes, edges)
-
-	// Render the tree
-	var result strings.Builder
+	tree := m.buildTree(nodes, edges)
+	tree.Projects = projectIndex(m.nodes, m.edges)
+	if m.execMode {
+		tree.Rollups = m.Rollups()
+	}
+	if m.filterMode == FilterFiles {
+		tree.Risks = m.riskIndex()
+	}
 
 	// Header with filter info
 	headerStyle := lipgloss.NewStyle().
@@ -37,43 +78,79 @@ func RenderGraph(m Model, maxWidth int) string {
 	countStyle := lipgloss.NewStyle().
 		Foreground(styles.Muted)
 
-	result.WriteString(headerStyle.Render(fmt.Sprintf("Filter: %s", m.filterMode.String())))
-	result.WriteString(countStyle.Render(fmt.Sprintf(" (%d nodes)", len(nodes))))
-	result.WriteString("\n\n")
+	header := headerStyle.Render("Filter: " + m.filterMode.String())
+	if m.execMode {
+		header += headerStyle.Render(" · Exec")
+	}
+	header += countStyle.Render(fmt.Sprintf(" (%d nodes)", len(nodes)))
 
-	// Render tree nodes
+	layout := graphLayout{
+		header: []string{header, ""},
+		tree:   tree,
+		rows:   make([]treeRow, 0, len(nodes)),
+	}
 	for i, root := range tree.Roots {
 		isLast := i == len(tree.Roots)-1
-		result.WriteString(renderTreeNode(root, tree, m, "", isLast, maxWidth))
+		layout.rows = m.appendTreeRows(layout.rows, root, tree, "", isLast, 1)
+	}
+	return layout
+}
+
+// lineCount returns the number of lines in the rendered graph, including the
+// trailing empty line left by the final row's newline.
+func (l graphLayout) lineCount() int {
+	if len(l.rows) == 0 {
+		return len(l.header)
 	}
+	return len(l.header) + len(l.rows) + 1
+}
 
-	return result.String()
+// line renders line i of the layout.
+func (l graphLayout) line(m Model, i int, maxWidth int) string {
+	if i < len(l.header) {
+		return l.header[i]
+	}
+	i -= len(l.header)
+	if i < len(l.rows) {
+		return m.renderTreeRow(l.rows[i], l.tree, maxWidth)
+	}
+	return ""
 }
 
 // TreeStructure holds the hierarchical representation of nodes
 type TreeStructure struct {
-	Roots    []string            // Root node IDs (no parents)
-	Children map[string][]string // Parent -> Children mapping
-	Nodes    map[string]DisplayNode
+	Roots    []string                  // Root node IDs (no parents)
+	Children map[string][]string       // Parent -> Children mapping
+	Nodes    map[string]*DisplayNode   // Points into the filtered node slice
+	Rollups  map[string]ProjectSummary // Exec mode only: metrics per project/service
+	Risks    map[string]FileRisk       // Files filter only: hotspot score per file
+	Projects map[string]string         // Node ID -> name of the project it belongs to
 }
 
 // buildTree creates a hierarchical tree from nodes and edges
-func buildTree(nodes []DisplayNode, edges []DisplayEdge) TreeStructure {
+func (m Model) buildTree(nodes []DisplayNode, edges []DisplayEdge) TreeStructure {
 	tree := TreeStructure{
 		Roots:    make([]string, 0),
 		Children: make(map[string][]string),
-		Nodes:    make(map[string]DisplayNode),
+		Nodes:    make(map[string]*DisplayNode, len(nodes)),
 	}
 
-	// Index all nodes
-	for _, node := range nodes {
+	// Index all nodes, precomputing sort keys so comparisons don't re-derive them
+	keys := make(map[string]treeSortKey, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
 		tree.Nodes[node.ID] = node
+		keys[node.ID] = treeSortKey{
+			typePriority:   m.typePriority(node.Type),
+			statusPriority: styles.CategoryOf(node.Status).Rank(),
+			title:          node.Title,
+		}
 	}
 
 	// Build parent-child relationships
 	hasParent := make(map[string]bool)
 	for _, edge := range edges {
-		// Only consider "owns", "implements", "modifies" as parent-child
+		// Only consider "owns", "implements", "modifies", "depends_on" as parent-child
 		if isHierarchicalEdge(edge.Relation) {
 			if _, fromExists := tree.Nodes[edge.FromID]; fromExists {
 				if _, toExists := tree.Nodes[edge.ToID]; toExists {
@@ -93,103 +170,138 @@ func buildTree(nodes []DisplayNode, edges []DisplayEdge) TreeStructure {
 
 	// Sort roots by type priority, then by title
 	sort.Slice(tree.Roots, func(i, j int) bool {
-		ni := tree.Nodes[tree.Roots[i]]
-		nj := tree.Nodes[tree.Roots[j]]
-		if typePriority(ni.Type) != typePriority(nj.Type) {
-			return typePriority(ni.Type) < typePriority(nj.Type)
+		ki := keys[tree.Roots[i]]
+		kj := keys[tree.Roots[j]]
+		if ki.typePriority != kj.typePriority {
+			return ki.typePriority < kj.typePriority
 		}
-		return ni.Title < nj.Title
+		return ki.title < kj.title
 	})
 
 	// Sort children of each node by type, then by status, then by title
 	for parent := range tree.Children {
-		sort.Slice(tree.Children[parent], func(i, j int) bool {
-			ni := tree.Nodes[tree.Children[parent][i]]
-			nj := tree.Nodes[tree.Children[parent][j]]
+		children := tree.Children[parent]
+		sort.Slice(children, func(i, j int) bool {
+			ki := keys[children[i]]
+			kj := keys[children[j]]
 			// First sort by type (projects before issues, etc.)
-			if typePriority(ni.Type) != typePriority(nj.Type) {
-				return typePriority(ni.Type) < typePriority(nj.Type)
+			if ki.typePriority != kj.typePriority {
+				return ki.typePriority < kj.typePriority
 			}
 			// Then sort by status (In Progress → Backlog → Done)
-			if statusPriority(ni.Status) != statusPriority(nj.Status) {
-				return statusPriority(ni.Status) < statusPriority(nj.Status)
+			if ki.statusPriority != kj.statusPriority {
+				return ki.statusPriority < kj.statusPriority
 			}
 			// Finally sort by title alphabetically
-			return ni.Title < nj.Title
+			return ki.title < kj.title
 		})
 	}
 
 	return tree
 }
 
+// treeSortKey caches the fields buildTree orders siblings by
+type treeSortKey struct {
+	typePriority   int
+	statusPriority int
+	title          string
+}
+
 // isHierarchicalEdge returns true if the edge represents a parent-child relationship
 func isHierarchicalEdge(relation graph.EdgeType) bool {
 	switch relation {
-	case graph.EdgeOwns, graph.EdgeImplements, graph.EdgeModifies:
+	case graph.EdgeOwns, graph.EdgeImplements, graph.EdgeModifies, graph.EdgeDependsOn:
 		return true
 	default:
 		return false
 	}
 }
 
+// WithNodeTypeStyles returns a new Model drawing node types with styles
+// (nil = the built-in styles).
+func (m Model) WithNodeTypeStyles(nodeTypes styles.NodeTypeStyles) Model {
+	m.nodeTypes = nodeTypes
+	return m
+}
+
 // typePriority returns sort priority for node types (lower = higher priority)
-func typePriority(t graph.NodeType) int {
-	switch t {
-	case graph.NodeTypeService:
-		return 0
-	case graph.NodeTypeProject:
-		return 1
-	case graph.NodeTypeIssue:
-		return 2
-	case graph.NodeTypePR:
-		return 3
-	case graph.NodeTypeCommit:
-		return 4
-	case graph.NodeTypeFile:
-		return 5
-	default:
-		return 99
-	}
+func (m Model) typePriority(t graph.NodeType) int {
+	return m.nodeTypes.Get(string(t)).Priority
 }
 
-// statusPriority returns sort priority for issue statuses (lower = higher priority)
-// Active work shows first, upcoming work second, completed work last
-func statusPriority(status string) int {
-	s := strings.ToLower(status)
-	switch s {
-	case "in progress", "in_progress", "started", "in review":
-		return 0 // Show first - active work
-	case "backlog", "todo", "pending", "triage":
-		return 1 // Show second - upcoming work
-	case "done", "completed", "merged", "closed":
-		return 2 // Show last - completed work
-	case "blocked", "canceled", "cancelled":
-		return 3 // Show at bottom - blocked/cancelled
-	default:
-		return 1 // Default to backlog priority
+// Precomputed tree styles (rendering runs for every visible row on every keystroke)
+var (
+	treePrefixStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	treeFocusStyle  = lipgloss.NewStyle().Bold(true).Foreground(styles.Accent)
+	treeMarkerStyle = lipgloss.NewStyle().Foreground(styles.Muted).Faint(true)
+	treeMoreStyle   = lipgloss.NewStyle().Foreground(styles.Muted).Italic(true)
+	treeSelectedBg  = lipgloss.Color("236")
+)
+
+// appendTreeRows lays out a node and its visible descendants in display order.
+// Supports collapsed state, the max depth setting and sibling pagination.
+func (m Model) appendTreeRows(rows []treeRow, nodeID string, tree TreeStructure, prefix string, isLast bool, depth int) []treeRow {
+	if _, exists := tree.Nodes[nodeID]; !exists {
+		return rows
 	}
-}
 
-// renderTreeNode renders a single node and its children recursively
-// Now supports collapsed state from model
-func renderTreeNode(nodeID string, tree TreeStructure, m Model, prefix string, isLast bool, maxWidth int) string {
-	node, exists := tree.Nodes[nodeID]
-	if !exists {
-		return ""
+	rows = append(rows, treeRow{kind: rowNode, nodeID: nodeID, prefix: prefix + m.treeConnector(isLast, depth)})
+
+	// Lay out children only if not collapsed
+	if m.IsCollapsed(nodeID) {
+		return rows
 	}
+	children := tree.Children[nodeID]
+	childPrefix := prefix + m.treeIndent(isLast)
+	markerPrefix := childPrefix + m.treeConnector(true, depth+1)
 
-	var result strings.Builder
+	// Below the max depth, summarize the hidden subtree in a single marker row
+	if m.maxDepth > 0 && depth >= m.maxDepth && len(children) > 0 {
+		return append(rows, treeRow{kind: rowDepthMarker, nodeID: nodeID, prefix: markerPrefix, hidden: countDescendants(nodeID, tree)})
+	}
+
+	shown := m.visibleChildCount(nodeID, len(children))
+	for i, childID := range children[:shown] {
+		childIsLast := i == len(children)-1
+		rows = m.appendTreeRows(rows, childID, tree, childPrefix, childIsLast, depth+1)
+	}
 
-	// Determine tree connector
-	connector := "├── "
-	if isLast {
-		connector = "└── "
+	// Remaining siblings collapse into a "show more" pseudo-row
+	if hidden := len(children) - shown; hidden > 0 {
+		rows = append(rows, treeRow{kind: rowMore, nodeID: nodeID, prefix: markerPrefix, hidden: hidden})
 	}
+	return rows
+}
+
+// renderTreeRow styles a single laid-out tree row.
+func (m Model) renderTreeRow(row treeRow, tree TreeStructure, maxWidth int) string {
+	switch row.kind {
+	case rowDepthMarker:
+		return treePrefixStyle.Render(row.prefix) + treeMarkerStyle.Render(fmt.Sprintf("… (+%d more)", row.hidden))
+	case rowMore:
+		page := siblingPageSize
+		if row.hidden < page {
+			page = row.hidden
+		}
+		moreText := fmt.Sprintf("  ⋯ show %d more (%d hidden)", page, row.hidden)
+		moreStyle := treeMoreStyle
+		if m.focusedNode == moreRowID(row.nodeID) {
+			moreStyle = treeFocusStyle
+			if m.accessible {
+				moreText += " " + selectedMarker
+			} else {
+				moreStyle = moreStyle.Background(treeSelectedBg)
+			}
+		}
+		return treePrefixStyle.Render(row.prefix) + moreStyle.Render(moreText)
+	}
+
+	node := tree.Nodes[row.nodeID]
 
 	// Build the node line
-	isFocused := nodeID == m.focusedNode
-	isCollapsed := m.IsCollapsed(nodeID)
-	hasChildren := len(tree.Children[nodeID]) > 0
+	isFocused := row.nodeID == m.focusedNode
+	isCollapsed := m.IsCollapsed(row.nodeID)
+	hasChildren := len(tree.Children[row.nodeID]) > 0
 
 	// Collapse/expand indicator for nodes with children
 	var collapseIcon string
@@ -204,15 +316,29 @@ func renderTreeNode(nodeID string, tree TreeStructure, m Model, prefix string, i
 	}
 
 	// Type icon
-	icon := getTypeIcon(node.Type)
+	icon := m.typeIcon(node.Type)
 
 	// Status indicator with color
-	status := getStatusIndicator(node.Status)
-	statusColor := getStatusColor(node.Status)
+	status := styles.CategoryOf(node.Status).Indicator()
 
-	// Title (truncate if needed)
+	// Accessible mode spells out the type; status is already in the text suffix
+	if m.accessible {
+		collapseIcon = ""
+		icon = m.typeLabel(node.Type) + ":"
+		status = ""
+	}
+
+	// Title (truncate if needed), after any decoration the user's rules add
 	title := node.Title
-	maxTitleLen := maxWidth - len(prefix) - len(connector) - 15 // Reserve space for icons, status, etc.
+	if decoration := m.scriptResults[row.nodeID].Decoration; decoration != "" {
+		title = decoration + " " + title
+	}
+	reserved := 15 // Reserve space for icons, status, etc.
+	if m.isCompact() {
+		reserved = 8 // No status suffix in the condensed layout
+	}
+	marker := m.projectMarker(tree.Projects[row.nodeID])
+	maxTitleLen := maxWidth - lipgloss.Width(row.prefix) - lipgloss.Width(marker) - reserved
 	if maxTitleLen < 10 {
 		maxTitleLen = 10
 	}
@@ -223,162 +349,102 @@ func renderTreeNode(nodeID string, tree TreeStructure, m Model, prefix string, i
 	// Status text for display
 	statusText := ""
 	if node.Status != "" {
-		statusText = fmt.Sprintf(" [%s]", node.Status)
+		statusText = " [" + node.Status + "]"
 	}
-
-	// Build the line content
-	lineContent := fmt.Sprintf("%s%s%s %s%s", collapseIcon, icon, status, title, statusText)
-
-	// Apply styling
-	var lineStyle lipgloss.Style
-	if isFocused {
-		lineStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(styles.Accent).
-			Background(lipgloss.Color("236"))
-	} else {
-		// Color status text differently
-		lineStyle = lipgloss.NewStyle().
-			Foreground(getTypeColor(node.Type))
+	if rollup, ok := tree.Rollups[row.nodeID]; ok {
+		statusText = " " + rollupBadge(rollup)
 	}
-
-	// Status styling (applied separately for non-focused items)
-	statusStyle := lipgloss.NewStyle().Foreground(statusColor).Faint(true)
-
-	// Tree prefix styling
-	prefixStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-
-	result.WriteString(prefixStyle.Render(prefix + connector))
-	if isFocused {
-		result.WriteString(lineStyle.Render(lineContent))
-	} else {
-		// Render with colored status
-		baseContent := fmt.Sprintf("%s%s%s %s", collapseIcon, icon, status, title)
-		result.WriteString(lineStyle.Render(baseContent))
-		if statusText != "" {
-			result.WriteString(statusStyle.Render(statusText))
+	risk, hasRisk := tree.Risks[row.nodeID]
+	if hasRisk {
+		statusText += " " + riskBadge(risk)
+	}
+	if node.Type == graph.NodeTypePR {
+		if badge := reviewBadge(node.Review, m.accessible); badge != "" {
+			statusText += " " + badge
 		}
 	}
-	result.WriteString("\n")
+	for _, badge := range m.scriptResults[row.nodeID].Badges {
+		statusText += " ‹" + badge + "›"
+	}
+	statusText += m.metricBadges(row.nodeID)
+	if m.IsPending(row.nodeID) {
+		statusText += " " + pendingMarker
+	}
+	if m.isCompact() && !m.accessible {
+		statusText = "" // The status icon carries it; the title needs the room
+	}
+	if m.accessible && hasChildren && isCollapsed {
+		statusText += " (collapsed)"
+	}
+	if m.accessible && isFocused {
+		statusText += " " + selectedMarker
+	}
 
-	// Render children only if not collapsed
-	if !isCollapsed {
-		children := tree.Children[nodeID]
-		childPrefix := prefix
-		if isLast {
-			childPrefix += "    "
-		} else {
-			childPrefix += "│   "
+	baseContent := collapseIcon + icon + status + " " + title
+	line := treePrefixStyle.Render(row.prefix) + marker
+	if m.presentation {
+		// High contrast for screen sharing: white rows, a reversed focus row
+		if isFocused {
+			return presentRowStyle.Render(row.prefix) + marker + presentFocusStyle.Render(baseContent+statusText)
 		}
-
-		for i, childID := range children {
-			childIsLast := i == len(children)-1
-			result.WriteString(renderTreeNode(childID, tree, m, childPrefix, childIsLast, maxWidth))
+		return presentRowStyle.Render(row.prefix) + marker + presentRowStyle.Render(baseContent+statusText)
+	}
+	if isFocused {
+		focusStyle := treeFocusStyle
+		if !m.accessible {
+			focusStyle = focusStyle.Background(treeSelectedBg)
 		}
+		return line + focusStyle.Render(baseContent+statusText)
 	}
 
-	return result.String()
-}
-
-// getTypeIcon returns an emoji icon for the node type
-func getTypeIcon(t graph.NodeType) string {
-	switch t {
-	case graph.NodeTypeProject:
-		return "📦"
-	case graph.NodeTypeIssue:
-		return "🔹"
-	case graph.NodeTypePR:
-		return "🔀"
-	case graph.NodeTypeCommit:
-		return "💾"
-	case graph.NodeTypeFile:
-		return "📄"
-	case graph.NodeTypeService:
-		return "⚙️"
-	default:
-		return "❓"
+	// Render with colored status (applied separately for non-focused items)
+	if hasRisk {
+		// Files are heat-colored by risk instead
+		return line + lipgloss.NewStyle().Foreground(styles.HeatColor(risk.Heat)).Render(baseContent+statusText)
+	}
+	line += lipgloss.NewStyle().Foreground(m.typeColor(node.Type)).Render(baseContent)
+	if statusText != "" {
+		line += lipgloss.NewStyle().Foreground(styles.StatusColor(node.Status)).Faint(true).Render(statusText)
 	}
+	return line
 }
 
-// getStatusIndicator returns a compact status indicator with Linear status support
-func getStatusIndicator(status string) string {
-	// Normalize to lowercase for comparison
-	s := strings.ToLower(status)
-	switch s {
-	case "done", "merged", "completed", "closed":
-		return "[✓]"
-	case "in progress", "in_progress", "open", "started", "in review":
-		return "[◐]"
-	case "backlog", "todo", "pending", "triage":
-		return "[○]"
-	case "draft":
-		return "[◌]"
-	case "blocked", "canceled", "cancelled":
-		return "[✗]"
-	default:
-		return "[-]"
+// countDescendants returns how many distinct nodes sit beneath nodeID in the tree.
+func countDescendants(nodeID string, tree TreeStructure) int {
+	seen := map[string]bool{nodeID: true}
+	queue := append([]string(nil), tree.Children[nodeID]...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		queue = append(queue, tree.Children[id]...)
 	}
+	return len(seen) - 1
 }
 
-// getStatusColor returns the color for a status
-func getStatusColor(status string) lipgloss.Color {
-	s := strings.ToLower(status)
-	switch s {
-	case "done", "merged", "completed", "closed":
-		return lipgloss.Color("42") // Green
-	case "in progress", "in_progress", "open", "started", "in review":
-		return lipgloss.Color("214") // Orange
-	case "backlog", "todo", "pending", "triage":
-		return lipgloss.Color("240") // Gray
-	case "blocked", "canceled", "cancelled":
-		return lipgloss.Color("196") // Red
-	default:
-		return lipgloss.Color("252")
+// rollupBadge summarizes a project/service roll-up for exec mode rows.
+func rollupBadge(summary ProjectSummary) string {
+	if summary.Total == 0 {
+		return "[no tracked work]"
 	}
+	badge := fmt.Sprintf("[%.0f%% · %d open", summary.Progress()*100, summary.Total-summary.Done)
+	if summary.Blocked > 0 {
+		badge += fmt.Sprintf(" · %d blocked", summary.Blocked)
+	}
+	return badge + "]"
 }
 
-// getTypeTag returns a short type tag
-func getTypeTag(t graph.NodeType) string {
-	tagStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
-		Faint(true)
-
-	switch t {
-	case graph.NodeTypeProject:
-		return tagStyle.Render("")
-	case graph.NodeTypeIssue:
-		return tagStyle.Render("")
-	case graph.NodeTypePR:
-		return tagStyle.Render("")
-	case graph.NodeTypeCommit:
-		return tagStyle.Render("")
-	case graph.NodeTypeFile:
-		return tagStyle.Render("")
-	case graph.NodeTypeService:
-		return tagStyle.Render("")
-	default:
-		return ""
-	}
+// typeIcon returns the emoji icon for the node type
+func (m Model) typeIcon(t graph.NodeType) string {
+	return m.nodeTypes.Get(string(t)).Icon
 }
 
-// getTypeColor returns the color for a node type
-func getTypeColor(t graph.NodeType) lipgloss.Color {
-	switch t {
-	case graph.NodeTypeProject:
-		return lipgloss.Color("33") // Blue
-	case graph.NodeTypeIssue:
-		return lipgloss.Color("214") // Orange
-	case graph.NodeTypePR:
-		return lipgloss.Color("135") // Purple
-	case graph.NodeTypeCommit:
-		return lipgloss.Color("250") // Gray
-	case graph.NodeTypeFile:
-		return lipgloss.Color("70") // Green
-	case graph.NodeTypeService:
-		return lipgloss.Color("45") // Cyan
-	default:
-		return lipgloss.Color("252")
-	}
+// typeColor returns the color for a node type
+func (m Model) typeColor(t graph.NodeType) lipgloss.Color {
+	return m.nodeTypes.Get(string(t)).Color
 }
 
 // RenderGraphList renders nodes as a simple flat list (legacy fallback)
@@ -390,8 +456,8 @@ func RenderGraphList(m Model) string {
 
 	var result strings.Builder
 	for _, node := range nodes {
-		icon := getTypeIcon(node.Type)
-		status := getStatusIndicator(node.Status)
+		icon := m.typeIcon(node.Type)
+		status := styles.CategoryOf(node.Status).Indicator()
 		focused := ""
 		if node.ID == m.focusedNode {
 			focused = " ← FOCUSED"
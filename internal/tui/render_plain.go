@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderPlain renders the filtered graph as a plain-text tree for pipes and
+// scripts: every node (no paging or collapsing), one per line, no ANSI
+// styling. Accessible mode swaps the tree lines for level labels as usual.
+func RenderPlain(m Model) string {
+	nodes, edges := m.filteredGraph()
+	if len(nodes) == 0 {
+		return "No nodes match the current filter\n"
+	}
+	tree := m.buildTree(nodes, edges)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Filter: %s (%d nodes)\n\n", m.filterMode, len(nodes))
+	for i, root := range tree.Roots {
+		m.writePlainRows(&b, root, tree, "", i == len(tree.Roots)-1, 1)
+	}
+	return b.String()
+}
+
+// writePlainRows writes a node and all its descendants
+func (m Model) writePlainRows(b *strings.Builder, nodeID string, tree TreeStructure, prefix string, isLast bool, depth int) {
+	node, ok := tree.Nodes[nodeID]
+	if !ok {
+		return
+	}
+	b.WriteString(prefix + m.treeConnector(isLast, depth) + m.typeLabel(node.Type) + " ")
+	if node.Identifier != "" && node.Identifier != node.Title {
+		b.WriteString(node.Identifier + " ")
+	}
+	b.WriteString(node.Title)
+	if node.Status != "" {
+		b.WriteString(" [" + node.Status + "]")
+	}
+	b.WriteByte('\n')
+
+	children := tree.Children[nodeID]
+	for i, childID := range children {
+		m.writePlainRows(b, childID, tree, prefix+m.treeIndent(isLast), i == len(children)-1, depth+1)
+	}
+}
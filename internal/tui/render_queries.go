@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/config"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// queriesSidebarWidth is the fixed width of the saved queries sidebar
+const queriesSidebarWidth = 34
+
+// renderQueriesView renders the saved queries sidebar next to the current graph.
+func (m Model) renderQueriesView(width, height int) string {
+	sidebarWidth := queriesSidebarWidth
+	if width < sidebarWidth*2 {
+		// Too narrow for a side-by-side layout - sidebar takes the full width
+		sidebarWidth = width
+	}
+
+	sidebar := m.renderQueriesSidebar(sidebarWidth-4, height-2)
+	sidebarBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Accent).
+		Padding(0, 1).
+		Width(sidebarWidth - 2).
+		Height(height - 2).
+		Render(sidebar)
+
+	if sidebarWidth == width {
+		return sidebarBox
+	}
+
+	graphWidth := width - sidebarWidth - 1
+	return lipgloss.JoinHorizontal(lipgloss.Top, sidebarBox, " ", m.renderGraphView(graphWidth, height))
+}
+
+// renderQueriesSidebar renders the list of saved queries with selection highlighting.
+func (m Model) renderQueriesSidebar(width, height int) string {
+	var lines []string
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Accent)
+	lines = append(lines, headerStyle.Render("🔖 Saved Views"))
+	lines = append(lines, "")
+
+	queries := m.SavedQueries()
+	if len(queries) == 0 {
+		hintStyle := lipgloss.NewStyle().
+			Foreground(styles.Muted).
+			Italic(true).
+			Width(width)
+		lines = append(lines, hintStyle.Render("No saved views yet. Press 'a' to save the current filters, or add saved_queries to config.yaml."))
+		return strings.Join(lines, "\n")
+	}
+
+	summaryStyle := lipgloss.NewStyle().Foreground(styles.Muted).Faint(true)
+	for i, q := range queries {
+		marker := "  "
+		if q.Name == m.activeQuery {
+			marker = "● "
+		}
+
+		name := truncate(q.Name, width-2)
+		if i == m.selectedQueryIdx && m.accessible {
+			lines = append(lines, lipgloss.NewStyle().Foreground(styles.Accent).Bold(true).Render(marker+name+" "+selectedMarker))
+		} else if i == m.selectedQueryIdx {
+			lines = append(lines, lipgloss.NewStyle().
+				Background(styles.Primary).
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Bold(true).
+				Width(width).
+				Render(marker+name))
+		} else {
+			lines = append(lines, lipgloss.NewStyle().Foreground(styles.Foreground).Render(marker+name))
+		}
+		lines = append(lines, summaryStyle.Render("  "+truncate(savedQuerySummary(q), width-2)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// savedQuerySummary describes a saved query's filters in one line.
+func savedQuerySummary(q config.SavedQuery) string {
+	var parts []string
+	if q.Filter != "" {
+		parts = append(parts, q.Filter)
+	}
+	if q.Status != "" {
+		parts = append(parts, q.Status)
+	}
+	if q.Search != "" {
+		parts = append(parts, fmt.Sprintf("%q", q.Search))
+	}
+	if q.SQL != "" {
+		parts = append(parts, "sql")
+	}
+	if len(parts) == 0 {
+		return "everything"
+	}
+	return strings.Join(parts, " · ")
+}
+
+// renderQueryNameBar renders the name prompt when saving the current filters.
+func (m Model) renderQueryNameBar() string {
+	promptStyle := lipgloss.NewStyle().
+		Foreground(styles.Accent).
+		Bold(true)
+
+	inputStyle := lipgloss.NewStyle().
+		Foreground(styles.Foreground)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.Muted).
+		Faint(true)
+
+	content := fmt.Sprintf("%s %s%s  %s  %s",
+		promptStyle.Render("Save view as:"),
+		inputStyle.Render(m.queryName),
+		inputStyle.Render("█"), // Cursor
+		hintStyle.Render("("+savedQuerySummary(m.currentAsSavedQuery())+")"),
+		hintStyle.Render("Enter:save | Esc:cancel"),
+	)
+
+	return styles.RenderStatusBar(content, m.width)
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// renderRetroView renders a cycle's completed, carried-over, blocked and
+// added issues.
+func (m Model) renderRetroView(width, height int) string {
+	var builder strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Accent).
+		Width(width).
+		Align(lipgloss.Center).
+		MarginBottom(1)
+
+	cycle, ok := m.retroCycle()
+	retro, found := m.Retro(cycle.Name, time.Now())
+	if !ok || !found {
+		builder.WriteString(titleStyle.Render("🔁 Retro"))
+		builder.WriteString("\n")
+		builder.WriteString(lipgloss.NewStyle().
+			Width(width).
+			Height(height-3).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render(styles.LoadingStyle.Render("No issues are planned in a cycle.")))
+		return builder.String()
+	}
+
+	builder.WriteString(titleStyle.Render(fmt.Sprintf("🔁 Retro — %s (%s – %s)",
+		cycle.Name, cycle.StartsAt.Format("Jan 2"), cycle.EndsAt.Format("Jan 2"))))
+	builder.WriteString("\n")
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted).Italic(true)
+	blockedStyle := lipgloss.NewStyle().Foreground(styles.StatusCanceled)
+	entryWidth := clampMin(width-8, 10)
+
+	planned := len(retro.Completed) + len(retro.CarriedOver)
+	lines := []string{mutedStyle.Render(fmt.Sprintf("  %d of %d issues completed, %d added mid-cycle",
+		len(retro.Completed), planned, len(retro.Added))), ""}
+
+	section := func(title string, entries []string, style lipgloss.Style) {
+		if len(lines) > 2 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, headerStyle.Render(title))
+		if len(entries) == 0 {
+			lines = append(lines, mutedStyle.Render("  Nothing"))
+			return
+		}
+		for _, entry := range entries {
+			lines = append(lines, style.Render("  "+truncate(entry, entryWidth)))
+		}
+	}
+	nodeEntries := func(nodes []DisplayNode, suffix func(DisplayNode) string) []string {
+		entries := make([]string, len(nodes))
+		for i, node := range nodes {
+			entries[i] = m.typeIcon(node.Type) + " " + standupLabel(node) + node.Title + suffix(node)
+		}
+		return entries
+	}
+	none := func(DisplayNode) string { return "" }
+
+	section("✅ Completed", nodeEntries(retro.Completed, none), lipgloss.NewStyle())
+	section("↪️  "+retro.carriedOverLabel(), nodeEntries(retro.CarriedOver, none), lipgloss.NewStyle())
+	blocked := make([]string, len(retro.Blocked))
+	for i, b := range retro.Blocked {
+		blocked[i] = m.typeIcon(b.Issue.Type) + " " + standupLabel(b.Issue) + b.Issue.Title + ", " + formatBlockedFor(b.For) + blockedByRefs(b.By)
+	}
+	section("🚧 Blocked", blocked, blockedStyle)
+	section("➕ Added mid-cycle", nodeEntries(retro.Added, estimateSuffix), lipgloss.NewStyle())
+
+	if len(lines) > height-3 {
+		lines = append(lines[:height-4], mutedStyle.Render("  …"))
+	}
+	builder.WriteString(strings.Join(lines, "\n"))
+	return builder.String()
+}
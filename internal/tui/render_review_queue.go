@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// renderReviewQueueView renders open PRs awaiting the viewer's review, oldest first.
+func (m Model) renderReviewQueueView(width, height int) string {
+	var lines []string
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	queue := m.ReviewQueue(time.Now())
+	pending := 0
+	for _, item := range queue {
+		if !item.Viewed {
+			pending++
+		}
+	}
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("👀 Review Queue (%d waiting, %d viewed)", pending, len(queue)-pending)))
+	lines = append(lines, "")
+
+	if m.viewer == "" {
+		lines = append(lines, mutedStyle.Italic(true).Render("Set --me or GITHUB_USER to see PRs awaiting your review."))
+		return strings.Join(lines, "\n")
+	}
+	if len(queue) == 0 {
+		lines = append(lines, mutedStyle.Italic(true).Render("No open PRs are waiting on your review."))
+		return strings.Join(lines, "\n")
+	}
+
+	const ageWidth = 10
+	for i, item := range queue {
+		marker := "○ "
+		if item.Viewed {
+			marker = "✓ "
+		}
+		if m.accessible {
+			marker = "[ ] "
+			if item.Viewed {
+				marker = "[viewed] "
+			}
+		}
+
+		age := relativeTime(item.PR.CreatedAt)
+		title := item.PR.Title
+		if badge := reviewBadge(item.PR.Review, m.accessible); badge != "" {
+			title += "  " + badge
+		}
+		title = truncate(title, clampMin(width-lipgloss.Width(marker)-ageWidth-2, 10))
+		row := marker + title
+		padding := clampMin(width-lipgloss.Width(row)-lipgloss.Width(age), 1)
+
+		switch {
+		case i == m.selectedReviewIdx && m.accessible:
+			lines = append(lines, lipgloss.NewStyle().Foreground(styles.Accent).Bold(true).Render(row+" "+selectedMarker)+strings.Repeat(" ", clampMin(padding-len(selectedMarker)-1, 1))+age)
+		case i == m.selectedReviewIdx:
+			lines = append(lines, lipgloss.NewStyle().
+				Background(styles.Primary).
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Bold(true).
+				Width(width).
+				Render(row+strings.Repeat(" ", padding)+age))
+		case item.Viewed:
+			lines = append(lines, mutedStyle.Faint(true).Render(row+strings.Repeat(" ", padding)+age))
+		default:
+			lines = append(lines, lipgloss.NewStyle().Foreground(styles.Foreground).Render(row)+strings.Repeat(" ", padding)+mutedStyle.Render(age))
+		}
+	}
+
+	// Keep the selection visible on short terminals
+	if len(lines) > height {
+		start := clampMin(m.selectedReviewIdx+2-height+1, 0)
+		lines = append(lines[:2], lines[2+start:]...)
+		if len(lines) > height {
+			lines = lines[:height]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
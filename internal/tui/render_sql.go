@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// maxSQLCellWidth caps individual cells so JSON blobs don't blow out the table
+const maxSQLCellWidth = 40
+
+// renderSQLView renders the results of the last ad-hoc SQL query as a table.
+func (m Model) renderSQLView(width, height int) string {
+	var builder strings.Builder
+
+	// View title
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Accent).
+		Width(width).
+		Align(lipgloss.Center).
+		MarginBottom(1)
+
+	builder.WriteString(titleStyle.Render("🗄  SQL Query"))
+	builder.WriteString("\n")
+
+	queryStyle := lipgloss.NewStyle().
+		Foreground(styles.Muted).
+		Italic(true)
+	builder.WriteString(queryStyle.Render(truncate(m.sqlQuery, width-4)))
+	builder.WriteString("\n\n")
+
+	if m.sqlResult == nil || len(m.sqlResult.Columns) == 0 {
+		builder.WriteString(styles.LoadingStyle.Render("No results. Press ':' to run a query, e.g. SELECT * FROM issue_dependencies"))
+		return builder.String()
+	}
+
+	if len(m.sqlResult.Rows) == 0 {
+		builder.WriteString(styles.LoadingStyle.Render("Query returned no rows."))
+		return builder.String()
+	}
+
+	// Reserve lines for title, query, borders, header and scroll indicator
+	visibleRows := height - 9
+	if visibleRows < 1 {
+		visibleRows = 1
+	}
+
+	start := m.sqlScroll
+	end := start + visibleRows
+	if end > len(m.sqlResult.Rows) {
+		end = len(m.sqlResult.Rows)
+	}
+
+	rows := make([][]string, 0, end-start)
+	for _, row := range m.sqlResult.Rows[start:end] {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = truncate(strings.ReplaceAll(cell, "\n", " "), maxSQLCellWidth)
+		}
+		rows = append(rows, cells)
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Accent).Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Foreground(styles.Foreground).Padding(0, 1)
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(styles.Border)).
+		Headers(m.sqlResult.Columns...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return cellStyle
+		})
+
+	builder.WriteString(t.Render())
+
+	if len(m.sqlResult.Rows) > visibleRows {
+		scrollInfo := lipgloss.NewStyle().
+			Foreground(styles.Muted).
+			Faint(true).
+			Render(fmt.Sprintf("\n[rows %d-%d of %d]", start+1, end, len(m.sqlResult.Rows)))
+		builder.WriteString(scrollInfo)
+	}
+
+	return builder.String()
+}
+
+// renderSQLBar renders the SQL input prompt when in SQL mode.
+func (m Model) renderSQLBar() string {
+	promptStyle := lipgloss.NewStyle().
+		Foreground(styles.Accent).
+		Bold(true)
+
+	inputStyle := lipgloss.NewStyle().
+		Foreground(styles.Foreground)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(styles.Muted).
+		Faint(true)
+
+	content := fmt.Sprintf("%s %s%s  %s",
+		promptStyle.Render("SQL>"),
+		inputStyle.Render(m.sqlQuery),
+		inputStyle.Render("█"), // Cursor
+		hintStyle.Render("read-only | Enter:run | Esc:cancel"),
+	)
+
+	return styles.RenderStatusBar(content, m.width)
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// renderStandupView renders the viewer's yesterday / today / blockers lists.
+func (m Model) renderStandupView(width, height int) string {
+	var builder strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Accent).
+		Width(width).
+		Align(lipgloss.Center).
+		MarginBottom(1)
+
+	standup, ok := m.Standup(time.Now())
+	if !ok {
+		builder.WriteString(titleStyle.Render("🗣  Standup"))
+		builder.WriteString("\n")
+		builder.WriteString(lipgloss.NewStyle().
+			Width(width).
+			Height(height-3).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render(styles.LoadingStyle.Render("Set --me (or \"me\" in config) to see your standup.")))
+		return builder.String()
+	}
+
+	builder.WriteString(titleStyle.Render("🗣  Standup — " + standup.Person.Title))
+	builder.WriteString("\n")
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted).Italic(true)
+	blockedStyle := lipgloss.NewStyle().Foreground(styles.StatusCanceled)
+
+	var lines []string
+	sections := []struct {
+		title string
+		nodes []DisplayNode
+		style lipgloss.Style
+	}{
+		{"☀️  Yesterday", standup.Yesterday, lipgloss.NewStyle()},
+		{"🎯 Today", standup.Today, lipgloss.NewStyle()},
+		{"🚧 Blockers", standup.Blockers, blockedStyle},
+	}
+	for i, section := range sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, headerStyle.Render(section.title))
+		if len(section.nodes) == 0 {
+			lines = append(lines, mutedStyle.Render("  Nothing"))
+			continue
+		}
+		for _, node := range section.nodes {
+			entry := "  " + m.typeIcon(node.Type) + " " + truncate(standupLabel(node)+node.Title, clampMin(width-8, 10))
+			lines = append(lines, section.style.Render(entry))
+		}
+	}
+
+	if len(lines) > height-3 {
+		lines = append(lines[:height-4], mutedStyle.Render("  …"))
+	}
+	builder.WriteString(strings.Join(lines, "\n"))
+	return builder.String()
+}
@@ -0,0 +1,337 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// Cycle is a sprint issues are planned in (a Linear cycle).
+type Cycle struct {
+	Name     string
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// Retro looks back on a cycle: what got done, what didn't, what stood in
+// the way and what was added once it had started.
+type Retro struct {
+	Cycle       Cycle
+	Ended       bool           // False while the cycle is still running
+	Completed   []DisplayNode  // Done by the cycle's end, newest first
+	CarriedOver []DisplayNode  // Not done by its end (still open, if running)
+	Blocked     []BlockedIssue // Longest blocked first
+	Added       []DisplayNode  // Filed after the cycle started, largest estimate first
+}
+
+// BlockedIssue is an issue that spent part of a cycle blocked.
+type BlockedIssue struct {
+	Issue DisplayNode
+	By    []DisplayNode // Issues blocking it ("blocks" edges)
+	For   time.Duration // How long within the cycle
+}
+
+// issuePlan is the cycle fields of an issue's data
+type issuePlan struct {
+	Cycle       string    `json:"cycle"`
+	StartsAt    time.Time `json:"cycle_starts_at"`
+	EndsAt      time.Time `json:"cycle_ends_at"`
+	Estimate    float64   `json:"estimate"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// planOf reads an issue's cycle fields, reporting false for unplanned issues
+func planOf(node DisplayNode) (issuePlan, bool) {
+	var plan issuePlan
+	if node.Type != graph.NodeTypeIssue || json.Unmarshal(node.Data, &plan) != nil || plan.Cycle == "" {
+		return issuePlan{}, false
+	}
+	return plan, true
+}
+
+// completedAt is when an issue was done: its completion time, or its last
+// update for sources that don't record one. False while it is open.
+func completedAt(node DisplayNode, plan issuePlan) (time.Time, bool) {
+	if !StatusDone.MatchesStatus(node.Status) {
+		return time.Time{}, false
+	}
+	if !plan.CompletedAt.IsZero() {
+		return plan.CompletedAt, true
+	}
+	return node.UpdatedAt, true
+}
+
+// Cycles lists the cycles issues are planned in, newest first.
+func (m Model) Cycles() []Cycle {
+	seen := map[string]bool{}
+	var cycles []Cycle
+	for _, node := range m.nodes {
+		plan, ok := planOf(node)
+		if !ok || seen[plan.Cycle] {
+			continue
+		}
+		seen[plan.Cycle] = true
+		cycles = append(cycles, Cycle{Name: plan.Cycle, StartsAt: plan.StartsAt, EndsAt: plan.EndsAt})
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		if !cycles[i].StartsAt.Equal(cycles[j].StartsAt) {
+			return cycles[i].StartsAt.After(cycles[j].StartsAt)
+		}
+		return cycles[i].Name < cycles[j].Name
+	})
+	return cycles
+}
+
+// LatestCycle returns the newest cycle that had started by now.
+func (m Model) LatestCycle(now time.Time) (Cycle, bool) {
+	for _, cycle := range m.Cycles() {
+		if !cycle.StartsAt.After(now) {
+			return cycle, true
+		}
+	}
+	return Cycle{}, false
+}
+
+// Retro computes the retro of the named cycle as of now.
+// Pure function over the model's unfiltered graph.
+func (m Model) Retro(name string, now time.Time) (Retro, bool) {
+	var retro Retro
+	found := false
+	for _, cycle := range m.Cycles() {
+		if strings.EqualFold(cycle.Name, name) {
+			retro.Cycle, found = cycle, true
+			break
+		}
+	}
+	if !found {
+		return Retro{}, false
+	}
+	start, end := retro.Cycle.StartsAt, retro.Cycle.EndsAt
+	retro.Ended = !end.After(now)
+	// Time in the cycle stops at its end, or now while it runs
+	stop := end
+	if !retro.Ended {
+		stop = now
+	}
+
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+	blockers := map[string][]string{}
+	for _, edge := range m.edges {
+		if edge.Relation == graph.EdgeBlocks {
+			blockers[edge.ToID] = append(blockers[edge.ToID], edge.FromID)
+		}
+	}
+
+	estimates := map[string]float64{}
+	for _, node := range m.nodes {
+		plan, ok := planOf(node)
+		if !ok || plan.Cycle != retro.Cycle.Name {
+			continue
+		}
+		done, isDone := completedAt(node, plan)
+		if isDone && !done.After(end) {
+			retro.Completed = append(retro.Completed, node)
+		} else {
+			retro.CarriedOver = append(retro.CarriedOver, node)
+		}
+		if node.CreatedAt.After(start) {
+			retro.Added = append(retro.Added, node)
+			estimates[node.ID] = plan.Estimate
+		}
+		if blocked, ok := blockedFor(node, blockers[node.ID], nodeByID, start, stop); ok {
+			retro.Blocked = append(retro.Blocked, blocked)
+		}
+	}
+
+	sortByRecency(retro.Completed)
+	sortByRecency(retro.CarriedOver)
+	sort.SliceStable(retro.Added, func(i, j int) bool {
+		return estimates[retro.Added[i].ID] > estimates[retro.Added[j].ID]
+	})
+	sort.SliceStable(retro.Blocked, func(i, j int) bool {
+		return retro.Blocked[i].For > retro.Blocked[j].For
+	})
+	return retro, true
+}
+
+// blockedFor works out how long an issue was blocked between start and
+// stop: from when it (or the cycle) started until its last blocker was done,
+// or since its last update while its status says blocked
+func blockedFor(issue DisplayNode, blockerIDs []string, nodeByID map[string]DisplayNode, start, stop time.Time) (BlockedIssue, bool) {
+	blocked := BlockedIssue{Issue: issue}
+	from := start
+	if issue.CreatedAt.After(from) {
+		from = issue.CreatedAt
+	}
+	until := from
+	for _, id := range blockerIDs {
+		blocker, ok := nodeByID[id]
+		if !ok {
+			continue
+		}
+		blocked.By = append(blocked.By, blocker)
+		plan, _ := planOf(blocker)
+		cleared, done := completedAt(blocker, plan)
+		if !done || cleared.After(stop) {
+			cleared = stop
+		}
+		if cleared.After(until) {
+			until = cleared
+		}
+	}
+	if styles.CategoryOf(issue.Status) == styles.CategoryBlocked {
+		since := issue.UpdatedAt
+		if since.Before(from) {
+			since = from
+		}
+		if stop.Sub(since) > until.Sub(from) {
+			from, until = since, stop
+		}
+	}
+	blocked.For = until.Sub(from)
+	return blocked, blocked.For > 0
+}
+
+// Markdown renders the retro as a Markdown report.
+func (r Retro) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Retro: %s (%s – %s)\n\n", r.Cycle.Name, r.Cycle.StartsAt.Format("Jan 2"), r.Cycle.EndsAt.Format("Jan 2"))
+	planned := len(r.Completed) + len(r.CarriedOver)
+	fmt.Fprintf(&b, "%d of %d issues completed, %d added mid-cycle\n", len(r.Completed), planned, len(r.Added))
+
+	for _, section := range []traceGroup{
+		{"Completed", r.Completed},
+		{r.carriedOverLabel(), r.CarriedOver},
+	} {
+		fmt.Fprintf(&b, "\n### %s\n\n", section.label)
+		if len(section.nodes) == 0 {
+			b.WriteString("- Nothing\n")
+			continue
+		}
+		for _, node := range section.nodes {
+			fmt.Fprintf(&b, "- %s%s\n", standupLabel(node), node.Title)
+		}
+	}
+
+	b.WriteString("\n### Blocked\n\n")
+	if len(r.Blocked) == 0 {
+		b.WriteString("- Nothing\n")
+	}
+	for _, blocked := range r.Blocked {
+		fmt.Fprintf(&b, "- %s%s, %s%s\n", standupLabel(blocked.Issue), blocked.Issue.Title, formatBlockedFor(blocked.For), blockedByRefs(blocked.By))
+	}
+
+	b.WriteString("\n### Added mid-cycle\n\n")
+	if len(r.Added) == 0 {
+		b.WriteString("- Nothing\n")
+	}
+	for _, node := range r.Added {
+		fmt.Fprintf(&b, "- %s%s%s\n", standupLabel(node), node.Title, estimateSuffix(node))
+	}
+	return b.String()
+}
+
+// carriedOverLabel names the unfinished issues: carried over once the cycle
+// has ended, still open before
+func (r Retro) carriedOverLabel() string {
+	if r.Ended {
+		return "Carried over"
+	}
+	return "Still open"
+}
+
+// formatBlockedFor renders a blocked duration in days, or hours under a day
+func formatBlockedFor(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("blocked %dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("blocked %.1fd", d.Hours()/24)
+}
+
+// blockedByRefs lists the blockers of an issue (" by CET-1, CET-2")
+func blockedByRefs(by []DisplayNode) string {
+	if len(by) == 0 {
+		return ""
+	}
+	refs := make([]string, len(by))
+	for i, node := range by {
+		refs[i] = node.Title
+		if node.Identifier != "" {
+			refs[i] = node.Identifier
+		}
+	}
+	return " by " + strings.Join(refs, ", ")
+}
+
+// estimateSuffix shows an issue's estimate (" (estimate 5)") when it has one
+func estimateSuffix(node DisplayNode) string {
+	plan, _ := planOf(node)
+	if plan.Estimate <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (estimate %g)", plan.Estimate)
+}
+
+// WithRetroCycle returns a new Model showing the retro of the idx-th cycle
+// (newest first), clamped to the cycles loaded.
+func (m Model) WithRetroCycle(idx int) Model {
+	m.retroCycleIdx = max(min(idx, len(m.Cycles())-1), 0)
+	return m
+}
+
+// retroCycle returns the cycle the Retro view shows
+func (m Model) retroCycle() (Cycle, bool) {
+	cycles := m.Cycles()
+	if m.retroCycleIdx >= len(cycles) {
+		return Cycle{}, false
+	}
+	return cycles[m.retroCycleIdx], true
+}
+
+// openRetro opens the Retro view on the newest cycle that has started
+func (m Model) openRetro() Model {
+	idx := 0
+	if latest, ok := m.LatestCycle(time.Now()); ok {
+		for i, cycle := range m.Cycles() {
+			if cycle.Name == latest.Name {
+				idx = i
+				break
+			}
+		}
+	}
+	return m.PushView(ViewRetro).WithRetroCycle(idx)
+}
+
+// handleRetroKeys processes keys in the Retro view.
+func (m Model) handleRetroKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "[", "h", "left":
+		// Older cycle
+		return m.WithRetroCycle(m.retroCycleIdx + 1), nil
+	case "]", "l", "right":
+		// Newer cycle
+		return m.WithRetroCycle(m.retroCycleIdx - 1), nil
+	case "y":
+		// Copy the retro as Markdown
+		if cycle, ok := m.retroCycle(); ok {
+			if retro, ok := m.Retro(cycle.Name, time.Now()); ok {
+				return m.logActivity(ActivityExport, "Copied the "+cycle.Name+" retro"), copyToClipboard(retro.Markdown(), "Retro")
+			}
+		}
+		return m, nil
+	case "esc", "E":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
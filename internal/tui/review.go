@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// Review states reported by GitHub for a pull request
+const (
+	ReviewApproved         = "approved"
+	ReviewChangesRequested = "changes_requested"
+	ReviewRequired         = "review_required"
+)
+
+// Mergeability values reported by GitHub
+const (
+	MergeableClean       = "mergeable"
+	MergeableConflicting = "conflicting"
+)
+
+// PRReview is the review status of a pull request (empty for other nodes).
+type PRReview struct {
+	State              string   // approved | changes_requested | review_required
+	Approvals          int      // Reviews currently approving
+	ChangesRequested   int      // Reviews currently requesting changes
+	RequestedReviewers []string // Logins (or team slugs) still asked to review
+	Mergeable          string   // mergeable | conflicting | "" when unknown
+}
+
+// reviewFromNode extracts review status from a PR node's data.
+func reviewFromNode(node graph.Node) PRReview {
+	if node.Type != graph.NodeTypePR {
+		return PRReview{}
+	}
+	var data PRData
+	if err := json.Unmarshal(node.Data, &data); err != nil {
+		return PRReview{}
+	}
+	return data.review()
+}
+
+// review is the review status a PR's data carries.
+func (d PRData) review() PRReview {
+	return PRReview{
+		State:              d.ReviewState,
+		Approvals:          d.Approvals,
+		ChangesRequested:   d.ChangesRequested,
+		RequestedReviewers: d.RequestedReviewers,
+		Mergeable:          d.Mergeable,
+	}
+}
+
+// IsRequested reports whether login is among the requested reviewers.
+func (r PRReview) IsRequested(login string) bool {
+	login = strings.TrimPrefix(login, "@")
+	if login == "" {
+		return false
+	}
+	for _, reviewer := range r.RequestedReviewers {
+		if strings.EqualFold(strings.TrimPrefix(reviewer, "@"), login) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsReviewFrom reports whether an open PR is waiting on login's review.
+func needsReviewFrom(node DisplayNode, login string) bool {
+	if node.Type != graph.NodeTypePR || !StatusNotDone.MatchesStatus(node.Status) {
+		return false
+	}
+	return node.Review.IsRequested(login)
+}
+
+// reviewBadge renders review counts for a PR tree row, e.g. "✅2 ❌1".
+// Accessible mode spells the counts out instead of relying on emoji.
+func reviewBadge(review PRReview, accessible bool) string {
+	var parts []string
+	if accessible {
+		if review.Approvals > 0 {
+			parts = append(parts, fmt.Sprintf("%d approved", review.Approvals))
+		}
+		if review.ChangesRequested > 0 {
+			parts = append(parts, fmt.Sprintf("%d changes requested", review.ChangesRequested))
+		}
+		if review.Mergeable == MergeableConflicting {
+			parts = append(parts, "merge conflicts")
+		}
+		if len(parts) == 0 {
+			return ""
+		}
+		return "(" + strings.Join(parts, ", ") + ")"
+	}
+
+	if review.Approvals > 0 {
+		parts = append(parts, fmt.Sprintf("✅%d", review.Approvals))
+	}
+	if review.ChangesRequested > 0 {
+		parts = append(parts, fmt.Sprintf("❌%d", review.ChangesRequested))
+	}
+	if review.Mergeable == MergeableConflicting {
+		parts = append(parts, "⚠ conflicts")
+	}
+	return strings.Join(parts, " ")
+}
+
+// reviewStateLabel returns a human-readable review state for the Details view.
+func reviewStateLabel(state string) string {
+	switch state {
+	case ReviewApproved:
+		return "Approved"
+	case ReviewChangesRequested:
+		return "Changes requested"
+	case ReviewRequired:
+		return "Review required"
+	default:
+		return state
+	}
+}
+
+// WithViewer returns a new Model that knows the viewer's GitHub login,
+// used by the "needs my review" filter.
+func (m Model) WithViewer(login string) Model {
+	m.viewer = strings.TrimPrefix(login, "@")
+	return m
+}
+
+// WithNeedsReview returns a new Model with the "needs my review" filter toggled.
+// It narrows the graph to open PRs that request the viewer's review.
+func (m Model) WithNeedsReview(enabled bool) Model {
+	if enabled && m.viewer == "" {
+		return m.WithStatus("Set --me or GITHUB_USER to filter PRs needing your review", true)
+	}
+	m.needsReview = enabled
+	m.graphScroll = 0
+	return m.refocusFiltered()
+}
+
+// IsNeedsReview reports whether the "needs my review" filter is active.
+func (m Model) IsNeedsReview() bool {
+	return m.needsReview
+}
+
+// renderReviewDetails renders the review lines shown in the Details view for a PR.
+func (m Model) renderReviewDetails(review PRReview) []string {
+	var lines []string
+	labelStyle := lipgloss.NewStyle().Foreground(styles.Muted).Bold(true)
+
+	if review.State != "" {
+		var stateColor lipgloss.TerminalColor = styles.Muted
+		switch review.State {
+		case ReviewApproved:
+			stateColor = styles.StatusDone
+		case ReviewChangesRequested:
+			stateColor = styles.StatusCanceled
+		}
+		state := reviewStateLabel(review.State)
+		if badge := reviewBadge(review, m.accessible); badge != "" {
+			state += "  " + badge
+		}
+		lines = append(lines, labelStyle.Render("👀 Review: ")+lipgloss.NewStyle().Foreground(stateColor).Bold(true).Render(state))
+	}
+
+	if len(review.RequestedReviewers) > 0 {
+		reviewers := make([]string, len(review.RequestedReviewers))
+		for i, r := range review.RequestedReviewers {
+			reviewers[i] = "@" + strings.TrimPrefix(r, "@")
+			if m.viewer != "" && strings.EqualFold(strings.TrimPrefix(r, "@"), m.viewer) {
+				reviewers[i] += " (you)"
+			}
+		}
+		lines = append(lines, labelStyle.Render("Requested: ")+strings.Join(reviewers, ", "))
+	}
+
+	switch review.Mergeable {
+	case MergeableClean:
+		lines = append(lines, labelStyle.Render("Mergeable: ")+lipgloss.NewStyle().Foreground(styles.StatusDone).Render("yes"))
+	case MergeableConflicting:
+		lines = append(lines, labelStyle.Render("Mergeable: ")+lipgloss.NewStyle().Foreground(styles.StatusCanceled).Render("no - merge conflicts"))
+	}
+
+	return lines
+}
@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// ReviewQueueItem is an open PR waiting on the viewer's review.
+type ReviewQueueItem struct {
+	PR     DisplayNode
+	Age    time.Duration // Time since the PR was opened
+	Viewed bool          // Marked viewed locally since its last update
+}
+
+// ReviewQueue lists open PRs requesting the viewer's review, oldest first.
+// A PR requests review by login (GitHub reviewers) or through an assigned_to
+// edge to the viewer's Person node.
+func (m Model) ReviewQueue(now time.Time) []ReviewQueueItem {
+	requested := make(map[string]bool)
+	if me, ok := m.ViewerPerson(); ok {
+		for _, edge := range m.edges {
+			if edge.Relation == graph.EdgeAssignedTo && edge.ToID == me.ID {
+				requested[edge.FromID] = true
+			}
+		}
+	}
+
+	var queue []ReviewQueueItem
+	for _, node := range m.nodes {
+		if node.Type != graph.NodeTypePR || !StatusNotDone.MatchesStatus(node.Status) {
+			continue
+		}
+		if !requested[node.ID] && !needsReviewFrom(node, m.viewer) {
+			continue
+		}
+		viewedAt, viewed := m.reviewedPRs[node.ID]
+		queue = append(queue, ReviewQueueItem{
+			PR:     node,
+			Age:    now.Sub(node.CreatedAt),
+			Viewed: viewed && !viewedAt.Before(node.UpdatedAt),
+		})
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		if queue[i].Age != queue[j].Age {
+			return queue[i].Age > queue[j].Age
+		}
+		return queue[i].PR.ID < queue[j].PR.ID
+	})
+	return queue
+}
+
+// WithReviewedPRs returns a new Model with the PRs marked viewed locally.
+// Marks are written back to reviewedPath.
+func (m Model) WithReviewedPRs(viewed map[string]time.Time, reviewedPath string) Model {
+	m.reviewedPRs = viewed
+	m.reviewedPath = reviewedPath
+	return m
+}
+
+// WithSelectedReviewIdx returns a new Model with the queue selection moved (wrapping).
+func (m Model) WithSelectedReviewIdx(idx int) Model {
+	count := len(m.ReviewQueue(time.Now()))
+	if count == 0 {
+		m.selectedReviewIdx = 0
+		return m
+	}
+	m.selectedReviewIdx = (idx%count + count) % count
+	return m
+}
+
+// toggleReviewed flips the viewed mark of a PR. Marks are stamped with the
+// current time so that a later push to the PR brings it back as unviewed.
+func (m Model) toggleReviewed(item ReviewQueueItem, now time.Time) Model {
+	viewed := make(map[string]time.Time, len(m.reviewedPRs)+1)
+	for id, at := range m.reviewedPRs {
+		viewed[id] = at
+	}
+	if item.Viewed {
+		delete(viewed, item.PR.ID)
+	} else {
+		viewed[item.PR.ID] = now
+	}
+	m.reviewedPRs = viewed
+	return m
+}
+
+// handleReviewQueueKeys processes keys in the Review queue view.
+func (m Model) handleReviewQueueKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	queue := m.ReviewQueue(time.Now())
+	var selected *ReviewQueueItem
+	if m.selectedReviewIdx < len(queue) {
+		selected = &queue[m.selectedReviewIdx]
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		return m.WithSelectedReviewIdx(m.selectedReviewIdx + 1), nil
+	case "k", "up":
+		return m.WithSelectedReviewIdx(m.selectedReviewIdx - 1), nil
+	case "o":
+		if selected == nil {
+			return m, nil
+		}
+		return m, openInBrowser(selected.PR.URL)
+	case "y":
+		if selected == nil {
+			return m, nil
+		}
+		return m, copyToClipboard(selected.PR.URL, "URL")
+	case "x", " ":
+		// Mark viewed (local only - GitHub is not told)
+		if selected == nil {
+			return m, nil
+		}
+		m = m.toggleReviewed(*selected, time.Now())
+		return m, m.writes.track(writeReviewedPRs(m.reviewedPath, m.reviewedPRs))
+	case "enter":
+		// Show the PR in the graph
+		if selected == nil {
+			return m, nil
+		}
+		return m.PopView().WithFocusedNode(selected.PR.ID), nil
+	case "esc", "W":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
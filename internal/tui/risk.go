@@ -0,0 +1,206 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// FileRisk is a file's hotspot score: how often it changes times how much
+// open work points at it.
+type FileRisk struct {
+	File   DisplayNode
+	Churn  int     // Recent commits touching the file
+	Issues int     // Open issues referencing it, directly or through its commits and PRs
+	Score  int     // Churn × (1 + Issues)
+	Heat   float64 // Score relative to the riskiest file, 0-1
+}
+
+// FileRisks ranks files by risk, riskiest first; files that never changed
+// are left out. Pure function over the model's unfiltered graph.
+func (m Model) FileRisks() []FileRisk {
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+	isOpenIssue := func(id string) bool {
+		node, ok := nodeByID[id]
+		return ok && node.Type == graph.NodeTypeIssue && !styles.CategoryOf(node.Status).IsDone()
+	}
+
+	// Commits and PRs touching each file, and the issues each change references
+	touchedBy := map[string][]string{}
+	changeIssues := map[string][]string{}
+	fileIssues := map[string]map[string]bool{}
+	for _, edge := range m.edges {
+		from, ok := nodeByID[edge.FromID]
+		if !ok {
+			continue
+		}
+		switch {
+		case edge.Relation == graph.EdgeModifies && (from.Type == graph.NodeTypeCommit || from.Type == graph.NodeTypePR):
+			touchedBy[edge.ToID] = append(touchedBy[edge.ToID], edge.FromID)
+		case (edge.Relation == graph.EdgeImplements || edge.Relation == graph.EdgeMentions) && isOpenIssue(edge.ToID):
+			changeIssues[edge.FromID] = append(changeIssues[edge.FromID], edge.ToID)
+		}
+		// An issue naming the file itself
+		if isOpenIssue(edge.FromID) && nodeByID[edge.ToID].Type == graph.NodeTypeFile {
+			if fileIssues[edge.ToID] == nil {
+				fileIssues[edge.ToID] = map[string]bool{}
+			}
+			fileIssues[edge.ToID][edge.FromID] = true
+		}
+	}
+
+	var risks []FileRisk
+	top := 0
+	for _, node := range m.nodes {
+		if node.Type != graph.NodeTypeFile {
+			continue
+		}
+		commits := 0
+		issues := fileIssues[node.ID]
+		if issues == nil {
+			issues = map[string]bool{}
+		}
+		for _, change := range touchedBy[node.ID] {
+			if nodeByID[change].Type == graph.NodeTypeCommit {
+				commits++
+			}
+			for _, issue := range changeIssues[change] {
+				issues[issue] = true
+			}
+		}
+		churn := max(fileChurn(node), commits)
+		if churn == 0 {
+			continue
+		}
+		risk := FileRisk{File: node, Churn: churn, Issues: len(issues), Score: churn * (1 + len(issues))}
+		top = max(top, risk.Score)
+		risks = append(risks, risk)
+	}
+	for i := range risks {
+		risks[i].Heat = float64(risks[i].Score) / float64(top)
+	}
+
+	sort.Slice(risks, func(i, j int) bool {
+		if risks[i].Score != risks[j].Score {
+			return risks[i].Score > risks[j].Score
+		}
+		return risks[i].File.Title < risks[j].File.Title
+	})
+	return risks
+}
+
+// fileChurn reads the commit count the file scan recorded with ownership
+func fileChurn(node DisplayNode) int {
+	var data struct {
+		Commits int `json:"owner_commits"`
+	}
+	_ = json.Unmarshal(node.Data, &data)
+	return data.Commits
+}
+
+// riskIndex keys FileRisks by file ID, for heat-coloring the Files tree
+func (m Model) riskIndex() map[string]FileRisk {
+	risks := m.FileRisks()
+	index := make(map[string]FileRisk, len(risks))
+	for _, risk := range risks {
+		index[risk.File.ID] = risk
+	}
+	return index
+}
+
+// riskBadge summarizes a file's risk for its tree row
+func riskBadge(risk FileRisk) string {
+	return fmt.Sprintf("[risk %d · churn %d · issues %d]", risk.Score, risk.Churn, risk.Issues)
+}
+
+// WithSelectedRiskIdx returns a new Model with the given hotspot selected,
+// wrapping around the list.
+func (m Model) WithSelectedRiskIdx(idx int) Model {
+	count := len(m.FileRisks())
+	if count == 0 {
+		m.selectedRiskIdx = 0
+		return m
+	}
+	m.selectedRiskIdx = (idx%count + count) % count
+	return m
+}
+
+// handleHotspotsKeys processes keys in the Hotspots view.
+func (m Model) handleHotspotsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		return m.WithSelectedRiskIdx(m.selectedRiskIdx + 1), nil
+	case "k", "up":
+		return m.WithSelectedRiskIdx(m.selectedRiskIdx - 1), nil
+	case "enter":
+		// Show the file in the Files tree
+		risks := m.FileRisks()
+		if m.selectedRiskIdx >= len(risks) {
+			return m, nil
+		}
+		return m.PopView().WithView(ViewGraph).WithFilterMode(FilterFiles).WithFocusedNode(risks[m.selectedRiskIdx].File.ID), nil
+	case "esc", "H":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// renderHotspotsView renders files ranked by risk, each with a heat bar.
+func (m Model) renderHotspotsView(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Accent).
+		Width(width).
+		Align(lipgloss.Center).
+		MarginBottom(1)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	var builder strings.Builder
+	builder.WriteString(titleStyle.Render("🔥 Hotspots — churn × open issues"))
+	builder.WriteString("\n")
+
+	risks := m.FileRisks()
+	if len(risks) == 0 {
+		builder.WriteString(lipgloss.NewStyle().
+			Width(width).
+			Height(height-3).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render(styles.LoadingStyle.Render("No file has commit history loaded.")))
+		return builder.String()
+	}
+
+	const barWidth = 20
+	scoreWidth := len(fmt.Sprint(risks[0].Score))
+	lines := []string{mutedStyle.Render(fmt.Sprintf("  %-*s  %-*s  commits  issues  file", barWidth, "heat", scoreWidth, "risk"))}
+	visible := max(height-4, 1)
+	offset := max(m.selectedRiskIdx-visible+1, 0)
+	for i := offset; i < len(risks) && i < offset+visible; i++ {
+		risk := risks[i]
+		filled := max(int(risk.Heat*barWidth+0.5), 1)
+		bar := lipgloss.NewStyle().Foreground(styles.HeatColor(risk.Heat)).Render(strings.Repeat("█", filled)) +
+			mutedStyle.Render(strings.Repeat("░", barWidth-filled))
+		text := fmt.Sprintf("  %*d  %7d  %6d  %s", scoreWidth, risk.Score, risk.Churn, risk.Issues,
+			truncate(risk.File.Title, clampMin(width-barWidth-scoreWidth-24, 10)))
+		line := "  " + bar + text
+		if i == m.selectedRiskIdx {
+			line = "▸ " + bar + treeFocusStyle.Render(text)
+			if m.accessible {
+				line += " " + selectedMarker
+			}
+		}
+		lines = append(lines, line)
+	}
+	builder.WriteString(strings.Join(lines, "\n"))
+	return builder.String()
+}
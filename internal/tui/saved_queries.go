@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/config"
+)
+
+// WithSavedQueries returns a new Model with saved queries from config and from
+// the interactive queries file. New queries are written back to queriesPath.
+func (m Model) WithSavedQueries(fromConfig, fromUser []config.SavedQuery, queriesPath string) Model {
+	m.configQueries = fromConfig
+	m.userQueries = fromUser
+	m.queriesPath = queriesPath
+	return m
+}
+
+// SavedQueries returns all saved queries: config-defined first, then user-saved.
+func (m Model) SavedQueries() []config.SavedQuery {
+	all := make([]config.SavedQuery, 0, len(m.configQueries)+len(m.userQueries))
+	all = append(all, m.configQueries...)
+	return append(all, m.userQueries...)
+}
+
+// GetActiveQuery returns the name of the applied saved query, if any.
+func (m Model) GetActiveQuery() string {
+	return m.activeQuery
+}
+
+// WithSelectedQueryIdx returns a new Model with the sidebar selection moved (wrapping).
+func (m Model) WithSelectedQueryIdx(idx int) Model {
+	count := len(m.SavedQueries())
+	if count == 0 {
+		m.selectedQueryIdx = 0
+		return m
+	}
+	m.selectedQueryIdx = (idx%count + count) % count
+	return m
+}
+
+// WithQueryNameMode returns a new Model with the "save as" name prompt enabled/disabled.
+func (m Model) WithQueryNameMode(enabled bool) Model {
+	m.queryNameMode = enabled
+	m.queryName = ""
+	return m
+}
+
+// currentAsSavedQuery captures the active filters, including the SQL of an
+// applied SQL-backed view, as an unnamed saved query.
+func (m Model) currentAsSavedQuery() config.SavedQuery {
+	q := config.SavedQuery{Search: m.searchQuery, SQL: m.activeSQL}
+	if m.filterMode != FilterAll {
+		q.Filter = m.filterMode.Key()
+	}
+	if m.statusFilter != StatusAll {
+		q.Status = m.statusFilter.Key()
+	}
+	return q
+}
+
+// applySavedQuery makes the query the active graph filter. SQL-backed queries
+// resolve their node IDs asynchronously via the store.
+func (m Model) applySavedQuery(q config.SavedQuery) (Model, tea.Cmd) {
+	filter, ok := ParseFilterMode(q.Filter)
+	if !ok {
+		filter = FilterAll
+	}
+	status, ok := ParseStatusFilter(q.Status)
+	if !ok {
+		status = StatusAll
+	}
+
+	m = m.WithFilterMode(filter).WithStatusFilter(status).WithSearchQuery(q.Search)
+	m.activeQuery = q.Name
+	m.activeSQL = q.SQL
+	m.idFilter = nil
+	m = m.WithView(ViewGraph).WithGraphScroll(0).refocusFiltered()
+
+	if q.SQL != "" {
+		return m, runSavedQuerySQL(m.ctx, m.store, q.Name, q.SQL)
+	}
+	return m, nil
+}
+
+// WithSavedQueryIDs restricts the graph to the IDs returned by a saved query's SQL.
+// Results for a query that is no longer active are ignored.
+func (m Model) WithSavedQueryIDs(name string, ids []string) Model {
+	if name != m.activeQuery {
+		return m
+	}
+	m.idFilter = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		m.idFilter[id] = true
+	}
+	return m.WithGraphScroll(0).refocusFiltered()
+}
+
+// clearSavedQuery removes the active saved query and restores default filters.
+func (m Model) clearSavedQuery() Model {
+	m.activeQuery = ""
+	m.activeSQL = ""
+	m.idFilter = nil
+	m = m.WithFilterMode(FilterProjects).WithStatusFilter(StatusAll).WithSearchQuery("")
+	return m.WithGraphScroll(0).refocusFiltered()
+}
+
+// refocusFiltered moves focus to the first visible node if the current one is filtered out.
+func (m Model) refocusFiltered() Model {
+	filteredNodes := m.GetFilteredNodes()
+	if len(filteredNodes) == 0 {
+		return m
+	}
+	for _, node := range filteredNodes {
+		if node.ID == m.focusedNode {
+			return m
+		}
+	}
+	return m.WithFocusedNode(filteredNodes[0].ID)
+}
+
+// handleQueriesKeys processes keys in the Views sidebar.
+func (m Model) handleQueriesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		return m.WithSelectedQueryIdx(m.selectedQueryIdx + 1), nil
+	case "k", "up":
+		return m.WithSelectedQueryIdx(m.selectedQueryIdx - 1), nil
+	case "enter":
+		queries := m.SavedQueries()
+		if len(queries) == 0 || m.selectedQueryIdx >= len(queries) {
+			return m, nil
+		}
+		m.navStack = NewNavigationStack()
+		return m.applySavedQuery(queries[m.selectedQueryIdx])
+	case "a":
+		// Save the current filter combination under a new name
+		return m.WithQueryNameMode(true), nil
+	case "esc", "v":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// handleQueryNameInput processes input while naming a new saved query.
+func (m Model) handleQueryNameInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		return m.WithQueryNameMode(false), nil
+
+	case tea.KeyEnter:
+		name := m.queryName
+		m = m.WithQueryNameMode(false)
+		if name == "" {
+			return m, nil
+		}
+		for _, q := range m.SavedQueries() {
+			if q.Name == name {
+				return m.WithStatus(fmt.Sprintf("A view named %q already exists", name), true), nil
+			}
+		}
+
+		q := m.currentAsSavedQuery()
+		q.Name = name
+		userQueries := make([]config.SavedQuery, len(m.userQueries), len(m.userQueries)+1)
+		copy(userQueries, m.userQueries)
+		m.userQueries = append(userQueries, q)
+		m = m.WithSelectedQueryIdx(len(m.SavedQueries()) - 1)
+		return m, m.writes.track(writeSavedQueries(m.queriesPath, m.userQueries))
+
+	case tea.KeyBackspace:
+		if len(m.queryName) > 0 {
+			m.queryName = dropLastRune(m.queryName)
+		}
+		return m, nil
+
+	case tea.KeySpace:
+		m.queryName += " "
+		return m, nil
+
+	case tea.KeyRunes:
+		m.queryName += string(msg.Runes)
+		return m, nil
+
+	case tea.KeyCtrlC:
+		return m.quit()
+	}
+
+	return m, nil
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// SourceRefresher reloads the data sources (the data source loader): all
+// of them, or only those that produced a node, leaving the rest as loaded.
+type SourceRefresher interface {
+	// LoadAll reloads every source
+	LoadAll(ctx context.Context) ([]graph.Node, []graph.Edge, error)
+	// SourcesOf names the sources that produced nodeID
+	SourcesOf(nodeID string) []string
+	// RefreshNode reloads those sources, returning what they produce now,
+	// the edges touching it and the IDs they no longer produce
+	RefreshNode(ctx context.Context, nodeID string) ([]graph.Node, []graph.Edge, []string, error)
+	// Diagnostics is what the last sync reported as it ran, in order
+	Diagnostics() []string
+	// Warnings is the diagnostics about something that went wrong
+	Warnings() []string
+}
+
+// WithSourceRefresh returns a new Model whose syncs reload the sources (r,
+// space s) or only the focused node's (R, space r).
+func (m Model) WithSourceRefresh(refresher SourceRefresher) Model {
+	m.refresher = refresher
+	return m
+}
+
+// syncRunning is the status shown when a sync is asked for while one runs
+const syncRunning = "A sync is already running"
+
+// syncBusy reports whether a sync or the initial load is still running: the
+// loader runs one at a time, so another waits for it to report back.
+func (m Model) syncBusy() bool {
+	return m.syncing || m.loading
+}
+
+// refreshFocused starts reloading the sources behind the focused node; on
+// a project node that is the repository's git and file scans.
+func (m Model) refreshFocused() (tea.Model, tea.Cmd) {
+	if m.refresher == nil {
+		return m.WithStatus("Scoped refresh is not available for this graph", true), nil
+	}
+	node, ok := m.GetFocusedNode()
+	if !ok {
+		return m, nil
+	}
+	if m.syncBusy() {
+		return m.WithStatus(syncRunning, true), nil
+	}
+	sources := m.refresher.SourcesOf(node.ID)
+	if len(sources) == 0 {
+		return m.WithStatus(fmt.Sprintf("No source loaded %s; space s syncs everything", node.Title), true), nil
+	}
+	m = m.WithLoading(true).WithStatus(fmt.Sprintf("Resyncing %s…", strings.Join(sources, ", ")), false)
+	m.syncing = true
+	refresh := refreshSources(m.ctx, m.refresher, node.ID, sources, m.nodes, m.edges)
+	return m, tea.Batch(m.dispatcher.Dispatch(m.ctx, refresh, m.dispatcher.syncProviders(sources)...), m.spinner.Tick)
+}
+
+// refreshSources reloads the sources behind nodeID, returning how they
+// changed the graph as loaded when the refresh began
+func refreshSources(ctx context.Context, refresher SourceRefresher, nodeID string, sources []string, nodes []DisplayNode, edges []DisplayEdge) tea.Cmd {
+	return func() tea.Msg {
+		fresh, freshEdges, removed, err := refresher.RefreshNode(ctx, nodeID)
+		if err != nil {
+			return SyncFailedMsg{Err: fmt.Errorf("resync failed: %w", err), Diagnostics: refresher.Diagnostics()}
+		}
+		delta := graphDelta(nodes, edges, displayNodesFromGraph(fresh), EdgesToDisplayEdges(freshEdges), removed)
+		delta.Sources = sources
+		delta.Focus = nodeID
+		delta.Diagnostics, delta.Warnings = refresher.Diagnostics(), refresher.Warnings()
+		return delta
+	}
+}
+
+// reloadSources reloads every source, returning how they changed the graph
+// as loaded when the sync began
+func reloadSources(ctx context.Context, refresher SourceRefresher, nodes []DisplayNode, edges []DisplayEdge) tea.Cmd {
+	return func() tea.Msg {
+		fresh, freshEdges, err := refresher.LoadAll(ctx)
+		if err != nil {
+			return SyncFailedMsg{Err: fmt.Errorf("sync failed: %w", err), Diagnostics: refresher.Diagnostics()}
+		}
+		delta := fullDelta(nodes, edges, displayNodesFromGraph(fresh), EdgesToDisplayEdges(freshEdges))
+		delta.Diagnostics, delta.Warnings = refresher.Diagnostics(), refresher.Warnings()
+		return delta
+	}
+}
+
+// withSynced merges a finished sync's delta into the graph and reports it.
+func (m Model) withSynced(delta GraphDeltaMsg) Model {
+	m = m.withDelta(delta).WithLoading(false)
+	m.syncing = false
+	sources := "all sources"
+	if delta.Sources != nil {
+		sources = strings.Join(delta.Sources, ", ")
+	} else {
+		m.loadedAt = time.Now()
+	}
+	m = m.logDiagnostics(delta.Diagnostics).logActivity(ActivitySync, "Synced "+sources)
+	if len(delta.Warnings) > 0 {
+		return m.WithStatus(fmt.Sprintf("Synced %s with %d warnings (activity log): %s", sources, len(delta.Warnings), delta.Warnings[0]), true)
+	}
+	if delta.Empty() {
+		return m.WithStatus(fmt.Sprintf("Synced %s: no changes", sources), false)
+	}
+	return m.WithStatus(fmt.Sprintf("Synced %s: %d added, %d updated, %d removed", sources,
+		len(delta.AddedNodes), len(delta.UpdatedNodes), len(delta.RemovedNodes)), false)
+}
+
+// logDiagnostics adds what a sync reported as it ran to the activity log
+func (m Model) logDiagnostics(diagnostics []string) Model {
+	for _, diagnostic := range diagnostics {
+		m = m.logActivity(ActivitySync, diagnostic)
+	}
+	return m
+}
@@ -0,0 +1,19 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// WithAltScreen records whether the program runs on the alternate screen.
+// Inline (false), the last frame stays in the terminal's scrollback on exit.
+func (m Model) WithAltScreen(enabled bool) Model {
+	m.altScreen = enabled
+	return m
+}
+
+// toggleAltScreen switches between the alternate screen and inline output
+func (m Model) toggleAltScreen() (Model, tea.Cmd) {
+	m.altScreen = !m.altScreen
+	if m.altScreen {
+		return m.WithStatus("Full screen", false), tea.EnterAltScreen
+	}
+	return m.WithStatus("Inline: the last screen stays in scrollback after quitting (I for full screen)", false), tea.ExitAltScreen
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/manutej/maat-terminal/internal/script"
+)
+
+// WithScript returns a new Model whose graph view is customized by the
+// user's Starlark rules (hidden nodes, title decorations, computed badges).
+func (m Model) WithScript(engine *script.Engine) Model {
+	m.script = engine
+	return m.withScriptResults()
+}
+
+// withScriptResults re-runs the rules over the current graph. Rules see
+// static node data, so results are computed once per load, not per frame.
+func (m Model) withScriptResults() Model {
+	if m.script == nil {
+		m.scriptResults = nil
+		return m
+	}
+
+	degree := make(map[string]int, len(m.nodes))
+	for _, edge := range m.edges {
+		degree[edge.FromID]++
+		degree[edge.ToID]++
+	}
+	nodes := make([]script.Node, len(m.nodes))
+	for i, node := range m.nodes {
+		nodes[i] = script.Node{
+			ID:          node.ID,
+			Type:        string(node.Type),
+			Title:       node.Title,
+			Identifier:  node.Identifier,
+			Status:      node.Status,
+			Description: node.Description,
+			Priority:    node.Priority,
+			Labels:      node.Labels,
+			Owner:       node.Owner,
+			CreatedAt:   node.CreatedAt,
+			UpdatedAt:   node.UpdatedAt,
+			Edges:       degree[node.ID],
+		}
+	}
+
+	results, err := m.script.Eval(nodes, time.Now())
+	m.scriptResults = results
+	if err != nil {
+		// Backtraces end with the error itself - the status bar has room for one line
+		lines := strings.Split(strings.TrimSpace(err.Error()), "\n")
+		m = m.WithStatus("Script: "+strings.TrimSpace(lines[len(lines)-1]), true)
+	}
+	return m
+}
+
+// scriptHidden reports whether the rules' filter hides the node.
+func (m Model) scriptHidden(nodeID string) bool {
+	return m.scriptResults[nodeID].Hidden
+}
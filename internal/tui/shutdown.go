@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/config"
+)
+
+// ShutdownTimeout bounds how long quitting waits for pending writes.
+const ShutdownTimeout = 10 * time.Second
+
+// writesPollInterval is how often quitting checks whether writes finished
+const writesPollInterval = 50 * time.Millisecond
+
+// inFlight counts write commands that are running. It is shared by every
+// copy of a Model, like the store.
+type inFlight struct {
+	count atomic.Int32
+}
+
+// track returns cmd counted as in flight while it runs. A command Bubble Tea
+// never runs (dropped on quit) is never counted, so it can't hold up quitting.
+func (f *inFlight) track(cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		f.count.Add(1)
+		defer f.count.Add(-1)
+		return cmd()
+	}
+}
+
+// pending returns how many tracked commands are still running
+func (f *inFlight) pending() int {
+	return int(f.count.Load())
+}
+
+// wait polls until every tracked command finished or the timeout passed,
+// and reports whether they all finished
+func (f *inFlight) wait(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for f.pending() > 0 {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(writesPollInterval)
+	}
+	return true
+}
+
+// pollWrites checks on pending writes again after a short while
+func pollWrites(deadline time.Time) tea.Cmd {
+	return tea.Tick(writesPollInterval, func(time.Time) tea.Msg {
+		return WritesPolledMsg{Deadline: deadline}
+	})
+}
+
+// withWritesPolled quits once pending writes finished or the deadline
+// passed, and keeps polling otherwise
+func (m Model) withWritesPolled(msg WritesPolledMsg) (Model, tea.Cmd) {
+	pending := m.writes.pending()
+	if pending == 0 || !time.Now().Before(msg.Deadline) {
+		return m, tea.Quit
+	}
+	m = m.WithStatus(fmt.Sprintf("Saving… waiting for %d pending writes (ctrl+c to quit now)", pending), false)
+	return m, pollWrites(msg.Deadline)
+}
+
+// WithContext returns a new Model whose reads (SQL queries) run under ctx.
+// Quitting cancels it; main passes a context that an interrupt cancels too.
+func (m Model) WithContext(ctx context.Context) Model {
+	m.ctx, m.cancel = context.WithCancel(ctx)
+	m.writes = &inFlight{}
+	return m
+}
+
+// WithSessionState returns a new Model resumed where a previous session left
+// off, saving its own state to path on quit ("" = not saved). Nodes or
+// filters that no longer exist are ignored.
+func (m Model) WithSessionState(state config.SessionState, path string) Model {
+	m.sessionPath = path
+	if filter, ok := ParseFilterMode(state.Filter); ok {
+		m = m.WithFilterMode(filter)
+	}
+	if status, ok := ParseStatusFilter(state.Status); ok {
+		m = m.WithStatusFilter(status)
+	}
+	if _, ok := m.GetNodeByID(state.Focused); ok {
+		m = m.WithFocusedNode(state.Focused)
+	}
+	return m
+}
+
+// SessionState returns what is saved on quit.
+func (m Model) SessionState() config.SessionState {
+	return config.SessionState{
+		Focused: m.focusedNode,
+		Filter:  m.filterMode.Key(),
+		Status:  m.statusFilter.Key(),
+	}
+}
+
+// PendingWrites returns how many writes (external actions, saved views,
+// viewed marks) have not finished yet. The count is shared by every copy of
+// the model, so main can check it after the program exited by other means
+// (a signal).
+func (m Model) PendingWrites() int {
+	return m.writes.pending()
+}
+
+// WaitForWrites blocks until pending writes finish or timeout passes, and
+// reports whether they all finished. It is for main, once the program has
+// exited; the TUI polls with WritesPolledMsg instead.
+func (m Model) WaitForWrites(timeout time.Duration) bool {
+	return m.writes.wait(timeout)
+}
+
+// quit shuts down gracefully: outstanding reads are cancelled, the session
+// state is saved, and pending writes are given time to finish behind a
+// "Saving…" notice. Quitting again while waiting exits at once.
+func (m Model) quit() (Model, tea.Cmd) {
+	if m.quitting {
+		return m, tea.Quit
+	}
+	m.quitting = true
+	m.cancel()
+
+	save := tea.Sequence(saveSession(m.sessionPath, m.SessionState()), exportActivity(m.activityPath, m.activity))
+	pending := m.writes.pending()
+	if pending == 0 {
+		return m, tea.Sequence(save, tea.Quit)
+	}
+	m = m.WithStatus(fmt.Sprintf("Saving… waiting for %d pending writes (ctrl+c to quit now)", pending), false)
+	return m, tea.Sequence(save, pollWrites(time.Now().Add(ShutdownTimeout)))
+}
+
+// saveSession persists the session state (local file, not an external write)
+func saveSession(path string, state config.SessionState) tea.Cmd {
+	return func() tea.Msg {
+		if path == "" {
+			return nil
+		}
+		if err := config.WriteSession(path, state); err != nil {
+			return StatusMsg{Message: "Failed to save session: " + err.Error(), IsError: true}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// standupWindow is how far back "yesterday" reaches
+const standupWindow = 24 * time.Hour
+
+// Standup is the viewer's daily update: what they finished, what they're on
+// and what's in their way.
+type Standup struct {
+	Person    DisplayNode
+	Yesterday []DisplayNode // Merged PRs, commits and completed issues, newest first
+	Today     []DisplayNode // In-progress issues
+	Blockers  []DisplayNode // Blocked issues (by status or an open blocker)
+}
+
+// Standup computes the viewer's standup as of now.
+// Pure function over the model's unfiltered graph.
+func (m Model) Standup(now time.Time) (Standup, bool) {
+	me, ok := m.ViewerPerson()
+	if !ok {
+		return Standup{}, false
+	}
+	standup := Standup{Person: me}
+
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+	since := now.Add(-standupWindow)
+
+	// Issues with an open blocker
+	openlyBlocked := map[string]bool{}
+	for _, edge := range m.edges {
+		if edge.Relation == graph.EdgeBlocks && !StatusDone.MatchesStatus(nodeByID[edge.FromID].Status) {
+			openlyBlocked[edge.ToID] = true
+		}
+	}
+
+	yesterday := map[string]bool{}
+	today := map[string]bool{}
+	blockers := map[string]bool{}
+	for _, edge := range m.edges {
+		switch {
+		case edge.Relation == graph.EdgeAuthored && edge.FromID == me.ID:
+			work := nodeByID[edge.ToID]
+			if !work.UpdatedAt.After(since) {
+				continue
+			}
+			if work.Type == graph.NodeTypeCommit || (work.Type == graph.NodeTypePR && StatusDone.MatchesStatus(work.Status)) {
+				yesterday[work.ID] = true
+			}
+		case edge.Relation == graph.EdgeAssignedTo && edge.ToID == me.ID:
+			work := nodeByID[edge.FromID]
+			if work.Type != graph.NodeTypeIssue {
+				continue
+			}
+			switch {
+			case StatusDone.MatchesStatus(work.Status):
+				if work.UpdatedAt.After(since) {
+					yesterday[work.ID] = true
+				}
+			case styles.CategoryOf(work.Status) == styles.CategoryBlocked || openlyBlocked[work.ID]:
+				blockers[work.ID] = true
+			case StatusActive.MatchesStatus(work.Status):
+				today[work.ID] = true
+			}
+		}
+	}
+
+	standup.Yesterday = collectNodes(yesterday, nodeByID)
+	standup.Today = collectNodes(today, nodeByID)
+	standup.Blockers = collectNodes(blockers, nodeByID)
+	sortByRecency(standup.Yesterday)
+	return standup, true
+}
+
+// Markdown renders the standup as plain Markdown, ready to paste or read aloud.
+func (s Standup) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Standup — %s\n", s.Person.Title)
+	for _, section := range []traceGroup{
+		{"Yesterday", s.Yesterday},
+		{"Today", s.Today},
+		{"Blockers", s.Blockers},
+	} {
+		fmt.Fprintf(&b, "\n**%s**\n", section.label)
+		if len(section.nodes) == 0 {
+			b.WriteString("- Nothing\n")
+			continue
+		}
+		for _, node := range section.nodes {
+			fmt.Fprintf(&b, "- %s%s\n", standupLabel(node), node.Title)
+		}
+	}
+	return b.String()
+}
+
+// standupLabel prefixes an entry with its kind and short reference
+func standupLabel(node DisplayNode) string {
+	switch node.Type {
+	case graph.NodeTypePR:
+		return "PR: "
+	case graph.NodeTypeCommit:
+		return "Commit: "
+	}
+	if node.Identifier != "" {
+		return node.Identifier + " "
+	}
+	return ""
+}
+
+// handleStandupKeys processes keys in the Standup view.
+func (m Model) handleStandupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		// Copy the standup as Markdown
+		if standup, ok := m.Standup(time.Now()); ok {
+			return m.logActivity(ActivityExport, "Copied the standup"), copyToClipboard(standup.Markdown(), "Standup")
+		}
+		return m, nil
+	case "esc", "S":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
 )
 
 // ViewMode represents the current full-screen view in single-pane design.
@@ -11,10 +12,26 @@ import (
 type ViewMode int
 
 const (
-	ViewGraph     ViewMode = iota // Full-screen hierarchical graph
-	ViewDetails                   // Full-screen node details
-	ViewRelations                 // Full-screen relationship view
-	ViewConfirm                   // Confirmation dialog (overlay)
+	ViewGraph       ViewMode = iota // Full-screen hierarchical graph
+	ViewDetails                     // Full-screen node details
+	ViewRelations                   // Full-screen relationship view
+	ViewConfirm                     // Confirmation dialog (overlay)
+	ViewSQL                         // Ad-hoc SQL query results (: key)
+	ViewQueries                     // Saved queries sidebar (v key)
+	ViewDashboard                   // Cross-project roll-up dashboard (D key)
+	ViewStandup                     // My yesterday / today / blockers (S key)
+	ViewReviewQueue                 // PRs awaiting my review, oldest first (W key)
+	ViewActionQueue                 // Deferred write actions awaiting one confirmation (A key)
+	ViewArchive                     // Nodes archived past their TTL, restorable (Z key)
+	ViewDiff                        // Highlighted diff of a commit or PR (d key)
+	ViewPreview                     // Highlighted contents of a file (space p)
+	ViewActivity                    // What was done this session (L key)
+	ViewSyncStats                   // What recent syncs cost per source (T key)
+	ViewNewIssue                    // Template menu, then the new-issue form (n key)
+	ViewChecklist                   // A PR's readiness to merge (C key)
+	ViewRetro                       // A cycle's completed, carried-over, blocked and added issues (E key)
+	ViewHotspots                    // Files ranked by churn × open issues (H key)
+	ViewTable                       // Filtered nodes as a table with metric columns (V key)
 )
 
 // FilterMode controls which node types are displayed in the graph
@@ -25,18 +42,20 @@ const (
 	FilterProjects                   // Projects + Issues + PRs only (useful default)
 	FilterIssues                     // Issues only
 	FilterPRs                        // PRs only
-	FilterFiles                      // Files only
+	FilterFiles                      // Files and their Markdown tasks
 	FilterCommits                    // Commits only
+	FilterPeople                     // People and teams only
+	FilterDocs                       // Knowledge base notes and decision threads
 )
 
 // StatusFilter controls which statuses are displayed
 type StatusFilter int
 
 const (
-	StatusAll        StatusFilter = iota // Show all statuses
-	StatusActive                         // In Progress only (active work)
-	StatusNotDone                        // In Progress + Backlog (hide completed)
-	StatusDone                           // Done only (completed work)
+	StatusAll     StatusFilter = iota // Show all statuses
+	StatusActive                      // In Progress only (active work)
+	StatusNotDone                     // In Progress + Backlog (hide completed)
+	StatusDone                        // Done only (completed work)
 )
 
 // StatusFilterString returns the display name for the status filter
@@ -55,6 +74,30 @@ func (s StatusFilter) String() string {
 	}
 }
 
+// Key returns the config name for the status filter (inverse of ParseStatusFilter)
+func (s StatusFilter) Key() string {
+	switch s {
+	case StatusActive:
+		return "active"
+	case StatusNotDone:
+		return "not_done"
+	case StatusDone:
+		return "done"
+	default:
+		return "all"
+	}
+}
+
+// ParseStatusFilter converts a config name (e.g. "not_done") to a StatusFilter
+func ParseStatusFilter(name string) (StatusFilter, bool) {
+	for _, s := range []StatusFilter{StatusAll, StatusActive, StatusNotDone, StatusDone} {
+		if strings.EqualFold(name, s.Key()) {
+			return s, true
+		}
+	}
+	return StatusAll, false
+}
+
 // CycleStatusFilter returns the next status filter
 func (s StatusFilter) CycleStatusFilter() StatusFilter {
 	switch s {
@@ -73,23 +116,20 @@ func (s StatusFilter) CycleStatusFilter() StatusFilter {
 
 // MatchesStatus returns true if the given status passes this filter
 func (s StatusFilter) MatchesStatus(status string) bool {
-	statusLower := strings.ToLower(status)
+	category := styles.CategoryOf(status)
 
 	switch s {
 	case StatusAll:
 		return true
 	case StatusActive:
 		// Only In Progress items
-		return statusLower == "in progress" || statusLower == "in_progress" ||
-			statusLower == "started" || statusLower == "in review"
+		return category == styles.CategoryStarted
 	case StatusNotDone:
 		// Everything except Done
-		return statusLower != "done" && statusLower != "completed" &&
-			statusLower != "merged" && statusLower != "closed"
+		return !category.IsDone()
 	case StatusDone:
 		// Only completed items
-		return statusLower == "done" || statusLower == "completed" ||
-			statusLower == "merged" || statusLower == "closed"
+		return category.IsDone()
 	default:
 		return true
 	}
@@ -101,15 +141,19 @@ func (f FilterMode) Types() []graph.NodeType {
 	case FilterAll:
 		return nil // nil means show all
 	case FilterProjects:
-		return []graph.NodeType{graph.NodeTypeProject, graph.NodeTypeIssue, graph.NodeTypePR, graph.NodeTypeService}
+		return []graph.NodeType{graph.NodeTypeProject, graph.NodeTypeIssue, graph.NodeTypePR, graph.NodeTypeService, graph.NodeTypeRelease, graph.NodeTypeDependency}
 	case FilterIssues:
 		return []graph.NodeType{graph.NodeTypeIssue}
 	case FilterPRs:
 		return []graph.NodeType{graph.NodeTypePR}
 	case FilterFiles:
-		return []graph.NodeType{graph.NodeTypeFile}
+		return []graph.NodeType{graph.NodeTypeFile, graph.NodeTypeTask}
 	case FilterCommits:
 		return []graph.NodeType{graph.NodeTypeCommit}
+	case FilterPeople:
+		return []graph.NodeType{graph.NodeTypePerson, graph.NodeTypeTeam}
+	case FilterDocs:
+		return []graph.NodeType{graph.NodeTypeDocument, graph.NodeTypeDiscussion}
 	default:
 		return nil
 	}
@@ -130,11 +174,30 @@ func (f FilterMode) String() string {
 		return "Files"
 	case FilterCommits:
 		return "Commits"
+	case FilterPeople:
+		return "People"
+	case FilterDocs:
+		return "Docs"
 	default:
 		return "Unknown"
 	}
 }
 
+// Key returns the config name for the filter mode (inverse of ParseFilterMode)
+func (f FilterMode) Key() string {
+	return strings.ToLower(f.String())
+}
+
+// ParseFilterMode converts a config name (e.g. "issues") to a FilterMode
+func ParseFilterMode(name string) (FilterMode, bool) {
+	for _, f := range []FilterMode{FilterAll, FilterProjects, FilterIssues, FilterPRs, FilterFiles, FilterCommits, FilterPeople, FilterDocs} {
+		if strings.EqualFold(name, f.Key()) {
+			return f, true
+		}
+	}
+	return FilterAll, false
+}
+
 // CycleFilter returns the next filter mode
 func (f FilterMode) CycleFilter() FilterMode {
 	switch f {
@@ -147,6 +210,10 @@ func (f FilterMode) CycleFilter() FilterMode {
 	case FilterCommits:
 		return FilterFiles
 	case FilterFiles:
+		return FilterPeople
+	case FilterPeople:
+		return FilterDocs
+	case FilterDocs:
 		return FilterAll
 	case FilterAll:
 		return FilterProjects
@@ -166,6 +233,38 @@ func (v ViewMode) String() string {
 		return "Relations"
 	case ViewConfirm:
 		return "Confirm"
+	case ViewSQL:
+		return "SQL"
+	case ViewQueries:
+		return "Views"
+	case ViewDashboard:
+		return "Dashboard"
+	case ViewStandup:
+		return "Standup"
+	case ViewReviewQueue:
+		return "Review queue"
+	case ViewActionQueue:
+		return "Action queue"
+	case ViewArchive:
+		return "Archive"
+	case ViewDiff:
+		return "Diff"
+	case ViewPreview:
+		return "Preview"
+	case ViewActivity:
+		return "Activity"
+	case ViewSyncStats:
+		return "Sync stats"
+	case ViewNewIssue:
+		return "New issue"
+	case ViewChecklist:
+		return "Checklist"
+	case ViewRetro:
+		return "Retro"
+	case ViewHotspots:
+		return "Hotspots"
+	case ViewTable:
+		return "Table"
 	default:
 		return "Unknown"
 	}
@@ -222,3 +321,34 @@ func (n NavigationStack) Pop() (NavigationStack, ViewMode, bool) {
 func (n NavigationStack) IsEmpty() bool {
 	return len(n.stack) == 0
 }
+
+// RelationSort orders the Relations list within each direction
+type RelationSort int
+
+const (
+	RelationSortRelation RelationSort = iota // Relation type, then target title
+	RelationSortType                         // Target node type, then title
+	RelationSortStatus                       // Open targets first
+	RelationSortRecent                       // Newest edges first
+)
+
+// String returns the display name for the relation sort
+func (s RelationSort) String() string {
+	switch s {
+	case RelationSortRelation:
+		return "relation"
+	case RelationSortType:
+		return "type"
+	case RelationSortStatus:
+		return "status"
+	case RelationSortRecent:
+		return "recent"
+	default:
+		return "unknown"
+	}
+}
+
+// Cycle returns the next relation sort
+func (s RelationSort) Cycle() RelationSort {
+	return (s + 1) % (RelationSortRecent + 1)
+}
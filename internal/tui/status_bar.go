@@ -0,0 +1,277 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// Status bar segments (config "status_bar"), rendered left to right in the
+// configured order. Key hints are always right-aligned wherever they appear.
+const (
+	SegmentView      = "view"       // [Graph], [Details], ...
+	SegmentSavedView = "saved_view" // Applied saved query
+	SegmentMode      = "mode"       // EXEC and roles narrower than IC
+	SegmentFilters   = "filters"    // Type, status, review, my-work, owner, depth and search (Graph view)
+	SegmentQueue     = "queue"      // Deferred write actions
+	SegmentFocused   = "focused"    // Title of the focused node
+	SegmentLoading   = "loading"    // Spinner and per-source progress
+	SegmentSync      = "sync"       // Age of the loaded data
+	SegmentErrors    = "errors"     // Current error, sources that failed to load and sources truncated at their cap
+	SegmentMessage   = "message"    // Transient status messages
+	SegmentKeys      = "keys"       // Key hints for the current view
+)
+
+// defaultStatusBar is the segment order used when none is configured
+var defaultStatusBar = []string{
+	SegmentView, SegmentSavedView, SegmentMode, SegmentFilters, SegmentQueue,
+	SegmentFocused, SegmentLoading, SegmentErrors, SegmentMessage, SegmentKeys,
+}
+
+// ParseStatusBar validates configured status bar segment names.
+// An empty list keeps the default layout.
+func ParseStatusBar(segments []string) ([]string, error) {
+	known := map[string]bool{SegmentSync: true}
+	for _, segment := range defaultStatusBar {
+		known[segment] = true
+	}
+	parsed := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		segment = strings.ToLower(strings.TrimSpace(segment))
+		if !known[segment] {
+			return nil, fmt.Errorf("unknown status bar segment %q (want view, saved_view, mode, filters, queue, focused, loading, sync, errors, message or keys)", segment)
+		}
+		parsed = append(parsed, segment)
+	}
+	if len(parsed) == 0 {
+		return nil, nil
+	}
+	return parsed, nil
+}
+
+// WithStatusBar returns a new Model showing the given status bar segments in order.
+func (m Model) WithStatusBar(segments []string) Model {
+	m.statusBar = segments
+	return m
+}
+
+// WithLoadErrors returns a new Model remembering which sources failed to load.
+func (m Model) WithLoadErrors(errs []error) Model {
+	m.loadErrors = errs
+	return m
+}
+
+// WithTruncations returns a new Model remembering which sources loaded only
+// part of their data, so the status bar can say the graph is partial.
+func (m Model) WithTruncations(truncations []string) Model {
+	m.truncated = truncations
+	return m
+}
+
+// statusBarSegments returns the configured segments, or the default layout.
+func (m Model) statusBarSegments() []string {
+	if len(m.statusBar) > 0 {
+		return m.statusBar
+	}
+	return defaultStatusBar
+}
+
+// statusSegment renders one left-hand status bar segment (possibly several parts).
+func (m Model) statusSegment(segment string) []string {
+	var parts []string
+
+	switch segment {
+	case SegmentView:
+		// Show current view mode with clear indicator
+		parts = append(parts, styles.StatusBarKeyStyle.Render(fmt.Sprintf("[%s]", m.viewName())))
+
+	case SegmentSavedView:
+		// Show applied saved query
+		if m.activeQuery != "" {
+			parts = append(parts, styles.StatusBarKeyStyle.Render(fmt.Sprintf("View: %s", m.activeQuery)))
+		}
+
+	case SegmentMode:
+		// Show exec mode and any role narrower than IC
+		if m.execMode {
+			parts = append(parts, styles.StatusBarKeyStyle.Render("EXEC"))
+		}
+		if m.role != graph.RoleIC {
+			parts = append(parts, styles.StatusBarTextStyle.Render(fmt.Sprintf("Role: %s", m.role)))
+		}
+
+	case SegmentFilters:
+		// Show filter mode in Graph view
+		if m.currentView != ViewGraph {
+			return nil
+		}
+		parts = append(parts, styles.StatusBarTextStyle.Render(fmt.Sprintf("Type: %s", m.filterMode.String())))
+
+		// Show status filter if not "All"
+		if m.statusFilter != StatusAll {
+			parts = append(parts, styles.StatusBarKeyStyle.Render(fmt.Sprintf("Status: %s", m.statusFilter.String())))
+		}
+
+		// Show the review filter with whose reviews it tracks
+		if m.needsReview {
+			parts = append(parts, styles.StatusBarKeyStyle.Render(fmt.Sprintf("Needs review: @%s", m.viewer)))
+		}
+
+		// Show my-work mode with whose work it is
+		if m.myWork {
+			if me, ok := m.ViewerPerson(); ok {
+				parts = append(parts, styles.StatusBarKeyStyle.Render("My work: "+me.Title))
+			}
+		}
+
+		// Show the owner filter
+		if m.ownerFilter != "" {
+			parts = append(parts, styles.StatusBarKeyStyle.Render("Owner: "+m.ownerFilter))
+		}
+
+		// Show depth limit if set
+		if m.maxDepth > 0 {
+			parts = append(parts, styles.StatusBarKeyStyle.Render(fmt.Sprintf("Depth: %d", m.maxDepth)))
+		}
+
+		// Show active search query if any
+		if m.searchQuery != "" {
+			parts = append(parts, styles.StatusBarKeyStyle.Render(fmt.Sprintf("Search: \"%s\"", m.searchQuery)))
+		}
+
+	case SegmentQueue:
+		// Deferred writes stay visible until flushed
+		if n := len(m.actionQueue); n > 0 {
+			parts = append(parts, styles.StatusBarKeyStyle.Render(fmt.Sprintf("⏳ %d queued", n)))
+		}
+
+	case SegmentFocused:
+		// Show focused node if any
+		if node, ok := m.GetFocusedNode(); ok {
+			parts = append(parts, styles.StatusBarTextStyle.Render(fmt.Sprintf("→ %s", truncate(node.Title, 25))))
+		}
+
+	case SegmentLoading:
+		// Show loading indicator
+		if m.loading {
+			loadingText := m.spinner.View() + styles.StatusBarLoadingStyle.Render("Loading...")
+			if p := m.loadProgress; p.Total > 0 {
+				loadingText = m.spinner.View() + styles.StatusBarLoadingStyle.Render(fmt.Sprintf("Loading... %d/%d (%s done)", p.Done, p.Total, p.Source))
+			}
+			parts = append(parts, loadingText)
+		}
+
+	case SegmentSync:
+		// Show how old the loaded data is
+		if !m.loadedAt.IsZero() {
+			parts = append(parts, styles.StatusBarTextStyle.Render("Synced "+relativeTime(m.loadedAt)))
+		}
+
+	case SegmentErrors:
+		// Show error if any, and how many sources failed to load
+		if m.err != nil {
+			parts = append(parts, styles.StatusBarErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		}
+		if n := len(m.loadErrors); n > 0 {
+			label := "sources"
+			if n == 1 {
+				label = "source"
+			}
+			parts = append(parts, styles.StatusBarErrorStyle.Render(fmt.Sprintf("⚠ %d %s failed", n, label)))
+		}
+		// Name the capped sources; the full markers went to stderr at load
+		if len(m.truncated) > 0 {
+			names := make([]string, len(m.truncated))
+			for i, truncation := range m.truncated {
+				names[i], _, _ = strings.Cut(truncation, ":")
+			}
+			parts = append(parts, styles.StatusBarKeyStyle.Render("⚠ partial: "+strings.Join(names, ", ")))
+		}
+
+	case SegmentMessage:
+		// Show transient status message if any
+		if m.statusMsg != "" {
+			if m.statusIsError {
+				parts = append(parts, styles.StatusBarErrorStyle.Render(m.statusMsg))
+			} else {
+				parts = append(parts, styles.StatusBarTextStyle.Render(m.statusMsg))
+			}
+		}
+	}
+	return parts
+}
+
+// statusKeyHints returns the key hints for the current view, " | " separated.
+func (m Model) statusKeyHints() string {
+	if module, ok := m.views[m.currentView]; ok {
+		return module.Hints(m)
+	}
+	switch m.currentView {
+	case ViewGraph:
+		return "/:search | F:focus | n:new issue | v:views | D:dashboard | S:standup | E:retro | H:hotspots | X:exec | M:my work | N:my reviews | R:resync | W:review queue | L:activity | P:present | O:owner | :sql | f:type | s:status | 1-4:depth | space:more | ?:legend | jk:nav | Enter:toggle | q:quit"
+	case ViewDetails:
+		if m.editor != nil {
+			return "Tab:next field | ctrl+s:save | Esc:cancel"
+		}
+		if m.composer != nil {
+			return "ctrl+s:post | Esc:discard"
+		}
+		actions := ""
+		if node, ok := m.GetFocusedNode(); ok && m.takesComments(node) {
+			actions = "c:comment | "
+		}
+		if m.refresher != nil {
+			actions += "R:resync | "
+		}
+		if node, ok := m.GetFocusedNode(); ok && m.writesBack(node) {
+			actions = "e:edit | " + actions
+		}
+		if node, ok := m.GetFocusedNode(); ok && editable(node) {
+			return "e:edit | t:trace | Tab:Relations | Esc:back | q:quit"
+		} else if ok && node.Type == graph.NodeTypePR {
+			return "d:diff | C:checklist | t:trace | " + actions + "Tab:Relations | Esc:back | q:quit"
+		} else if ok && node.Type == graph.NodeTypeCommit {
+			return "d:diff | t:trace | " + actions + "Tab:Relations | Esc:back | q:quit"
+		} else if ok && (node.Type == graph.NodeTypeFile || node.Type == graph.NodeTypeDocument) {
+			return "space p:preview | t:trace | " + actions + "Tab:Relations | Esc:back | q:quit"
+		}
+		return "t:trace | " + actions + "Tab:Relations | Esc:back | q:quit"
+	case ViewSQL:
+		return ":query | jk:scroll | Esc:back | q:quit"
+	case ViewRelations:
+		relations := m.GetRelationsList()
+		if len(relations) > 0 {
+			del := ""
+			if m.selectedRelIdx < len(relations) && relations[m.selectedRelIdx].Edge.HandMade() {
+				del = "d:delete | D:delete all | "
+			}
+			page := ""
+			if len(relations) > m.relationsListRows() {
+				page = "Ctrl+D/U:page | "
+			}
+			return fmt.Sprintf("jk:select (%d/%d) | /:filter | s:sort (%s) | %sEnter:jump | %sTab:Graph | q:quit", m.selectedRelIdx+1, len(relations), m.relationsSort, page, del)
+		}
+		if m.relationsFilter != "" {
+			return "/:filter | Esc:clear | Tab:Graph | q:quit"
+		}
+		return "Tab:Graph | q:quit"
+	default:
+		return "Tab:view | Esc:back | q:quit"
+	}
+}
+
+// fitKeyHints drops hints from the end (keeping the last, usually q:quit)
+// until they fit in width, so the status bar stays on one line.
+func fitKeyHints(hints string, width int) string {
+	parts := strings.Split(hints, " | ")
+	for len(parts) > 1 && lipgloss.Width(strings.Join(parts, " | ")) > width {
+		parts = append(parts[:len(parts)-2], parts[len(parts)-1])
+	}
+	if lipgloss.Width(parts[0]) > width {
+		return ""
+	}
+	return strings.Join(parts, " | ")
+}
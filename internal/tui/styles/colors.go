@@ -48,24 +48,6 @@ var (
 	StatusBarFg = lipgloss.AdaptiveColor{Light: "#1A1A2E", Dark: "#A1A1AA"}
 )
 
-// StatusColor returns the appropriate color for a given status string.
-func StatusColor(status string) lipgloss.Color {
-	switch status {
-	case "todo":
-		return StatusTodo
-	case "in_progress":
-		return StatusInProgress
-	case "done":
-		return StatusDone
-	case "canceled":
-		return StatusCanceled
-	case "blocked":
-		return StatusBlocked
-	default:
-		return StatusTodo
-	}
-}
-
 // PriorityColor returns the appropriate color for a given priority level.
 // Priority: 1 = Urgent, 2 = High, 3 = Medium, 4+ = Low
 func PriorityColor(priority int) lipgloss.Color {
@@ -80,3 +62,34 @@ func PriorityColor(priority int) lipgloss.Color {
 		return PriorityLow
 	}
 }
+
+// PriorityLabel returns Linear's name for a priority level (0 = none set).
+func PriorityLabel(priority int) string {
+	switch priority {
+	case 0:
+		return "No priority"
+	case 1:
+		return "Urgent"
+	case 2:
+		return "High"
+	case 3:
+		return "Medium"
+	default:
+		return "Low"
+	}
+}
+
+// HeatColor returns the color of a risk heat between 0 (cool) and 1 (the
+// hottest loaded), in the priority scale from gray to red.
+func HeatColor(heat float64) lipgloss.Color {
+	switch {
+	case heat >= 0.75:
+		return PriorityUrgent
+	case heat >= 0.5:
+		return PriorityHigh
+	case heat >= 0.25:
+		return PriorityMedium
+	default:
+		return PriorityLow
+	}
+}
@@ -0,0 +1,40 @@
+package styles
+
+import (
+	"hash/fnv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// languageColors are the colors GitHub's language bar uses, for the
+// languages the file scanner detects
+var languageColors = map[string]lipgloss.Color{
+	"Go":         "#00ADD8",
+	"JavaScript": "#F1E05A",
+	"TypeScript": "#3178C6",
+	"Python":     "#3572A5",
+	"Ruby":       "#701516",
+	"Rust":       "#DEA584",
+	"Java":       "#B07219",
+	"Kotlin":     "#A97BFF",
+	"C":          "#555555",
+	"C++":        "#F34B7D",
+	"Markdown":   "#083FA1",
+	"YAML":       "#CB171E",
+	"JSON":       "#292929",
+	"TOML":       "#9C4221",
+	"HTML":       "#E34C26",
+	"CSS":        "#563D7C",
+	"SCSS":       "#C6538C",
+}
+
+// LanguageColor returns a language's color in the language bar: GitHub's
+// for known languages, else a project palette color picked by its name.
+func LanguageColor(language string) lipgloss.Color {
+	if color, ok := languageColors[language]; ok {
+		return color
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(language))
+	return projectPalette[h.Sum32()%uint32(len(projectPalette))]
+}
@@ -0,0 +1,111 @@
+package styles
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// NodeTypeStyle is how one node type is drawn everywhere in the TUI.
+type NodeTypeStyle struct {
+	Icon     string         // Tree rows, Details, dashboards and lists
+	Color    lipgloss.Color // Title color in the tree
+	Label    string         // Plain-text name (accessible mode)
+	Priority int            // Sort rank among siblings (lower first)
+}
+
+// builtinNodeTypes are the node type styles, keyed by node type name
+// ("Issue", "PR", ...). Types missing here fall back to unknownNodeType.
+var builtinNodeTypes = map[string]NodeTypeStyle{
+	"Service":    {Icon: "⚙️", Color: "45", Label: "Service", Priority: 0},     // Cyan
+	"Project":    {Icon: "📦", Color: "33", Label: "Project", Priority: 1},      // Blue
+	"Issue":      {Icon: "🔹", Color: "214", Label: "Issue", Priority: 2},       // Orange
+	"PR":         {Icon: "🔀", Color: "135", Label: "PR", Priority: 3},          // Purple
+	"Commit":     {Icon: "💾", Color: "250", Label: "Commit", Priority: 4},      // Gray
+	"File":       {Icon: "📄", Color: "70", Label: "File", Priority: 5},         // Green
+	"Team":       {Icon: "👥", Color: "175", Label: "Team", Priority: 6},        // Pink
+	"Person":     {Icon: "👤", Color: "175", Label: "Person", Priority: 7},      // Pink
+	"Task":       {Icon: "☑️", Color: "222", Label: "Task", Priority: 8},       // Light orange
+	"Document":   {Icon: "📝", Color: "180", Label: "Document", Priority: 9},    // Tan
+	"Discussion": {Icon: "💬", Color: "152", Label: "Discussion", Priority: 10}, // Pale blue
+	"Release":    {Icon: "🚀", Color: "120", Label: "Release", Priority: 11},    // Light green
+	"Dependency": {Icon: "🧩", Color: "109", Label: "Dependency", Priority: 12}, // Slate
+}
+
+var unknownNodeType = NodeTypeStyle{Icon: "❓", Color: "252", Priority: 99}
+
+// NodeTypeStyles restyles node types over the built-in styles, keyed by
+// node type name. The zero value draws every type the built-in way.
+type NodeTypeStyles map[string]NodeTypeStyle
+
+// NodeTypeOverride changes part of a node type's style. Empty fields and a
+// nil Priority keep the current (or fallback) value, so a config can change
+// just an icon.
+type NodeTypeOverride struct {
+	Icon     string
+	Color    lipgloss.Color
+	Label    string
+	Priority *int
+}
+
+// With returns a copy of s with a node type restyled or added.
+func (s NodeTypeStyles) With(name string, override NodeTypeOverride) NodeTypeStyles {
+	current := s.Get(name)
+	if override.Icon != "" {
+		current.Icon = override.Icon
+	}
+	if override.Color != "" {
+		current.Color = override.Color
+	}
+	if override.Label != "" {
+		current.Label = override.Label
+	}
+	if override.Priority != nil {
+		current.Priority = *override.Priority
+	}
+
+	styles := make(NodeTypeStyles, len(s)+1)
+	for k, v := range s {
+		styles[k] = v
+	}
+	styles[name] = current
+	return styles
+}
+
+// Get returns the style for a node type. Unknown types get a generic icon
+// and color, labelled with their own name.
+func (s NodeTypeStyles) Get(name string) NodeTypeStyle {
+	if style, ok := s[name]; ok {
+		return style
+	}
+	if style, ok := builtinNodeTypes[name]; ok {
+		return style
+	}
+	style := unknownNodeType
+	style.Label = name
+	if style.Label == "" {
+		style.Label = "Node"
+	}
+	return style
+}
+
+// Names returns the styled node type names in sibling sort order.
+func (s NodeTypeStyles) Names() []string {
+	names := make([]string, 0, len(builtinNodeTypes)+len(s))
+	for name := range builtinNodeTypes {
+		names = append(names, name)
+	}
+	for name := range s {
+		if _, ok := builtinNodeTypes[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		pi, pj := s.Get(names[i]).Priority, s.Get(names[j]).Priority
+		if pi != pj {
+			return pi < pj
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
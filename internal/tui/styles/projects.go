@@ -0,0 +1,38 @@
+package styles
+
+import (
+	"hash/fnv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// projectPalette holds accents that stay apart from each other and from the
+// status colors on a dark background. Projects without a configured color
+// get one of these picked by their name, so it never changes between runs.
+var projectPalette = []lipgloss.Color{
+	"39",  // Sky blue
+	"208", // Orange
+	"170", // Orchid
+	"113", // Lime
+	"220", // Gold
+	"204", // Rose
+	"43",  // Teal
+	"141", // Lavender
+	"180", // Tan
+	"75",  // Steel blue
+}
+
+// ProjectColors pins projects' accent colors, keyed by project name. The
+// zero value gives every project a palette color.
+type ProjectColors map[string]lipgloss.Color
+
+// Get returns the accent of a project: its configured color, or a palette
+// color derived from its name.
+func (c ProjectColors) Get(name string) lipgloss.Color {
+	if color, ok := c[name]; ok {
+		return color
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return projectPalette[h.Sum32()%uint32(len(projectPalette))]
+}
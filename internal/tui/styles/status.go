@@ -0,0 +1,188 @@
+package styles
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// StatusCategory groups free-form status strings from every source into
+// Linear's workflow state categories, plus Blocked (a label several sources
+// use) and Unknown. Icons, colors, filters and sort order all derive from it.
+type StatusCategory int
+
+const (
+	CategoryUnknown   StatusCategory = iota
+	CategoryTriage                   // Awaiting triage
+	CategoryBacklog                  // Accepted, not planned
+	CategoryUnstarted                // Planned: Todo, Draft
+	CategoryStarted                  // In Progress, In Review, open PRs
+	CategoryCompleted                // Done, merged, closed
+	CategoryCanceled                 // Canceled, Duplicate
+	CategoryBlocked                  // Blocked
+)
+
+// statusCategories maps normalized status names (lowercase, "_" and "-"
+// as spaces) to their category. Linear's own category names are included
+// so sources that only know the state type still land correctly.
+var statusCategories = map[string]StatusCategory{
+	"triage": CategoryTriage,
+
+	"backlog": CategoryBacklog,
+	"icebox":  CategoryBacklog,
+
+	"unstarted": CategoryUnstarted,
+	"todo":      CategoryUnstarted,
+	"to do":     CategoryUnstarted,
+	"pending":   CategoryUnstarted,
+	"planned":   CategoryUnstarted,
+	"draft":     CategoryUnstarted,
+
+	"started":     CategoryStarted,
+	"in progress": CategoryStarted,
+	"in review":   CategoryStarted,
+	"open":        CategoryStarted,
+	"doing":       CategoryStarted,
+
+	"completed": CategoryCompleted,
+	"done":      CategoryCompleted,
+	"merged":    CategoryCompleted,
+	"closed":    CategoryCompleted,
+	"resolved":  CategoryCompleted,
+
+	"canceled":  CategoryCanceled,
+	"cancelled": CategoryCanceled,
+	"duplicate": CategoryCanceled,
+	"wontfix":   CategoryCanceled,
+	"won't fix": CategoryCanceled,
+
+	"blocked": CategoryBlocked,
+}
+
+// StatusCategories returns every category in workflow order, for legends.
+func StatusCategories() []StatusCategory {
+	return []StatusCategory{
+		CategoryTriage, CategoryBacklog, CategoryUnstarted, CategoryStarted,
+		CategoryCompleted, CategoryCanceled, CategoryBlocked, CategoryUnknown,
+	}
+}
+
+// StatusNames returns the status names that fall into a category, sorted.
+func StatusNames(c StatusCategory) []string {
+	var names []string
+	for name, category := range statusCategories {
+		if category == c {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CategoryOf returns the workflow category of a status string.
+func CategoryOf(status string) StatusCategory {
+	normalized := strings.NewReplacer("_", " ", "-", " ").Replace(strings.ToLower(strings.TrimSpace(status)))
+	return statusCategories[normalized]
+}
+
+// String returns the category name as Linear spells it.
+func (c StatusCategory) String() string {
+	switch c {
+	case CategoryTriage:
+		return "triage"
+	case CategoryBacklog:
+		return "backlog"
+	case CategoryUnstarted:
+		return "unstarted"
+	case CategoryStarted:
+		return "started"
+	case CategoryCompleted:
+		return "completed"
+	case CategoryCanceled:
+		return "canceled"
+	case CategoryBlocked:
+		return "blocked"
+	default:
+		return "unknown"
+	}
+}
+
+// IsDone reports whether work in this category is finished successfully.
+func (c StatusCategory) IsDone() bool {
+	return c == CategoryCompleted
+}
+
+// Rank returns the sort order among siblings: active work first, upcoming
+// work (and unknown statuses) second, completed work, then blocked or canceled.
+func (c StatusCategory) Rank() int {
+	switch c {
+	case CategoryStarted:
+		return 0
+	case CategoryCompleted:
+		return 2
+	case CategoryCanceled, CategoryBlocked:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// Indicator returns the compact status marker used in tree rows.
+func (c StatusCategory) Indicator() string {
+	switch c {
+	case CategoryCompleted:
+		return "[✓]"
+	case CategoryStarted:
+		return "[◐]"
+	case CategoryTriage, CategoryBacklog, CategoryUnstarted:
+		return "[○]"
+	case CategoryCanceled, CategoryBlocked:
+		return "[✗]"
+	default:
+		return "[-]"
+	}
+}
+
+// Icon returns the larger status icon used in the Details view.
+func (c StatusCategory) Icon() string {
+	switch c {
+	case CategoryCompleted:
+		return "✅"
+	case CategoryStarted:
+		return "🔄"
+	case CategoryTriage:
+		return "📥"
+	case CategoryBacklog, CategoryUnstarted:
+		return "📋"
+	case CategoryBlocked:
+		return "🚫"
+	case CategoryCanceled:
+		return "❌"
+	default:
+		return "⚪"
+	}
+}
+
+// Color returns the category's status color.
+func (c StatusCategory) Color() lipgloss.Color {
+	switch c {
+	case CategoryCompleted:
+		return StatusDone
+	case CategoryStarted:
+		return StatusInProgress
+	case CategoryTriage, CategoryBacklog, CategoryUnstarted:
+		return StatusTodo
+	case CategoryCanceled:
+		return StatusCanceled
+	case CategoryBlocked:
+		return StatusBlocked
+	default:
+		return lipgloss.Color("252")
+	}
+}
+
+// StatusColor returns the color for a status string.
+func StatusColor(status string) lipgloss.Color {
+	return CategoryOf(status).Color()
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// PendingEdit describes a local change to a synced node. Write-back actions
+// attach one to their ConfirmationRequested so the remote copy is re-read
+// before pushing: concurrent remote changes must never be silently overwritten.
+type PendingEdit struct {
+	NodeID      string
+	SyncedAt    time.Time                      // When the base values were fetched
+	Base        map[string]string              // Field values as of the last sync
+	Local       map[string]string              // Field values after the local edit
+	FetchRemote func() (RemoteSnapshot, error) // Re-reads the node from its source
+}
+
+// RemoteSnapshot is the current remote state of an edited node.
+type RemoteSnapshot struct {
+	UpdatedAt time.Time
+	Fields    map[string]string
+}
+
+// FieldConflict is one field edited locally that also changed remotely
+// to a different value since the last sync.
+type FieldConflict struct {
+	Field  string
+	Base   string
+	Local  string
+	Remote string
+}
+
+// DetectConflicts performs a three-way comparison of the fields edited
+// locally. A field conflicts when both sides moved away from the base and
+// disagree; edits that converged, or remote-only changes, are not conflicts.
+func DetectConflicts(base, local, remote map[string]string) []FieldConflict {
+	var conflicts []FieldConflict
+	for field, localValue := range local {
+		baseValue, remoteValue := base[field], remote[field]
+		if localValue == baseValue || remoteValue == baseValue || localValue == remoteValue {
+			continue
+		}
+		conflicts = append(conflicts, FieldConflict{
+			Field:  field,
+			Base:   baseValue,
+			Local:  localValue,
+			Remote: remoteValue,
+		})
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Field < conflicts[j].Field })
+	return conflicts
+}
+
+// checkRemote re-reads the remote copy of a pending edit before confirmation
+func checkRemote(req ConfirmationRequest) tea.Cmd {
+	return func() tea.Msg {
+		remote, err := req.Edit.FetchRemote()
+		return RemoteCheckedMsg{Request: req, Remote: remote, Err: err}
+	}
+}
+
+// withRemoteChecked opens the confirmation for a pending edit once its remote
+// copy is known: a three-way diff when the remote changed the edited fields
+// since the last sync, the plain dialog otherwise (or none, when the write-back
+// policy does not ask).
+func (m Model) withRemoteChecked(msg RemoteCheckedMsg) (Model, tea.Cmd) {
+	req := msg.Request
+	if msg.Err != nil {
+		req.Warning = "Could not check for remote changes: " + msg.Err.Error()
+		return m.WithConfirmation(&req), nil
+	}
+	if msg.Remote.UpdatedAt.After(req.Edit.SyncedAt) {
+		req.Conflicts = DetectConflicts(req.Edit.Base, req.Edit.Local, msg.Remote.Fields)
+	}
+	return m.confirmOrRun(req)
+}
+
+// renderConflictDiff renders the base / mine / theirs table of a conflicting edit.
+func renderConflictDiff(conflicts []FieldConflict, width int) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	localStyle := lipgloss.NewStyle().Foreground(styles.StatusDone)
+	remoteStyle := lipgloss.NewStyle().Foreground(styles.StatusInProgress)
+
+	colWidth := clampMin((width-12)/3, 8)
+	cell := func(value string) string {
+		value = strings.ReplaceAll(value, "\n", " ")
+		if value == "" {
+			value = "—"
+		}
+		return fmt.Sprintf("%-*s", colWidth, truncate(value, colWidth))
+	}
+
+	lines := []string{headerStyle.Render(fmt.Sprintf("%-10s ", "Field") + cell("Base") + " " + cell("Mine") + " " + cell("Theirs"))}
+	for _, c := range conflicts {
+		lines = append(lines, fmt.Sprintf("%-10s ", truncate(c.Field, 10))+
+			mutedStyle.Render(cell(c.Base))+" "+
+			localStyle.Render(cell(c.Local))+" "+
+			remoteStyle.Render(cell(c.Remote)))
+	}
+	return strings.Join(lines, "\n")
+}
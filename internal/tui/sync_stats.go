@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// syncRunsLimit caps how much sync history the stats view reads
+const syncRunsLimit = 200
+
+// sourceTotals sums one source's runs for the stats view
+type sourceTotals struct {
+	Source   string
+	Runs     int
+	Failed   int
+	Duration time.Duration
+	Requests int
+	Bytes    int64
+	Cost     int
+}
+
+// WithSyncRuns returns a new Model listing runs in the sync stats view.
+func (m Model) WithSyncRuns(runs []graph.SyncRun) Model {
+	m.syncRuns = runs
+	return m.WithSyncStatsScroll(m.syncStatsScroll)
+}
+
+// WithSyncStatsScroll returns a new Model with the sync run list scrolled to
+// offset, clamped to the list.
+func (m Model) WithSyncStatsScroll(offset int) Model {
+	m.syncStatsScroll = max(min(offset, len(m.syncRuns)-1), 0)
+	return m
+}
+
+// listSyncRuns reads the store's history of syncs
+func listSyncRuns(ctx context.Context, store graph.GraphStore) tea.Cmd {
+	return func() tea.Msg {
+		recorder, ok := store.(graph.SyncRecorder)
+		if !ok {
+			return StatusMsg{Message: "This graph store keeps no sync history", IsError: true}
+		}
+		runs, err := recorder.ListSyncRuns(ctx, syncRunsLimit)
+		if err != nil {
+			return StatusMsg{Message: "Sync stats error: " + err.Error(), IsError: true}
+		}
+		return SyncRunsListedMsg{Runs: runs}
+	}
+}
+
+// handleSyncStatsKeys processes keys in the Sync stats view.
+func (m Model) handleSyncStatsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		return m.WithSyncStatsScroll(m.syncStatsScroll + 1), nil
+	case "k", "up":
+		return m.WithSyncStatsScroll(m.syncStatsScroll - 1), nil
+	case "esc", "T":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// syncTotals sums runs per source, busiest API first
+func syncTotals(runs []graph.SyncRun) []sourceTotals {
+	var totals []sourceTotals
+	index := make(map[string]int)
+	for _, run := range runs {
+		i, ok := index[run.Source]
+		if !ok {
+			i = len(totals)
+			index[run.Source] = i
+			totals = append(totals, sourceTotals{Source: run.Source})
+		}
+		total := &totals[i]
+		total.Runs++
+		if run.Error != "" {
+			total.Failed++
+		}
+		total.Duration += run.Duration
+		total.Requests += run.Requests
+		total.Bytes += run.Bytes
+		total.Cost += run.Cost
+	}
+	// Ties keep the most recently synced source first
+	sort.SliceStable(totals, func(i, j int) bool {
+		return totals[i].Requests > totals[j].Requests
+	})
+	return totals
+}
+
+// renderSyncStatsView renders per-source totals over the recent syncs, then
+// each source's load, newest first.
+func (m Model) renderSyncStatsView(width, height int) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	sourceStyle := lipgloss.NewStyle().Foreground(styles.Secondary)
+	errorStyle := lipgloss.NewStyle().Foreground(styles.StatusCanceled)
+
+	lines := []string{headerStyle.Render(fmt.Sprintf("📊 Sync stats (last %d source loads)", len(m.syncRuns))), ""}
+	if len(m.syncRuns) == 0 {
+		lines = append(lines, mutedStyle.Italic(true).Render("No syncs recorded yet. Each load and sync logs what every source cost."))
+		return strings.Join(lines, "\n")
+	}
+
+	lines = append(lines, mutedStyle.Render(fmt.Sprintf("%-10s %5s %9s %9s %11s %8s", "source", "runs", "avg time", "requests", "transfer", "cost")))
+	for _, total := range syncTotals(m.syncRuns) {
+		runs := fmt.Sprintf("%5d", total.Runs)
+		if total.Failed > 0 {
+			runs = errorStyle.Render(runs)
+		}
+		avg := total.Duration / time.Duration(total.Runs)
+		lines = append(lines, sourceStyle.Render(fmt.Sprintf("%-10s", truncate(total.Source, 10)))+" "+runs+
+			fmt.Sprintf(" %9s %9d %11s %8d", formatDuration(avg), total.Requests, formatBytes(total.Bytes), total.Cost))
+	}
+	lines = append(lines, "", headerStyle.Render("Recent loads"))
+
+	rows := clampMin(height-len(lines), 1)
+	for _, run := range m.syncRuns[m.syncStatsScroll:] {
+		if rows == 0 {
+			break
+		}
+		prefix := mutedStyle.Render(run.StartedAt.Format("01-02 15:04")) + " " + sourceStyle.Render(fmt.Sprintf("%-10s", truncate(run.Source, 10))) + " "
+		text := fmt.Sprintf("%s, %d nodes", formatDuration(run.Duration), run.Nodes)
+		if run.Requests > 0 {
+			text += fmt.Sprintf(", %d requests, %s, %d points", run.Requests, formatBytes(run.Bytes), run.Cost)
+		}
+		line := prefix + truncate(text, clampMin(width-lipgloss.Width(prefix), 10))
+		if run.Error != "" {
+			line = prefix + errorStyle.Render(truncate("failed: "+run.Error, clampMin(width-lipgloss.Width(prefix), 10)))
+		}
+		lines = append(lines, line)
+		rows--
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatDuration renders a load time to a useful precision ("80ms", "1.4s")
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
+
+// formatBytes renders a size with a binary unit ("12.4 MiB")
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGT"[exp])
+}
@@ -0,0 +1,246 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// tableFixedColumns come before one column per metric in the Table view
+var tableFixedColumns = []string{"Title", "Type", "Status"}
+
+// maxTableTitleWidth caps the Title column so metric columns stay on screen
+const maxTableTitleWidth = 48
+
+// tableColumns names the Table view's columns: the fixed ones, then each
+// metric in config order
+func (m Model) tableColumns() []string {
+	columns := append([]string(nil), tableFixedColumns...)
+	for _, metric := range m.metrics {
+		columns = append(columns, metric.Name)
+	}
+	return columns
+}
+
+// TableRows returns the nodes the graph shows under the current filters,
+// sorted by the Table view's sort column. Nodes without a value for a
+// metric column sort last either way.
+func (m Model) TableRows() []DisplayNode {
+	rows := m.GetFilteredNodes()
+	column := m.tableSortColumn
+	less := func(a, b DisplayNode) int {
+		switch column {
+		case 0:
+			return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		case 1:
+			return strings.Compare(string(a.Type), string(b.Type))
+		case 2:
+			return strings.Compare(a.Status, b.Status)
+		}
+		return 0
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if column >= len(tableFixedColumns) {
+			name := m.metrics[column-len(tableFixedColumns)].Name
+			vi, okI := m.metricValue(rows[i].ID, name)
+			vj, okJ := m.metricValue(rows[j].ID, name)
+			if okI != okJ {
+				return okI
+			}
+			if !okI {
+				return false
+			}
+			return compareMetricValues(vi, vj, m.tableSortDesc)
+		}
+		c := less(rows[i], rows[j])
+		if m.tableSortDesc {
+			return c > 0
+		}
+		return c < 0
+	})
+	return rows
+}
+
+// compareMetricValues orders numbers before text, numbers by value and
+// text alphabetically, reversed when desc is set
+func compareMetricValues(a, b MetricValue, desc bool) bool {
+	if a.IsText != b.IsText {
+		return !a.IsText
+	}
+	if a.IsText {
+		if desc {
+			return a.Text > b.Text
+		}
+		return a.Text < b.Text
+	}
+	if desc {
+		return a.Number > b.Number
+	}
+	return a.Number < b.Number
+}
+
+// tableCells renders a node's row of the Table view
+func (m Model) tableCells(node DisplayNode) []string {
+	cells := []string{truncate(node.Title, maxTableTitleWidth), string(node.Type), node.Status}
+	for _, metric := range m.metrics {
+		cell := ""
+		if value, ok := m.metricValue(node.ID, metric.Name); ok {
+			cell = value.String()
+		}
+		cells = append(cells, cell)
+	}
+	return cells
+}
+
+// openTable opens the Table view, sorted by the first metric (largest
+// first) when there is one
+func (m Model) openTable() Model {
+	if len(m.metrics) > 0 && m.tableSortColumn == 0 && !m.tableSortDesc {
+		m.tableSortColumn = len(tableFixedColumns)
+		m.tableSortDesc = true
+	}
+	return m.PushView(ViewTable).WithSelectedRowIdx(0)
+}
+
+// WithTableSort returns a new Model sorting the Table view by column,
+// wrapping around the columns.
+func (m Model) WithTableSort(column int, desc bool) Model {
+	count := len(m.tableColumns())
+	m.tableSortColumn = (column%count + count) % count
+	m.tableSortDesc = desc
+	return m.WithSelectedRowIdx(0)
+}
+
+// WithSelectedRowIdx returns a new Model with the given Table row selected,
+// clamped to the rows shown.
+func (m Model) WithSelectedRowIdx(idx int) Model {
+	m.selectedRowIdx = max(min(idx, len(m.GetFilteredNodes())-1), 0)
+	return m
+}
+
+// TableMarkdown renders the Table view's rows as a Markdown table.
+func (m Model) TableMarkdown() string {
+	var b strings.Builder
+	columns := m.tableColumns()
+	b.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+	for _, node := range m.TableRows() {
+		cells := m.tableCells(node)
+		for i, cell := range cells {
+			cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return b.String()
+}
+
+// handleTableKeys processes keys in the Table view.
+func (m Model) handleTableKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		return m.WithSelectedRowIdx(m.selectedRowIdx + 1), nil
+	case "k", "up":
+		return m.WithSelectedRowIdx(m.selectedRowIdx - 1), nil
+	case "l", "right":
+		// Sort by the next column
+		return m.WithTableSort(m.tableSortColumn+1, false), nil
+	case "h", "left":
+		return m.WithTableSort(m.tableSortColumn-1, false), nil
+	case "r":
+		// Reverse the sort
+		return m.WithTableSort(m.tableSortColumn, !m.tableSortDesc), nil
+	case "y":
+		// Copy the table as Markdown
+		return m.logActivity(ActivityExport, "Copied the table"), copyToClipboard(m.TableMarkdown(), "Table")
+	case "enter":
+		// Show the node in the graph
+		rows := m.TableRows()
+		if m.selectedRowIdx >= len(rows) {
+			return m, nil
+		}
+		return m.PopView().WithView(ViewGraph).WithFocusedNode(rows[m.selectedRowIdx].ID), nil
+	case "esc", "V":
+		return m.PopView(), nil
+	case "ctrl+c", "q":
+		return m.quit()
+	}
+	return m, nil
+}
+
+// renderTableView renders the filtered nodes as a table, one column per
+// metric, sorted by the selected column.
+func (m Model) renderTableView(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(styles.Accent).
+		Width(width).
+		Align(lipgloss.Center).
+		MarginBottom(1)
+
+	var builder strings.Builder
+	builder.WriteString(titleStyle.Render(fmt.Sprintf("📋 Table — %s", m.filterMode)))
+	builder.WriteString("\n")
+
+	rows := m.TableRows()
+	if len(rows) == 0 {
+		builder.WriteString(lipgloss.NewStyle().
+			Width(width).
+			Height(height-3).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render(styles.LoadingStyle.Render("No nodes match the current filters.")))
+		return builder.String()
+	}
+
+	// Reserve lines for the title, borders, header and scroll indicator
+	visible := max(height-7, 1)
+	offset := max(m.selectedRowIdx-visible+1, 0)
+	end := min(offset+visible, len(rows))
+
+	headers := m.tableColumns()
+	arrow := " ▲"
+	if m.tableSortDesc {
+		arrow = " ▼"
+	}
+	headers[m.tableSortColumn] += arrow
+
+	cells := make([][]string, 0, end-offset)
+	for i, node := range rows[offset:end] {
+		row := m.tableCells(node)
+		if m.accessible && offset+i == m.selectedRowIdx {
+			row[0] += " " + selectedMarker
+		}
+		cells = append(cells, row)
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Accent).Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Foreground(styles.Foreground).Padding(0, 1)
+	selectedStyle := treeFocusStyle.Padding(0, 1)
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(styles.Border)).
+		Headers(headers...).
+		Rows(cells...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			switch {
+			case row == table.HeaderRow:
+				return headerStyle
+			case offset+row == m.selectedRowIdx:
+				return selectedStyle
+			}
+			return cellStyle
+		})
+	builder.WriteString(t.Render())
+
+	if len(rows) > visible {
+		builder.WriteString(lipgloss.NewStyle().
+			Foreground(styles.Muted).
+			Faint(true).
+			Render(fmt.Sprintf("\n[rows %d-%d of %d]", offset+1, end, len(rows))))
+	}
+	return builder.String()
+}
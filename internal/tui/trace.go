@@ -0,0 +1,314 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/manutej/maat-terminal/internal/graph"
+	"github.com/manutej/maat-terminal/internal/tui/styles"
+)
+
+// Traceability links an issue to the work that delivered it:
+// PRs implementing it, commits referencing it (or its PRs), and the files
+// those commits and PRs touched (or that a TODO issue lives in).
+type Traceability struct {
+	Issue   DisplayNode
+	PRs     []DisplayNode
+	Commits []DisplayNode // Newest first
+	Files   []DisplayNode
+}
+
+// IsEmpty reports whether nothing has been traced to the issue yet.
+func (t Traceability) IsEmpty() bool {
+	return len(t.PRs) == 0 && len(t.Commits) == 0 && len(t.Files) == 0
+}
+
+// Trace computes the traceability report for an issue.
+// Pure function over the model's unfiltered graph.
+func (m Model) Trace(issueID string) Traceability {
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+	trace := Traceability{Issue: nodeByID[issueID]}
+
+	isType := func(id string, t graph.NodeType) bool {
+		node, ok := nodeByID[id]
+		return ok && node.Type == t
+	}
+
+	// PRs implementing the issue
+	prs := map[string]bool{}
+	for _, edge := range m.edges {
+		if edge.ToID == issueID && edge.Relation == graph.EdgeImplements && isType(edge.FromID, graph.NodeTypePR) {
+			prs[edge.FromID] = true
+		}
+	}
+
+	// Commits mentioning or implementing the issue or one of its PRs
+	commits := map[string]bool{}
+	for _, edge := range m.edges {
+		if edge.Relation != graph.EdgeMentions && edge.Relation != graph.EdgeImplements {
+			continue
+		}
+		if (edge.ToID == issueID || prs[edge.ToID]) && isType(edge.FromID, graph.NodeTypeCommit) {
+			commits[edge.FromID] = true
+		}
+	}
+
+	// Files modified by that work, and the file a TODO comment sits in
+	files := map[string]bool{}
+	for _, edge := range m.edges {
+		if edge.Relation == graph.EdgeModifies && (commits[edge.FromID] || prs[edge.FromID]) && isType(edge.ToID, graph.NodeTypeFile) {
+			files[edge.ToID] = true
+		}
+		if edge.Relation == graph.EdgeMentions && edge.FromID == issueID && isType(edge.ToID, graph.NodeTypeFile) {
+			files[edge.ToID] = true
+		}
+	}
+
+	trace.PRs = collectNodes(prs, nodeByID)
+	trace.Commits = collectNodes(commits, nodeByID)
+	trace.Files = collectNodes(files, nodeByID)
+	sortByRecency(trace.Commits)
+	return trace
+}
+
+// collectNodes resolves a set of IDs to nodes sorted by title.
+func collectNodes(ids map[string]bool, nodeByID map[string]DisplayNode) []DisplayNode {
+	nodes := make([]DisplayNode, 0, len(ids))
+	for id := range ids {
+		nodes = append(nodes, nodeByID[id])
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Title != nodes[j].Title {
+			return nodes[i].Title < nodes[j].Title
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+// sortByRecency orders nodes newest first, keeping title order among ties.
+func sortByRecency(nodes []DisplayNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].UpdatedAt.After(nodes[j].UpdatedAt)
+	})
+}
+
+// sortByStatus orders nodes open work first, keeping title order among ties.
+func sortByStatus(nodes []DisplayNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return styles.CategoryOf(nodes[i].Status).Rank() < styles.CategoryOf(nodes[j].Status).Rank()
+	})
+}
+
+// ResolveNodeRef finds a node by exact ID, by a short reference such as
+// "CET-352" (matching "linear:CET-352") or "12" (matching "issue:12"), or
+// by file path. Issues win when a short reference matches several nodes.
+func (m Model) ResolveNodeRef(ref string) (DisplayNode, bool) {
+	if node, ok := m.GetNodeByID(ref); ok {
+		return node, true
+	}
+
+	ref = strings.TrimPrefix(ref, "#")
+	var match DisplayNode
+	found := false
+	for _, node := range m.nodes {
+		suffix := node.ID[strings.LastIndex(node.ID, ":")+1:]
+		isPath := node.Type == graph.NodeTypeFile && node.Title == ref
+		if !isPath && !strings.EqualFold(suffix, ref) && !strings.EqualFold(node.Identifier, ref) {
+			continue
+		}
+		if !found || (node.Type == graph.NodeTypeIssue && match.Type != graph.NodeTypeIssue) {
+			match = node
+			found = true
+		}
+	}
+	return match, found
+}
+
+// WithTraceExpanded returns a new Model with the Details traceability (issues)
+// or impact (files) section expanded or collapsed.
+func (m Model) WithTraceExpanded(expanded bool) Model {
+	m.traceExpanded = expanded
+	return m
+}
+
+// renderTraceSection renders the traceability section of the Details view.
+// Collapsed it shows counts only; expanded it lists every PR, commit and file.
+func (m Model) renderTraceSection(issueID string, maxWidth int) []string {
+	trace := m.Trace(issueID)
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	summary := fmt.Sprintf("%d PRs · %d commits · %d files", len(trace.PRs), len(trace.Commits), len(trace.Files))
+	if !m.traceExpanded {
+		return []string{
+			headerStyle.Render("🧭 Traceability ▸ ") + mutedStyle.Render(summary+"  (t to expand)"),
+		}
+	}
+
+	lines := []string{headerStyle.Render("🧭 Traceability ▾ ") + mutedStyle.Render(summary+"  (t to collapse)")}
+	if trace.IsEmpty() {
+		return append(lines, mutedStyle.Italic(true).Render("  No PRs, commits or files reference this issue yet."))
+	}
+
+	return append(lines, m.renderTraceGroups([]traceGroup{
+		{"Pull requests", trace.PRs},
+		{"Commits", trace.Commits},
+		{"Files", trace.Files},
+	}, maxWidth)...)
+}
+
+// traceGroup is one labelled list in a traceability or impact section.
+type traceGroup struct {
+	label string
+	nodes []DisplayNode
+}
+
+// renderTraceGroups renders non-empty groups as indented node lists.
+// Open issues are highlighted: they are ongoing work the change collides with.
+func (m Model) renderTraceGroups(groups []traceGroup, maxWidth int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	openStyle := lipgloss.NewStyle().Foreground(styles.StatusInProgress)
+
+	var lines []string
+	for _, group := range groups {
+		if len(group.nodes) == 0 {
+			continue
+		}
+		lines = append(lines, lipgloss.NewStyle().Bold(true).Render("  "+group.label))
+		for _, node := range group.nodes {
+			entry := fmt.Sprintf("    %s %s", m.typeIcon(node.Type), truncate(node.Title, clampMin(maxWidth-12, 10)))
+			if node.Status != "" {
+				entry += " [" + node.Status + "]"
+			}
+			if node.Type == graph.NodeTypeIssue && !styles.CategoryOf(node.Status).IsDone() {
+				lines = append(lines, openStyle.Render(entry))
+			} else {
+				lines = append(lines, mutedStyle.Render(entry))
+			}
+		}
+	}
+	return lines
+}
+
+// FileImpact is reverse traceability for a file: the work that touched it
+// and the issues and projects that work belongs to.
+type FileImpact struct {
+	File     DisplayNode
+	Commits  []DisplayNode // Newest first
+	PRs      []DisplayNode
+	Issues   []DisplayNode // Open issues first
+	Projects []DisplayNode
+}
+
+// IsEmpty reports whether no tracked work touches the file.
+func (f FileImpact) IsEmpty() bool {
+	return len(f.Commits) == 0 && len(f.PRs) == 0
+}
+
+// OpenIssues returns how many referenced issues are not done yet.
+func (f FileImpact) OpenIssues() int {
+	open := 0
+	for _, issue := range f.Issues {
+		if !styles.CategoryOf(issue.Status).IsDone() {
+			open++
+		}
+	}
+	return open
+}
+
+// Impact computes reverse traceability for a file:
+// commits/PRs modifying it → issues they reference → projects owning those issues.
+// Pure function over the model's unfiltered graph.
+func (m Model) Impact(fileID string) FileImpact {
+	nodeByID := make(map[string]DisplayNode, len(m.nodes))
+	for _, node := range m.nodes {
+		nodeByID[node.ID] = node
+	}
+	impact := FileImpact{File: nodeByID[fileID]}
+
+	isType := func(id string, t graph.NodeType) bool {
+		node, ok := nodeByID[id]
+		return ok && node.Type == t
+	}
+
+	// Commits and PRs modifying the file
+	commits := map[string]bool{}
+	prs := map[string]bool{}
+	for _, edge := range m.edges {
+		if edge.ToID != fileID || edge.Relation != graph.EdgeModifies {
+			continue
+		}
+		switch {
+		case isType(edge.FromID, graph.NodeTypeCommit):
+			commits[edge.FromID] = true
+		case isType(edge.FromID, graph.NodeTypePR):
+			prs[edge.FromID] = true
+		}
+	}
+
+	// Commits implementing a PR pull that PR in; issues come from either
+	for _, edge := range m.edges {
+		if commits[edge.FromID] && edge.Relation == graph.EdgeImplements && isType(edge.ToID, graph.NodeTypePR) {
+			prs[edge.ToID] = true
+		}
+	}
+	issues := map[string]bool{}
+	for _, edge := range m.edges {
+		if edge.Relation != graph.EdgeMentions && edge.Relation != graph.EdgeImplements {
+			continue
+		}
+		if (commits[edge.FromID] || prs[edge.FromID]) && isType(edge.ToID, graph.NodeTypeIssue) {
+			issues[edge.ToID] = true
+		}
+	}
+
+	// Projects owning those issues
+	projects := map[string]bool{}
+	for _, edge := range m.edges {
+		if issues[edge.ToID] && isHierarchicalEdge(edge.Relation) && isType(edge.FromID, graph.NodeTypeProject) {
+			projects[edge.FromID] = true
+		}
+	}
+
+	impact.Commits = collectNodes(commits, nodeByID)
+	impact.PRs = collectNodes(prs, nodeByID)
+	impact.Issues = collectNodes(issues, nodeByID)
+	impact.Projects = collectNodes(projects, nodeByID)
+	sortByRecency(impact.Commits)
+	sortByStatus(impact.Issues)
+	return impact
+}
+
+// renderImpactSection renders the impact section of the Details view for a file.
+// Collapsed it shows counts only; expanded it lists the work and where it leads.
+func (m Model) renderImpactSection(fileID string, maxWidth int) []string {
+	impact := m.Impact(fileID)
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Secondary)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	summary := fmt.Sprintf("%d open issues · %d PRs · %d commits · %d projects",
+		impact.OpenIssues(), len(impact.PRs), len(impact.Commits), len(impact.Projects))
+	if !m.traceExpanded {
+		return []string{
+			headerStyle.Render("💥 Impact ▸ ") + mutedStyle.Render(summary+"  (t to expand)"),
+		}
+	}
+
+	lines := []string{headerStyle.Render("💥 Impact ▾ ") + mutedStyle.Render(summary+"  (t to collapse)")}
+	if impact.IsEmpty() {
+		return append(lines, mutedStyle.Italic(true).Render("  No tracked commits or PRs touch this file."))
+	}
+
+	return append(lines, m.renderTraceGroups([]traceGroup{
+		{"Issues", impact.Issues},
+		{"Pull requests", impact.PRs},
+		{"Commits", impact.Commits},
+		{"Projects", impact.Projects},
+	}, maxWidth)...)
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/manutej/maat-terminal/internal/graph"
 )
@@ -16,9 +17,17 @@ type DisplayNode struct {
 	Status      string
 	Priority    int
 	Labels      []string
-	URL         string // Link to source (Linear, GitHub, etc.)
-	Identifier  string // Short identifier (e.g., CET-352 for Linear issues)
-	Project     string // Parent project name
+	URL         string          // Link to source (Linear, GitHub, etc.)
+	Identifier  string          // Short identifier (e.g., CET-352 for Linear issues)
+	Project     string          // Parent project name
+	AccessLevel graph.Role      // Audience level from NodeMetadata (exec | lead | ic)
+	Review      PRReview        // Review state, reviewers and mergeability (PRs only)
+	Owner       string          // Inferred owner: majority commit author (files and directories)
+	Handles     []string        // Names, logins and emails a person is known by (people only)
+	Data        json.RawMessage // Source fields as loaded, for Details templates
+	CreatedBy   string          // Who created the node by hand ("" = loaded from a source)
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 // IssueData represents the JSON data structure for Issue nodes.
@@ -36,20 +45,33 @@ type IssueData struct {
 
 // PRData represents the JSON data structure for PR nodes.
 type PRData struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	Number      int    `json:"number"`
-	Author      string `json:"author"`
-	URL         string `json:"url"`
+	Title              string   `json:"title"`
+	Description        string   `json:"description"`
+	Status             string   `json:"status"`
+	Number             int      `json:"number"`
+	Author             string   `json:"author"`
+	URL                string   `json:"url"`
+	ReviewState        string   `json:"review_state"`
+	Approvals          int      `json:"approvals"`
+	ChangesRequested   int      `json:"changes_requested"`
+	RequestedReviewers []string `json:"requested_reviewers"`
+	Mergeable          string   `json:"mergeable"`
+	CIStatus           string   `json:"ci_status"` // success | failure | pending, as the CI reported it
 }
 
 // CommitData represents the JSON data structure for Commit nodes.
 type CommitData struct {
-	Message string `json:"message"`
-	Author  string `json:"author"`
-	Hash    string `json:"hash"`
-	Date    string `json:"date"`
+	Message      string   `json:"message"`
+	Author       string   `json:"author"`
+	Hash         string   `json:"hash"`
+	Date         string   `json:"date"`
+	Body         string   `json:"body"` // Message after the subject, trailers removed
+	FilesChanged int      `json:"files_changed"`
+	Insertions   int      `json:"insertions"`
+	Deletions    int      `json:"deletions"`
+	Tags         []string `json:"tags"`
+	SignedOffBy  []string `json:"signed_off_by"`
+	CoAuthors    []string `json:"co_authors"`
 }
 
 // FileData represents the JSON data structure for File nodes.
@@ -57,13 +79,30 @@ type FileData struct {
 	Path     string `json:"path"`
 	Language string `json:"language"`
 	Lines    int    `json:"lines"`
+	Owner    string `json:"owner"`
+	Status   string `json:"status"` // Frontmatter status (Markdown files)
+}
+
+// handMadeBy returns who created a node by hand, or "" for one loaded from
+// a source. Sources fill CreatedBy with their own labels (a commit's author,
+// "git-scanner"), so only nodes in the local namespace count.
+func handMadeBy(node graph.Node) string {
+	if !graph.IsLocalID(node.ID) {
+		return ""
+	}
+	return node.Metadata.CreatedBy
 }
 
 // NodeToDisplayNode converts a graph.Node to a DisplayNode for TUI display.
 func NodeToDisplayNode(node graph.Node) DisplayNode {
 	display := DisplayNode{
-		ID:   node.ID,
-		Type: node.Type,
+		ID:          node.ID,
+		Type:        node.Type,
+		AccessLevel: node.Metadata.AccessLevel,
+		Data:        node.Data,
+		CreatedBy:   handMadeBy(node),
+		CreatedAt:   node.Metadata.CreatedAt,
+		UpdatedAt:   node.Metadata.UpdatedAt,
 	}
 
 	switch node.Type {
@@ -86,6 +125,7 @@ func NodeToDisplayNode(node graph.Node) DisplayNode {
 			display.Title = data.Title
 			display.Description = data.Description
 			display.Status = data.Status
+			display.Review = data.review()
 		}
 
 	case graph.NodeTypeCommit:
@@ -100,6 +140,8 @@ func NodeToDisplayNode(node graph.Node) DisplayNode {
 		if err := json.Unmarshal(node.Data, &data); err == nil {
 			display.Title = data.Path
 			display.Description = data.Language
+			display.Owner = data.Owner
+			display.Status = data.Status
 		}
 
 	default:
@@ -113,9 +155,20 @@ func NodeToDisplayNode(node graph.Node) DisplayNode {
 			} else {
 				display.Title = node.ID
 			}
+			if owner, ok := generic["owner"].(string); ok {
+				display.Owner = owner
+			}
+			if status, ok := generic["status"].(string); ok {
+				display.Status = status
+			}
+			if identifier, ok := generic["identifier"].(string); ok {
+				display.Identifier = identifier
+			}
 		}
 	}
 
+	display.Handles = node.Handles()
+
 	// Fallback if title is still empty
 	if display.Title == "" {
 		display.Title = node.ID
@@ -135,17 +188,28 @@ func NodesToDisplayNodes(nodes []graph.Node) []DisplayNode {
 
 // DisplayEdge is a simplified edge representation for TUI display.
 type DisplayEdge struct {
-	FromID   string
-	ToID     string
-	Relation graph.EdgeType
+	FromID    string
+	ToID      string
+	Relation  graph.EdgeType
+	CreatedBy string // Who created the edge by hand ("" = derived from a source)
+	CreatedAt time.Time
+}
+
+// HandMade reports whether the edge was made by hand rather than derived
+// from a source: it names its creator, or it touches a node in the local
+// namespace, which no source loads.
+func (e DisplayEdge) HandMade() bool {
+	return e.CreatedBy != "" || graph.IsLocalID(e.FromID) || graph.IsLocalID(e.ToID)
 }
 
 // EdgeToDisplayEdge converts a graph.Edge to a DisplayEdge.
 func EdgeToDisplayEdge(edge graph.Edge) DisplayEdge {
 	return DisplayEdge{
-		FromID:   edge.FromID,
-		ToID:     edge.ToID,
-		Relation: edge.Relation,
+		FromID:    edge.FromID,
+		ToID:      edge.ToID,
+		Relation:  edge.Relation,
+		CreatedBy: edge.Metadata.CreatedBy,
+		CreatedAt: edge.Metadata.CreatedAt,
 	}
 }
 
@@ -1,7 +1,11 @@
 package tui
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -11,7 +15,7 @@ func (m Model) Init() tea.Cmd {
 	if len(m.nodes) > 0 {
 		return nil
 	}
-	return fetchData()
+	return tea.Batch(fetchData(), m.spinner.Tick)
 }
 
 // Update handles all messages (Commandment #1: VALUE receiver, no pointer mutation)
@@ -27,6 +31,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(m.nodes) == 0 {
 				return m, fetchData()
 			}
+			m = m.withFilterSuggestion()
 		}
 		return m, nil
 
@@ -34,6 +39,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
 
+	// Back from Ctrl+Z (fg): the shell may have drawn over the screen or the
+	// terminal been resized meanwhile, so repaint at the current size
+	case tea.ResumeMsg:
+		return m, tea.Batch(tea.ClearScreen, tea.WindowSize())
+
 	// Custom messages
 	case DataLoadedMsg:
 		return m.WithData(msg.Data), nil
@@ -41,13 +51,83 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case GraphDataLoadedMsg:
 		// Load graph nodes and edges into model
 		m = m.WithNodes(msg.Nodes).WithEdges(msg.Edges).WithLoading(false)
-		return m, nil
+		m.loadedAt = time.Now()
+		return m.withFilterSuggestion(), nil
+
+	case spinner.TickMsg:
+		// Let the tick chain lapse once loading finishes
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case LoadProgressMsg:
+		return m.WithLoadProgress(msg), nil
 
 	case ErrorOccurred:
 		return m.WithError(msg.Err), nil
 
+	case StatusMsg:
+		return m.WithStatus(msg.Message, msg.IsError), nil
+
+	case SavedQueryResultMsg:
+		return m.WithSavedQueryIDs(msg.Name, msg.IDs), nil
+
+	case ArchiveListedMsg:
+		return m.WithArchived(msg.Search, msg.Nodes), nil
+
+	case SyncRunsListedMsg:
+		return m.WithSyncRuns(msg.Runs), nil
+
+	case ArchiveRestoredMsg:
+		m = m.withRestored(msg.Nodes, msg.Edges)
+		return m.WithStatus(fmt.Sprintf("Restored %d nodes to the graph", len(msg.Nodes)), false), nil
+
+	case DiffLoadedMsg:
+		m = m.WithPager(msg.NodeID, msg.Lines, false).WithStatus("", false)
+		if m.currentView != ViewDiff {
+			m = m.PushView(ViewDiff)
+		}
+		return m, nil
+
+	case FilePreviewMsg:
+		m = m.WithPager(msg.NodeID, msg.Lines, msg.Numbered).WithStatus("", false)
+		if m.currentView != ViewPreview {
+			m = m.PushView(ViewPreview)
+		}
+		return m, nil
+
+	case BranchHistoryLoadedMsg:
+		return m.withBranchHistory(msg), nil
+
+	case GraphDeltaMsg:
+		return m.withSynced(msg), nil
+
+	case SyncFailedMsg:
+		m.syncing = false
+		return m.logDiagnostics(msg.Diagnostics).logActivity(ActivitySync, msg.Err.Error()).WithError(msg.Err), nil
+
+	case SQLResultMsg:
+		m = m.WithSQLResult(msg.Query, msg.Result)
+		m = m.WithStatus(fmt.Sprintf("%d rows", len(msg.Result.Rows)), false)
+		if m.currentView != ViewSQL {
+			m = m.PushView(ViewSQL)
+		}
+		return m, nil
+
 	case RefreshRequested:
-		return m.WithLoading(true), refreshData()
+		if m.refresher != nil {
+			if m.syncBusy() {
+				return m.WithStatus(syncRunning, true), nil
+			}
+			m = m.WithLoading(true).WithStatus("Syncing all sources…", false)
+			m.syncing = true
+			return m, tea.Batch(m.dispatcher.Dispatch(m.ctx, reloadSources(m.ctx, m.refresher, m.nodes, m.edges), m.dispatcher.syncProviders(nil)...), m.spinner.Tick)
+		}
+		m = m.logActivity(ActivitySync, "Refreshed sources")
+		return m.WithLoading(true), tea.Batch(refreshData(), m.spinner.Tick)
 
 	case AIInvoked:
 		// Commandment #6: Human Contact - AI requires explicit Ctrl+A
@@ -56,20 +136,94 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case ConfirmationRequested:
 		// Commandment #10: Sovereignty - external writes require confirmation
-		return m.WithConfirmation(&ConfirmationRequest{
-			Action:  msg.Action,
-			Execute: msg.Execute,
-		}), nil
+		req := ConfirmationRequest{
+			Action:      msg.Action,
+			Execute:     msg.Execute,
+			Edit:        msg.Edit,
+			Done:        msg.Done,
+			Category:    msg.Category,
+			Destructive: msg.Destructive,
+			Optimistic:  msg.Optimistic,
+			Target:      msg.Target,
+			Requeue:     msg.Requeue,
+			Draft:       msg.Draft,
+		}
+		if req.Edit != nil && req.Edit.FetchRemote != nil {
+			return m.WithStatus("Checking for remote changes...", false), m.dispatcher.Dispatch(m.ctx, checkRemote(req), req.providers()...)
+		}
+		return m.confirmOrRun(req)
+
+	case RemoteCheckedMsg:
+		return m.withRemoteChecked(msg)
 
 	case ConfirmationAccepted:
 		if m.confirmation != nil {
-			req := m.confirmation
-			return m.WithConfirmation(nil), executeConfirmedAction(req.Execute)
+			return m.runConfirmed(*m.confirmation)
+		}
+		return m, nil
+
+	case ConfirmationRemembered:
+		if m.confirmation != nil {
+			req := *m.confirmation
+			return m.rememberConfirmation(req.Category).runConfirmed(req)
 		}
 		return m, nil
 
 	case ConfirmationRejected:
-		return m.WithConfirmation(nil).PopView(), nil
+		if m.confirmation != nil && m.confirmation.Requeue != nil {
+			m = m.withRequeued(*m.confirmation.Requeue).WithStatus("Kept in the action queue", false)
+		}
+		if m.confirmation != nil && m.confirmation.Draft != nil {
+			draft := m.confirmation.Draft
+			return m.WithConfirmation(nil).withDraft(draft).WithStatus("Comment not posted; draft kept", false), nil
+		}
+		return m.WithConfirmation(nil), nil
+
+	case WriteSettledMsg:
+		return m.withWriteSettled(msg)
+
+	case WritesPolledMsg:
+		return m.withWritesPolled(msg)
+
+	case NodeEditedMsg:
+		return m.withNodeEdited(msg).WithStatus("Saved", false), nil
+
+	case IssueCreatedMsg:
+		return m.withIssueCreated(msg), nil
+
+	case CommentPostedMsg:
+		return m.withCommentPosted(msg), nil
+
+	case CommentFailedMsg:
+		return m.withDraft(msg.Draft).WithError(msg.Err), nil
+
+	case EdgeDeletedMsg:
+		return m.withoutEdge(msg.Edge).WithStatus("Relation deleted", false), nil
+
+	case QueueActionRequested:
+		return m.enqueueAction(ConfirmationRequest{
+			Action:      msg.Action,
+			Execute:     msg.Execute,
+			Edit:        msg.Edit,
+			Category:    msg.Category,
+			Destructive: msg.Destructive,
+			Optimistic:  msg.Optimistic,
+			Target:      msg.Target,
+		}), nil
+
+	case BatchConfirmationRequested:
+		if len(msg.Actions) == 0 {
+			return m, nil
+		}
+		return m.confirmOrRun(*batchConfirmation(msg))
+
+	case QueueFlushedMsg:
+		// Bring the model in line with every write that ran
+		for _, done := range msg.Applied {
+			next, _ := m.Update(done)
+			m = next.(Model)
+		}
+		return m.withQueueFlushed(msg), nil
 
 	case NavigateDown:
 		// Commandment #4: Navigation Monopoly - Enter drills down
@@ -85,6 +239,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKeyPress processes keyboard input
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// While saving on the way out, only a second quit does anything
+	if m.quitting {
+		if key.Matches(msg, m.keys.Quit) {
+			return m.quit()
+		}
+		return m, nil
+	}
+
+	// Ctrl+Z backgrounds the TUI from any view or prompt (Commandment #9)
+	if key.Matches(msg, m.keys.Suspend) {
+		return m, tea.Suspend
+	}
+
 	// Handle confirmation view separately
 	if m.currentView == ViewConfirm {
 		return m.handleConfirmationKeys(msg)
@@ -95,20 +262,95 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleSearchInput(msg)
 	}
 
+	// Handle SQL prompt input
+	if m.sqlMode {
+		return m.handleSQLInput(msg)
+	}
+
+	// Handle saved query name prompt
+	if m.queryNameMode {
+		return m.handleQueryNameInput(msg)
+	}
+
+	// Handle archive search input
+	if m.archiveSearchMode {
+		return m.handleArchiveSearchInput(msg)
+	}
+
+	// Handle Relations filter input
+	if m.relationsFilterMode {
+		return m.handleRelationsFilterInput(msg)
+	}
+
+	// Inline node editor takes every key until saved or cancelled
+	if m.editor != nil {
+		return m.handleEditorInput(msg)
+	}
+
+	// So does the comment composer, until posted or discarded
+	if m.composer != nil {
+		return m.handleCommentInput(msg)
+	}
+
+	// Status messages are transient - cleared by the next key press
+	m = m.WithStatus("", false)
+
+	// The large graph prompt answers y/n; other keys dismiss it
+	if m.suggestion != nil {
+		return m.handleSuggestionKeys(msg)
+	}
+
+	// The legend overlay takes keys until it is closed
+	if m.legend {
+		return m.handleLegendKeys(msg)
+	}
+
+	// A key after the leader (space) is a chord
+	if m.leaderPending {
+		return m.handleLeaderKey(msg)
+	}
+
+	// Registered views (everything but Graph, Details, Relations and SQL)
+	// handle their own keys
+	if module, ok := m.views[m.currentView]; ok {
+		return module.HandleKey(m, msg)
+	}
+
+	// A registered view's opening key, from the Graph
+	if m.currentView == ViewGraph {
+		if opener, ok := m.openerFor(msg.String()); ok {
+			return opener.Open(m)
+		}
+	}
+
 	// Global keybindings
 	switch {
 	case key.Matches(msg, m.keys.Quit):
 		// Commandment #9: Terminal Citizenship - Ctrl+C exits
-		return m, tea.Quit
+		return m.quit()
 
 	case key.Matches(msg, m.keys.Enter):
 		// Drill down - behavior depends on view
 		if m.currentView == ViewRelations {
 			// Jump to selected relation's node
+			if relations := m.GetRelationsList(); m.selectedRelIdx < len(relations) {
+				rel := relations[m.selectedRelIdx]
+				from, _ := m.GetFocusedNode()
+				m = m.logActivity(ActivityJump, fmt.Sprintf("%s → %s (%s)", from.Title, rel.NodeTitle, rel.Relation))
+			}
 			return m.jumpToSelectedRelation(), nil
 		}
 		// In Graph view, toggle collapse for projects/nodes with children
 		if m.currentView == ViewGraph {
+			if parentID, ok := parseMoreRowID(m.focusedNode); ok {
+				return m.WithMoreSiblings(parentID), nil
+			}
+			if m.expandsHistory(m.focusedNode) {
+				return m.expandBranch(m.focusedNode)
+			}
+			if m.previewsFile(m.focusedNode) {
+				return m.openPreview()
+			}
 			if m.HasChildren(m.focusedNode) {
 				return m.ToggleCollapse(m.focusedNode), nil
 			}
@@ -118,9 +360,19 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.Update(NavigateDown{})
 
 	case key.Matches(msg, m.keys.Back):
+		// Esc clears a Relations filter before leaving the view
+		if m.currentView == ViewRelations && m.relationsFilter != "" {
+			return m.WithRelationsFilter(""), nil
+		}
 		// Back up
 		if m.navStack.IsEmpty() {
-			// At top level, Esc does nothing
+			// At top level, Esc widens focus mode first, then clears a saved query
+			if m.FocusRoot() != "" {
+				return m.WidenFocus(), nil
+			}
+			if m.activeQuery != "" {
+				return m.clearSavedQuery(), nil
+			}
 			return m, nil
 		}
 		return m.Update(NavigateUp{})
@@ -136,6 +388,9 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.currentView == ViewRelations {
 			return m.moveRelationUp(), nil
 		}
+		if m.currentView == ViewSQL {
+			return m.WithSQLScroll(m.sqlScroll - 1), nil
+		}
 		return m.HandleNavigation("k"), nil
 
 	case key.Matches(msg, m.keys.Down):
@@ -143,6 +398,9 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.currentView == ViewRelations {
 			return m.moveRelationDown(), nil
 		}
+		if m.currentView == ViewSQL {
+			return m.WithSQLScroll(m.sqlScroll + 1), nil
+		}
 		return m.HandleNavigation("j"), nil
 
 	case key.Matches(msg, m.keys.Left):
@@ -178,24 +436,15 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "f":
 		// Cycle filter mode (only in Graph view)
 		if m.currentView == ViewGraph {
-			m = m.WithFilterMode(m.filterMode.CycleFilter())
-			// Reset focus to first filtered node if current focus is filtered out
-			filteredNodes := m.GetFilteredNodes()
-			if len(filteredNodes) > 0 {
-				found := false
-				for _, node := range filteredNodes {
-					if node.ID == m.focusedNode {
-						found = true
-						break
-					}
-				}
-				if !found {
-					m = m.WithFocusedNode(filteredNodes[0].ID)
-				}
-			}
+			m = m.cycleTypeFilter()
 		}
 		return m, nil
 	case "s":
+		// Cycle the Relations sort key
+		if m.currentView == ViewRelations {
+			m = m.WithRelationsSort(m.relationsSort.Cycle())
+			return m.WithStatus("Relations sorted by "+m.relationsSort.String(), false), nil
+		}
 		// Cycle status filter (only in Graph view)
 		if m.currentView == ViewGraph {
 			m = m.WithStatusFilter(m.statusFilter.CycleStatusFilter())
@@ -217,11 +466,196 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case "/":
-		// Enter search mode (only in Graph view)
+		// Enter search mode in Graph view, or filter the Relations list
 		if m.currentView == ViewGraph {
 			m = m.WithSearchMode(true)
 		}
+		if m.currentView == ViewRelations {
+			m = m.WithRelationsFilterMode(true)
+		}
+		return m, nil
+	case "D":
+		// Delete every hand-made relation listed, with one confirmation
+		if m.currentView == ViewRelations {
+			return m.requestBulkEdgeDeletion()
+		}
+		// Open the cross-project dashboard
+		if m.currentView == ViewGraph {
+			m = m.PushView(ViewDashboard)
+		}
 		return m, nil
+	case "C":
+		// Check whether the focused PR is ready to merge
+		if m.currentView == ViewGraph || m.currentView == ViewDetails {
+			m = m.openChecklist()
+		}
+		return m, nil
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9", "0":
+		// Follow a numbered link in Details
+		if m.currentView == ViewDetails && m.editor == nil {
+			return m.followLink(int(msg.String()[0] - '0'))
+		}
+		// Limit tree depth (0 restores unlimited depth)
+		if m.currentView == ViewGraph && msg.String() <= "4" {
+			m = m.WithMaxDepth(int(msg.String()[0] - '0'))
+		}
+		return m, nil
+	case "F":
+		// Focus mode: isolate the focused node's subtree
+		if m.currentView == ViewGraph {
+			m = m.WithFocusRoot(m.focusedNode)
+		}
+		return m, nil
+	case "?":
+		// Legend of status glyphs, type icons and colors
+		return m.WithLegend(true), nil
+	case "P":
+		// Presentation mode for screen sharing (P again leaves it)
+		if m.currentView == ViewGraph {
+			m = m.WithPresentation(!m.presentation)
+		}
+		return m, nil
+	case "I":
+		// Toggle inline output, which leaves the last screen in scrollback
+		return m.toggleAltScreen()
+	case "X":
+		// Toggle exec mode (project/service roll-ups only)
+		if m.currentView == ViewGraph {
+			m = m.WithExecMode(!m.execMode)
+		}
+		return m, nil
+	case " ":
+		// Leader key: show the chords for this view
+		return m.startLeader()
+	case "ctrl+d", "pgdown", "ctrl+u", "pgup", "home", "end":
+		// Page through a long Relations list
+		if m.currentView == ViewRelations {
+			switch msg.String() {
+			case "ctrl+d", "pgdown":
+				return m.pageRelations(1), nil
+			case "ctrl+u", "pgup":
+				return m.pageRelations(-1), nil
+			case "home":
+				return m.WithSelectedRelIdx(0), nil
+			default:
+				return m.WithSelectedRelIdx(max(len(m.GetRelationsList())-1, 0)), nil
+			}
+		}
+		return m, nil
+	case "d":
+		// Delete the selected relation (hand-made edges only, after confirmation)
+		if m.currentView == ViewRelations {
+			return m.requestEdgeDeletion()
+		}
+		// Show the focused commit's or PR's diff
+		if m.currentView == ViewGraph || m.currentView == ViewDetails {
+			return m.openDiff()
+		}
+		return m, nil
+	case "e":
+		// Edit a hand-made node's or Linear issue's title and description in place
+		if m.currentView == ViewDetails {
+			return m.startEditing()
+		}
+		return m, nil
+	case "c":
+		// Write a comment to post on the issue shown
+		if m.currentView == ViewDetails {
+			return m.startComment()
+		}
+		return m, nil
+	case "t":
+		// Expand/collapse the traceability / impact section in Details
+		if m.currentView == ViewDetails {
+			m = m.WithTraceExpanded(!m.traceExpanded)
+		}
+		return m, nil
+	case "O":
+		// Cycle the owner filter: all → each inferred owner → all
+		if m.currentView == ViewGraph {
+			m = m.WithOwnerFilter(m.nextOwner())
+		}
+		return m, nil
+	case "M":
+		// Toggle my-work mode (assigned issues, my PRs and reviews, recent commits)
+		if m.currentView == ViewGraph {
+			m = m.WithMyWork(!m.myWork)
+		}
+		return m, nil
+	case "N":
+		// Toggle the "needs my review" PR filter
+		if m.currentView == ViewGraph {
+			m = m.WithNeedsReview(!m.needsReview)
+		}
+		return m, nil
+	case "R":
+		// Resync the sources behind the focused node (on a project, its
+		// repository's git and file scans)
+		if m.currentView == ViewGraph || m.currentView == ViewDetails {
+			return m.refreshFocused()
+		}
+		return m, nil
+	case ":":
+		// Open read-only SQL prompt (Graph or SQL results view)
+		if m.currentView == ViewGraph || m.currentView == ViewSQL {
+			m = m.WithSQLMode(true)
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// cycleTypeFilter moves the Graph to the next type filter, moving focus to
+// the first node left if the focused one is filtered out.
+func (m Model) cycleTypeFilter() Model {
+	m = m.WithFilterMode(m.filterMode.CycleFilter())
+	filteredNodes := m.GetFilteredNodes()
+	if len(filteredNodes) > 0 {
+		found := false
+		for _, node := range filteredNodes {
+			if node.ID == m.focusedNode {
+				found = true
+				break
+			}
+		}
+		if !found {
+			m = m.WithFocusedNode(filteredNodes[0].ID)
+		}
+	}
+	return m
+}
+
+// handleSQLInput processes input while typing an ad-hoc SQL query
+func (m Model) handleSQLInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		// Cancel the prompt
+		return m.WithSQLMode(false), nil
+
+	case tea.KeyEnter:
+		// Run the query asynchronously (Commandment #5: Controlled Effects)
+		query := m.sqlQuery
+		m.sqlMode = false
+		if query == "" {
+			return m, nil
+		}
+		return m, runSQLQuery(m.ctx, m.store, query)
+
+	case tea.KeyBackspace:
+		if len(m.sqlQuery) > 0 {
+			m = m.WithSQLQuery(dropLastRune(m.sqlQuery))
+		}
+		return m, nil
+
+	case tea.KeySpace:
+		return m.WithSQLQuery(m.sqlQuery + " "), nil
+
+	case tea.KeyRunes:
+		return m.WithSQLQuery(m.sqlQuery + string(msg.Runes)), nil
+
+	case tea.KeyCtrlC:
+		return m.quit()
 	}
 
 	return m, nil
@@ -248,7 +682,7 @@ func (m Model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyBackspace:
 		// Remove last character from query
 		if len(m.searchQuery) > 0 {
-			m = m.WithSearchQuery(m.searchQuery[:len(m.searchQuery)-1])
+			m = m.WithSearchQuery(dropLastRune(m.searchQuery))
 		}
 		return m, nil
 
@@ -264,7 +698,7 @@ func (m Model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyCtrlC:
-		return m, tea.Quit
+		return m.quit()
 	}
 
 	return m, nil
@@ -272,13 +706,32 @@ func (m Model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleConfirmationKeys processes keys in confirmation view
 func (m Model) handleConfirmationKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// A batch moves its selection and toggles items first
+	if m.confirmation != nil && len(m.confirmation.Batch) > 0 {
+		if next, ok := m.handleBatchKeys(msg); ok {
+			return next, nil
+		}
+	}
 	switch msg.String() {
 	case "y", "Y", "enter":
 		return m.Update(ConfirmationAccepted{})
 	case "n", "N", "esc":
 		return m.Update(ConfirmationRejected{})
+	case "s":
+		// Confirm, and stop asking for this kind of action until maat exits
+		if m.confirmation != nil && m.confirmation.Category != "" && len(m.confirmation.Conflicts) == 0 {
+			return m.Update(ConfirmationRemembered{})
+		}
+		return m, nil
+	case "a":
+		// Defer a single action to the queue instead of running it now
+		if m.confirmation != nil && len(m.confirmation.Batch) == 0 {
+			req := *m.confirmation
+			return m.WithConfirmation(nil).enqueueAction(req), nil
+		}
+		return m, nil
 	case "ctrl+c", "q":
-		return m, tea.Quit
+		return m.quit()
 	}
 	return m, nil
 }
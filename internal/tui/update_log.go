@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ReplayableMsgs lists the message types an update log can re-drive: the
+// ones that survive a JSON round trip. Messages carrying functions (write
+// confirmations) or errors are logged as text only.
+func ReplayableMsgs() []tea.Msg {
+	return []tea.Msg{
+		tea.KeyMsg{},
+		tea.MouseMsg{},
+		tea.WindowSizeMsg{},
+		tea.ResumeMsg{},
+		spinner.TickMsg{},
+		DataLoadedMsg{},
+		StatusMsg{},
+		SQLResultMsg{},
+		SavedQueryResultMsg{},
+		ArchiveListedMsg{},
+		ArchiveRestoredMsg{},
+		SyncRunsListedMsg{},
+		BranchHistoryLoadedMsg{},
+		DiffLoadedMsg{},
+		FilePreviewMsg{},
+		GraphDataLoadedMsg{},
+		LoadProgressMsg{},
+		RefreshRequested{},
+		GraphDeltaMsg{},
+		AIInvoked{},
+		ConfirmationAccepted{},
+		ConfirmationRejected{},
+		ConfirmationRemembered{},
+		NodeEditedMsg{},
+		IssueCreatedMsg{},
+		CommentPostedMsg{},
+		EdgeDeletedMsg{},
+		NavigateDown{},
+		NavigateUp{},
+	}
+}
+
+// StateHash fingerprints the UI state an update can change, so a replayed
+// session can be checked against the recording step by step. Wall-clock
+// dependent output (relative times, spinner frames) is deliberately left
+// out: a replay days later must still match.
+func (m Model) StateHash() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "view=%d stack=%v size=%dx%d ready=%t loading=%t\n", m.currentView, m.navStack.stack, m.width, m.height, m.ready, m.loading)
+	fmt.Fprintf(h, "graph=%d/%d focused=%q filter=%d status=%d owner=%q depth=%d\n", len(m.nodes), len(m.edges), m.focusedNode, m.filterMode, m.statusFilter, m.ownerFilter, m.maxDepth)
+	fmt.Fprintf(h, "scroll=%d rel=%d/%d sql=%d card=%d query=%d review=%d action=%d pager=%q:%d\n",
+		m.graphScroll, m.selectedRelIdx, m.relationsScroll, m.sqlScroll, m.selectedCard, m.selectedQueryIdx, m.selectedReviewIdx, m.selectedActionIdx, m.pager.nodeID, m.pager.scroll)
+	fmt.Fprintf(h, "search=%t:%q sqlMode=%t:%q name=%t:%q active=%q ids=%d relFilter=%t:%q relSort=%d leader=%t\n", m.searchMode, m.searchQuery, m.sqlMode, m.sqlQuery, m.queryNameMode, m.queryName, m.activeQuery, len(m.idFilter), m.relationsFilterMode, m.relationsFilter, m.relationsSort, m.leaderPending)
+	fmt.Fprintf(h, "exec=%t role=%q focus=%v myWork=%t review=%t trace=%t accessible=%t alt=%t queued=%d present=%t legend=%t suggest=%t\n",
+		m.execMode, m.role, m.focusStack, m.myWork, m.needsReview, m.traceExpanded, m.accessible, m.altScreen, len(m.actionQueue), m.presentation, m.legend, m.suggestion != nil)
+	fmt.Fprintf(h, "msg=%q error=%t confirm=%t\n", m.statusMsg, m.statusIsError, m.confirmation != nil)
+	if m.confirmation != nil {
+		fmt.Fprintf(h, "confirm=%q conflicts=%d batch=%d skip=%v cursor=%d\n", m.confirmation.Action, len(m.confirmation.Conflicts), len(m.confirmation.Batch), m.confirmation.Skip, m.confirmation.Cursor)
+	}
+	writeSortedKeys(h, "remembered", m.confirmRemembered)
+	fmt.Fprintf(h, "activity=%d/%d syncRuns=%d/%d\n", len(m.activity), m.activityScroll, len(m.syncRuns), m.syncStatsScroll)
+	writeSortedKeys(h, "collapsed", m.collapsed)
+	writeSortedKeys(h, "limits", m.siblingLimit)
+	writeSortedKeys(h, "history", m.historyDone)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// writeSortedKeys writes a map's entries in key order (map iteration is random)
+func writeSortedKeys[V any](w io.Writer, label string, values map[string]V) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s %q=%v\n", label, key, values[key])
+	}
+}
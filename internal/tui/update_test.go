@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// fakeRemote is a Linear issue as the source has it now
+type fakeRemote struct {
+	updatedAt time.Time
+	fields    map[string]string
+	pushed    []map[string]string // Fields written back, in order
+}
+
+func (r *fakeRemote) RemoteFields(ctx context.Context, node graph.Node) (time.Time, map[string]string, error) {
+	fields := make(map[string]string, len(r.fields))
+	for k, v := range r.fields {
+		fields[k] = v
+	}
+	return r.updatedAt, fields, nil
+}
+
+func (r *fakeRemote) UpdateFields(ctx context.Context, node graph.Node, fields map[string]string) error {
+	r.pushed = append(r.pushed, fields)
+	for k, v := range fields {
+		r.fields[k] = v
+	}
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// TestWriteBackChecksTheRemoteCopy drives an edit of a Linear issue through
+// Update: the remote copy is re-read before the dialog, a title changed
+// there since the sync shows as a conflict, and only the edited field is
+// written back.
+func TestWriteBackChecksTheRemoteCopy(t *testing.T) {
+	synced := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		remoteChanged bool
+		wantConflicts int
+	}{
+		{name: "remote unchanged", remoteChanged: false, wantConflicts: 0},
+		{name: "remote retitled", remoteChanged: true, wantConflicts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote := &fakeRemote{updatedAt: synced, fields: map[string]string{"title": "Flaky login", "description": "Seen on CI"}}
+			if tt.remoteChanged {
+				remote.updatedAt = synced.Add(time.Hour)
+				remote.fields["title"] = "Login flakes on Safari"
+			}
+			issue := DisplayNode{ID: "linear:A-1", Type: graph.NodeTypeIssue, Identifier: "A-1", Title: "Flaky login", Description: "Seen on CI", UpdatedAt: synced}
+			var model tea.Model = NewModel().WithNodes([]DisplayNode{issue}).WithFocusedNode(issue.ID).WithWriteBack(remote).PushView(ViewDetails)
+			update := func(msg tea.Msg) tea.Cmd {
+				t.Helper()
+				var cmd tea.Cmd
+				model, cmd = model.Update(msg)
+				return cmd
+			}
+
+			update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+			if model.(Model).editor == nil {
+				t.Fatal("e did not open the editor on a Linear issue")
+			}
+			update(tea.KeyMsg{Type: tea.KeyCtrlU})
+			update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Login fails after timeout")})
+			check := update(tea.KeyMsg{Type: tea.KeyCtrlS})
+			if check == nil {
+				t.Fatal("saving did not re-read the remote copy")
+			}
+			update(check())
+
+			confirmation := model.(Model).confirmation
+			if confirmation == nil {
+				t.Fatal("no confirmation after the remote check")
+			}
+			if len(confirmation.Conflicts) != tt.wantConflicts {
+				t.Fatalf("conflicts = %+v, want %d", confirmation.Conflicts, tt.wantConflicts)
+			}
+			if tt.wantConflicts > 0 && confirmation.Conflicts[0].Remote != "Login flakes on Safari" {
+				t.Errorf("conflict = %+v, want the remote title", confirmation.Conflicts[0])
+			}
+
+			write := update(ConfirmationAccepted{})
+			if write == nil {
+				t.Fatal("accepting did not write the edit back")
+			}
+			update(write())
+			if len(remote.pushed) != 1 || len(remote.pushed[0]) != 1 || remote.pushed[0]["title"] != "Login fails after timeout" {
+				t.Errorf("pushed %v, want only the new title", remote.pushed)
+			}
+			if node, _ := model.(Model).GetNodeByID(issue.ID); node.Title != "Login fails after timeout" {
+				t.Errorf("shown title = %q after the write", node.Title)
+			}
+		})
+	}
+}
+
+// TestQuitWaitsForRunningWrites checks quitting polls until the running
+// writes finish, and that a write command never run does not hold it up.
+func TestQuitWaitsForRunningWrites(t *testing.T) {
+	m := NewModel().WithContext(context.Background())
+	_ = m.writes.track(func() tea.Msg { return nil }) // Created, never run
+	if got := m.writes.pending(); got != 0 {
+		t.Fatalf("pending = %d before the command ran", got)
+	}
+
+	m.writes.count.Add(1) // A write in progress
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd == nil || !next.(Model).quitting {
+		t.Fatal("q did not start quitting")
+	}
+	deadline := time.Now().Add(time.Minute)
+	next, cmd = next.Update(WritesPolledMsg{Deadline: deadline})
+	if msg := cmd(); msg != (WritesPolledMsg{Deadline: deadline}) {
+		t.Fatalf("with a write running, polling gave %T, want another poll", msg)
+	}
+
+	m.writes.count.Add(-1)
+	_, cmd = next.Update(WritesPolledMsg{Deadline: deadline})
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("polling did not quit once the write finished")
+	}
+}
+
+// fakeRefresher is a loader whose one source produced every node
+type fakeRefresher struct{}
+
+func (r *fakeRefresher) LoadAll(ctx context.Context) ([]graph.Node, []graph.Edge, error) {
+	return nil, nil, nil
+}
+
+func (r *fakeRefresher) SourcesOf(nodeID string) []string {
+	return []string{"git"}
+}
+
+func (r *fakeRefresher) RefreshNode(ctx context.Context, nodeID string) ([]graph.Node, []graph.Edge, []string, error) {
+	return nil, nil, nil, nil
+}
+
+func (r *fakeRefresher) Diagnostics() []string { return nil }
+
+func (r *fakeRefresher) Warnings() []string { return nil }
+
+// TestResyncWaitsForRunningSync presses R while a full sync is pending: it
+// is refused until the sync reports back, then runs.
+func TestResyncWaitsForRunningSync(t *testing.T) {
+	project := graph.Node{ID: "project:maat", Type: graph.NodeTypeProject, Data: []byte(`{"name":"maat"}`)}
+	var model tea.Model = NewModelWithData([]graph.Node{project}, nil, "").WithSourceRefresh(&fakeRefresher{})
+	model, sync := model.Update(RefreshRequested{})
+	if sync == nil {
+		t.Fatal("r did not start a sync")
+	}
+
+	model, resync := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if resync != nil {
+		t.Fatal("R started a resync while a sync was running")
+	}
+	if status := model.(Model).statusMsg; status != syncRunning {
+		t.Errorf("status = %q, want %q", status, syncRunning)
+	}
+
+	model, _ = model.Update(GraphDeltaMsg{})
+	if _, resync = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")}); resync == nil {
+		t.Error("R did not resync once the sync finished")
+	}
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/manutej/maat-terminal/internal/graph"
@@ -18,18 +19,32 @@ func (m Model) View() string {
 		return m.renderLoadingScreen()
 	}
 
-	// Handle confirmation dialog overlay
-	if m.confirmation != nil {
-		return m.renderConfirmDialog()
+	// Nothing useful fits below the minimum size
+	if m.isTooSmall() {
+		return m.renderTooSmall()
 	}
 
-	// Render current view mode (full screen)
-	return m.renderCurrentView()
+	// Render current view mode (full screen); presentation mode drops the
+	// status bar and enlarges the focused node. The confirmation dialog and
+	// the legend overlay it.
+	view := m.renderCurrentView
+	switch {
+	case m.confirmation != nil:
+		view = m.renderConfirmDialog
+	case m.legend:
+		view = m.renderLegend
+	case m.presentation && m.currentView == ViewGraph && !m.leaderPending:
+		view = m.renderPresentation
+	}
+	if m.accessible {
+		return plainText(view())
+	}
+	return view()
 }
 
 // renderLoadingScreen shows a loading message while waiting for window size.
 func (m Model) renderLoadingScreen() string {
-	loadingMsg := styles.LoadingStyle.Render("Initializing MAAT...")
+	loadingMsg := m.spinner.View() + styles.LoadingStyle.Render("Initializing MAAT...")
 
 	// Center the loading message
 	return styles.LoadingContainerStyle.
@@ -41,7 +56,7 @@ func (m Model) renderLoadingScreen() string {
 // renderCurrentView renders the full-screen view based on currentView mode.
 func (m Model) renderCurrentView() string {
 	// Reserve space for status bar (2 lines)
-	contentHeight := m.height - 2
+	contentHeight := clampMin(m.height-2, 1)
 
 	// Render content based on current view mode
 	var content string
@@ -52,13 +67,39 @@ func (m Model) renderCurrentView() string {
 		content = m.renderDetailsView(m.width, contentHeight)
 	case ViewRelations:
 		content = m.renderRelationsView(m.width, contentHeight)
+	case ViewSQL:
+		content = m.renderSQLView(m.width, contentHeight)
 	default:
-		content = m.renderGraphView(m.width, contentHeight)
+		if module, ok := m.views[m.currentView]; ok {
+			content = module.Render(m, m.width, contentHeight)
+		} else {
+			content = m.renderGraphView(m.width, contentHeight)
+		}
 	}
 
+	// Clip instead of wrapping so overlong lines can't break the layout
+	content = lipgloss.NewStyle().
+		MaxWidth(m.width).
+		MaxHeight(contentHeight).
+		Render(content)
+
 	// Render status bar
 	statusBar := m.renderStatusBar()
 
+	// Chord hints after the leader key cover the bottom of the content
+	if m.leaderPending {
+		hints := m.renderLeaderHints()
+		content = lipgloss.NewStyle().
+			MaxHeight(clampMin(contentHeight-lipgloss.Height(hints), 1)).
+			Render(content)
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			content,
+			hints,
+			statusBar,
+		)
+	}
+
 	// Stack content and status bar vertically
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -82,48 +123,59 @@ func (m Model) renderGraphView(width, height int) string {
 	builder.WriteString(titleStyle.Render("📊 Knowledge Graph"))
 	builder.WriteString("\n")
 
+	// Focus mode breadcrumb replaces one line of graph content
+	if m.FocusRoot() != "" {
+		builder.WriteString(lipgloss.NewStyle().
+			Foreground(styles.Accent).
+			Italic(true).
+			Render(truncate(m.renderFocusBreadcrumb(), width)))
+		builder.WriteString("\n")
+		height--
+	}
+
 	// Render graph with full terminal width
 	if len(m.nodes) == 0 {
 		noDataMsg := styles.LoadingStyle.Render("No nodes loaded. Press 'r' to refresh.")
 		builder.WriteString(lipgloss.NewStyle().
 			Width(width).
-			Height(height - 3).
+			Height(clampMin(height-3, 0)).
 			Align(lipgloss.Center, lipgloss.Center).
 			Render(noDataMsg))
 	} else {
-		// Use hierarchical tree rendering with FULL WIDTH (no pane constraint)
-		graphViz := RenderGraph(m, width-4) // -4 for padding
-
-		// Apply scrolling - split into lines and show only visible portion
-		lines := strings.Split(graphViz, "\n")
-		visibleHeight := height - 4 // Reserve for title and margins
+		// Use hierarchical tree layout with FULL WIDTH (no pane constraint)
+		layout := m.layoutGraph()
+		maxWidth := clampMin(width-4, 1) // -4 for padding
+		totalLines := layout.lineCount()
+		visibleHeight := clampMin(height-4, 1) // Reserve for title and margins
 
 		// Calculate scroll bounds
 		scrollStart := m.graphScroll
 		if scrollStart < 0 {
 			scrollStart = 0
 		}
-		if scrollStart >= len(lines) {
+		if scrollStart >= totalLines {
 			scrollStart = 0
 		}
 
 		scrollEnd := scrollStart + visibleHeight
-		if scrollEnd > len(lines) {
-			scrollEnd = len(lines)
+		if scrollEnd > totalLines {
+			scrollEnd = totalLines
 		}
 
-		// Show only visible lines
-		if scrollStart < len(lines) {
-			visibleLines := lines[scrollStart:scrollEnd]
-			builder.WriteString(strings.Join(visibleLines, "\n"))
+		// Style only the visible lines - off-screen rows are never rendered
+		for i := scrollStart; i < scrollEnd; i++ {
+			if i > scrollStart {
+				builder.WriteByte('\n')
+			}
+			builder.WriteString(layout.line(m, i, maxWidth))
 		}
 
 		// Show scroll indicator if content is scrolled
-		if len(lines) > visibleHeight {
+		if totalLines > visibleHeight {
 			scrollInfo := lipgloss.NewStyle().
 				Foreground(styles.Muted).
 				Faint(true).
-				Render(fmt.Sprintf("\n[%d-%d of %d lines]", scrollStart+1, scrollEnd, len(lines)))
+				Render(fmt.Sprintf("\n[%d-%d of %d lines]", scrollStart+1, scrollEnd, totalLines))
 			builder.WriteString(scrollInfo)
 		}
 	}
@@ -152,7 +204,7 @@ func (m Model) renderDetailsView(width, height int) string {
 		noSelectionMsg := styles.PaneContentStyle.Render("No node selected. Press Tab to view Graph and select a node.")
 		builder.WriteString(lipgloss.NewStyle().
 			Width(width).
-			Height(height - 3).
+			Height(clampMin(height-3, 0)).
 			Align(lipgloss.Center, lipgloss.Center).
 			Render(noSelectionMsg))
 		return builder.String()
@@ -161,10 +213,16 @@ func (m Model) renderDetailsView(width, height int) string {
 	// Render detailed node information (centered, max 80 chars wide)
 	contentWidth := 80
 	if width < 80 {
-		contentWidth = width - 4
+		contentWidth = clampMin(width-4, 1)
 	}
 
 	detailsBox := m.renderNodeDetailsExpanded(node, contentWidth)
+	if m.editor != nil && m.editor.nodeID == node.ID {
+		detailsBox = m.renderEditor(contentWidth)
+	}
+	if m.composer != nil && m.composer.nodeID == node.ID {
+		detailsBox = m.renderComposer(node, contentWidth)
+	}
 	centeredDetails := lipgloss.NewStyle().
 		Width(width).
 		Align(lipgloss.Center).
@@ -187,7 +245,7 @@ func (m Model) renderRelationsView(width, height int) string {
 		Align(lipgloss.Center).
 		MarginBottom(1)
 
-	builder.WriteString(titleStyle.Render("🔗 Relationships (j/k to select, Enter to jump)"))
+	builder.WriteString(titleStyle.Render(fmt.Sprintf("🔗 Relationships (j/k to select, Enter to jump, sorted by %s)", m.relationsSort)))
 	builder.WriteString("\n")
 
 	// Get focused node
@@ -196,7 +254,7 @@ func (m Model) renderRelationsView(width, height int) string {
 		noSelectionMsg := styles.PaneContentStyle.Render("No node selected. Press Tab to view Graph and select a node.")
 		builder.WriteString(lipgloss.NewStyle().
 			Width(width).
-			Height(height - 3).
+			Height(clampMin(height-3, 0)).
 			Align(lipgloss.Center, lipgloss.Center).
 			Render(noSelectionMsg))
 		return builder.String()
@@ -205,7 +263,7 @@ func (m Model) renderRelationsView(width, height int) string {
 	// Render interactive relationship list
 	contentWidth := 100
 	if width < 100 {
-		contentWidth = width - 4
+		contentWidth = clampMin(width-4, 1)
 	}
 
 	relationsBox := m.renderInteractiveRelationsList(node, contentWidth)
@@ -236,6 +294,10 @@ func (m Model) renderInteractiveRelationsList(node DisplayNode, maxWidth int) st
 		noRelStyle := lipgloss.NewStyle().
 			Foreground(styles.Muted).
 			Italic(true)
+		if m.relationsFilter != "" {
+			lines = append(lines, noRelStyle.Render(fmt.Sprintf("No relations match %q (Esc clears the filter).", m.relationsFilter)))
+			return strings.Join(lines, "\n")
+		}
 		lines = append(lines, noRelStyle.Render("No relationships found for this node."))
 		return strings.Join(lines, "\n")
 	}
@@ -250,6 +312,8 @@ func (m Model) renderInteractiveRelationsList(node DisplayNode, maxWidth int) st
 		}
 	}
 
+	// Group headings and rows; only the scrolled window is shown
+	var body []string
 	idx := 0
 
 	// Outgoing relationships
@@ -257,15 +321,15 @@ func (m Model) renderInteractiveRelationsList(node DisplayNode, maxWidth int) st
 		outgoingStyle := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(styles.Primary)
-		lines = append(lines, outgoingStyle.Render("→ Outgoing Relations:"))
-		lines = append(lines, "")
+		body = append(body, outgoingStyle.Render("→ Outgoing Relations:"))
+		body = append(body, "")
 
 		for _, rel := range outgoing {
 			line := m.renderRelationLine(rel, idx, maxWidth)
-			lines = append(lines, line)
+			body = append(body, line)
 			idx++
 		}
-		lines = append(lines, "")
+		body = append(body, "")
 	}
 
 	// Incoming relationships
@@ -273,24 +337,32 @@ func (m Model) renderInteractiveRelationsList(node DisplayNode, maxWidth int) st
 		incomingStyle := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(styles.Secondary)
-		lines = append(lines, incomingStyle.Render("← Incoming Relations:"))
-		lines = append(lines, "")
+		body = append(body, incomingStyle.Render("← Incoming Relations:"))
+		body = append(body, "")
 
 		for _, rel := range incoming {
 			line := m.renderRelationLine(rel, idx, maxWidth)
-			lines = append(lines, line)
+			body = append(body, line)
 			idx++
 		}
 	}
 
-	// Summary and instructions
+	rows := m.relationsListRows()
+	start := max(min(m.relationsScroll, len(body)-rows), 0)
+	end := min(start+rows, len(body))
+	lines = append(lines, body[start:end]...)
+	if len(body) > rows {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.Muted).Faint(true).Render(
+			fmt.Sprintf("[%d-%d of %d lines · Ctrl+D/U page]", start+1, end, len(body))))
+	}
+
+	// Summary, graph context and instructions, over every relation
 	lines = append(lines, "")
-	summaryStyle := lipgloss.NewStyle().Foreground(styles.Muted)
-	lines = append(lines, summaryStyle.Render(fmt.Sprintf(
-		"Total: %d outgoing, %d incoming | j/k: navigate | Enter: jump to selected",
-		len(outgoing),
-		len(incoming),
-	)))
+	if m.relationsFilter != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.Accent).Render(fmt.Sprintf(
+			"Filter %q: %d of %d relations (/ edits, Esc clears)", m.relationsFilter, len(relations), len(m.allRelations()))))
+	}
+	lines = append(lines, m.renderRelationStats(node.ID, m.allRelations(), maxWidth)...)
 
 	return strings.Join(lines, "\n")
 }
@@ -301,7 +373,11 @@ func (m Model) renderRelationLine(rel RelationItem, idx int, maxWidth int) strin
 
 	// Style based on selection
 	var lineStyle lipgloss.Style
-	if isSelected {
+	if isSelected && m.accessible {
+		lineStyle = lipgloss.NewStyle().
+			Foreground(styles.Accent).
+			Bold(true)
+	} else if isSelected {
 		lineStyle = lipgloss.NewStyle().
 			Background(styles.Primary).
 			Foreground(lipgloss.Color("#FFFFFF")).
@@ -313,7 +389,7 @@ func (m Model) renderRelationLine(rel RelationItem, idx int, maxWidth int) strin
 	}
 
 	// Build relation display
-	icon := getNodeIcon(rel.NodeType)
+	icon := m.typeIcon(rel.NodeType)
 	arrow := "→"
 	if !rel.IsOutgoing {
 		arrow = "←"
@@ -324,15 +400,18 @@ func (m Model) renderRelationLine(rel RelationItem, idx int, maxWidth int) strin
 		relTypeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
 	}
 
-	// Format: [idx] icon Title ← relation
-	content := fmt.Sprintf("  %s %s %s %s",
+	// Format: [idx] icon Title ← relation, the icon after the target's project color
+	content := fmt.Sprintf("  %s%s %s %s %s",
+		m.projectMarker(rel.Project),
 		icon,
 		truncate(rel.NodeTitle, 40),
 		arrow,
 		relTypeStyle.Render(rel.Relation),
 	)
 
-	if isSelected {
+	if isSelected && m.accessible {
+		content += " " + selectedMarker
+	} else if isSelected {
 		content = "▶ " + content[2:] // Replace leading spaces with indicator
 	}
 
@@ -378,71 +457,46 @@ func (m Model) renderStatusBar() string {
 		return m.renderSearchBar()
 	}
 
-	var parts []string
-
-	// Show current view mode with clear indicator
-	viewText := styles.StatusBarKeyStyle.Render(fmt.Sprintf("[%s]", m.currentView.String()))
-	parts = append(parts, viewText)
-
-	// Show filter mode in Graph view
-	if m.currentView == ViewGraph {
-		filterText := styles.StatusBarTextStyle.Render(fmt.Sprintf("Type: %s", m.filterMode.String()))
-		parts = append(parts, filterText)
-
-		// Show status filter if not "All"
-		if m.statusFilter != StatusAll {
-			statusFilterText := styles.StatusBarKeyStyle.Render(fmt.Sprintf("Status: %s", m.statusFilter.String()))
-			parts = append(parts, statusFilterText)
-		}
+	// SQL prompt takes over the status bar the same way
+	if m.sqlMode {
+		return m.renderSQLBar()
+	}
 
-		// Show active search query if any
-		if m.searchQuery != "" {
-			searchText := styles.StatusBarKeyStyle.Render(fmt.Sprintf("Search: \"%s\"", m.searchQuery))
-			parts = append(parts, searchText)
-		}
+	if m.queryNameMode {
+		return m.renderQueryNameBar()
 	}
 
-	// Show focused node if any
-	if node, ok := m.GetFocusedNode(); ok {
-		nodeText := styles.StatusBarTextStyle.Render(fmt.Sprintf("→ %s", truncate(node.Title, 25)))
-		parts = append(parts, nodeText)
+	if m.archiveSearchMode {
+		return m.renderArchiveSearchBar()
 	}
 
-	// Show loading indicator
-	if m.loading {
-		loadingText := styles.StatusBarLoadingStyle.Render("Loading...")
-		parts = append(parts, loadingText)
+	if m.relationsFilterMode {
+		return m.renderRelationsFilterBar()
 	}
 
-	// Show error if any
-	if m.err != nil {
-		errText := styles.StatusBarErrorStyle.Render(fmt.Sprintf("Error: %v", m.err))
-		parts = append(parts, errText)
+	if m.suggestion != nil {
+		return m.renderSuggestionBar()
 	}
 
-	// Add key hints on the right (updated for filter and search)
-	var keyHints string
-	switch m.currentView {
-	case ViewGraph:
-		keyHints = styles.StatusBarTextStyle.Render("/:search | f:type | s:status | jk:nav | Enter:toggle | q:quit")
-	case ViewDetails:
-		keyHints = styles.StatusBarTextStyle.Render("Tab:Relations | Esc:back | q:quit")
-	case ViewRelations:
-		relations := m.GetRelationsList()
-		if len(relations) > 0 {
-			keyHints = styles.StatusBarTextStyle.Render(fmt.Sprintf("jk:select (%d/%d) | Enter:jump | Tab:Graph | q:quit", m.selectedRelIdx+1, len(relations)))
-		} else {
-			keyHints = styles.StatusBarTextStyle.Render("Tab:Graph | q:quit")
+	var parts []string
+	keyHints := ""
+	for _, segment := range m.statusBarSegments() {
+		if segment == SegmentKeys {
+			keyHints = m.statusKeyHints()
+			continue
 		}
-	default:
-		keyHints = styles.StatusBarTextStyle.Render("Tab:view | Esc:back | q:quit")
+		parts = append(parts, m.statusSegment(segment)...)
 	}
 
 	// Join left and right parts
 	leftContent := strings.Join(parts, " | ")
 
-	// Calculate spacing to right-align key hints
+	// Calculate spacing to right-align key hints, keeping only those that fit
 	leftLen := lipgloss.Width(leftContent)
+	keyHints = fitKeyHints(keyHints, m.width-leftLen-6)
+	if keyHints != "" {
+		keyHints = styles.StatusBarTextStyle.Render(keyHints)
+	}
 	rightLen := lipgloss.Width(keyHints)
 	spacing := m.width - leftLen - rightLen - 4 // -4 for padding
 	if spacing < 2 {
@@ -451,6 +505,11 @@ func (m Model) renderStatusBar() string {
 
 	fullContent := leftContent + strings.Repeat(" ", spacing) + keyHints
 
+	if m.isCompact() || keyHints == "" {
+		// Condensed layout: drop key hints, keep the status to one clipped line
+		fullContent = lipgloss.NewStyle().MaxWidth(clampMin(m.width-2, 1)).Render(leftContent)
+	}
+
 	return styles.RenderStatusBar(fullContent, m.width)
 }
 
@@ -479,29 +538,70 @@ func (m Model) renderConfirmDialog() string {
 	buttonStyle := lipgloss.NewStyle().
 		MarginTop(1)
 
-	yesButton := lipgloss.NewStyle().
+	yesButton := m.button(lipgloss.NewStyle().
 		Background(styles.Accent).
 		Foreground(lipgloss.Color("#000000")).
 		Padding(0, 2).
-		Bold(true).
-		Render("[y] Yes")
+		Bold(true), "[y] Yes")
 
-	noButton := lipgloss.NewStyle().
+	noButton := m.button(lipgloss.NewStyle().
 		Background(styles.Muted).
 		Foreground(lipgloss.Color("#FFFFFF")).
-		Padding(0, 2).
-		Render("[n] No")
-
-	dialog := dialogStyle.Render(
-		lipgloss.JoinVertical(
-			lipgloss.Center,
-			titleStyle.Render("Confirm Action"),
-			contentStyle.Render(m.confirmation.Action),
-			buttonStyle.Render(
-				lipgloss.JoinHorizontal(lipgloss.Top, yesButton, "  ", noButton),
-			),
-		),
-	)
+		Padding(0, 2), "[n] No")
+
+	// Categorised actions can stop asking for the session; single actions
+	// can be deferred to the action queue
+	buttons := []string{yesButton, "  ", noButton}
+	if m.confirmation.Category != "" {
+		buttons = append(buttons, "  ", m.button(lipgloss.NewStyle().
+			Background(styles.Accent).
+			Foreground(lipgloss.Color("#000000")).
+			Padding(0, 2), "[s] Yes, this session"))
+	}
+	if len(m.confirmation.Batch) == 0 {
+		buttons = append(buttons, "  ", m.button(lipgloss.NewStyle().
+			Background(styles.Secondary).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Padding(0, 2), "[a] Queue"))
+	}
+
+	sections := []string{
+		titleStyle.Render("Confirm Action"),
+		contentStyle.Render(m.confirmation.Action),
+	}
+	if batch := *m.confirmation; len(batch.Batch) > 0 {
+		dialogStyle = dialogStyle.Width(clampMin(min(m.width-8, 80), 40))
+		sections = append(sections, lipgloss.NewStyle().MarginTop(1).
+			Render(renderBatchItems(batch, clampMin(min(m.width-8, 80), 40)-6, clampMin(m.height-16, 3))))
+	}
+	if m.confirmation.Warning != "" {
+		sections = append(sections, lipgloss.NewStyle().Foreground(styles.StatusInProgress).MarginTop(1).
+			Render(m.confirmation.Warning))
+	}
+
+	// A remote change to the edited fields turns the dialog into a three-way diff
+	if conflicts := m.confirmation.Conflicts; len(conflicts) > 0 {
+		diffWidth := clampMin(min(m.width-8, 90), 40)
+		dialogStyle = dialogStyle.Width(diffWidth).BorderForeground(styles.StatusCanceled)
+		sections[0] = titleStyle.Foreground(styles.StatusCanceled).Render("⚠ Remote changed since last sync")
+		sections = append(sections,
+			lipgloss.NewStyle().MarginTop(1).Align(lipgloss.Left).Render(renderConflictDiff(conflicts, diffWidth-6)))
+		yesButton = m.button(lipgloss.NewStyle().
+			Background(styles.StatusCanceled).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Padding(0, 2).
+			Bold(true), "[y] Overwrite theirs")
+		noButton = m.button(lipgloss.NewStyle().
+			Background(styles.Muted).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Padding(0, 2), "[n] Cancel")
+		buttons = []string{yesButton, "  ", noButton}
+	}
+	sections = append(sections, buttonStyle.Render(
+		lipgloss.JoinHorizontal(lipgloss.Top, buttons...),
+	))
+
+	dialog := dialogStyle.Render(lipgloss.JoinVertical(lipgloss.Center, sections...))
 
 	// Center dialog on screen
 	return lipgloss.Place(
@@ -514,76 +614,175 @@ func (m Model) renderConfirmDialog() string {
 }
 
 // renderNodeDetailsExpanded renders comprehensive node details (for Details view).
+// A Details template configured for the node's type replaces the default layout.
 func (m Model) renderNodeDetailsExpanded(node DisplayNode, maxWidth int) string {
-	var lines []string
+	if tmpl := m.detailTemplates.forType(node.Type); tmpl != nil {
+		return m.renderDetailTemplate(tmpl, node, maxWidth)
+	}
 
-	// Node icon and title (large and prominent)
-	icon := getNodeIcon(node.Type)
-	titleStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(styles.Accent).
-		Underline(true)
+	var lines []string
+	section := func(name string) []string { return m.detailSection(name, node, maxWidth) }
 
-	// Show identifier if available (e.g., CET-352)
-	titleText := node.Title
-	if node.Identifier != "" {
-		titleText = fmt.Sprintf("[%s] %s", node.Identifier, node.Title)
+	lines = append(lines, section("title")...)
+	lines = append(lines, "")
+	lines = append(lines, section("type")...)
+	lines = append(lines, "")
+	lines = append(lines, section("status")...)
+	lines = append(lines, section("priority")...)
+	lines = append(lines, section("review")...)
+	lines = append(lines, section("owner")...)
+	lines = append(lines, section("metadata")...)
+	if block := section("languages"); len(block) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, block...)
+	}
+	lines = append(lines, "")
+	lines = append(lines, section("description")...)
+
+	// Optional sections are set off by blank lines
+	for _, name := range []string{"commit", "links", "labels", "trace", "related", "link"} {
+		if block := section(name); len(block) > 0 {
+			lines = append(lines, "")
+			if name == "related" {
+				lines = append(lines, "")
+			}
+			lines = append(lines, block...)
+		}
 	}
-	lines = append(lines, titleStyle.Render(fmt.Sprintf("%s %s", icon, titleText)))
+
 	lines = append(lines, "")
+	lines = append(lines, section("id")...)
+	if hint := section("hint"); len(hint) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, hint...)
+	}
 
-	// Type and Project badges on same line
-	typeStyle := lipgloss.NewStyle().
-		Background(styles.Primary).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Padding(0, 2).
-		Bold(true)
+	return strings.Join(lines, "\n")
+}
 
-	badgeLine := typeStyle.Render(fmt.Sprintf("Type: %s", node.Type))
-	if node.Project != "" {
-		projectStyle := lipgloss.NewStyle().
-			Background(styles.Secondary).
+// detailSection renders one named section of the Details view, or nothing
+// when it does not apply to the node. Details templates call these by name.
+func (m Model) detailSection(name string, node DisplayNode, maxWidth int) []string {
+	switch name {
+	case "title":
+		// Node icon and title (large and prominent)
+		icon := m.typeIcon(node.Type)
+		titleStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(styles.Accent).
+			Underline(true)
+		if project := m.projectOf(node.ID); project != "" {
+			titleStyle = titleStyle.Foreground(m.projectColors.Get(project))
+		}
+
+		// Show identifier if available (e.g., CET-352)
+		titleText := node.Title
+		if node.Identifier != "" {
+			titleText = fmt.Sprintf("[%s] %s", node.Identifier, node.Title)
+		}
+		title := titleStyle.Render(fmt.Sprintf("%s %s", icon, titleText))
+		if m.IsPending(node.ID) {
+			title += " " + lipgloss.NewStyle().Foreground(styles.Muted).Render(pendingMarker)
+		}
+		return []string{title}
+
+	case "type":
+		// Type and Project badges on same line
+		typeStyle := lipgloss.NewStyle().
+			Background(styles.Primary).
 			Foreground(lipgloss.Color("#FFFFFF")).
-			Padding(0, 2)
-		badgeLine += "  " + projectStyle.Render(fmt.Sprintf("📦 %s", node.Project))
-	}
-	lines = append(lines, badgeLine)
-	lines = append(lines, "")
+			Padding(0, 2).
+			Bold(true)
 
-	// Status with color and icon
-	if node.Status != "" {
+		badgeLine := typeStyle.Render(fmt.Sprintf("Type: %s", node.Type))
+		if project := m.projectOf(node.ID); project != "" && node.Type != graph.NodeTypeProject {
+			projectStyle := lipgloss.NewStyle().
+				Background(m.projectColors.Get(project)).
+				Foreground(lipgloss.Color("#000000")).
+				Padding(0, 2)
+			badgeLine += "  " + projectStyle.Render(fmt.Sprintf("📦 %s", project))
+		}
+		return []string{badgeLine}
+
+	case "status":
+		// Status with color and icon
+		if node.Status == "" {
+			return nil
+		}
 		statusColor := styles.StatusColor(node.Status)
 		statusStyle := lipgloss.NewStyle().
 			Foreground(statusColor).
 			Bold(true)
-		statusIcon := getStatusIconLarge(node.Status)
-		lines = append(lines, statusStyle.Render(fmt.Sprintf("%s Status: %s", statusIcon, node.Status)))
-	}
+		statusIcon := styles.CategoryOf(node.Status).Icon()
+		return []string{statusStyle.Render(fmt.Sprintf("%s Status: %s", statusIcon, node.Status))}
 
-	// Priority with color and badge
-	if node.Priority > 0 {
+	case "priority":
+		// Priority with color and badge
+		if node.Priority <= 0 {
+			return nil
+		}
 		priorityColor := styles.PriorityColor(node.Priority)
 		priorityStyle := lipgloss.NewStyle().
 			Foreground(priorityColor).
 			Bold(true)
-		priorityLabel := getPriorityLabel(node.Priority)
-		lines = append(lines, priorityStyle.Render(fmt.Sprintf("🔥 Priority: %s", priorityLabel)))
-	}
+		priorityLabel := styles.PriorityLabel(node.Priority)
+		return []string{priorityStyle.Render(fmt.Sprintf("🔥 Priority: %s", priorityLabel))}
 
-	lines = append(lines, "")
+	case "review":
+		// Review state, outstanding reviewers and mergeability (PRs)
+		if node.Type != graph.NodeTypePR {
+			return nil
+		}
+		return m.renderReviewDetails(node.Review)
 
-	// Description (wrapped to maxWidth)
-	if node.Description != "" {
+	case "owner":
+		// Inferred owner (files and directories)
+		if node.Owner == "" {
+			return nil
+		}
+		ownerStyle := lipgloss.NewStyle().Foreground(styles.Secondary)
+		return []string{ownerStyle.Render("👤 Owner: "+node.Owner) +
+			lipgloss.NewStyle().Foreground(styles.Muted).Render("  (inferred from commit history)")}
+
+	case "metadata":
+		// License, module path and package found by the scans (projects,
+		// directories, files)
+		return renderMetadataDetails(node)
+
+	case "languages":
+		// Language bar of the files in a project
+		return m.renderLanguageDetails(node, maxWidth)
+
+	case "description":
+		// Description, rendered as Markdown
+		if node.Description == "" {
+			return nil
+		}
 		descStyle := lipgloss.NewStyle().
 			Foreground(styles.Foreground).
 			Width(maxWidth)
-		lines = append(lines, descStyle.Render("Description:"))
-		lines = append(lines, descStyle.Render(wrapText(node.Description, maxWidth-4)))
-	}
+		lines := []string{descStyle.Render("Description:")}
+		if !describesAsMarkdown(node.Type) {
+			return append(lines, descStyle.Render(wrapText(node.Description, maxWidth-4)))
+		}
+		return append(lines, m.renderMarkdown(node.Description, clampMin(maxWidth-4, 20))...)
 
-	// Labels as badges
-	if len(node.Labels) > 0 {
-		lines = append(lines, "")
+	case "commit":
+		// Body, diff stats, tags and trailers (commits)
+		if node.Type != graph.NodeTypeCommit {
+			return nil
+		}
+		return renderCommitDetails(node, maxWidth)
+
+	case "links":
+		// Numbered identifiers and URLs from the description or message
+		return m.renderLinksSection(node, maxWidth)
+
+	case "labels":
+		// Labels as badges
+		if len(node.Labels) == 0 {
+			return nil
+		}
 		var labelParts []string
 		labelParts = append(labelParts, "🏷  Labels: ")
 		for _, label := range node.Labels {
@@ -593,18 +792,33 @@ func (m Model) renderNodeDetailsExpanded(node DisplayNode, maxWidth int) string
 				Padding(0, 1)
 			labelParts = append(labelParts, labelStyle.Render(label)+" ")
 		}
-		lines = append(lines, strings.Join(labelParts, ""))
-	}
+		return []string{strings.Join(labelParts, "")}
+
+	case "trace":
+		// Issue -> PR -> commit -> file traceability, or the reverse for files (t expands)
+		traceBlock := lipgloss.NewStyle().Width(maxWidth)
+		switch node.Type {
+		case graph.NodeTypeIssue:
+			return []string{traceBlock.Render(strings.Join(m.renderTraceSection(node.ID, maxWidth), "\n"))}
+		case graph.NodeTypeFile:
+			return []string{traceBlock.Render(strings.Join(m.renderImpactSection(node.ID, maxWidth), "\n"))}
+		case graph.NodeTypePerson, graph.NodeTypeTeam:
+			return []string{traceBlock.Render(strings.Join(m.renderInvolvementSection(node.ID, maxWidth), "\n"))}
+		case graph.NodeTypeRelease:
+			return []string{traceBlock.Render(strings.Join(m.renderShippedSection(node.ID, maxWidth), "\n"))}
+		}
+		return nil
 
-	// Related nodes preview (quick glance at connections)
-	relations := m.GetRelationsList()
-	if len(relations) > 0 {
-		lines = append(lines, "")
-		lines = append(lines, "")
+	case "related":
+		// Related nodes preview (quick glance at connections)
+		relations := m.GetRelationsList()
+		if len(relations) == 0 {
+			return nil
+		}
 		relHeader := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(styles.Secondary)
-		lines = append(lines, relHeader.Render(fmt.Sprintf("🔗 Related (%d connections):", len(relations))))
+		lines := []string{relHeader.Render(fmt.Sprintf("🔗 Related (%d connections):", len(relations)))}
 
 		// Show first 5 relations as preview
 		maxPreview := 5
@@ -614,7 +828,7 @@ func (m Model) renderNodeDetailsExpanded(node DisplayNode, maxWidth int) string
 
 		for i := 0; i < maxPreview; i++ {
 			rel := relations[i]
-			relIcon := getNodeIcon(rel.NodeType)
+			relIcon := m.typeIcon(rel.NodeType)
 			arrow := "→"
 			if !rel.IsOutgoing {
 				arrow = "←"
@@ -627,103 +841,62 @@ func (m Model) renderNodeDetailsExpanded(node DisplayNode, maxWidth int) string
 			moreStyle := lipgloss.NewStyle().Foreground(styles.Muted).Italic(true)
 			lines = append(lines, moreStyle.Render(fmt.Sprintf("  ... and %d more (Tab to Relations view)", len(relations)-maxPreview)))
 		}
-	}
+		return lines
 
-	// URL link (if available)
-	if node.URL != "" {
-		lines = append(lines, "")
+	case "link":
+		// URL link (if available)
+		if node.URL == "" {
+			return nil
+		}
 		urlStyle := lipgloss.NewStyle().
 			Foreground(styles.Accent).
 			Underline(true)
 		linkLabel := lipgloss.NewStyle().
 			Foreground(styles.Muted).
 			Bold(true)
-		lines = append(lines, linkLabel.Render("🔗 Link: ")+urlStyle.Render(node.URL))
-	}
+		return []string{linkLabel.Render("🔗 Link: ") + urlStyle.Render(node.URL)}
 
-	// ID (faint, at bottom)
-	lines = append(lines, "")
-	idStyle := lipgloss.NewStyle().Foreground(styles.Muted).Faint(true)
-	lines = append(lines, idStyle.Render(fmt.Sprintf("ID: %s", node.ID)))
+	case "id":
+		// ID (faint, at bottom)
+		idStyle := lipgloss.NewStyle().Foreground(styles.Muted).Faint(true)
+		return []string{idStyle.Render(fmt.Sprintf("ID: %s", node.ID))}
 
-	// Helpful hint if no description
-	if node.Description == "" && node.Type == graph.NodeTypeIssue {
-		lines = append(lines, "")
+	case "hint":
+		// Helpful hint if no description
+		if node.Description != "" || node.Type != graph.NodeTypeIssue {
+			return nil
+		}
 		hintStyle := lipgloss.NewStyle().
 			Foreground(styles.Muted).
 			Italic(true)
-		lines = append(lines, hintStyle.Render("💡 Description not loaded. Use the link above to view full details in Linear."))
+		return []string{hintStyle.Render("💡 Description not loaded. Use the link above to view full details in Linear.")}
 	}
-
-	return strings.Join(lines, "\n")
+	return nil
 }
 
-
 // Helper functions
 
-// getNodeIcon returns an icon character for a node type.
-func getNodeIcon(nodeType graph.NodeType) string {
-	switch nodeType {
-	case graph.NodeTypeIssue:
-		return "🐛"
-	case graph.NodeTypePR:
-		return "🔀"
-	case graph.NodeTypeCommit:
-		return "💾"
-	case graph.NodeTypeFile:
-		return "📄"
-	case graph.NodeTypeProject:
-		return "📦"
-	case graph.NodeTypeService:
-		return "⚙️"
-	default:
-		return "❓"
-	}
-}
-
-// getStatusIconLarge returns a larger icon for status (Details view).
-func getStatusIconLarge(status string) string {
-	switch status {
-	case "done", "merged", "completed":
-		return "✅"
-	case "in_progress", "open", "in progress":
-		return "🔄"
-	case "todo", "pending":
-		return "📋"
-	case "blocked":
-		return "🚫"
-	case "canceled":
-		return "❌"
-	default:
-		return "⚪"
-	}
-}
-
-// getPriorityLabel returns a human-readable priority label.
-func getPriorityLabel(priority int) string {
-	switch priority {
-	case 1:
-		return "Urgent"
-	case 2:
-		return "High"
-	case 3:
-		return "Medium"
-	default:
-		return "Low"
-	}
-}
-
 // truncate shortens a string to max length with ellipsis.
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
+	if maxLen <= 0 {
+		return ""
+	}
 	if maxLen <= 3 {
 		return s[:maxLen]
 	}
 	return s[:maxLen-3] + "..."
 }
 
+// dropLastRune removes the last character typed into an input, keeping
+// multibyte characters whole.
+func dropLastRune(s string) string {
+	_, size := utf8.DecodeLastRuneInString(s)
+	return s[:len(s)-size]
+}
+
 // wrapText wraps text to fit within maxWidth.
 func wrapText(text string, maxWidth int) string {
 	if len(text) <= maxWidth {
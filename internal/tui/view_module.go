@@ -0,0 +1,199 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ViewModule is a full-screen view that draws itself and handles its own
+// keys. The core views (Graph, Details, Relations, SQL) share the global
+// keys in update.go; every other view is a module in the model's registry,
+// so adding one takes a ViewMode and a WithViewModule call rather than
+// edits to the switches in view.go, update.go and status_bar.go.
+type ViewModule interface {
+	// Name is shown in the status bar, e.g. "[Table]"
+	Name() string
+	// Render draws the view in the space above the status bar
+	Render(m Model, width, height int) string
+	// HandleKey processes a key while the view is current
+	HandleKey(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd)
+	// Hints are the view's key hints for the status bar, " | " separated
+	Hints(m Model) string
+}
+
+// ViewOpener is a ViewModule opened by a key in the Graph view. Its key
+// is checked before the global keys, so it must not be one of theirs.
+type ViewOpener interface {
+	ViewModule
+	// Key opens the view from the Graph, e.g. "V"
+	Key() string
+	// Open returns the model with the view pushed, and any command to run
+	Open(m Model) (tea.Model, tea.Cmd)
+}
+
+// ViewCustom is the first ViewMode free for views registered from outside
+// this package.
+const ViewCustom ViewMode = 1000
+
+// funcView is a ViewModule made of a view's methods on Model
+type funcView struct {
+	name   string
+	render func(Model, int, int) string
+	keys   func(Model, tea.KeyMsg) (tea.Model, tea.Cmd)
+	hints  func(Model) string
+}
+
+func (v funcView) Name() string { return v.name }
+
+func (v funcView) Render(m Model, width, height int) string { return v.render(m, width, height) }
+
+func (v funcView) HandleKey(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) { return v.keys(m, msg) }
+
+func (v funcView) Hints(m Model) string { return v.hints(m) }
+
+// openableView is a funcView with a Graph key that opens it
+type openableView struct {
+	funcView
+	key  string
+	open func(Model) (tea.Model, tea.Cmd)
+}
+
+func (v openableView) Key() string { return v.key }
+
+func (v openableView) Open(m Model) (tea.Model, tea.Cmd) { return v.open(m) }
+
+// staticHints returns hints that do not depend on the model
+func staticHints(hints string) func(Model) string {
+	return func(Model) string { return hints }
+}
+
+// pushing returns an opener that pushes a view, then applies reset (the
+// view's selection or scroll back to the top)
+func pushing(mode ViewMode, reset func(Model) Model) func(Model) (tea.Model, tea.Cmd) {
+	return func(m Model) (tea.Model, tea.Cmd) {
+		m = m.PushView(mode)
+		if reset != nil {
+			m = reset(m)
+		}
+		return m, nil
+	}
+}
+
+// builtinViews returns the registry every model starts with
+func builtinViews() map[ViewMode]ViewModule {
+	return map[ViewMode]ViewModule{
+		ViewQueries: openableView{
+			funcView{"Views", Model.renderQueriesView, Model.handleQueriesKeys,
+				staticHints("jk:select | Enter:apply | a:save current | Esc:back | q:quit")},
+			"v", pushing(ViewQueries, nil),
+		},
+		ViewDashboard: funcView{"Dashboard", Model.renderDashboardView, Model.handleDashboardKeys,
+			staticHints("hjkl:select | Enter:open project | Esc:back | q:quit")},
+		ViewStandup: openableView{
+			funcView{"Standup", Model.renderStandupView, Model.handleStandupKeys,
+				staticHints("y:copy markdown | Esc:back | q:quit")},
+			"S", pushing(ViewStandup, nil),
+		},
+		ViewRetro: openableView{
+			funcView{"Retro", Model.renderRetroView, Model.handleRetroKeys,
+				staticHints("[/]:older/newer cycle | y:copy markdown | Esc:back | q:quit")},
+			"E", func(m Model) (tea.Model, tea.Cmd) { return m.openRetro(), nil },
+		},
+		ViewHotspots: openableView{
+			funcView{"Hotspots", Model.renderHotspotsView, Model.handleHotspotsKeys,
+				staticHints("jk:select | Enter:show in Files | Esc:back | q:quit")},
+			"H", pushing(ViewHotspots, func(m Model) Model { return m.WithSelectedRiskIdx(0) }),
+		},
+		ViewTable: openableView{
+			funcView{"Table", Model.renderTableView, Model.handleTableKeys,
+				staticHints("jk:select | hl:sort column | r:reverse | y:copy markdown | Enter:show in graph | Esc:back | q:quit")},
+			"V", func(m Model) (tea.Model, tea.Cmd) { return m.openTable(), nil },
+		},
+		ViewReviewQueue: openableView{
+			funcView{"Review queue", Model.renderReviewQueueView, Model.handleReviewQueueKeys,
+				staticHints("jk:select | o:open | x:viewed | y:copy URL | Enter:graph | Esc:back | q:quit")},
+			"W", pushing(ViewReviewQueue, func(m Model) Model { return m.WithSelectedReviewIdx(0) }),
+		},
+		ViewActionQueue: openableView{
+			funcView{"Action queue", Model.renderActionQueueView, Model.handleActionQueueKeys,
+				staticHints("jk:select | Enter:run all | r:run selected | x:drop | Esc:back | q:quit")},
+			"A", pushing(ViewActionQueue, func(m Model) Model { return m.WithSelectedActionIdx(0) }),
+		},
+		ViewArchive: openableView{
+			funcView{"Archive", Model.renderArchiveView, Model.handleArchiveKeys,
+				staticHints("jk:select | /:search | Enter:restore | Esc:back | q:quit")},
+			"Z", func(m Model) (tea.Model, tea.Cmd) {
+				m = m.PushView(ViewArchive)
+				return m, listArchived(m.ctx, m.store, m.archiveSearch)
+			},
+		},
+		ViewActivity: openableView{
+			funcView{"Activity", Model.renderActivityView, Model.handleActivityKeys,
+				staticHints("jk:scroll | y:copy markdown | Esc:back | q:quit")},
+			"L", pushing(ViewActivity, func(m Model) Model { return m.WithActivityScroll(0) }),
+		},
+		ViewSyncStats: openableView{
+			funcView{"Sync stats", Model.renderSyncStatsView, Model.handleSyncStatsKeys,
+				staticHints("jk:scroll | Esc:back | q:quit")},
+			"T", func(m Model) (tea.Model, tea.Cmd) {
+				m = m.PushView(ViewSyncStats).WithSyncStatsScroll(0)
+				return m, listSyncRuns(m.ctx, m.store)
+			},
+		},
+		ViewNewIssue: openableView{
+			funcView{"New issue", Model.renderNewIssueView, Model.handleNewIssueKeys, func(m Model) string {
+				if m.editor != nil {
+					return "Tab:next field | ctrl+s:create | Esc:templates"
+				}
+				return "jk:select | 1-9:pick | Enter:open form | Esc:back | q:quit"
+			}},
+			"n", pushing(ViewNewIssue, func(m Model) Model { return m.WithSelectedTemplateIdx(0) }),
+		},
+		ViewChecklist: funcView{"Checklist", Model.renderChecklistView, Model.handleChecklistKeys,
+			staticHints("jk:select | Enter:show in graph | y:copy markdown | Esc:back | q:quit")},
+		ViewDiff: funcView{"Diff",
+			func(m Model, width, height int) string { return m.renderPagerView("± Diff", width, height) },
+			func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) { return m.handlePagerKeys(msg, "d") },
+			staticHints(pagerHints)},
+		ViewPreview: funcView{"Preview",
+			func(m Model, width, height int) string { return m.renderPagerView("📄 Preview", width, height) },
+			func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) { return m.handlePagerKeys(msg, " ") },
+			staticHints(pagerHints)},
+	}
+}
+
+// pagerHints are the keys of the Diff and Preview pagers
+const pagerHints = "jk:scroll | ctrl+d/u:page | g/G:top/bottom | Esc:back | q:quit"
+
+// WithViewModule returns a new Model with a view registered under mode,
+// replacing the one there; a nil module removes it.
+func (m Model) WithViewModule(mode ViewMode, module ViewModule) Model {
+	views := make(map[ViewMode]ViewModule, len(m.views)+1)
+	for k, v := range m.views {
+		views[k] = v
+	}
+	if module == nil {
+		delete(views, mode)
+	} else {
+		views[mode] = module
+	}
+	m.views = views
+	return m
+}
+
+// viewName names the current view for the status bar
+func (m Model) viewName() string {
+	if module, ok := m.views[m.currentView]; ok {
+		return module.Name()
+	}
+	return m.currentView.String()
+}
+
+// openerFor returns the registered view a key opens from the Graph
+func (m Model) openerFor(key string) (ViewOpener, bool) {
+	for _, module := range m.views {
+		if opener, ok := module.(ViewOpener); ok && opener.Key() == key {
+			return opener, true
+		}
+	}
+	return nil, false
+}
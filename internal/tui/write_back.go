@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/manutej/maat-terminal/internal/graph"
+)
+
+// WriteBack pushes edits of synced nodes to the source they came from (the
+// data source loader, calling Linear's issueUpdate), re-reading the remote
+// copy first so a concurrent change is never silently overwritten.
+type WriteBack interface {
+	RemoteFields(ctx context.Context, node graph.Node) (time.Time, map[string]string, error)
+	UpdateFields(ctx context.Context, node graph.Node, fields map[string]string) error
+}
+
+// WithWriteBack returns a new Model that can edit Linear issues' title and
+// description from Details (e key) and write them back.
+func (m Model) WithWriteBack(writeBack WriteBack) Model {
+	m.writeBack = writeBack
+	return m
+}
+
+// writesBack reports whether edits of a node can be written back to its
+// source: a Linear issue, with a source to write through
+func (m Model) writesBack(node DisplayNode) bool {
+	return m.writeBack != nil && node.Type == graph.NodeTypeIssue && providerOf(node.ID) == ProviderLinear
+}
+
+// requestWriteBack asks to push an edited issue's changed fields. Its
+// PendingEdit has the remote copy re-read before the dialog opens: fields
+// changed there since the last sync show as a three-way diff.
+func (m Model) requestWriteBack(node DisplayNode, title, description string) (tea.Model, tea.Cmd) {
+	base := map[string]string{"title": node.Title, "description": node.Description}
+	local := make(map[string]string, len(base))
+	for field, value := range map[string]string{"title": title, "description": description} {
+		if value != base[field] {
+			local[field] = value
+		}
+	}
+	if len(local) == 0 {
+		return m.WithStatus("Nothing changed", false), nil
+	}
+
+	edited := node
+	edited.Title, edited.Description = title, description
+	synced := graph.Node{ID: node.ID, Type: node.Type, Data: node.Data}
+	return m.Update(ConfirmationRequested{
+		Action:  fmt.Sprintf("Update %s in Linear", issueName(node)),
+		Execute: pushEdit(m.writeBack, synced, local),
+		Edit: &PendingEdit{
+			NodeID:      node.ID,
+			SyncedAt:    node.UpdatedAt,
+			Base:        base,
+			Local:       local,
+			FetchRemote: fetchRemote(m.writeBack, synced),
+		},
+		Done:       NodeEditedMsg{NodeID: node.ID, Title: title, Description: description},
+		Category:   CategoryWriteBack,
+		Optimistic: &edited,
+	})
+}
+
+// fetchRemote re-reads a node's editable fields from its source
+func fetchRemote(writeBack WriteBack, node graph.Node) func() (RemoteSnapshot, error) {
+	return func() (RemoteSnapshot, error) {
+		updatedAt, fields, err := writeBack.RemoteFields(context.Background(), node)
+		if err != nil {
+			return RemoteSnapshot{}, err
+		}
+		return RemoteSnapshot{UpdatedAt: updatedAt, Fields: fields}, nil
+	}
+}
+
+// pushEdit writes only the fields edited locally, so remote changes to the
+// others survive. Writes are waited for on quit, so it does not give up on
+// the model's context.
+func pushEdit(writeBack WriteBack, node graph.Node, fields map[string]string) func() error {
+	return func() error {
+		return writeBack.UpdateFields(context.Background(), node, fields)
+	}
+}